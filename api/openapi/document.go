@@ -0,0 +1,229 @@
+package openapi
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"eval/api/problem"
+)
+
+// ========================================
+// DOCUMENT OPENAPI 3 GÉNÉRÉ DEPUIS LES ROUTES
+// ========================================
+// Chaque package de handlers décrit ses routes (méthode, chemin, paramètres, DTO de réponse).
+// La même liste sert à:
+//   - enregistrer les handlers (Route.Pattern, syntaxe http.ServeMux de Go 1.22)
+//   - valider les paramètres de requête avant le handler (Validate)
+//   - générer le document servi sur /api/openapi.json (NewDocument)
+//
+// La documentation ne peut donc pas diverger des routes réellement servies
+
+// OpenAPIVersion version de la spécification produite
+const OpenAPIVersion = "3.0.3"
+
+// Route route HTTP et sa description OpenAPI
+type Route struct {
+	Method    string // http.MethodGet, http.MethodPost, ...
+	Path      string // /api/v2/exports/{id}: même syntaxe des paramètres de chemin pour ServeMux et OpenAPI
+	Handler   http.HandlerFunc
+	Operation Operation
+	// StrictQuery rejette les paramètres de requête non déclarés (400) au lieu de les ignorer
+	// Opt-in par route: les clients V1 existants envoient des paramètres que V1 a toujours ignorés
+	StrictQuery bool
+}
+
+// Pattern motif d'enregistrement dans http.ServeMux ("GET /api/v2/stats")
+func (r Route) Pattern() string {
+	return r.Method + " " + r.Path
+}
+
+// Operation description d'une route
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter paramètre de requête (in=query) ou de chemin (in=path)
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// QueryParam paramètre de requête facultatif
+func QueryParam(name, description string, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+// RequiredQueryParam paramètre de requête obligatoire
+func RequiredQueryParam(name, description string, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Required: true, Schema: schema}
+}
+
+// PathParam paramètre de chemin ({id}), toujours obligatoire
+func PathParam(name, description string, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: schema}
+}
+
+// RequestBody corps de requête JSON
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+	model    interface{}
+}
+
+// JSONBody corps JSON décrit par le type de model (une valeur zéro suffit: createExportJobRequest{})
+func JSONBody(model interface{}) *RequestBody {
+	return &RequestBody{Required: true, model: model}
+}
+
+// Response réponse documentée
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+	model       interface{}
+	contentType string
+}
+
+// MediaType schéma d'un contenu
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// JSONResponse réponse JSON décrite par le type de model (nil = objet JSON non typé)
+func JSONResponse(description string, model interface{}) Response {
+	return Response{Description: description, model: model, contentType: "application/json"}
+}
+
+// FileResponse réponse binaire ou texte téléchargée (text/csv, application/octet-stream)
+func FileResponse(description, contentType string) Response {
+	return Response{Description: description, contentType: contentType}
+}
+
+// EmptyResponse réponse sans corps décrit
+func EmptyResponse(description string) Response {
+	return Response{Description: description}
+}
+
+// ProblemResponse réponse d'erreur application/problem+json (400, 404, 409, 503, ...)
+func ProblemResponse(description string) Response {
+	return Response{Description: description, model: problem.Problem{}, contentType: problem.ContentType}
+}
+
+// Info métadonnées du document
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Document document OpenAPI 3 complet
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+// Components schémas réutilisables (DTOs)
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// NewDocument génère le document des routes
+// Les DTOs (réponses, corps) sont décrits par réflexion dans components/schemas;
+// chaque route avec des paramètres documente aussi la réponse 400 de Validate,
+// et chaque route la réponse 500 (problem+json, code internal_error)
+func NewDocument(info Info, routes []Route) *Document {
+	registry := newSchemaRegistry()
+	doc := &Document{
+		OpenAPI: OpenAPIVersion,
+		Info:    info,
+		Paths:   make(map[string]map[string]Operation),
+	}
+
+	for _, route := range routes {
+		// Copie de l'opération: la Route reste telle que déclarée par son package
+		op := route.Operation
+		op.Responses = make(map[string]Response, len(route.Operation.Responses)+1)
+		for status, response := range route.Operation.Responses {
+			op.Responses[status] = response.withContent(registry)
+		}
+		if op.RequestBody != nil {
+			body := *op.RequestBody
+			body.Content = map[string]MediaType{
+				"application/json": {Schema: registry.schemaFor(reflect.TypeOf(body.model))},
+			}
+			op.RequestBody = &body
+		}
+		if _, documented := op.Responses["400"]; !documented && len(op.Parameters) > 0 {
+			op.Responses["400"] = ProblemResponse("Paramètres invalides").withContent(registry)
+		}
+		if _, documented := op.Responses["500"]; !documented {
+			op.Responses["500"] = ProblemResponse("Erreur interne").withContent(registry)
+		}
+
+		if doc.Paths[route.Path] == nil {
+			doc.Paths[route.Path] = make(map[string]Operation)
+		}
+		doc.Paths[route.Path][strings.ToLower(route.Method)] = op
+	}
+
+	doc.Components.Schemas = registry.schemas
+	return doc
+}
+
+// withContent complète la réponse avec le schéma de son contenu
+func (r Response) withContent(registry *schemaRegistry) Response {
+	switch {
+	case r.contentType == "":
+		return r
+	case r.model != nil:
+		r.Content = map[string]MediaType{r.contentType: {Schema: registry.schemaFor(reflect.TypeOf(r.model))}}
+	case r.contentType == "application/json":
+		r.Content = map[string]MediaType{r.contentType: {Schema: &Schema{Type: "object"}}}
+	default:
+		r.Content = map[string]MediaType{r.contentType: {Schema: &Schema{Type: "string", Format: "binary"}}}
+	}
+	return r
+}
+
+// OperationIDs liste triée des operationId (contrôle d'unicité dans les tests)
+func (d *Document) OperationIDs() []string {
+	var ids []string
+	for _, item := range d.Paths {
+		for _, op := range item {
+			ids = append(ids, op.OperationID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Handler sert le document en JSON
+// PERFORMANCE: sérialisé une seule fois au démarrage, chaque requête écrit les mêmes octets
+func (d *Document) Handler() http.HandlerFunc {
+	body, err := json.Marshal(d)
+	if err != nil {
+		log.Printf("Error encoding OpenAPI document: %v", err)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			problem.Write(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
@@ -0,0 +1,110 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"eval/api/problem"
+)
+
+type itemDTO struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Tags      []string  `json:"tags"`
+	Price     *float64  `json:"price"`
+	Parent    *itemDTO  `json:"parent,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Internal  string    `json:"-"`
+	hidden    int
+}
+
+// TestSchemaFor_Struct vérifie que le schéma suit les règles d'encoding/json
+func TestSchemaFor_Struct(t *testing.T) {
+	registry := newSchemaRegistry()
+	ref := registry.schemaFor(reflect.TypeOf([]itemDTO{}))
+
+	if ref.Type != "array" || ref.Items.Ref != "#/components/schemas/ItemDTO" {
+		t.Fatalf("schema = %+v, want an array of ItemDTO references", ref)
+	}
+
+	item := registry.schemas["ItemDTO"]
+	if item == nil {
+		t.Fatal("ItemDTO not registered in components")
+	}
+	if len(item.Properties) != 6 {
+		t.Errorf("got %d properties, want 6 (json:\"-\" and unexported fields skipped)", len(item.Properties))
+	}
+	if p := item.Properties["price"]; p.Type != "number" || !p.Nullable {
+		t.Errorf("price = %+v, want a nullable number", p)
+	}
+	if p := item.Properties["created_at"]; p.Type != "string" || p.Format != "date-time" {
+		t.Errorf("created_at = %+v, want a date-time string", p)
+	}
+	if p := item.Properties["parent"]; p.Ref != "#/components/schemas/ItemDTO" {
+		t.Errorf("parent = %+v, want a reference to ItemDTO (recursive type)", p)
+	}
+	wantRequired := []string{"id", "name", "tags", "price", "created_at"}
+	if !reflect.DeepEqual(item.Required, wantRequired) {
+		t.Errorf("required = %v, want %v", item.Required, wantRequired)
+	}
+}
+
+// TestNewDocument vérifie le document généré: chemins, méthodes, DTOs et réponse 400 de validation
+func TestNewDocument(t *testing.T) {
+	routes := []Route{
+		{
+			Method: http.MethodGet, Path: "/items/{id}",
+			Operation: Operation{
+				OperationID: "getItem",
+				Parameters:  []Parameter{PathParam("id", "", IntegerSchema(1, 0))},
+				Responses:   map[string]Response{"200": JSONResponse("Item", itemDTO{})},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/items/{id}/file",
+			Operation: Operation{
+				OperationID: "getItemFile",
+				Responses:   map[string]Response{"200": FileResponse("CSV", "text/csv")},
+			},
+		},
+	}
+
+	doc := NewDocument(Info{Title: "Test", Version: "1.0.0"}, routes)
+
+	if doc.OpenAPI != OpenAPIVersion {
+		t.Errorf("openapi = %q, want %q", doc.OpenAPI, OpenAPIVersion)
+	}
+	op, ok := doc.Paths["/items/{id}"]["get"]
+	if !ok {
+		t.Fatal("GET /items/{id} missing")
+	}
+	if got := op.Responses["200"].Content["application/json"].Schema.Ref; got != "#/components/schemas/ItemDTO" {
+		t.Errorf("200 schema = %q, want ItemDTO reference", got)
+	}
+	if got := op.Responses["400"].Content[problem.ContentType].Schema.Ref; got != "#/components/schemas/Problem" {
+		t.Errorf("400 schema = %q, want Problem reference", got)
+	}
+
+	file := doc.Paths["/items/{id}/file"]["get"]
+	if _, ok := file.Responses["400"]; ok {
+		t.Error("an operation without parameters should not document a 400 response")
+	}
+	if _, ok := file.Responses["500"]; !ok {
+		t.Error("every operation should document the 500 problem response")
+	}
+	if got := file.Responses["200"].Content["text/csv"].Schema.Format; got != "binary" {
+		t.Errorf("file schema format = %q, want binary", got)
+	}
+
+	// Les routes déclarées ne sont pas modifiées par la génération
+	if routes[0].Operation.Responses["200"].Content != nil {
+		t.Error("NewDocument should not mutate the routes")
+	}
+
+	if _, err := json.Marshal(doc); err != nil {
+		t.Fatal(err)
+	}
+}
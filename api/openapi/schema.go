@@ -0,0 +1,182 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Schema sous-ensemble de JSON Schema utilisé par OpenAPI 3.0
+// Sert à la fois à documenter (paramètres, DTOs) et à valider les paramètres de requête (voir Validate)
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// IntegerSchema entier >= min (max = 0: pas de borne haute)
+func IntegerSchema(min, max int) *Schema {
+	s := &Schema{Type: "integer", Minimum: float64Ptr(float64(min))}
+	if max != 0 {
+		s.Maximum = float64Ptr(float64(max))
+	}
+	return s
+}
+
+// NumberSchema nombre décimal quelconque
+func NumberSchema() *Schema {
+	return &Schema{Type: "number"}
+}
+
+// NumberRangeSchema nombre décimal compris entre min et max (bornes incluses)
+func NumberRangeSchema(min, max float64) *Schema {
+	return &Schema{Type: "number", Minimum: float64Ptr(min), Maximum: float64Ptr(max)}
+}
+
+// BooleanSchema booléen (true/false, 1/0 acceptés comme strconv.ParseBool)
+func BooleanSchema() *Schema {
+	return &Schema{Type: "boolean"}
+}
+
+// StringSchema chaîne libre
+func StringSchema() *Schema {
+	return &Schema{Type: "string"}
+}
+
+// EnumSchema chaîne parmi values (la casse est ignorée à la validation, comme les parseurs du domaine)
+func EnumSchema(values ...string) *Schema {
+	return &Schema{Type: "string", Enum: values}
+}
+
+// PatternSchema chaîne respectant l'expression régulière pattern (syntaxe RE2 de regexp)
+func PatternSchema(pattern string) *Schema {
+	return &Schema{Type: "string", Pattern: pattern}
+}
+
+// DateSchema date ISO 8601 (2025-09-30)
+func DateSchema() *Schema {
+	return &Schema{Type: "string", Format: "date"}
+}
+
+// WithDefault retourne une copie du schéma avec sa valeur par défaut (documentation uniquement)
+func (s *Schema) WithDefault(value interface{}) *Schema {
+	copied := *s
+	copied.Default = value
+	return &copied
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+// timeType type time.Time, sérialisé en chaîne RFC 3339 par encoding/json
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaRegistry construit les schémas des DTOs par réflexion et les range dans components/schemas
+// Chaque struct nommée n'est décrite qu'une fois, les usages suivants sont des $ref
+type schemaRegistry struct {
+	schemas map[string]*Schema
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{schemas: make(map[string]*Schema)}
+}
+
+// schemaFor décrit le JSON produit par encoding/json pour le type t
+// Mêmes règles que encoding/json: tags `json`, champs "-" ignorés, pointeur = nullable,
+// omitempty / omitzero = champ facultatif, interface{} = valeur quelconque
+func (r *schemaRegistry) schemaFor(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := r.schemaFor(t.Elem())
+		if s.Ref != "" {
+			return s // PIÈGE: OpenAPI 3.0 ignore les voisins d'un $ref (pas de nullable possible)
+		}
+		s.Nullable = true
+		return s
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		return r.structRef(t)
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaFor(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+// structRef enregistre la struct dans les composants et retourne sa référence
+// Une struct anonyme n'a pas de nom de composant: elle est décrite sur place
+func (r *schemaRegistry) structRef(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		s := &Schema{Type: "object"}
+		r.describeFields(s, t)
+		return s
+	}
+
+	name := componentName(t)
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if _, exists := r.schemas[name]; exists {
+		return ref
+	}
+
+	s := &Schema{Type: "object"}
+	r.schemas[name] = s // avant les champs: un type récursif retombe sur le $ref
+	r.describeFields(s, t)
+	return ref
+}
+
+// describeFields ajoute à s les propriétés JSON des champs exportés de la struct t
+func (r *schemaRegistry) describeFields(s *Schema, t reflect.Type) {
+	s.Properties = make(map[string]*Schema)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		jsonName, options, _ := strings.Cut(tag, ",")
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		s.Properties[jsonName] = r.schemaFor(field.Type)
+		if !strings.Contains(options, "omitempty") && !strings.Contains(options, "omitzero") {
+			s.Required = append(s.Required, jsonName)
+		}
+	}
+}
+
+// componentName nom du composant: nom du type Go avec une majuscule (exportJobResponse → ExportJobResponse)
+func componentName(t reflect.Type) string {
+	name := []rune(t.Name())
+	name[0] = unicode.ToUpper(name[0])
+	return string(name)
+}
@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"eval/api/problem"
+)
+
+// Validate vérifie les paramètres de la requête contre l'opération de la route avant d'appeler son handler
+//   - paramètre inconnu (faute de frappe: dayz=30): 400 au lieu d'être ignoré silencieusement,
+//     uniquement si la route l'accepte (Route.StrictQuery), ignoré sinon
+//   - paramètre obligatoire absent, valeur du mauvais type, hors bornes ou hors énumération: 400
+//   - une valeur vide (days=) équivaut à un paramètre absent, comme dans les handlers
+//
+// Toutes les erreurs sont renvoyées ensemble (problem+json, code invalid_parameters, liste invalid_params),
+// dans l'ordre des paramètres de l'opération
+// Les règles métier (from <= to, period combinée à from/to, ...) restent dans les handlers
+//
+// PERFORMANCE: les expressions régulières sont compilées une fois, à l'enregistrement de la route
+func Validate(route Route) http.HandlerFunc {
+	known := make(map[string]bool, len(route.Operation.Parameters))
+	patterns := make(map[string]*regexp.Regexp)
+	for _, p := range route.Operation.Parameters {
+		if p.In == "query" {
+			known[p.Name] = true
+		}
+		if p.Schema != nil && p.Schema.Pattern != "" {
+			patterns[p.Name] = regexp.MustCompile(p.Schema.Pattern)
+		}
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var invalid []problem.InvalidParam
+
+		for _, p := range route.Operation.Parameters {
+			var values []string
+			switch p.In {
+			case "query":
+				values = query[p.Name]
+			case "path":
+				values = []string{r.PathValue(p.Name)}
+			default:
+				continue
+			}
+
+			if len(values) == 0 || values[0] == "" {
+				if p.Required {
+					invalid = append(invalid, problem.InvalidParam{Name: p.Name, In: p.In, Reason: "is required"})
+				}
+				continue
+			}
+			if len(values) > 1 {
+				invalid = append(invalid, problem.InvalidParam{Name: p.Name, In: p.In, Value: strings.Join(values, ","), Reason: "must be given only once"})
+				continue
+			}
+			if reason := checkValue(p.Schema, patterns[p.Name], values[0]); reason != "" {
+				invalid = append(invalid, problem.InvalidParam{Name: p.Name, In: p.In, Value: values[0], Reason: reason})
+			}
+		}
+
+		// SYNTAXE: les clés d'une map sont parcourues dans un ordre aléatoire, triées pour une réponse stable
+		var unknown []string
+		for name := range query {
+			if route.StrictQuery && !known[name] {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		for _, name := range unknown {
+			invalid = append(invalid, problem.InvalidParam{Name: name, In: "query", Value: query.Get(name), Reason: "is not a known parameter"})
+		}
+
+		if len(invalid) > 0 {
+			writeInvalidParams(w, r, invalid)
+			return
+		}
+		route.Handler(w, r)
+	}
+}
+
+// checkValue retourne la raison du rejet de value ("" si la valeur respecte le schéma)
+func checkValue(schema *Schema, pattern *regexp.Regexp, value string) string {
+	if schema == nil {
+		return ""
+	}
+
+	switch schema.Type {
+	case "integer":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "must be an integer"
+		}
+		return checkRange(schema, float64(n))
+	case "number":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return "must be a number"
+		}
+		return checkRange(schema, f)
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "must be a boolean (true or false)"
+		}
+	case "string":
+		if schema.Format == "date" {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				return "must be a date (YYYY-MM-DD)"
+			}
+		}
+		if len(schema.Enum) > 0 && !containsFold(schema.Enum, value) {
+			return "must be one of " + strings.Join(schema.Enum, ", ")
+		}
+		if pattern != nil && !pattern.MatchString(value) {
+			return "must match " + schema.Pattern
+		}
+	}
+	return ""
+}
+
+// checkRange vérifie les bornes minimum / maximum du schéma
+func checkRange(schema *Schema, v float64) string {
+	if schema.Minimum != nil && v < *schema.Minimum {
+		return fmt.Sprintf("must be >= %v", *schema.Minimum)
+	}
+	if schema.Maximum != nil && v > *schema.Maximum {
+		return fmt.Sprintf("must be <= %v", *schema.Maximum)
+	}
+	return ""
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeInvalidParams écrit la réponse 400 structurée
+func writeInvalidParams(w http.ResponseWriter, r *http.Request, invalid []problem.InvalidParam) {
+	names := make([]string, 0, len(invalid))
+	for _, p := range invalid {
+		names = append(names, p.Name)
+	}
+	problem.WriteInvalidParams(w, r, "invalid parameters: "+strings.Join(names, ", "), invalid)
+}
@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"eval/api/problem"
+)
+
+// testRoute route dont le handler signale simplement qu'il a été appelé
+func testRoute(called *bool) Route {
+	return Route{
+		Method:      http.MethodGet,
+		Path:        "/items/{id}",
+		StrictQuery: true,
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			*called = true
+		},
+		Operation: Operation{
+			Parameters: []Parameter{
+				PathParam("id", "", IntegerSchema(1, 0)),
+				QueryParam("days", "", IntegerSchema(1, 0).WithDefault(30)),
+				QueryParam("ratio", "", NumberRangeSchema(0, 1)),
+				QueryParam("sort", "", EnumSchema("lift", "support")),
+				QueryParam("from", "", DateSchema()),
+				QueryParam("currency", "", PatternSchema(`^[a-zA-Z]{3}$`)),
+				RequiredQueryParam("product_id", "", IntegerSchema(1, 0)),
+			},
+		},
+	}
+}
+
+func serve(route Route, target string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc(route.Pattern(), Validate(route))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w
+}
+
+// TestValidate_AcceptsValidParameters vérifie que les valeurs conformes (et vides) atteignent le handler
+func TestValidate_AcceptsValidParameters(t *testing.T) {
+	var called bool
+	w := serve(testRoute(&called), "/items/7?product_id=3&days=&ratio=0.5&sort=LIFT&from=2024-01-31&currency=usd")
+	if w.Code != http.StatusOK || !called {
+		t.Errorf("status = %d, called = %v, want 200 and the handler called", w.Code, called)
+	}
+}
+
+// TestValidate_RejectsInvalidParameters vérifie la réponse 400 structurée, toutes erreurs réunies
+func TestValidate_RejectsInvalidParameters(t *testing.T) {
+	var called bool
+	w := serve(testRoute(&called), "/items/abc?days=abc&ratio=2&sort=name&from=31/01/2024&currency=EURO&dayz=30")
+
+	if w.Code != http.StatusBadRequest || called {
+		t.Fatalf("status = %d, called = %v, want 400 without calling the handler", w.Code, called)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != problem.ContentType {
+		t.Errorf("Content-Type = %q, want %s", ct, problem.ContentType)
+	}
+
+	var body problem.Problem
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Status != http.StatusBadRequest || body.Code != problem.CodeInvalidParameters {
+		t.Errorf("status = %d, code = %q, want 400 invalid_parameters", body.Status, body.Code)
+	}
+
+	want := []struct{ parameter, in, reason string }{
+		{"id", "path", "must be an integer"},
+		{"days", "query", "must be an integer"},
+		{"ratio", "query", "must be <= 1"},
+		{"sort", "query", "must be one of lift, support"},
+		{"from", "query", "must be a date (YYYY-MM-DD)"},
+		{"currency", "query", "must match ^[a-zA-Z]{3}$"},
+		{"product_id", "query", "is required"},
+		{"dayz", "query", "is not a known parameter"},
+	}
+	if len(body.InvalidParams) != len(want) {
+		t.Fatalf("got %d invalid params, want %d: %+v", len(body.InvalidParams), len(want), body.InvalidParams)
+	}
+	for i, w := range want {
+		d := body.InvalidParams[i]
+		if d.Name != w.parameter || d.In != w.in || d.Reason != w.reason {
+			t.Errorf("detail %d = %+v, want %s (%s): %s", i, d, w.parameter, w.in, w.reason)
+		}
+	}
+}
+
+// TestValidate_RepeatedParameter vérifie qu'un paramètre donné deux fois est rejeté (un seul est lu)
+func TestValidate_RepeatedParameter(t *testing.T) {
+	var called bool
+	w := serve(testRoute(&called), "/items/7?product_id=3&days=7&days=30")
+	if w.Code != http.StatusBadRequest || called {
+		t.Errorf("status = %d, called = %v, want 400", w.Code, called)
+	}
+}
+
+// TestValidate_UnknownParametersOptIn vérifie qu'un paramètre inconnu n'est rejeté que sur une route StrictQuery
+func TestValidate_UnknownParametersOptIn(t *testing.T) {
+	var called bool
+	route := testRoute(&called)
+	route.StrictQuery = false
+
+	if w := serve(route, "/items/7?product_id=3&dayz=30"); w.Code != http.StatusOK || !called {
+		t.Errorf("lenient route: status = %d, called = %v, want 200 and the handler called", w.Code, called)
+	}
+
+	called = false
+	route.StrictQuery = true
+	if w := serve(route, "/items/7?product_id=3&dayz=30"); w.Code != http.StatusBadRequest || called {
+		t.Errorf("strict route: status = %d, called = %v, want 400 without calling the handler", w.Code, called)
+	}
+}
@@ -0,0 +1,125 @@
+package problem
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	shareddomain "eval/internal/shared/domain"
+)
+
+// ========================================
+// RÉPONSES D'ERREUR application/problem+json (RFC 9457)
+// ========================================
+// Toutes les erreurs de l'API ont la même forme, quel que soit le handler:
+//
+//	{"type":"about:blank","title":"Bad Request","status":400,"code":"invalid_days",
+//	 "detail":"days cannot be negative","request_id":"3f2a9c..."}
+//
+// Le status est déduit de la catégorie de l'erreur du domaine (shareddomain.ErrorKind):
+//   - validation → 400, not_found → 404, conflict → 409, unavailable → 503
+//   - erreur non typée (SQL, I/O, ...) → 500, détail masqué au client et journalisé avec l'ID de requête
+//   - detail = Message() de l'erreur du domaine; le texte complet (contexte fmt.Errorf, cause) est journalisé
+//
+// request_id (en-tête X-Request-ID, voir RequestID) permet de retrouver la ligne de log d'une réponse
+
+// ContentType type MIME des réponses d'erreur
+const ContentType = "application/problem+json"
+
+// Codes des erreurs qui ne proviennent pas du domaine
+const (
+	CodeInternal          = "internal_error"
+	CodeInvalidParameters = "invalid_parameters"
+)
+
+// Problem corps JSON d'une réponse d'erreur
+type Problem struct {
+	Type          string         `json:"type"`  // toujours "about:blank": la sémantique est celle du status
+	Title         string         `json:"title"` // libellé du status (http.StatusText)
+	Status        int            `json:"status"`
+	Code          string         `json:"code"`   // identifiant stable ("invalid_period"), à tester côté client
+	Detail        string         `json:"detail"` // message lisible, peut évoluer
+	RequestID     string         `json:"request_id,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"` // code invalid_parameters uniquement
+}
+
+// InvalidParam problème sur un paramètre de requête ou de chemin
+type InvalidParam struct {
+	Name   string `json:"name"`
+	In     string `json:"in"` // query | path
+	Value  string `json:"value,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// New construit le problème correspondant à err
+// Seul le message d'une erreur du domaine est exposé: le détail d'une erreur non typée
+// (requête SQL, chemin de fichier, ...) ne sort jamais de l'application
+// SÉCURITÉ: Message() et non err.Error(), quelle que soit la catégorie
+//   - Error() contient le texte des wrappers fmt.Errorf("...: %w", err) et la cause technique
+//     (identifiants, chemins, adresse de la base, ...): il est journalisé par Write, jamais exposé
+func New(err error, requestID string) Problem {
+	domainErr, ok := shareddomain.AsError(err)
+	if !ok {
+		return newProblem(http.StatusInternalServerError, CodeInternal, "Internal server error", requestID)
+	}
+	return newProblem(StatusOf(domainErr.Kind()), domainErr.Code(), domainErr.Message(), requestID)
+}
+
+// StatusOf retourne le status HTTP d'une catégorie d'erreur du domaine
+func StatusOf(kind shareddomain.ErrorKind) int {
+	switch kind {
+	case shareddomain.ErrorKindValidation:
+		return http.StatusBadRequest
+	case shareddomain.ErrorKindNotFound:
+		return http.StatusNotFound
+	case shareddomain.ErrorKindConflict:
+		return http.StatusConflict
+	case shareddomain.ErrorKindUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Write répond avec le problème correspondant à err
+// Les erreurs 5xx, et celles dont le texte complet diffère du détail exposé (contexte ajouté
+// par un wrapper), sont journalisées avec l'ID de requête, la méthode et le chemin:
+// les handlers n'ont pas à le faire eux-mêmes
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	requestID := RequestIDFromContext(r.Context())
+	p := New(err, requestID)
+	if p.Status >= http.StatusInternalServerError || err.Error() != p.Detail {
+		log.Printf("[%s] %s %s: %d %v", requestID, r.Method, r.URL.Path, p.Status, err)
+	}
+	WriteProblem(w, p)
+}
+
+// WriteInvalidParams répond 400 invalid_parameters avec la liste des paramètres rejetés
+func WriteInvalidParams(w http.ResponseWriter, r *http.Request, detail string, params []InvalidParam) {
+	p := newProblem(http.StatusBadRequest, CodeInvalidParameters, detail, RequestIDFromContext(r.Context()))
+	p.InvalidParams = params
+	WriteProblem(w, p)
+}
+
+// WriteProblem écrit p en application/problem+json avec son status
+// PIÈGE: les en-têtes doivent être fixés avant WriteHeader, ils sont ignorés ensuite
+//   - un export a pu fixer Content-Disposition avant l'erreur: retiré pour que l'erreur
+//     ne soit pas téléchargée sous le nom du fichier attendu
+func WriteProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Del("Content-Disposition")
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+func newProblem(status int, code, detail, requestID string) Problem {
+	return Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Code:      code,
+		Detail:    detail,
+		RequestID: requestID,
+	}
+}
@@ -0,0 +1,153 @@
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	shareddomain "eval/internal/shared/domain"
+)
+
+// TestNew vérifie le status, le code et le détail exposé pour chaque catégorie d'erreur
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		detail string
+	}{
+		{
+			name:   "validation",
+			err:    shareddomain.NewValidationError("invalid_days", "days cannot be negative"),
+			status: http.StatusBadRequest, code: "invalid_days", detail: "days cannot be negative",
+		},
+		{
+			name:   "wrapper context is not exposed",
+			err:    fmt.Errorf("load /var/exports/abc.csv: %w", shareddomain.NewNotFoundError("export_job_not_found", "export job not found")),
+			status: http.StatusNotFound, code: "export_job_not_found", detail: "export job not found",
+		},
+		{
+			name:   "conflict",
+			err:    shareddomain.NewConflictError("export_job_not_ready", "export job is not finished"),
+			status: http.StatusConflict, code: "export_job_not_ready", detail: "export job is not finished",
+		},
+		{
+			name:   "unavailable hides the cause",
+			err:    shareddomain.NewUnavailableError("database_unavailable", "database is unreachable", errors.New("dial tcp 10.0.0.5:5432")),
+			status: http.StatusServiceUnavailable, code: "database_unavailable", detail: "database is unreachable",
+		},
+		{
+			name:   "untyped error hides the detail",
+			err:    errors.New(`pq: relation "orders" does not exist`),
+			status: http.StatusInternalServerError, code: CodeInternal, detail: "Internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(tt.err, "req-1")
+			if p.Status != tt.status || p.Code != tt.code || p.Detail != tt.detail {
+				t.Errorf("New = %d %s %q, want %d %s %q", p.Status, p.Code, p.Detail, tt.status, tt.code, tt.detail)
+			}
+			if p.Title != http.StatusText(tt.status) || p.Type != "about:blank" || p.RequestID != "req-1" {
+				t.Errorf("New = %+v: unexpected type, title or request_id", p)
+			}
+		})
+	}
+}
+
+// TestWrite_WithRequestID vérifie la réponse problem+json complète derrière le middleware RequestID
+func TestWrite_WithRequestID(t *testing.T) {
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", "attachment; filename=sales.csv")
+		Write(w, r, shareddomain.NewValidationError("invalid_period", `invalid period: "2025-Q5"`))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/stats?period=2025-Q5", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q, want %s", ct, ContentType)
+	}
+	if cd := w.Header().Get("Content-Disposition"); cd != "" {
+		t.Errorf("Content-Disposition = %q, want it removed", cd)
+	}
+
+	var body Problem
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	id := w.Header().Get(RequestIDHeader)
+	if len(id) != 32 || body.RequestID != id {
+		t.Errorf("request_id = %q, header = %q, want the same generated identifier", body.RequestID, id)
+	}
+	if body.Code != "invalid_period" || body.Status != http.StatusBadRequest {
+		t.Errorf("body = %+v, want 400 invalid_period", body)
+	}
+}
+
+// TestWrite_LogsFullError vérifie que le texte complet d'une erreur enveloppée est journalisé
+// avec l'ID de requête, alors que la réponse n'expose que le message du domaine
+func TestWrite_LogsFullError(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	err := fmt.Errorf("%w (status running)", shareddomain.NewConflictError("export_job_not_ready", "export job is not finished"))
+	r := httptest.NewRequest(http.MethodGet, "/api/v2/exports/abc/download", nil)
+	r.Header.Set(RequestIDHeader, "trace-7")
+	w := httptest.NewRecorder()
+	RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, err)
+	})).ServeHTTP(w, r)
+
+	var body Problem
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Detail != "export job is not finished" {
+		t.Errorf("detail = %q, want the domain message only", body.Detail)
+	}
+	if line := logs.String(); !strings.Contains(line, "[trace-7]") || !strings.Contains(line, err.Error()) {
+		t.Errorf("log = %q, want the request ID and %q", line, err.Error())
+	}
+}
+
+// TestRequestID_ClientValue vérifie que l'identifiant du client est repris s'il est valide
+func TestRequestID_ClientValue(t *testing.T) {
+	var seen string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+	}))
+
+	for header, wantKept := range map[string]bool{
+		"client-trace-42":        true,
+		"":                       false,
+		"with space":             false,
+		"line\nbreak":            false,
+		strings.Repeat("a", 129): false,
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+		r.Header.Set(RequestIDHeader, header)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if kept := seen == header; kept != wantKept {
+			t.Errorf("X-Request-ID %q: kept = %v, want %v", header, kept, wantKept)
+		}
+		if got := w.Header().Get(RequestIDHeader); got != seen || got == "" {
+			t.Errorf("X-Request-ID %q: response header = %q, context = %q", header, got, seen)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package problem
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader en-tête portant l'identifiant de requête (requête et réponse)
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength longueur maximale d'un identifiant fourni par le client
+const maxRequestIDLength = 128
+
+// requestIDKey clé de contexte de l'identifiant de requête
+// SYNTAXE: type non exporté, aucune collision possible avec une clé d'un autre package
+type requestIDKey struct{}
+
+// RequestID middleware qui attribue un identifiant à chaque requête
+//   - reprend X-Request-ID s'il est fourni (proxy, client qui corrèle ses propres logs)
+//   - sinon en génère un (16 octets aléatoires en hexadécimal)
+//
+// L'identifiant est renvoyé dans l'en-tête X-Request-ID de toutes les réponses
+// et dans le champ request_id des réponses d'erreur
+//
+// SÉCURITÉ: un identifiant client trop long ou non imprimable est remplacé (injection dans les logs)
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+// RequestIDFromContext retourne l'identifiant de la requête ("" hors du middleware RequestID)
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// validRequestID accepte 1 à maxRequestIDLength caractères ASCII imprimables, sans espace
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID génère un identifiant aléatoire
+// crypto/rand.Read ne retourne jamais d'erreur depuis Go 1.24
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
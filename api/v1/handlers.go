@@ -2,10 +2,10 @@ package v1
 
 import (
 	"encoding/json"
-	"log"
 	"net/http"
 	"strconv"
 
+	"eval/api/problem"
 	analyticsapp "eval/internal/analytics/application"
 	exportapp "eval/internal/export/application"
 )
@@ -57,8 +57,7 @@ func (h *Handlers) GetStats(w http.ResponseWriter, r *http.Request) {
 	//   - Bubble sort O(n²) sur potentiellement des milliers de produits
 	stats, err := h.statsService.GetStats(days)
 	if err != nil {
-		log.Printf("Error getting stats (V1): %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -82,8 +81,7 @@ func (h *Handlers) ExportCSV(w http.ResponseWriter, r *http.Request) {
 	// Export avec N+1 queries (inefficace)
 	csvData, err := h.exportService.ExportSalesToCSV(days)
 	if err != nil {
-		log.Printf("Error exporting CSV (V1): %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -102,8 +100,7 @@ func (h *Handlers) ExportStatsCSV(w http.ResponseWriter, r *http.Request) {
 
 	csvData, err := h.exportService.ExportStatsToCSV(days)
 	if err != nil {
-		log.Printf("Error exporting stats CSV (V1): %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -122,8 +119,7 @@ func (h *Handlers) ExportParquet(w http.ResponseWriter, r *http.Request) {
 
 	parquetData, err := h.exportService.ExportToParquet(days)
 	if err != nil {
-		log.Printf("Error exporting Parquet (V1): %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -132,26 +128,24 @@ func (h *Handlers) ExportParquet(w http.ResponseWriter, r *http.Request) {
 	w.Write(parquetData)
 }
 
-// statsToJSON convertit les stats du domaine en format JSON
+// StatsResponse réponse de GET /api/v1/stats
 // SYNTAXE: interface{} = type "any" en Go, accepte n'importe quel type
 //   - Similaire à Object en Java ou any en TypeScript
-//   - Perte de type safety mais gain de flexibilité
-//
-// SYNTAXE: map[string]interface{} = dictionnaire/hashmap clé=string, valeur=n'importe quoi
-//   - map[KeyType]ValueType est la syntaxe des hashmaps en Go
-//
-// MÉMOIRE: map est alloué sur le HEAP (structure dynamique)
-//   - Contient des pointeurs vers les buckets internes
-//   - Les strings sont aussi sur le HEAP (immutables en Go)
+//   - Perte de type safety mais gain de flexibilité: stats est encodé tel quel
 //
-// PERFORMANCE: ⚠️ Interface{} nécessite boxing/unboxing (overhead léger)
-//   - Dans un vrai projet, utiliser des structs typées (DTOs) est plus performant
-func (h *Handlers) statsToJSON(stats interface{}) map[string]interface{} {
-	// Pour simplifier, on retourne une structure générique
-	// Dans un vrai projet, on créerait des DTOs spécifiques
-	return map[string]interface{}{
-		"version": "v1",
-		"message": "Stats calculated with V1 (inefficient: N+1 queries + bubble sort)",
-		"stats":   stats,
+// PERFORMANCE: une struct typée (DTO) évite la map[string]interface{} (allouée sur le HEAP,
+// une entrée par clé) et décrit les champs de premier niveau dans le document OpenAPI
+type StatsResponse struct {
+	Version string      `json:"version"`
+	Message string      `json:"message"`
+	Stats   interface{} `json:"stats"`
+}
+
+// statsToJSON convertit les stats du domaine en format JSON
+func (h *Handlers) statsToJSON(stats interface{}) StatsResponse {
+	return StatsResponse{
+		Version: "v1",
+		Message: "Stats calculated with V1 (inefficient: N+1 queries + bubble sort)",
+		Stats:   stats,
 	}
 }
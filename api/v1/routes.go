@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"net/http"
+
+	"eval/api/openapi"
+)
+
+// Routes décrit les routes V1 (enregistrement, validation des paramètres et document OpenAPI)
+// V1 n'accepte que days=N: un days invalide est rejeté par la validation (400)
+// au lieu de retomber silencieusement sur la valeur par défaut du handler
+func (h *Handlers) Routes() []openapi.Route {
+	tags := []string{"v1"}
+
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/api/v1/stats", Handler: h.GetStats,
+			Operation: openapi.Operation{
+				OperationID: "getStatsV1",
+				Summary:     "Statistiques (version non optimisée)",
+				Tags:        tags,
+				Parameters:  []openapi.Parameter{daysParam(365)},
+				Responses:   map[string]openapi.Response{"200": openapi.JSONResponse("Statistiques", StatsResponse{})},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/export/csv", Handler: h.ExportCSV,
+			Operation: openapi.Operation{
+				OperationID: "exportSalesCSVV1",
+				Summary:     "Export CSV des ventes (version non optimisée)",
+				Tags:        tags,
+				Parameters:  []openapi.Parameter{daysParam(30)},
+				Responses:   map[string]openapi.Response{"200": openapi.FileResponse("Lignes de vente", "text/csv")},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/export/stats-csv", Handler: h.ExportStatsCSV,
+			Operation: openapi.Operation{
+				OperationID: "exportStatsCSVV1",
+				Summary:     "Export CSV des statistiques (version non optimisée)",
+				Tags:        tags,
+				Parameters:  []openapi.Parameter{daysParam(365)},
+				Responses:   map[string]openapi.Response{"200": openapi.FileResponse("Statistiques", "text/csv")},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v1/export/parquet", Handler: h.ExportParquet,
+			Operation: openapi.Operation{
+				OperationID: "exportSalesParquetV1",
+				Summary:     "Export Parquet des ventes (version non optimisée)",
+				Tags:        tags,
+				Parameters:  []openapi.Parameter{daysParam(30)},
+				Responses:   map[string]openapi.Response{"200": openapi.FileResponse("Fichier Parquet", "application/octet-stream")},
+			},
+		},
+	}
+}
+
+// daysParam paramètre days=N (N derniers jours, defaultDays si absent)
+func daysParam(defaultDays int) openapi.Parameter {
+	return openapi.QueryParam("days", "Les N derniers jours", openapi.IntegerSchema(1, 0).WithDefault(defaultDays))
+}
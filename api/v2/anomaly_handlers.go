@@ -0,0 +1,158 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"eval/api/problem"
+	analyticsdomain "eval/internal/analytics/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// GetAnomalies handler pour GET /api/v2/stats/anomalies
+// Jours analysés: la période (30 jours par défaut), chacun comparé à ses baseline_days jours précédents (28)
+// method=mad|zscore (mad par défaut), threshold (3.5 en mad, 3 en zscore)
+// dimension=store|category et min_severity=warning|major|critical filtrent le rapport
+func (h *Handlers) GetAnomalies(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	dateRange, err := parseDateRange(params, 30)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	settings, err := parseAnomalySettings(params)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	dimension, err := analyticsdomain.ParseAnomalyDimension(params.Get("dimension"))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	minSeverity, err := analyticsdomain.ParseAnomalySeverity(params.Get("min_severity"))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	status, err := parseStatusFilter(params)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	currency, err := parseCurrency(params)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	report, err := h.anomalyService.GetAnomalyReport(dateRange, settings, status, currency)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	anomalies := report.Filter(dimension, minSeverity)
+	result := make([]AnomalyDTO, 0, len(anomalies))
+	for _, a := range anomalies {
+		contributions := make([]AnomalyContributionDTO, 0, len(a.Contributions()))
+		for _, c := range a.Contributions() {
+			direction := "spike"
+			if c.IsDrop() {
+				direction = "drop"
+			}
+			contributions = append(contributions, AnomalyContributionDTO{
+				Dimension: string(c.Dimension()),
+				ID:        c.DimensionID(),
+				Name:      c.Name(),
+				Metric:    string(c.Metric()),
+				Value:     c.Value(),
+				Expected:  c.Expected(),
+				Score:     c.Score(),
+				Severity:  string(c.Severity()),
+				Direction: direction,
+			})
+		}
+		result = append(result, AnomalyDTO{
+			Date:          a.Day().Format(dateParamLayout),
+			Severity:      string(a.Severity()),
+			Contributions: contributions,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(AnomalyReportResponse{
+		Version:      statsSchemaVersion,
+		Period:       periodToJSON(dateRange),
+		Currency:     currency,
+		Method:       string(settings.Method()),
+		Threshold:    settings.Threshold(),
+		BaselineDays: settings.BaselineDays(),
+		SeriesCount:  report.SeriesCount(),
+		Anomalies:    result,
+	})
+}
+
+// AnomalyReportResponse réponse de GET /api/v2/stats/anomalies
+type AnomalyReportResponse struct {
+	Version      string                `json:"version"`
+	Period       PeriodDTO             `json:"period"`
+	Currency     shareddomain.Currency `json:"currency"`
+	Method       string                `json:"method"`
+	Threshold    float64               `json:"threshold"`
+	BaselineDays int                   `json:"baseline_days"`
+	SeriesCount  int                   `json:"series_count"`
+	Anomalies    []AnomalyDTO          `json:"anomalies"`
+}
+
+// AnomalyDTO jour anormal et les séries qui l'expliquent
+type AnomalyDTO struct {
+	Date          string                   `json:"date"`
+	Severity      string                   `json:"severity"`
+	Contributions []AnomalyContributionDTO `json:"contributions"`
+}
+
+// AnomalyContributionDTO écart d'une série (direction = spike | drop)
+type AnomalyContributionDTO struct {
+	Dimension string  `json:"dimension"`
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Metric    string  `json:"metric"`
+	Value     float64 `json:"value"`
+	Expected  float64 `json:"expected"`
+	Score     float64 `json:"score"`
+	Severity  string  `json:"severity"`
+	Direction string  `json:"direction"`
+}
+
+// parseAnomalySettings lit method, threshold et baseline_days (défauts du domaine si absents)
+func parseAnomalySettings(params url.Values) (analyticsdomain.AnomalySettings, error) {
+	method, err := analyticsdomain.ParseAnomalyMethod(params.Get("method"))
+	if err != nil {
+		return analyticsdomain.AnomalySettings{}, err
+	}
+
+	threshold := method.DefaultThreshold()
+	if value := params.Get("threshold"); value != "" {
+		if threshold, err = strconv.ParseFloat(value, 64); err != nil {
+			return analyticsdomain.AnomalySettings{}, shareddomain.NewValidationError("invalid_threshold", fmt.Sprintf("invalid threshold: %q", value))
+		}
+	}
+
+	baselineDays := analyticsdomain.DefaultAnomalyBaselineDays
+	if value := params.Get("baseline_days"); value != "" {
+		if baselineDays, err = strconv.Atoi(value); err != nil {
+			return analyticsdomain.AnomalySettings{}, shareddomain.NewValidationError("invalid_baseline_days", fmt.Sprintf("invalid baseline_days: %q", value))
+		}
+	}
+
+	return analyticsdomain.NewAnomalySettings(method, threshold, baselineDays)
+}
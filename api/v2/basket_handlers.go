@@ -0,0 +1,158 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"eval/api/problem"
+	analyticsdomain "eval/internal/analytics/domain"
+	catalogdomain "eval/internal/catalog/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// basketParams paramètres communs aux endpoints d'analyse du panier
+type basketParams struct {
+	triples    bool
+	minSupport float64
+	sortBy     analyticsdomain.RuleSort
+	limit      int
+}
+
+// parseBasketParams lit triples, min_support (0-1), sort (lift|confidence|support) et limit (20 par défaut)
+func parseBasketParams(params url.Values) (basketParams, error) {
+	p := basketParams{limit: 20}
+
+	var err error
+	if p.sortBy, err = analyticsdomain.ParseRuleSort(params.Get("sort")); err != nil {
+		return p, err
+	}
+	if value := params.Get("triples"); value != "" {
+		if p.triples, err = strconv.ParseBool(value); err != nil {
+			return p, shareddomain.NewValidationError("invalid_triples", fmt.Sprintf("invalid triples: %q", value))
+		}
+	}
+	if value := params.Get("min_support"); value != "" {
+		if p.minSupport, err = strconv.ParseFloat(value, 64); err != nil || p.minSupport < 0 || p.minSupport > 1 {
+			return p, shareddomain.NewValidationError("invalid_min_support",
+				fmt.Sprintf("invalid min_support: %q (expected a fraction between 0 and 1)", value))
+		}
+	}
+	if value := params.Get("limit"); value != "" {
+		if p.limit, err = strconv.Atoi(value); err != nil || p.limit <= 0 {
+			return p, shareddomain.NewValidationError("invalid_limit", fmt.Sprintf("invalid limit: %q", value))
+		}
+	}
+
+	return p, nil
+}
+
+// GetBasketRules handler pour GET /api/v2/stats/basket?product_id=42
+// Produits le plus souvent achetés avec product_id sur la période (365 jours par défaut)
+func (h *Handlers) GetBasketRules(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(r.URL.Query().Get("product_id"), 10, 64)
+	if err != nil || productID <= 0 {
+		problem.Write(w, r, shareddomain.NewValidationError("invalid_product_id", "product_id is required and must be a positive integer"))
+		return
+	}
+
+	h.writeBasketRules(w, r, func(analysis *analyticsdomain.BasketAnalysis, p basketParams) []*analyticsdomain.AssociationRule {
+		return analysis.RulesFor(catalogdomain.ProductID(productID), p.minSupport, p.sortBy, p.limit)
+	})
+}
+
+// GetTopBasketRules handler pour GET /api/v2/stats/basket/top
+// Meilleures règles d'association de la période, tous produits confondus
+func (h *Handlers) GetTopBasketRules(w http.ResponseWriter, r *http.Request) {
+	h.writeBasketRules(w, r, func(analysis *analyticsdomain.BasketAnalysis, p basketParams) []*analyticsdomain.AssociationRule {
+		return analysis.TopRules(p.minSupport, p.sortBy, p.limit)
+	})
+}
+
+// writeBasketRules partie commune: période, paramètres, analyse (cache ou worker pool) et réponse JSON
+// selectRules choisit les règles à renvoyer dans l'analyse
+func (h *Handlers) writeBasketRules(
+	w http.ResponseWriter,
+	r *http.Request,
+	selectRules func(*analyticsdomain.BasketAnalysis, basketParams) []*analyticsdomain.AssociationRule,
+) {
+	dateRange, err := parseDateRange(r.URL.Query(), 365)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	params, err := parseBasketParams(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	analysis, err := h.basketService.GetBasketAnalysis(r.Context(), dateRange, params.triples)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	rules := selectRules(analysis, params)
+	response := make([]AssociationRuleDTO, 0, len(rules))
+	for _, rule := range rules {
+		antecedent := make([]BasketProductDTO, 0, len(rule.Antecedent()))
+		for _, p := range rule.Antecedent() {
+			antecedent = append(antecedent, basketProductToJSON(p))
+		}
+		response = append(response, AssociationRuleDTO{
+			Antecedent: antecedent,
+			Consequent: basketProductToJSON(rule.Consequent()),
+			OrderCount: rule.OrderCount(),
+			Support:    rule.Support(),
+			Confidence: rule.Confidence(),
+			Lift:       rule.Lift(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(BasketRulesResponse{
+		Version:     statsSchemaVersion,
+		Period:      periodToJSON(dateRange),
+		TotalOrders: analysis.TotalOrders(),
+		Triples:     analysis.IncludesTriples(),
+		Sort:        string(params.sortBy),
+		Rules:       response,
+	})
+}
+
+// BasketRulesResponse réponse de GET /api/v2/stats/basket et /api/v2/stats/basket/top
+type BasketRulesResponse struct {
+	Version     string               `json:"version"`
+	Period      PeriodDTO            `json:"period"`
+	TotalOrders int                  `json:"total_orders"`
+	Triples     bool                 `json:"triples"`
+	Sort        string               `json:"sort"`
+	Rules       []AssociationRuleDTO `json:"rules"`
+}
+
+// AssociationRuleDTO règle antecedent → consequent (support, confiance et lift entre 0 et 1, lift > 1 = affinité)
+type AssociationRuleDTO struct {
+	Antecedent []BasketProductDTO `json:"antecedent"`
+	Consequent BasketProductDTO   `json:"consequent"`
+	OrderCount int                `json:"order_count"`
+	Support    float64            `json:"support"`
+	Confidence float64            `json:"confidence"`
+	Lift       float64            `json:"lift"`
+}
+
+// BasketProductDTO produit d'une règle
+type BasketProductDTO struct {
+	ProductID int64  `json:"product_id"`
+	Name      string `json:"name"`
+}
+
+func basketProductToJSON(p analyticsdomain.BasketProduct) BasketProductDTO {
+	return BasketProductDTO{
+		ProductID: int64(p.ID()),
+		Name:      p.Name(),
+	}
+}
@@ -0,0 +1,121 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"eval/api/problem"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// cohortMonthLayout format des mois de cohorte ("2024-01")
+const cohortMonthLayout = "2006-01"
+
+// GetCohorts handler pour GET /api/v2/stats/cohorts
+// Matrice de rétention des clients acquis sur la période (365 jours par défaut)
+// Chaque cohorte liste ses mois observés, de l'acquisition (month_offset=0) à la fin de la période
+func (h *Handlers) GetCohorts(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := parseDateRange(r.URL.Query(), 365)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	currency, err := parseCurrency(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	analysis, err := h.cohortService.GetCohortRetention(dateRange, currency)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	cohorts := make([]CohortDTO, 0, len(analysis.Cohorts()))
+	for _, cohort := range analysis.Cohorts() {
+		months := make([]CohortMonthDTO, 0, len(cohort.Cells()))
+		for _, cell := range cohort.Cells() {
+			months = append(months, CohortMonthDTO{
+				MonthOffset:                  cell.MonthOffset(),
+				Month:                        cell.Month().Format(cohortMonthLayout),
+				ActiveCustomers:              cell.ActiveCustomers(),
+				RetentionRate:                cell.RetentionRate(),
+				Revenue:                      cell.Revenue().Amount(),
+				RevenuePerCustomer:           cell.RevenuePerCustomer().Amount(),
+				CumulativeRevenuePerCustomer: cell.CumulativeRevenuePerCustomer().Amount(),
+			})
+		}
+
+		cohorts = append(cohorts, CohortDTO{
+			Cohort:       cohort.Month().Format(cohortMonthLayout),
+			Size:         cohort.Size(),
+			TotalRevenue: cohort.TotalRevenue().Amount(),
+			Months:       months,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(CohortsResponse{
+		Version:        statsSchemaVersion,
+		Period:         periodToJSON(dateRange),
+		Currency:       currency,
+		TotalCustomers: analysis.TotalCustomers(),
+		Cohorts:        cohorts,
+	})
+}
+
+// CohortsResponse réponse de GET /api/v2/stats/cohorts
+type CohortsResponse struct {
+	Version        string                `json:"version"`
+	Period         PeriodDTO             `json:"period"`
+	Currency       shareddomain.Currency `json:"currency"`
+	TotalCustomers int                   `json:"total_customers"`
+	Cohorts        []CohortDTO           `json:"cohorts"`
+}
+
+// CohortDTO clients dont la première commande tombe dans le mois cohort (YYYY-MM)
+type CohortDTO struct {
+	Cohort       string           `json:"cohort"`
+	Size         int              `json:"size"`
+	TotalRevenue float64          `json:"total_revenue"`
+	Months       []CohortMonthDTO `json:"months"`
+}
+
+// CohortMonthDTO activité de la cohorte month_offset mois après son premier achat
+type CohortMonthDTO struct {
+	MonthOffset                  int     `json:"month_offset"`
+	Month                        string  `json:"month"`
+	ActiveCustomers              int     `json:"active_customers"`
+	RetentionRate                float64 `json:"retention_rate"`
+	Revenue                      float64 `json:"revenue"`
+	RevenuePerCustomer           float64 `json:"revenue_per_customer"`
+	CumulativeRevenuePerCustomer float64 `json:"cumulative_revenue_per_customer"`
+}
+
+// ExportCohortsCSV handler pour GET /api/v2/export/cohorts-csv
+// Même matrice que /api/v2/stats/cohorts, une ligne par (cohorte, mois)
+func (h *Handlers) ExportCohortsCSV(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := parseDateRange(r.URL.Query(), 365)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	currency, err := parseCurrency(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	csvData, err := h.cohortService.ExportCohortsToCSV(dateRange, currency)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=cohorts_v2.csv")
+	w.Write(csvData)
+}
@@ -0,0 +1,295 @@
+package v2
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"eval/api/problem"
+	customersdomain "eval/internal/customers/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// GetCustomerSegments handler pour GET /api/v2/customers/segments
+// Résumé de la segmentation RFM sur la période (365 jours par défaut)
+// segment=champions (optionnel) ajoute la liste des clients du segment, limitée par limit (100 par défaut)
+func (h *Handlers) GetCustomerSegments(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := parseDateRange(r.URL.Query(), 365)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	segment, err := parseSegment(r.URL.Query().Get("segment"))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 100 // Valeur par défaut
+	}
+
+	analysis, err := h.customerService.GetRFMAnalysis(dateRange)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	segments := make([]SegmentSummaryDTO, 0, len(analysis.Segments()))
+	for _, s := range analysis.Segments() {
+		segments = append(segments, SegmentSummaryDTO{
+			Segment:            string(s.Segment()),
+			CustomerCount:      s.CustomerCount(),
+			CustomerPercentage: s.CustomerPercentage(),
+			Revenue:            s.Revenue().Amount(),
+			RevenuePercentage:  s.RevenuePercentage(),
+			AvgRecencyDays:     s.AvgRecencyDays(),
+			AvgFrequency:       s.AvgFrequency(),
+			AvgMonetary:        s.AvgMonetary().Amount(),
+		})
+	}
+
+	response := CustomerSegmentsResponse{
+		Version:           statsSchemaVersion,
+		Period:            periodToJSON(dateRange),
+		ActiveCustomers:   analysis.ActiveCustomers(),
+		InactiveCustomers: analysis.InactiveCustomers(),
+		Segments:          segments,
+	}
+
+	if segment != "" {
+		members := analysis.CustomersInSegment(segment)
+		if len(members) > limit {
+			members = members[:limit]
+		}
+
+		response.Segment = string(segment)
+		response.Customers = make([]SegmentCustomerDTO, 0, len(members))
+		for _, c := range members {
+			response.Customers = append(response.Customers, SegmentCustomerDTO{
+				CustomerID:    int64(c.CustomerID()),
+				Name:          c.Name(),
+				Email:         c.Email(),
+				RFMScore:      c.Score().String(),
+				LastOrderDate: c.LastOrderDate().Format(dateParamLayout),
+				RecencyDays:   c.RecencyDays(),
+				OrderCount:    c.Frequency(),
+				TotalSpent:    c.Monetary().Amount(),
+			})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// CustomerSegmentsResponse réponse de GET /api/v2/customers/segments
+// SYNTAXE: omitzero omet segment ("") et customers (slice nil) quand le paramètre segment est absent;
+// une liste vide mais non nil reste encodée []
+type CustomerSegmentsResponse struct {
+	Version           string               `json:"version"`
+	Period            PeriodDTO            `json:"period"`
+	ActiveCustomers   int                  `json:"active_customers"`
+	InactiveCustomers int                  `json:"inactive_customers"`
+	Segments          []SegmentSummaryDTO  `json:"segments"`
+	Segment           string               `json:"segment,omitzero"`
+	Customers         []SegmentCustomerDTO `json:"customers,omitzero"`
+}
+
+// SegmentSummaryDTO indicateurs d'un segment RFM
+type SegmentSummaryDTO struct {
+	Segment            string  `json:"segment"`
+	CustomerCount      int     `json:"customer_count"`
+	CustomerPercentage float64 `json:"customer_percentage"`
+	Revenue            float64 `json:"revenue"`
+	RevenuePercentage  float64 `json:"revenue_percentage"`
+	AvgRecencyDays     float64 `json:"avg_recency_days"`
+	AvgFrequency       float64 `json:"avg_frequency"`
+	AvgMonetary        float64 `json:"avg_monetary"`
+}
+
+// SegmentCustomerDTO client du segment demandé
+type SegmentCustomerDTO struct {
+	CustomerID    int64   `json:"customer_id"`
+	Name          string  `json:"name"`
+	Email         string  `json:"email"`
+	RFMScore      string  `json:"rfm_score"`
+	LastOrderDate string  `json:"last_order_date"`
+	RecencyDays   int     `json:"recency_days"`
+	OrderCount    int     `json:"order_count"`
+	TotalSpent    float64 `json:"total_spent"`
+}
+
+// GetTopCustomers handler pour GET /api/v2/customers/top
+// Classement des clients par CA sur la période (365 jours par défaut), paginé par page et page_size (20 par défaut)
+func (h *Handlers) GetTopCustomers(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := parseDateRange(r.URL.Query(), 365)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	pagination, err := parsePagination(r.URL.Query(), 20)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	page, err := h.customerService.GetTopCustomers(dateRange, pagination)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	customers := make([]TopCustomerDTO, 0, len(page.Customers()))
+	for _, c := range page.Customers() {
+		customers = append(customers, TopCustomerDTO{
+			Rank:          c.Rank(),
+			CustomerID:    int64(c.CustomerID()),
+			Name:          c.Name(),
+			Email:         c.Email(),
+			OrderCount:    c.OrderCount(),
+			LastOrderDate: c.LastOrderDate().Format(dateParamLayout),
+			Revenue:       c.Revenue().Amount(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(TopCustomersResponse{
+		Version:        statsSchemaVersion,
+		Period:         periodToJSON(dateRange),
+		Page:           pagination.Page(),
+		PageSize:       pagination.PageSize(),
+		TotalCustomers: page.TotalCustomers(),
+		TotalPages:     page.TotalPages(),
+		Customers:      customers,
+	})
+}
+
+// TopCustomersResponse réponse de GET /api/v2/customers/top
+type TopCustomersResponse struct {
+	Version        string           `json:"version"`
+	Period         PeriodDTO        `json:"period"`
+	Page           int              `json:"page"`
+	PageSize       int              `json:"page_size"`
+	TotalCustomers int              `json:"total_customers"`
+	TotalPages     int              `json:"total_pages"`
+	Customers      []TopCustomerDTO `json:"customers"`
+}
+
+// TopCustomerDTO client classé par CA
+type TopCustomerDTO struct {
+	Rank          int     `json:"rank"`
+	CustomerID    int64   `json:"customer_id"`
+	Name          string  `json:"name"`
+	Email         string  `json:"email"`
+	OrderCount    int     `json:"order_count"`
+	LastOrderDate string  `json:"last_order_date"`
+	Revenue       float64 `json:"revenue"`
+}
+
+// GetCustomerLifetimeValue handler pour GET /api/v2/customers/{id}/lifetime-value
+// CLV historique (total dépensé) et prédictive sur horizon_months mois (12 par défaut)
+func (h *Handlers) GetCustomerLifetimeValue(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, r, shareddomain.NewValidationError("invalid_customer_id", fmt.Sprintf("invalid customer id: %q", r.PathValue("id"))))
+		return
+	}
+
+	horizon := customersdomain.DefaultCLVHorizonMonths
+	if value := r.URL.Query().Get("horizon_months"); value != "" {
+		if horizon, err = strconv.Atoi(value); err != nil || horizon < 1 {
+			problem.Write(w, r, shareddomain.NewValidationError("invalid_horizon", fmt.Sprintf("invalid horizon_months: %q", value)))
+			return
+		}
+	}
+
+	clv, err := h.customerService.GetCustomerLifetimeValue(customersdomain.CustomerID(id), horizon)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Le repository signale un client inconnu par sql.ErrNoRows: traduit en erreur du domaine (404)
+		err = shareddomain.NewNotFoundError("customer_not_found", fmt.Sprintf("customer %d not found", id))
+	}
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	response := CustomerLifetimeValueResponse{
+		Version:           statsSchemaVersion,
+		CustomerID:        int64(clv.CustomerID()),
+		Name:              clv.Name(),
+		Email:             clv.Email(),
+		AsOf:              clv.AsOf().Format(dateParamLayout),
+		OrderCount:        clv.OrderCount(),
+		HistoricalValue:   clv.HistoricalValue().Amount(),
+		AverageOrderValue: clv.AverageOrderValue().Amount(),
+		MonthlyFrequency:  clv.MonthlyFrequency(),
+		HorizonMonths:     clv.HorizonMonths(),
+		PredictedValue:    clv.PredictedValue().Amount(),
+	}
+	if clv.OrderCount() > 0 {
+		first, last := clv.FirstOrderDate().Format(dateParamLayout), clv.LastOrderDate().Format(dateParamLayout)
+		response.FirstOrderDate, response.LastOrderDate = &first, &last
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// CustomerLifetimeValueResponse réponse de GET /api/v2/customers/{id}/lifetime-value
+// first_order_date / last_order_date = null pour un client sans commande
+type CustomerLifetimeValueResponse struct {
+	Version           string  `json:"version"`
+	CustomerID        int64   `json:"customer_id"`
+	Name              string  `json:"name"`
+	Email             string  `json:"email"`
+	AsOf              string  `json:"as_of"`
+	OrderCount        int     `json:"order_count"`
+	HistoricalValue   float64 `json:"historical_value"`
+	AverageOrderValue float64 `json:"average_order_value"`
+	MonthlyFrequency  float64 `json:"monthly_frequency"`
+	HorizonMonths     int     `json:"horizon_months"`
+	PredictedValue    float64 `json:"predicted_value"`
+	FirstOrderDate    *string `json:"first_order_date"`
+	LastOrderDate     *string `json:"last_order_date"`
+}
+
+// ExportCustomerSegmentsCSV handler pour GET /api/v2/export/customer-segments-csv
+// Une ligne par client actif avec ses notes RFM et son segment (segment= pour filtrer)
+func (h *Handlers) ExportCustomerSegmentsCSV(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := parseDateRange(r.URL.Query(), 365)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	segment, err := parseSegment(r.URL.Query().Get("segment"))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	csvData, err := h.customerService.ExportSegmentsToCSV(dateRange, segment)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=customer_segments_v2.csv")
+	w.Write(csvData)
+}
+
+// parseSegment valide le paramètre segment ("" = tous les segments)
+func parseSegment(value string) (customersdomain.Segment, error) {
+	if value == "" {
+		return "", nil
+	}
+	return customersdomain.ParseSegment(value)
+}
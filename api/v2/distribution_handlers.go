@@ -0,0 +1,139 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"eval/api/problem"
+	analyticsdomain "eval/internal/analytics/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// GetDistribution handler pour GET /api/v2/stats/distribution
+// Période identique à /api/v2/stats (365 jours par défaut), mêmes filtres par dimension
+// buckets=25,50,100 bornes de l'histogramme des montants (dans la devise currency, EUR par défaut):
+// [0, 25), [25, 50), [50, 100), [100, +∞)
+func (h *Handlers) GetDistribution(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := parseDateRange(r.URL.Query(), 365)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	boundaries, err := analyticsdomain.ParseHistogramBoundaries(r.URL.Query().Get("buckets"))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	filter, err := parseStatsFilter(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	distribution, err := h.distributionService.GetOrderValueDistribution(dateRange, boundaries, filter)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	histogram := make([]HistogramBucketDTO, 0, len(distribution.Histogram()))
+	for _, b := range distribution.Histogram() {
+		var upper *float64
+		if u, ok := b.Upper(); ok {
+			upper = &u
+		}
+		histogram = append(histogram, HistogramBucketDTO{
+			Lower:      b.Lower(),
+			Upper:      upper,
+			OrderCount: b.OrderCount(),
+			Revenue:    b.Revenue().Amount(),
+			Share:      b.Share(),
+		})
+	}
+
+	itemBuckets := make([]ItemsPerOrderBucketDTO, 0, len(distribution.ItemsPerOrder()))
+	for _, b := range distribution.ItemsPerOrder() {
+		itemBuckets = append(itemBuckets, ItemsPerOrderBucketDTO{
+			Items:      b.Items(),
+			Open:       b.IsOpen(),
+			OrderCount: b.OrderCount(),
+			Share:      b.Share(),
+		})
+	}
+
+	summary, items := distribution.Summary(), distribution.ItemsSummary()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(DistributionResponse{
+		Version:  statsSchemaVersion,
+		Period:   periodToJSON(dateRange),
+		Currency: filter.Currency(),
+		OrderValue: OrderValueSummaryDTO{
+			OrderCount: summary.OrderCount(),
+			Mean:       summary.Mean().Amount(),
+			StdDev:     summary.StdDev().Amount(),
+			Min:        summary.Min().Amount(),
+			Max:        summary.Max().Amount(),
+			Median:     summary.Median().Amount(),
+			P90:        summary.P90().Amount(),
+			P95:        summary.P95().Amount(),
+			P99:        summary.P99().Amount(),
+		},
+		Histogram: histogram,
+		ItemsPerOrder: ItemsPerOrderDTO{
+			Mean:    items.Mean(),
+			Median:  items.Median(),
+			P90:     items.P90(),
+			Buckets: itemBuckets,
+		},
+	})
+}
+
+// DistributionResponse réponse de GET /api/v2/stats/distribution
+type DistributionResponse struct {
+	Version       string                `json:"version"`
+	Period        PeriodDTO             `json:"period"`
+	Currency      shareddomain.Currency `json:"currency"`
+	OrderValue    OrderValueSummaryDTO  `json:"order_value"`
+	Histogram     []HistogramBucketDTO  `json:"histogram"`
+	ItemsPerOrder ItemsPerOrderDTO      `json:"items_per_order"`
+}
+
+// OrderValueSummaryDTO statistiques descriptives du montant des commandes
+type OrderValueSummaryDTO struct {
+	OrderCount int     `json:"order_count"`
+	Mean       float64 `json:"mean"`
+	StdDev     float64 `json:"std_dev"`
+	Min        float64 `json:"min"`
+	Max        float64 `json:"max"`
+	Median     float64 `json:"median"`
+	P90        float64 `json:"p90"`
+	P95        float64 `json:"p95"`
+	P99        float64 `json:"p99"`
+}
+
+// HistogramBucketDTO intervalle [lower, upper) de l'histogramme (upper = null pour le dernier)
+type HistogramBucketDTO struct {
+	Lower      float64  `json:"lower"`
+	Upper      *float64 `json:"upper"`
+	OrderCount int      `json:"order_count"`
+	Revenue    float64  `json:"revenue"`
+	Share      float64  `json:"share"`
+}
+
+// ItemsPerOrderDTO distribution du nombre d'articles par commande
+type ItemsPerOrderDTO struct {
+	Mean    float64                  `json:"mean"`
+	Median  float64                  `json:"median"`
+	P90     float64                  `json:"p90"`
+	Buckets []ItemsPerOrderBucketDTO `json:"buckets"`
+}
+
+// ItemsPerOrderBucketDTO commandes de items articles (open = items et plus)
+type ItemsPerOrderBucketDTO struct {
+	Items      int     `json:"items"`
+	Open       bool    `json:"open"`
+	OrderCount int     `json:"order_count"`
+	Share      float64 `json:"share"`
+}
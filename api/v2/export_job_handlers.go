@@ -0,0 +1,184 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"eval/api/problem"
+	exportapp "eval/internal/export/application"
+	exportdomain "eval/internal/export/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// createExportJobRequest corps JSON de POST /api/v2/exports
+// Tous les champs sont optionnels (omitempty: non requis dans le schéma OpenAPI), un champ inconnu
+// est refusé (faute de frappe: "status" au lieu de "order_status")
+// Days est un pointeur: un champ absent (30 jours par défaut) se distingue de "days": 0 (refusé)
+type createExportJobRequest struct {
+	Format       string `json:"format,omitempty"` // csv (défaut) | parquet
+	Type         string `json:"type,omitempty"`   // sales (défaut) | stats
+	Days         *int   `json:"days,omitempty"`
+	From         string `json:"from,omitempty"`
+	To           string `json:"to,omitempty"`
+	Period       string `json:"period,omitempty"`
+	TZ           string `json:"tz,omitempty"`
+	OrderStatus  string `json:"order_status,omitempty"` // "completed,pending" | "all" (completed par défaut)
+	Compression  string `json:"compression,omitempty"`
+	RowGroupSize int    `json:"row_group_size,omitempty"`
+}
+
+// exportJobResponse représentation JSON d'un job d'export
+type exportJobResponse struct {
+	ID            string     `json:"id"`
+	Format        string     `json:"format"`
+	Type          string     `json:"type"`
+	From          string     `json:"from"`
+	To            string     `json:"to"`
+	Status        string     `json:"status"`
+	RowsProcessed int64      `json:"rows_processed"`
+	TotalRows     int64      `json:"total_rows"`
+	Progress      float64    `json:"progress"`
+	ErrorCode     string     `json:"error_code,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+	DownloadURL   string     `json:"download_url,omitempty"`
+}
+
+// CreateExportJob handler pour POST /api/v2/exports
+// Crée un job asynchrone et répond 202 Accepted avec son ID, sans attendre l'export
+func (h *Handlers) CreateExportJob(w http.ResponseWriter, r *http.Request) {
+	var body createExportJobRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&body); err != nil {
+		// Le message du décodeur nomme le champ fautif (json: unknown field "status")
+		problem.Write(w, r, shareddomain.NewValidationError("invalid_body",
+			"invalid JSON body: "+strings.TrimPrefix(err.Error(), "json: ")))
+		return
+	}
+	// Même règles que les paramètres de requête des exports synchrones (days <= 0 refusé par parseDateRange)
+	params := url.Values{}
+	params.Set("period", body.Period)
+	params.Set("from", body.From)
+	params.Set("to", body.To)
+	params.Set("tz", body.TZ)
+	if body.Days != nil {
+		params.Set("days", strconv.Itoa(*body.Days))
+	}
+	params.Set("status", body.OrderStatus)
+	dateRange, err := parseDateRange(params, 30)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+	statuses, err := parseStatusFilter(params)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	format, err := exportdomain.ParseExportFormat(body.Format)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+	exportType, err := exportdomain.ParseExportType(body.Type)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+	options, err := exportdomain.NewParquetOptions(body.Compression, body.RowGroupSize)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	job, err := h.exportJobService.Submit(exportapp.ExportJobRequest{
+		Format:         format,
+		ExportType:     exportType,
+		DateRange:      dateRange,
+		Statuses:       statuses,
+		ParquetOptions: options,
+	})
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/api/v2/exports/"+string(job.ID()))
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(exportJobToJSON(job))
+}
+
+// GetExportJob handler pour GET /api/v2/exports/{id}
+func (h *Handlers) GetExportJob(w http.ResponseWriter, r *http.Request) {
+	// Job inconnu: ErrExportJobNotFound (404)
+	job, err := h.exportJobService.GetJob(exportdomain.ExportJobID(r.PathValue("id")))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(exportJobToJSON(job))
+}
+
+// DownloadExportJob handler pour GET /api/v2/exports/{id}/download
+// http.ServeFile gère Content-Length, les requêtes Range (reprise de téléchargement) et If-Modified-Since
+func (h *Handlers) DownloadExportJob(w http.ResponseWriter, r *http.Request) {
+	// Job inconnu: ErrExportJobNotFound (404), job pas encore terminé: ErrExportJobNotReady (409)
+	job, path, err := h.exportJobService.GetResultFile(exportdomain.ExportJobID(r.PathValue("id")))
+	if errors.Is(err, exportdomain.ErrExportJobNotReady) {
+		err = fmt.Errorf("%w (status %s)", err, job.Status())
+	}
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	if job.Format() == exportdomain.ExportFormatParquet {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	} else {
+		w.Header().Set("Content-Type", "text/csv")
+	}
+	w.Header().Set("Content-Disposition", "attachment; filename="+filepath.Base(path))
+	http.ServeFile(w, r, path)
+}
+
+// exportJobToJSON convertit un job du domaine en réponse JSON
+func exportJobToJSON(job *exportdomain.ExportJob) exportJobResponse {
+	response := exportJobResponse{
+		ID:            string(job.ID()),
+		Format:        string(job.Format()),
+		Type:          string(job.ExportType()),
+		From:          job.DateRange().Start().Format(dateParamLayout),
+		To:            job.DateRange().End().Format(dateParamLayout),
+		Status:        string(job.Status()),
+		RowsProcessed: job.RowsProcessed(),
+		TotalRows:     job.TotalRows(),
+		Progress:      job.Progress(),
+		ErrorCode:     job.ErrorCode(),
+		Error:         job.ErrorMessage(),
+		CreatedAt:     job.CreatedAt(),
+	}
+	if startedAt := job.StartedAt(); !startedAt.IsZero() {
+		response.StartedAt = &startedAt
+	}
+	if finishedAt := job.FinishedAt(); !finishedAt.IsZero() {
+		response.FinishedAt = &finishedAt
+	}
+	if job.Status() == exportdomain.ExportJobStatusDone {
+		response.DownloadURL = "/api/v2/exports/" + string(job.ID()) + "/download"
+	}
+	return response
+}
@@ -0,0 +1,122 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"eval/api/problem"
+	analyticsdomain "eval/internal/analytics/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// GetForecast handler pour GET /api/v2/stats/forecast
+// granularity=day|month (day par défaut), horizon_days=N jours à prévoir (30 par défaut)
+// La période (period, from/to ou days) est la fenêtre d'apprentissage:
+// 365 jours par défaut en day, 3 ans en month (au moins deux saisons de 12 mois complets)
+// Filtres par dimension identiques à /api/v2/stats/timeseries (store_id, category_id, ...)
+func (h *Handlers) GetForecast(w http.ResponseWriter, r *http.Request) {
+	granularity, err := analyticsdomain.ParseGranularity(r.URL.Query().Get("granularity"))
+	if err == nil && granularity.SeasonLength() == 0 {
+		err = shareddomain.NewValidationError("invalid_granularity",
+			fmt.Sprintf("unsupported forecast granularity: %q (expected day or month)", granularity))
+	}
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	defaultDays := 365
+	if granularity == analyticsdomain.GranularityMonth {
+		defaultDays = 3 * 365
+	}
+	trainingRange, err := parseDateRange(r.URL.Query(), defaultDays)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	horizonDays := 30 // Valeur par défaut
+	if value := r.URL.Query().Get("horizon_days"); value != "" {
+		horizonDays, err = strconv.Atoi(value)
+		if err != nil || horizonDays < 1 || horizonDays > analyticsdomain.MaxForecastHorizonDays {
+			problem.Write(w, r, shareddomain.NewValidationError("invalid_horizon", fmt.Sprintf("invalid horizon_days: %q (expected 1-%d)",
+				value, analyticsdomain.MaxForecastHorizonDays)))
+			return
+		}
+	}
+
+	filter, err := parseStatsFilter(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	// Historique trop court: ErrInsufficientHistory, erreur de validation (400)
+	forecast, err := h.forecastService.GetRevenueForecast(trainingRange, granularity, filter, horizonDays)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	points := make([]ForecastPointDTO, 0, len(forecast.Points()))
+	for _, p := range forecast.Points() {
+		points = append(points, ForecastPointDTO{
+			Bucket:  p.BucketStart().Format(dateParamLayout),
+			Revenue: p.Revenue().Amount(),
+			Lower:   p.LowerBound().Amount(),
+			Upper:   p.UpperBound().Amount(),
+		})
+	}
+
+	model := forecast.Model()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ForecastResponse{
+		Version:        statsSchemaVersion,
+		Granularity:    string(forecast.Granularity()),
+		TrainingPeriod: periodToJSON(forecast.TrainingRange()),
+		Currency:       filter.Currency(),
+		HorizonDays:    forecast.HorizonDays(),
+		Confidence:     analyticsdomain.ForecastConfidence,
+		Model: ForecastModelDTO{
+			Method:       "holt_winters_additive",
+			Alpha:        model.Alpha(),
+			Beta:         model.Beta(),
+			Gamma:        model.Gamma(),
+			SeasonLength: model.SeasonLength(),
+			RMSE:         model.ResidualStdDev(),
+		},
+		Points: points,
+	})
+}
+
+// ForecastResponse réponse de GET /api/v2/stats/forecast
+type ForecastResponse struct {
+	Version        string                `json:"version"`
+	Granularity    string                `json:"granularity"`
+	TrainingPeriod PeriodDTO             `json:"training_period"`
+	Currency       shareddomain.Currency `json:"currency"`
+	HorizonDays    int                   `json:"horizon_days"`
+	Confidence     float64               `json:"confidence"`
+	Model          ForecastModelDTO      `json:"model"`
+	Points         []ForecastPointDTO    `json:"points"`
+}
+
+// ForecastModelDTO paramètres du modèle ajusté sur la période d'entraînement
+type ForecastModelDTO struct {
+	Method       string  `json:"method"`
+	Alpha        float64 `json:"alpha"`
+	Beta         float64 `json:"beta"`
+	Gamma        float64 `json:"gamma"`
+	SeasonLength int     `json:"season_length"`
+	RMSE         float64 `json:"rmse"`
+}
+
+// ForecastPointDTO prévision d'un bucket et son intervalle de confiance
+type ForecastPointDTO struct {
+	Bucket  string  `json:"bucket"`
+	Revenue float64 `json:"revenue"`
+	Lower   float64 `json:"lower"`
+	Upper   float64 `json:"upper"`
+}
@@ -1,90 +1,285 @@
 package v2
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 
+	"eval/api/problem"
 	analyticsapp "eval/internal/analytics/application"
+	analyticsdomain "eval/internal/analytics/domain"
+	catalogapp "eval/internal/catalog/application"
+	customersapp "eval/internal/customers/application"
 	exportapp "eval/internal/export/application"
+	exportdomain "eval/internal/export/domain"
+	shareddomain "eval/internal/shared/domain"
 )
 
 // Handlers contient tous les handlers pour l'API V2 (optimisée)
 type Handlers struct {
-	statsService  *analyticsapp.StatsServiceV2
-	exportService *exportapp.ExportServiceV2
+	statsService        *analyticsapp.StatsServiceV2
+	exportService       *exportapp.ExportServiceV2
+	exportJobService    *exportapp.ExportJobService
+	customerService     *customersapp.CustomerAnalyticsService
+	cohortService       *analyticsapp.CohortService
+	basketService       *analyticsapp.BasketService
+	promotionService    *analyticsapp.PromotionService
+	storeService        *analyticsapp.StoreService
+	forecastService     *analyticsapp.ForecastService
+	anomalyService      *analyticsapp.AnomalyService
+	distributionService *analyticsapp.DistributionService
+	supplierService     *catalogapp.SupplierAnalyticsService
+	inventoryService    *catalogapp.InventoryService
 }
 
 // NewHandlers crée une nouvelle instance des handlers V2
 func NewHandlers(
 	statsService *analyticsapp.StatsServiceV2,
 	exportService *exportapp.ExportServiceV2,
+	exportJobService *exportapp.ExportJobService,
+	customerService *customersapp.CustomerAnalyticsService,
+	cohortService *analyticsapp.CohortService,
+	basketService *analyticsapp.BasketService,
+	promotionService *analyticsapp.PromotionService,
+	storeService *analyticsapp.StoreService,
+	forecastService *analyticsapp.ForecastService,
+	anomalyService *analyticsapp.AnomalyService,
+	distributionService *analyticsapp.DistributionService,
+	supplierService *catalogapp.SupplierAnalyticsService,
+	inventoryService *catalogapp.InventoryService,
 ) *Handlers {
 	return &Handlers{
-		statsService:  statsService,
-		exportService: exportService,
+		statsService:        statsService,
+		exportService:       exportService,
+		exportJobService:    exportJobService,
+		customerService:     customerService,
+		cohortService:       cohortService,
+		basketService:       basketService,
+		promotionService:    promotionService,
+		storeService:        storeService,
+		forecastService:     forecastService,
+		anomalyService:      anomalyService,
+		distributionService: distributionService,
+		supplierService:     supplierService,
+		inventoryService:    inventoryService,
 	}
 }
 
 // GetStats handler pour GET /api/v2/stats
 func (h *Handlers) GetStats(w http.ResponseWriter, r *http.Request) {
-	// Récupérer le paramètre days
-	daysStr := r.URL.Query().Get("days")
-	days, err := strconv.Atoi(daysStr)
-	if err != nil || days <= 0 {
-		days = 365 // Valeur par défaut
+	// Récupérer la période (period, from/to ou days)
+	dateRange, err := parseDateRange(r.URL.Query(), 365)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	// Découpage par dimension (store_id, region, category_id, ...) et devise de reporting (currency)
+	filter, err := parseStatsFilter(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	// compare=true: ajoute la comparaison à la période précédente et à N-1
+	compare, _ := strconv.ParseBool(r.URL.Query().Get("compare"))
+	if compare {
+		comparison, err := h.statsService.GetStatsWithComparison(dateRange, filter)
+		if err != nil {
+			problem.Write(w, r, err)
+			return
+		}
+
+		response := newStatsResponse(comparison.Current(), dateRange, filter.Currency())
+		response.Comparison = comparisonToJSON(comparison)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+		return
 	}
 
 	// Utiliser le service V2 (optimisé avec cache + goroutines parallèles)
-	stats, err := h.statsService.GetStats(days)
+	stats, err := h.statsService.GetStatsForRange(dateRange, filter)
 	if err != nil {
-		log.Printf("Error getting stats (V2): %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
-	// Convertir en format JSON pour la réponse
-	response := h.statsToJSON(stats)
+	// Convertir en DTOs (contrat JSON stable de la v2, voir stats_dto.go)
+	response := newStatsResponse(stats, dateRange, filter.Currency())
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// GetTimeSeries handler pour GET /api/v2/stats/timeseries
+// granularity=day|week|month (day par défaut), période, filtres et devise identiques à /api/v2/stats
+func (h *Handlers) GetTimeSeries(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := parseDateRange(r.URL.Query(), 365)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	granularity, err := analyticsdomain.ParseGranularity(r.URL.Query().Get("granularity"))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	filter, err := parseStatsFilter(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	series, err := h.statsService.GetTimeSeries(dateRange, granularity, filter)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	points := make([]TimeSeriesPointDTO, 0, len(series.Points()))
+	for _, p := range series.Points() {
+		points = append(points, TimeSeriesPointDTO{
+			Bucket:            p.BucketStart().Format(dateParamLayout),
+			Revenue:           p.Revenue().Amount(),
+			OrderCount:        p.OrderCount(),
+			AverageOrderValue: p.AverageOrderValue().Amount(),
+			Quantity:          p.Quantity().Value(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(TimeSeriesResponse{
+		Version:     statsSchemaVersion,
+		Granularity: string(series.Granularity()),
+		Period:      periodToJSON(dateRange),
+		Currency:    filter.Currency(),
+		Points:      points,
+	})
+}
+
+// TimeSeriesResponse réponse de GET /api/v2/stats/timeseries
+type TimeSeriesResponse struct {
+	Version     string                `json:"version"`
+	Granularity string                `json:"granularity"`
+	Period      PeriodDTO             `json:"period"`
+	Currency    shareddomain.Currency `json:"currency"`
+	Points      []TimeSeriesPointDTO  `json:"points"`
+}
+
+// TimeSeriesPointDTO un bucket de la série (les buckets sans commande sont présents, à 0)
+type TimeSeriesPointDTO struct {
+	Bucket            string  `json:"bucket"`
+	Revenue           float64 `json:"revenue"`
+	OrderCount        int     `json:"order_count"`
+	AverageOrderValue float64 `json:"average_order_value"`
+	Quantity          int     `json:"quantity"`
+}
+
 // ExportCSV handler pour GET /api/v2/export/csv
 func (h *Handlers) ExportCSV(w http.ResponseWriter, r *http.Request) {
-	daysStr := r.URL.Query().Get("days")
-	days, err := strconv.Atoi(daysStr)
-	if err != nil || days <= 0 {
-		days = 30 // Valeur par défaut
+	dateRange, err := parseDateRange(r.URL.Query(), 30)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
 	}
 
-	// Export avec requête optimisée + batch processing
-	csvData, err := h.exportService.ExportSalesToCSV(days)
+	// status=completed,pending | all (completed uniquement par défaut)
+	statuses, err := parseStatusFilter(r.URL.Query())
 	if err != nil {
-		log.Printf("Error exporting CSV (V2): %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "text/csv")
 	w.Header().Set("Content-Disposition", "attachment; filename=sales_v2.csv")
-	w.Write(csvData)
+
+	// Streaming: les lignes sont écrites dans la réponse au fil de la lecture SQL
+	// r.Context() est annulé si le client se déconnecte, ce qui arrête l'export
+	stream := &streamWriter{ResponseWriter: w}
+	if err := h.exportService.StreamSalesToCSV(r.Context(), stream, dateRange, statuses); err != nil {
+		if errors.Is(err, context.Canceled) {
+			log.Printf("CSV export cancelled by client (V2)")
+			return
+		}
+		abortStream(stream, r, err)
+	}
+}
+
+// streamWriter http.ResponseWriter qui retient si la réponse a commencé (en-têtes ou corps envoyés)
+// Flush est transmis: le streaming par batch (http.Flusher) reste actif derrière le wrapper
+type streamWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+// WriteHeader implémente http.ResponseWriter
+func (w *streamWriter) WriteHeader(status int) {
+	w.started = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write implémente io.Writer
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.started = true
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implémente http.Flusher si la réponse sous-jacente le permet
+func (w *streamWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.started = true
+		flusher.Flush()
+	}
+}
+
+// Unwrap donne accès à la réponse d'origine (http.ResponseController)
+func (w *streamWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// abortStream termine une réponse en streaming après une erreur
+//   - rien n'est encore parti: réponse problem+json normale (status d'erreur)
+//   - sinon le status 200 et une partie du fichier sont déjà chez le client: un problème JSON
+//     ajouté à la fin du CSV serait lu comme des lignes de données
+//
+// PIÈGE: panic(http.ErrAbortHandler) est le moyen prévu par net/http pour couper la connexion
+// sans terminer proprement la réponse (pas de chunk final): le client voit un téléchargement
+// interrompu au lieu d'un fichier tronqué mais d'apparence complète
+// Le serveur ne journalise pas ce panic: l'erreur est journalisée ici, avec l'ID de requête
+func abortStream(w *streamWriter, r *http.Request, err error) {
+	if !w.started {
+		problem.Write(w, r, err)
+		return
+	}
+	log.Printf("[%s] %s %s: stream aborted after partial response: %v",
+		problem.RequestIDFromContext(r.Context()), r.Method, r.URL.Path, err)
+	panic(http.ErrAbortHandler)
 }
 
 // ExportStatsCSV handler pour GET /api/v2/export/stats-csv
 func (h *Handlers) ExportStatsCSV(w http.ResponseWriter, r *http.Request) {
-	daysStr := r.URL.Query().Get("days")
-	days, err := strconv.Atoi(daysStr)
-	if err != nil || days <= 0 {
-		days = 365
+	dateRange, err := parseDateRange(r.URL.Query(), 365)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	filter, err := parseStatsFilter(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
 	}
 
 	// Utilise le service stats V2 avec cache
-	csvData, err := h.exportService.ExportStatsToCSV(days)
+	csvData, err := h.exportService.ExportStatsToCSV(dateRange, filter)
 	if err != nil {
-		log.Printf("Error exporting stats CSV (V2): %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -95,32 +290,34 @@ func (h *Handlers) ExportStatsCSV(w http.ResponseWriter, r *http.Request) {
 
 // ExportParquet handler pour GET /api/v2/export/parquet
 func (h *Handlers) ExportParquet(w http.ResponseWriter, r *http.Request) {
-	daysStr := r.URL.Query().Get("days")
-	days, err := strconv.Atoi(daysStr)
-	if err != nil || days <= 0 {
-		days = 30
+	dateRange, err := parseDateRange(r.URL.Query(), 30)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	// Options Parquet: compression=none|snappy|gzip|zstd, row_group_size=nombre de lignes
+	rowGroupSize, _ := strconv.Atoi(r.URL.Query().Get("row_group_size"))
+	options, err := exportdomain.NewParquetOptions(r.URL.Query().Get("compression"), rowGroupSize)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
 	}
 
-	// Export avec worker pool + batch processing
-	parquetData, err := h.exportService.ExportToParquet(days)
+	statuses, err := parseStatusFilter(r.URL.Query())
 	if err != nil {
-		log.Printf("Error exporting Parquet (V2): %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/octet-stream")
 	w.Header().Set("Content-Disposition", "attachment; filename=sales_v2.parquet")
-	w.Write(parquetData)
-}
 
-// statsToJSON convertit les stats du domaine en format JSON
-func (h *Handlers) statsToJSON(stats interface{}) map[string]interface{} {
-	// Pour simplifier, on retourne une structure générique
-	// Dans un vrai projet, on créerait des DTOs spécifiques
-	return map[string]interface{}{
-		"version": "v2",
-		"message": "Stats calculated with V2 (optimized: cached + parallel SQL queries)",
-		"stats":   stats,
+	// Streaming: chaque row group (encodé par le writer parquet-go) est écrit dans la réponse
+	// dès qu'il est prêt: ni les ventes de la période ni le fichier complet ne sont gardés en mémoire,
+	// et la déconnexion du client (r.Context()) arrête la requête SQL
+	stream := &streamWriter{ResponseWriter: w}
+	if err := h.exportService.WriteSalesParquet(r.Context(), stream, dateRange, statuses, options, nil); err != nil {
+		abortStream(stream, r, err)
 	}
 }
@@ -0,0 +1,212 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"eval/api/problem"
+	catalogdomain "eval/internal/catalog/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// GetInventory handler pour GET /api/v2/stats/inventory
+// Vélocité calculée sur la période (30 jours par défaut), seuils risk_days (14) et dead_days (90)
+// limit = nombre maximum de produits par liste at_risk / dead_stock / out_of_stock (50 par défaut)
+func (h *Handlers) GetInventory(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := parseDateRange(r.URL.Query(), 30)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	thresholds, err := parseStockThresholds(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 50 // Valeur par défaut
+	}
+
+	currency, err := parseCurrency(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	report, err := h.inventoryService.GetInventoryReport(dateRange, thresholds, currency)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	categories := make([]CategoryTurnoverDTO, 0, len(report.Categories()))
+	for _, c := range report.Categories() {
+		var turnover, annualized *float64
+		if t, ok := c.Turnover(); ok {
+			turnover = &t
+		}
+		if t, ok := c.AnnualizedTurnover(); ok {
+			annualized = &t
+		}
+		categories = append(categories, CategoryTurnoverDTO{
+			CategoryID:         int64(c.CategoryID()),
+			Name:               c.Name(),
+			ProductCount:       c.ProductCount(),
+			StockUnits:         c.StockUnits(),
+			StockValue:         c.StockValue().Amount(),
+			UnitsSold:          c.UnitsSold(),
+			Turnover:           turnover,
+			AnnualizedTurnover: annualized,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(InventoryResponse{
+		Version:         statsSchemaVersion,
+		Period:          periodToJSON(dateRange),
+		Currency:        currency,
+		RiskDays:        thresholds.RiskDays(),
+		DeadDays:        thresholds.DeadStockDays(),
+		TotalProducts:   len(report.Products()),
+		TotalStockValue: report.TotalStockValue().Amount(),
+		StatusCounts:    report.CountByStatus(),
+		AtRisk:          stockLevelsToJSON(report.ProductsWithStatus(catalogdomain.StockStatusAtRisk), limit),
+		OutOfStock:      stockLevelsToJSON(report.ProductsWithStatus(catalogdomain.StockStatusOutOfStock), limit),
+		DeadStock:       stockLevelsToJSON(report.ProductsWithStatus(catalogdomain.StockStatusDeadStock), limit),
+		Categories:      categories,
+	})
+}
+
+// InventoryResponse réponse de GET /api/v2/stats/inventory
+type InventoryResponse struct {
+	Version         string                            `json:"version"`
+	Period          PeriodDTO                         `json:"period"`
+	Currency        shareddomain.Currency             `json:"currency"`
+	RiskDays        int                               `json:"risk_days"`
+	DeadDays        int                               `json:"dead_days"`
+	TotalProducts   int                               `json:"total_products"`
+	TotalStockValue float64                           `json:"total_stock_value"`
+	StatusCounts    map[catalogdomain.StockStatus]int `json:"status_counts"`
+	AtRisk          []ProductStockLevelDTO            `json:"at_risk"`
+	OutOfStock      []ProductStockLevelDTO            `json:"out_of_stock"`
+	DeadStock       []ProductStockLevelDTO            `json:"dead_stock"`
+	Categories      []CategoryTurnoverDTO             `json:"categories"`
+}
+
+// CategoryTurnoverDTO rotation du stock d'une catégorie (turnover = null sans stock)
+type CategoryTurnoverDTO struct {
+	CategoryID         int64    `json:"category_id"`
+	Name               string   `json:"name"`
+	ProductCount       int      `json:"product_count"`
+	StockUnits         int      `json:"stock_units"`
+	StockValue         float64  `json:"stock_value"`
+	UnitsSold          int      `json:"units_sold"`
+	Turnover           *float64 `json:"turnover"`
+	AnnualizedTurnover *float64 `json:"annualized_turnover"`
+}
+
+// ProductStockLevelDTO niveau de stock d'un produit
+type ProductStockLevelDTO struct {
+	ProductID     int64    `json:"product_id"`
+	Name          string   `json:"name"`
+	StockQuantity int      `json:"stock_quantity"`
+	StockValue    float64  `json:"stock_value"`
+	UnitsSold     int      `json:"units_sold"`
+	DailyVelocity float64  `json:"daily_velocity"`
+	DaysOfCover   *float64 `json:"days_of_cover"`
+	LastSaleDate  *string  `json:"last_sale_date"`
+}
+
+// ExportInventoryCSV handler pour GET /api/v2/export/inventory-csv
+// Même rapport que /api/v2/stats/inventory, une ligne par produit
+func (h *Handlers) ExportInventoryCSV(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := parseDateRange(r.URL.Query(), 30)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	thresholds, err := parseStockThresholds(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	currency, err := parseCurrency(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	csvData, err := h.inventoryService.ExportInventoryToCSV(dateRange, thresholds, currency)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=inventory_v2.csv")
+	w.Write(csvData)
+}
+
+// parseStockThresholds lit risk_days et dead_days (défauts du domaine si absents, 400 si invalides)
+func parseStockThresholds(params url.Values) (catalogdomain.StockThresholds, error) {
+	riskDays, deadDays := catalogdomain.DefaultStockRiskDays, catalogdomain.DefaultDeadStockDays
+
+	for _, p := range []struct {
+		name   string
+		target *int
+	}{
+		{"risk_days", &riskDays},
+		{"dead_days", &deadDays},
+	} {
+		value := params.Get(p.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return catalogdomain.StockThresholds{}, shareddomain.NewValidationError("invalid_thresholds", fmt.Sprintf("invalid %s: %q", p.name, value))
+		}
+		*p.target = parsed
+	}
+
+	return catalogdomain.NewStockThresholds(riskDays, deadDays)
+}
+
+// stockLevelsToJSON décrit au plus limit produits (days_of_cover / last_sale_date = null si non définis)
+func stockLevelsToJSON(levels []*catalogdomain.ProductStockLevel, limit int) []ProductStockLevelDTO {
+	if len(levels) > limit {
+		levels = levels[:limit]
+	}
+
+	result := make([]ProductStockLevelDTO, 0, len(levels))
+	for _, l := range levels {
+		var daysOfCover *float64
+		var lastSaleDate *string
+		if days, ok := l.DaysOfCover(); ok {
+			daysOfCover = &days
+		}
+		if date, ok := l.LastSaleDate(); ok {
+			formatted := date.Format(dateParamLayout)
+			lastSaleDate = &formatted
+		}
+		result = append(result, ProductStockLevelDTO{
+			ProductID:     int64(l.ProductID()),
+			Name:          l.Name(),
+			StockQuantity: l.StockQuantity().Value(),
+			StockValue:    l.StockValue().Amount(),
+			UnitsSold:     l.UnitsSold(),
+			DailyVelocity: l.DailyVelocity(),
+			DaysOfCover:   daysOfCover,
+			LastSaleDate:  lastSaleDate,
+		})
+	}
+	return result
+}
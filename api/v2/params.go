@@ -0,0 +1,159 @@
+package v2
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	analyticsdomain "eval/internal/analytics/domain"
+	ordersdomain "eval/internal/orders/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// dateParamLayout format des paramètres from/to (ISO 8601: 2025-09-30)
+const dateParamLayout = "2006-01-02"
+
+// parseDateRange construit la période demandée à partir des paramètres de requête
+// Par ordre de priorité:
+//   - period=2025-Q3 | 2025-09 | 2025-W38 | 2025 | last_month | ...
+//   - from=2025-07-01&to=2025-09-30 (bornes incluses, to vaut aujourd'hui si absent)
+//   - days=N (N derniers jours, defaultDays si absent; une valeur invalide est une erreur de validation)
+//
+// tz=Europe/Paris (optionnel) fixe le fuseau dans lequel "aujourd'hui" et les dates sont interprétés,
+// par défaut le fuseau du serveur
+func parseDateRange(params url.Values, defaultDays int) (shareddomain.DateRange, error) {
+	loc := time.Local
+	if tz := params.Get("tz"); tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return shareddomain.DateRange{}, shareddomain.NewValidationError("invalid_tz", fmt.Sprintf("invalid tz: %q", tz))
+		}
+	}
+	now := time.Now().In(loc)
+
+	period, from, to := params.Get("period"), params.Get("from"), params.Get("to")
+
+	if period != "" {
+		if from != "" || to != "" {
+			return shareddomain.DateRange{}, shareddomain.NewValidationError("invalid_date_range", "period cannot be combined with from/to")
+		}
+		return shareddomain.ParsePeriod(period, now)
+	}
+
+	if from != "" || to != "" {
+		if from == "" {
+			return shareddomain.DateRange{}, shareddomain.NewValidationError("invalid_date_range", "from is required when to is set")
+		}
+		start, err := time.ParseInLocation(dateParamLayout, from, loc)
+		if err != nil {
+			return shareddomain.DateRange{}, shareddomain.NewValidationError("invalid_date_range",
+				fmt.Sprintf("invalid from date: %q (expected YYYY-MM-DD)", from))
+		}
+		end := now
+		if to != "" {
+			if end, err = time.ParseInLocation(dateParamLayout, to, loc); err != nil {
+				return shareddomain.DateRange{}, shareddomain.NewValidationError("invalid_date_range",
+					fmt.Sprintf("invalid to date: %q (expected YYYY-MM-DD)", to))
+			}
+		}
+		return shareddomain.NewDateRange(start, end)
+	}
+
+	// PIÈGE: pas de repli silencieux sur defaultDays pour une valeur invalide: days=-5 donnerait
+	// une période de 30 jours sans que l'appelant le sache (corps JSON d'un job d'export, appel interne
+	// qui ne passe pas par openapi.Validate)
+	value := params.Get("days")
+	if value == "" {
+		return shareddomain.NewDateRangeFromDaysUntil(defaultDays, now)
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days <= 0 {
+		return shareddomain.DateRange{}, shareddomain.NewValidationError("invalid_date_range",
+			fmt.Sprintf("invalid days: %q (expected a positive integer)", value))
+	}
+	return shareddomain.NewDateRangeFromDaysUntil(days, now)
+}
+
+// parseStatsFilter construit le découpage par dimension des stats
+// store_id, region, city, category_id, supplier_id, payment_method_id, promotion_code, status,
+// ainsi que la devise de reporting (currency)
+// Les paramètres absents ne filtrent pas; un identifiant non numérique est une erreur (400)
+func parseStatsFilter(params url.Values) (analyticsdomain.StatsFilter, error) {
+	status, err := parseStatusFilter(params)
+	if err != nil {
+		return analyticsdomain.StatsFilter{}, err
+	}
+	currency, err := parseCurrency(params)
+	if err != nil {
+		return analyticsdomain.StatsFilter{}, err
+	}
+
+	criteria := analyticsdomain.StatsFilterCriteria{
+		Status:        status,
+		Region:        params.Get("region"),
+		City:          params.Get("city"),
+		PromotionCode: params.Get("promotion_code"),
+		Currency:      currency,
+	}
+
+	ids := []struct {
+		name   string
+		target *int64
+	}{
+		{"store_id", &criteria.StoreID},
+		{"category_id", &criteria.CategoryID},
+		{"supplier_id", &criteria.SupplierID},
+		{"payment_method_id", &criteria.PaymentMethodID},
+	}
+	for _, id := range ids {
+		value := params.Get(id.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 {
+			return analyticsdomain.StatsFilter{}, shareddomain.NewValidationError("invalid_filter", fmt.Sprintf("invalid %s: %q", id.name, value))
+		}
+		*id.target = parsed
+	}
+
+	return analyticsdomain.NewStatsFilter(criteria)
+}
+
+// parseCurrency lit currency=USD, devise de reporting des montants (EUR si absent)
+// Les montants sont convertis au taux du jour de chaque commande (table fx_rates)
+func parseCurrency(params url.Values) (shareddomain.Currency, error) {
+	return shareddomain.ParseCurrency(params.Get("currency"))
+}
+
+// parseStatusFilter lit status=completed,pending | all (completed uniquement si absent)
+func parseStatusFilter(params url.Values) (ordersdomain.StatusFilter, error) {
+	return ordersdomain.ParseStatusFilter(params.Get("status"))
+}
+
+// parsePagination lit page (1 par défaut) et page_size (defaultPageSize par défaut)
+// Une valeur présente mais invalide est une erreur (400), pas un retour silencieux au défaut
+func parsePagination(params url.Values, defaultPageSize int) (shareddomain.Pagination, error) {
+	page, pageSize := 1, defaultPageSize
+
+	for _, p := range []struct {
+		name   string
+		target *int
+	}{
+		{"page", &page},
+		{"page_size", &pageSize},
+	} {
+		value := params.Get(p.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return shareddomain.Pagination{}, shareddomain.NewValidationError("invalid_pagination", fmt.Sprintf("invalid %s: %q", p.name, value))
+		}
+		*p.target = parsed
+	}
+
+	return shareddomain.NewPagination(page, pageSize)
+}
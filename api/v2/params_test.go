@@ -0,0 +1,32 @@
+package v2
+
+import (
+	"net/url"
+	"testing"
+
+	shareddomain "eval/internal/shared/domain"
+)
+
+// TestParseDateRange_Days vérifie que days absent vaut la valeur par défaut
+// et qu'une valeur invalide est refusée au lieu de retomber sur le défaut
+func TestParseDateRange_Days(t *testing.T) {
+	want30, _ := shareddomain.NewDateRangeFromDays(30)
+	want7, _ := shareddomain.NewDateRangeFromDays(7)
+
+	dateRange, err := parseDateRange(url.Values{}, 30)
+	if err != nil || dateRange.Days() != want30.Days() {
+		t.Errorf("missing days = %d days (%v), want %d", dateRange.Days(), err, want30.Days())
+	}
+
+	dateRange, err = parseDateRange(url.Values{"days": {"7"}}, 30)
+	if err != nil || dateRange.Days() != want7.Days() {
+		t.Errorf("days=7 = %d days (%v), want %d", dateRange.Days(), err, want7.Days())
+	}
+
+	for _, value := range []string{"0", "-5", "abc"} {
+		_, err := parseDateRange(url.Values{"days": {value}}, 30)
+		if domainErr, ok := shareddomain.AsError(err); !ok || domainErr.Code() != "invalid_date_range" {
+			t.Errorf("days=%s error = %v, want invalid_date_range", value, err)
+		}
+	}
+}
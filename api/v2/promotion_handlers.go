@@ -0,0 +1,92 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"eval/api/problem"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// GetPromotionReport handler pour GET /api/v2/stats/promotions
+// Efficacité des promotions dont la fenêtre d'activité chevauche la période (365 jours par défaut)
+func (h *Handlers) GetPromotionReport(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := parseDateRange(r.URL.Query(), 365)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	currency, err := parseCurrency(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	report, err := h.promotionService.GetPromotionReport(dateRange, currency)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	promotions := make([]PromotionEffectivenessDTO, 0, len(report.Promotions()))
+	for _, p := range report.Promotions() {
+		promo := p.Promotion()
+		promotions = append(promotions, PromotionEffectivenessDTO{
+			PromotionID:                      int64(promo.ID()),
+			Code:                             promo.Code(),
+			Name:                             promo.Name(),
+			DiscountPercent:                  promo.DiscountPercent(),
+			Active:                           promo.IsActive(),
+			ActiveWindow:                     periodToJSON(promo.ActiveWindow()),
+			BaselineWindow:                   periodToJSON(promo.BaselineWindow()),
+			OrderCount:                       p.OrderCount(),
+			Revenue:                          p.Revenue().Amount(),
+			AverageBasket:                    p.AverageBasket().Amount(),
+			OrdersWithoutAnyPromotion:        p.OrdersWithoutAnyPromotion(),
+			AverageBasketWithoutAnyPromotion: p.AverageBasketWithoutAnyPromotion().Amount(),
+			EstimatedDiscountCost:            p.EstimatedDiscountCost().Amount(),
+			DailyRevenue:                     deltaToJSON(p.DailyRevenue()),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PromotionReportResponse{
+		Version:                    statsSchemaVersion,
+		Period:                     periodToJSON(dateRange),
+		Currency:                   currency,
+		TotalRevenue:               report.TotalRevenue().Amount(),
+		TotalEstimatedDiscountCost: report.TotalDiscountCost().Amount(),
+		Promotions:                 promotions,
+	})
+}
+
+// PromotionReportResponse réponse de GET /api/v2/stats/promotions
+type PromotionReportResponse struct {
+	Version                    string                      `json:"version"`
+	Period                     PeriodDTO                   `json:"period"`
+	Currency                   shareddomain.Currency       `json:"currency"`
+	TotalRevenue               float64                     `json:"total_revenue"`
+	TotalEstimatedDiscountCost float64                     `json:"total_estimated_discount_cost"`
+	Promotions                 []PromotionEffectivenessDTO `json:"promotions"`
+}
+
+// PromotionEffectivenessDTO performance d'une promotion sur la période
+// *_without_any_promotion: commandes de la fenêtre sans aucun code promo
+// (celles passées avec une promotion concurrente sont exclues de la référence)
+type PromotionEffectivenessDTO struct {
+	PromotionID                      int64          `json:"promotion_id"`
+	Code                             string         `json:"code"`
+	Name                             string         `json:"name"`
+	DiscountPercent                  float64        `json:"discount_percent"`
+	Active                           bool           `json:"active"`
+	ActiveWindow                     PeriodDTO      `json:"active_window"`
+	BaselineWindow                   PeriodDTO      `json:"baseline_window"`
+	OrderCount                       int            `json:"order_count"`
+	Revenue                          float64        `json:"revenue"`
+	AverageBasket                    float64        `json:"average_basket"`
+	OrdersWithoutAnyPromotion        int            `json:"orders_without_any_promotion"`
+	AverageBasketWithoutAnyPromotion float64        `json:"average_basket_without_any_promotion"`
+	EstimatedDiscountCost            float64        `json:"estimated_discount_cost"`
+	DailyRevenue                     MetricDeltaDTO `json:"daily_revenue"`
+}
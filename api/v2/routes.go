@@ -0,0 +1,456 @@
+package v2
+
+import (
+	"net/http"
+
+	"eval/api/openapi"
+	analyticsdomain "eval/internal/analytics/domain"
+	catalogdomain "eval/internal/catalog/domain"
+	customersdomain "eval/internal/customers/domain"
+	exportdomain "eval/internal/export/domain"
+	ordersdomain "eval/internal/orders/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// Routes décrit toutes les routes V2: enregistrement dans le ServeMux, validation des paramètres
+// et document OpenAPI (voir api/openapi)
+// Les paramètres déclarés ici doivent rester alignés sur ceux lus par les handlers (params.go):
+// un paramètre non déclaré est rejeté par la validation (StrictQuery sur toutes les routes V2)
+func (h *Handlers) Routes() []openapi.Route {
+	statsTag := []string{"stats"}
+	exportTag := []string{"exports"}
+	customerTag := []string{"customers"}
+
+	routes := []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/api/v2/stats", Handler: h.GetStats,
+			Operation: openapi.Operation{
+				OperationID: "getStatsV2",
+				Summary:     "Statistiques globales, par catégorie, produit, magasin et moyen de paiement",
+				Tags:        statsTag,
+				Parameters: joinParams(periodParams(365), statsFilterParams(),
+					[]openapi.Parameter{openapi.QueryParam("compare", "Ajoute la comparaison à la période précédente et à N-1", openapi.BooleanSchema().WithDefault(false))}),
+				Responses: map[string]openapi.Response{"200": openapi.JSONResponse("Statistiques de la période", StatsResponse{})},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/stats/timeseries", Handler: h.GetTimeSeries,
+			Operation: openapi.Operation{
+				OperationID: "getTimeSeries",
+				Summary:     "Série temporelle du CA (buckets vides à zéro)",
+				Tags:        statsTag,
+				Parameters: joinParams(periodParams(365), statsFilterParams(), []openapi.Parameter{
+					openapi.QueryParam("granularity", "Taille des buckets", granularitySchema(
+						analyticsdomain.GranularityDay, analyticsdomain.GranularityWeek, analyticsdomain.GranularityMonth)),
+				}),
+				Responses: jsonOK("Points de la série", TimeSeriesResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/stats/cohorts", Handler: h.GetCohorts,
+			Operation: openapi.Operation{
+				OperationID: "getCohorts",
+				Summary:     "Matrice de rétention des cohortes de clients",
+				Tags:        statsTag,
+				Parameters:  joinParams(periodParams(365), []openapi.Parameter{currencyParam()}),
+				Responses:   jsonOK("Cohortes et rétention mensuelle", CohortsResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/stats/basket", Handler: h.GetBasketRules,
+			Operation: openapi.Operation{
+				OperationID: "getBasketRules",
+				Summary:     "Produits le plus souvent achetés avec product_id",
+				Tags:        statsTag,
+				Parameters: joinParams(periodParams(365), basketQueryParams(), []openapi.Parameter{
+					openapi.RequiredQueryParam("product_id", "Produit analysé", openapi.IntegerSchema(1, 0)),
+				}),
+				Responses: jsonOK("Règles d'association", BasketRulesResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/stats/basket/top", Handler: h.GetTopBasketRules,
+			Operation: openapi.Operation{
+				OperationID: "getTopBasketRules",
+				Summary:     "Meilleures règles d'association, tous produits confondus",
+				Tags:        statsTag,
+				Parameters:  joinParams(periodParams(365), basketQueryParams()),
+				Responses:   jsonOK("Règles d'association", BasketRulesResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/stats/promotions", Handler: h.GetPromotionReport,
+			Operation: openapi.Operation{
+				OperationID: "getPromotionReport",
+				Summary:     "Efficacité des promotions actives sur la période",
+				Tags:        statsTag,
+				Parameters:  joinParams(periodParams(365), []openapi.Parameter{currencyParam()}),
+				Responses:   jsonOK("Rapport des promotions", PromotionReportResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/stats/stores", Handler: h.GetStoreDashboard,
+			Operation: openapi.Operation{
+				OperationID: "getStoreDashboard",
+				Summary:     "Classement des magasins et agrégation par région",
+				Tags:        statsTag,
+				Parameters: joinParams(periodParams(30), paginationParams(20), []openapi.Parameter{
+					statusParam(),
+					currencyParam(),
+					openapi.QueryParam("region", "Restreint la liste des magasins à une région", openapi.StringSchema()),
+					openapi.QueryParam("sort", "Critère de tri", openapi.EnumSchema(
+						string(analyticsdomain.StoreSortRevenue), string(analyticsdomain.StoreSortOrders),
+						string(analyticsdomain.StoreSortRevenuePerOrder), string(analyticsdomain.StoreSortItemsPerOrder),
+						string(analyticsdomain.StoreSortRankChange), string(analyticsdomain.StoreSortName),
+					).WithDefault(string(analyticsdomain.StoreSortRevenue))),
+					openapi.QueryParam("order", "Sens du tri (dépend de sort par défaut)", openapi.EnumSchema("asc", "desc")),
+				}),
+				Responses: jsonOK("Page de magasins et régions", StoreDashboardResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/stats/suppliers", Handler: h.GetSupplierStats,
+			Operation: openapi.Operation{
+				OperationID: "getSupplierStats",
+				Summary:     "Ventes par fournisseur et meilleurs produits",
+				Tags:        statsTag,
+				Parameters: joinParams(periodParams(30), []openapi.Parameter{
+					currencyParam(),
+					openapi.QueryParam("top_products", "Nombre de meilleurs produits par fournisseur", openapi.IntegerSchema(1, 0).WithDefault(5)),
+				}),
+				Responses: jsonOK("Rapport fournisseurs", SupplierReportResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/stats/inventory", Handler: h.GetInventory,
+			Operation: openapi.Operation{
+				OperationID: "getInventory",
+				Summary:     "Analyse des stocks (vélocité, couverture, ruptures, stock dormant)",
+				Tags:        statsTag,
+				Parameters: joinParams(periodParams(30), stockThresholdParams(), []openapi.Parameter{
+					currencyParam(),
+					openapi.QueryParam("limit", "Nombre maximum de produits par liste", openapi.IntegerSchema(1, 0).WithDefault(50)),
+				}),
+				Responses: jsonOK("Rapport des stocks", InventoryResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/stats/forecast", Handler: h.GetForecast,
+			Operation: openapi.Operation{
+				OperationID: "getForecast",
+				Summary:     "Prévision du CA (Holt-Winters), la période est la fenêtre d'apprentissage (3 ans par défaut en month)",
+				Tags:        statsTag,
+				Parameters: joinParams(periodParams(365), statsFilterParams(), []openapi.Parameter{
+					openapi.QueryParam("granularity", "Saison de 7 jours (day) ou 12 mois (month)", granularitySchema(
+						analyticsdomain.GranularityDay, analyticsdomain.GranularityMonth)),
+					openapi.QueryParam("horizon_days", "Nombre de jours à prévoir",
+						openapi.IntegerSchema(1, analyticsdomain.MaxForecastHorizonDays).WithDefault(30)),
+				}),
+				Responses: jsonOK("Prévision et intervalle à 95%", ForecastResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/stats/anomalies", Handler: h.GetAnomalies,
+			Operation: openapi.Operation{
+				OperationID: "getAnomalies",
+				Summary:     "Jours de ventes anormaux par magasin et par catégorie",
+				Tags:        statsTag,
+				Parameters: joinParams(periodParams(30), []openapi.Parameter{
+					statusParam(),
+					currencyParam(),
+					openapi.QueryParam("method", "Score robuste (mad) ou z-score", openapi.EnumSchema(
+						string(analyticsdomain.AnomalyMethodMAD), string(analyticsdomain.AnomalyMethodZScore),
+					).WithDefault(string(analyticsdomain.AnomalyMethodMAD))),
+					openapi.QueryParam("threshold", "Seuil du score (3.5 en mad, 3 en zscore)", openapi.NumberSchema()),
+					openapi.QueryParam("baseline_days", "Jours de référence avant chaque jour analysé",
+						openapi.IntegerSchema(analyticsdomain.MinAnomalyBaselineDays, analyticsdomain.MaxAnomalyBaselineDays).
+							WithDefault(analyticsdomain.DefaultAnomalyBaselineDays)),
+					openapi.QueryParam("dimension", "Restreint aux anomalies d'une dimension", openapi.EnumSchema(
+						string(analyticsdomain.AnomalyDimensionStore), string(analyticsdomain.AnomalyDimensionCategory))),
+					openapi.QueryParam("min_severity", "Sévérité minimale", openapi.EnumSchema(
+						string(analyticsdomain.AnomalySeverityWarning), string(analyticsdomain.AnomalySeverityMajor),
+						string(analyticsdomain.AnomalySeverityCritical),
+					).WithDefault(string(analyticsdomain.AnomalySeverityWarning))),
+				}),
+				Responses: jsonOK("Anomalies détectées", AnomalyReportResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/stats/distribution", Handler: h.GetDistribution,
+			Operation: openapi.Operation{
+				OperationID: "getDistribution",
+				Summary:     "Distribution des montants de commande (percentiles et histogramme)",
+				Tags:        statsTag,
+				Parameters: joinParams(periodParams(365), statsFilterParams(), []openapi.Parameter{
+					openapi.QueryParam("buckets", "Bornes croissantes de l'histogramme (25,50,100)", openapi.StringSchema()),
+				}),
+				Responses: jsonOK("Résumé et histogramme", DistributionResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/export/csv", Handler: h.ExportCSV,
+			Operation: openapi.Operation{
+				OperationID: "exportSalesCSVV2",
+				Summary:     "Export CSV des ventes en streaming",
+				Tags:        exportTag,
+				Parameters:  joinParams(periodParams(30), []openapi.Parameter{statusParam()}),
+				Responses:   fileOK("Lignes de vente", "text/csv"),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/export/stats-csv", Handler: h.ExportStatsCSV,
+			Operation: openapi.Operation{
+				OperationID: "exportStatsCSVV2",
+				Summary:     "Export CSV des statistiques",
+				Tags:        exportTag,
+				Parameters:  joinParams(periodParams(365), statsFilterParams()),
+				Responses:   fileOK("Statistiques", "text/csv"),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/export/parquet", Handler: h.ExportParquet,
+			Operation: openapi.Operation{
+				OperationID: "exportSalesParquetV2",
+				Summary:     "Export Apache Parquet des ventes",
+				Tags:        exportTag,
+				Parameters: joinParams(periodParams(30), []openapi.Parameter{
+					statusParam(),
+					openapi.QueryParam("compression", "Codec des pages", openapi.EnumSchema(
+						string(exportdomain.ParquetCompressionNone), string(exportdomain.ParquetCompressionSnappy),
+						string(exportdomain.ParquetCompressionGzip), string(exportdomain.ParquetCompressionZstd),
+					).WithDefault(string(exportdomain.ParquetCompressionSnappy))),
+					openapi.QueryParam("row_group_size", "Lignes par row group (0 = défaut)",
+						openapi.IntegerSchema(0, 0).WithDefault(exportdomain.DefaultParquetRowGroupSize)),
+				}),
+				Responses: fileOK("Fichier Parquet", "application/octet-stream"),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/export/customer-segments-csv", Handler: h.ExportCustomerSegmentsCSV,
+			Operation: openapi.Operation{
+				OperationID: "exportCustomerSegmentsCSV",
+				Summary:     "Export CSV des notes RFM et segments par client",
+				Tags:        exportTag,
+				Parameters:  joinParams(periodParams(365), []openapi.Parameter{segmentParam()}),
+				Responses:   fileOK("Clients et segments", "text/csv"),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/export/cohorts-csv", Handler: h.ExportCohortsCSV,
+			Operation: openapi.Operation{
+				OperationID: "exportCohortsCSV",
+				Summary:     "Export CSV des cohortes (une ligne par cohorte et par mois)",
+				Tags:        exportTag,
+				Parameters:  joinParams(periodParams(365), []openapi.Parameter{currencyParam()}),
+				Responses:   fileOK("Cohortes", "text/csv"),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/export/inventory-csv", Handler: h.ExportInventoryCSV,
+			Operation: openapi.Operation{
+				OperationID: "exportInventoryCSV",
+				Summary:     "Export CSV de l'analyse des stocks",
+				Tags:        exportTag,
+				Parameters:  joinParams(periodParams(30), stockThresholdParams(), []openapi.Parameter{currencyParam()}),
+				Responses:   fileOK("Produits et statut de stock", "text/csv"),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/customers/segments", Handler: h.GetCustomerSegments,
+			Operation: openapi.Operation{
+				OperationID: "getCustomerSegments",
+				Summary:     "Segmentation RFM des clients actifs",
+				Tags:        customerTag,
+				Parameters: joinParams(periodParams(365), []openapi.Parameter{
+					segmentParam(),
+					openapi.QueryParam("limit", "Nombre maximum de clients listés pour segment", openapi.IntegerSchema(1, 0).WithDefault(100)),
+				}),
+				Responses: jsonOK("Résumé des segments", CustomerSegmentsResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/customers/top", Handler: h.GetTopCustomers,
+			Operation: openapi.Operation{
+				OperationID: "getTopCustomers",
+				Summary:     "Classement paginé des clients par CA",
+				Tags:        customerTag,
+				Parameters:  joinParams(periodParams(365), paginationParams(20)),
+				Responses:   jsonOK("Page de clients", TopCustomersResponse{}),
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/customers/{id}/lifetime-value", Handler: h.GetCustomerLifetimeValue,
+			Operation: openapi.Operation{
+				OperationID: "getCustomerLifetimeValue",
+				Summary:     "CLV historique et prédictive d'un client",
+				Tags:        customerTag,
+				Parameters: []openapi.Parameter{
+					openapi.PathParam("id", "Identifiant du client", openapi.IntegerSchema(1, 0)),
+					openapi.QueryParam("horizon_months", "Horizon de la CLV prédictive",
+						openapi.IntegerSchema(1, 0).WithDefault(customersdomain.DefaultCLVHorizonMonths)),
+				},
+				Responses: map[string]openapi.Response{
+					"200": openapi.JSONResponse("CLV du client", CustomerLifetimeValueResponse{}),
+					"404": openapi.ProblemResponse("Client inconnu"),
+				},
+			},
+		},
+		{
+			Method: http.MethodPost, Path: "/api/v2/exports", Handler: h.CreateExportJob,
+			Operation: openapi.Operation{
+				OperationID: "createExportJob",
+				Summary:     "Crée un job d'export asynchrone",
+				Tags:        exportTag,
+				RequestBody: openapi.JSONBody(createExportJobRequest{}),
+				Responses: map[string]openapi.Response{
+					"202": openapi.JSONResponse("Job créé (en-tête Location)", exportJobResponse{}),
+					"400": openapi.ProblemResponse("Corps invalide (JSON, champ inconnu, format, type, période, days <= 0)"),
+					"503": openapi.ProblemResponse("File des jobs pleine (export_queue_full), réessayer plus tard"),
+				},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/exports/{id}", Handler: h.GetExportJob,
+			Operation: openapi.Operation{
+				OperationID: "getExportJob",
+				Summary:     "Statut et progression d'un job d'export",
+				Tags:        exportTag,
+				Parameters:  []openapi.Parameter{exportJobIDParam()},
+				Responses: map[string]openapi.Response{
+					"200": openapi.JSONResponse("Job d'export", exportJobResponse{}),
+					"404": openapi.ProblemResponse("Job inconnu"),
+				},
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/api/v2/exports/{id}/download", Handler: h.DownloadExportJob,
+			Operation: openapi.Operation{
+				OperationID: "downloadExportJob",
+				Summary:     "Télécharge le fichier produit par un job terminé",
+				Tags:        exportTag,
+				Parameters:  []openapi.Parameter{exportJobIDParam()},
+				Responses: map[string]openapi.Response{
+					"200": openapi.FileResponse("Fichier CSV ou Parquet", "application/octet-stream"),
+					"404": openapi.ProblemResponse("Job inconnu"),
+					"409": openapi.ProblemResponse("Job pas encore terminé"),
+				},
+			},
+		},
+	}
+
+	for i := range routes {
+		routes[i].StrictQuery = true
+	}
+	return routes
+}
+
+// joinParams concatène des groupes de paramètres
+func joinParams(groups ...[]openapi.Parameter) []openapi.Parameter {
+	var params []openapi.Parameter
+	for _, group := range groups {
+		params = append(params, group...)
+	}
+	return params
+}
+
+// jsonOK réponse 200 JSON décrite par le DTO encodé par le handler
+// PIÈGE: model doit être le type réellement encodé, sinon la spec et la réponse divergent
+func jsonOK(description string, model interface{}) map[string]openapi.Response {
+	return map[string]openapi.Response{"200": openapi.JSONResponse(description, model)}
+}
+
+// fileOK réponse 200 téléchargée en pièce jointe
+func fileOK(description, contentType string) map[string]openapi.Response {
+	return map[string]openapi.Response{"200": openapi.FileResponse(description, contentType)}
+}
+
+// periodParams paramètres de parseDateRange (days vaut defaultDays si aucune période n'est donnée)
+func periodParams(defaultDays int) []openapi.Parameter {
+	return []openapi.Parameter{
+		openapi.QueryParam("period", "2025, 2025-09, 2025-Q3, 2025-W38 ou today, yesterday, this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year (exclusif de from/to)", openapi.StringSchema()),
+		openapi.QueryParam("from", "Début de période (inclus)", openapi.DateSchema()),
+		openapi.QueryParam("to", "Fin de période (incluse), aujourd'hui si absent", openapi.DateSchema()),
+		openapi.QueryParam("days", "Les N derniers jours", openapi.IntegerSchema(1, 0).WithDefault(defaultDays)),
+		openapi.QueryParam("tz", "Fuseau IANA des dates (Europe/Paris), fuseau du serveur par défaut", openapi.StringSchema()),
+	}
+}
+
+// statsFilterParams paramètres de parseStatsFilter (découpage par dimension, statut et devise)
+func statsFilterParams() []openapi.Parameter {
+	return []openapi.Parameter{
+		openapi.QueryParam("store_id", "Magasin", openapi.IntegerSchema(1, 0)),
+		openapi.QueryParam("region", "Magasins d'une région", openapi.StringSchema()),
+		openapi.QueryParam("city", "Magasins d'une ville", openapi.StringSchema()),
+		openapi.QueryParam("category_id", "Commandes contenant un article de la catégorie", openapi.IntegerSchema(1, 0)),
+		openapi.QueryParam("supplier_id", "Commandes contenant un article du fournisseur", openapi.IntegerSchema(1, 0)),
+		openapi.QueryParam("payment_method_id", "Moyen de paiement", openapi.IntegerSchema(1, 0)),
+		openapi.QueryParam("promotion_code", "Code promotion utilisé", openapi.StringSchema()),
+		statusParam(),
+		currencyParam(),
+	}
+}
+
+// statusParam paramètre de parseStatusFilter
+func statusParam() openapi.Parameter {
+	return openapi.QueryParam("status", "Statuts retenus: completed,pending ou all",
+		openapi.StringSchema().WithDefault(string(ordersdomain.OrderStatusCompleted)))
+}
+
+// currencyParam paramètre de parseCurrency
+func currencyParam() openapi.Parameter {
+	return openapi.QueryParam("currency", "Devise de reporting (code ISO 4217)",
+		openapi.PatternSchema(`^[a-zA-Z]{3}$`).WithDefault(string(shareddomain.DefaultCurrency)))
+}
+
+// paginationParams paramètres de parsePagination
+func paginationParams(defaultPageSize int) []openapi.Parameter {
+	return []openapi.Parameter{
+		openapi.QueryParam("page", "Numéro de page", openapi.IntegerSchema(1, 0).WithDefault(1)),
+		openapi.QueryParam("page_size", "Taille de page", openapi.IntegerSchema(1, shareddomain.MaxPageSize).WithDefault(defaultPageSize)),
+	}
+}
+
+// basketQueryParams paramètres de parseBasketParams
+func basketQueryParams() []openapi.Parameter {
+	return []openapi.Parameter{
+		openapi.QueryParam("triples", "Inclut les règles à deux antécédents", openapi.BooleanSchema().WithDefault(false)),
+		openapi.QueryParam("min_support", "Support minimal (fraction des commandes)", openapi.NumberRangeSchema(0, 1)),
+		openapi.QueryParam("sort", "Critère de tri", openapi.EnumSchema(
+			string(analyticsdomain.RuleSortLift), string(analyticsdomain.RuleSortConfidence), string(analyticsdomain.RuleSortSupport),
+		).WithDefault(string(analyticsdomain.RuleSortLift))),
+		openapi.QueryParam("limit", "Nombre maximum de règles", openapi.IntegerSchema(1, 0).WithDefault(20)),
+	}
+}
+
+// stockThresholdParams paramètres de parseStockThresholds
+func stockThresholdParams() []openapi.Parameter {
+	return []openapi.Parameter{
+		openapi.QueryParam("risk_days", "Couverture en jours sous laquelle un produit est à risque",
+			openapi.IntegerSchema(1, 0).WithDefault(catalogdomain.DefaultStockRiskDays)),
+		openapi.QueryParam("dead_days", "Jours sans vente au-delà desquels le stock est dormant",
+			openapi.IntegerSchema(1, 0).WithDefault(catalogdomain.DefaultDeadStockDays)),
+	}
+}
+
+// segmentParam paramètre de parseSegment
+func segmentParam() openapi.Parameter {
+	segments := make([]string, 0, len(customersdomain.Segments))
+	for _, s := range customersdomain.Segments {
+		segments = append(segments, string(s))
+	}
+	return openapi.QueryParam("segment", "Segment RFM", openapi.EnumSchema(segments...))
+}
+
+// exportJobIDParam identifiant d'un job d'export
+func exportJobIDParam() openapi.Parameter {
+	return openapi.PathParam("id", "Identifiant du job", openapi.StringSchema())
+}
+
+// granularitySchema énumération des granularités acceptées (la première est la valeur par défaut)
+func granularitySchema(granularities ...analyticsdomain.Granularity) *openapi.Schema {
+	values := make([]string, 0, len(granularities))
+	for _, g := range granularities {
+		values = append(values, string(g))
+	}
+	return openapi.EnumSchema(values...).WithDefault(values[0])
+}
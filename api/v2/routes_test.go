@@ -0,0 +1,162 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"eval/api/openapi"
+	"eval/api/problem"
+)
+
+// TestRoutes_Document vérifie que toutes les routes V2 s'enregistrent et produisent un document cohérent
+func TestRoutes_Document(t *testing.T) {
+	routes := (&Handlers{}).Routes()
+
+	// http.ServeMux panique sur un motif invalide ou en conflit avec un autre
+	mux := http.NewServeMux()
+	for _, route := range routes {
+		mux.HandleFunc(route.Pattern(), openapi.Validate(route))
+	}
+
+	doc := openapi.NewDocument(openapi.Info{Title: "test", Version: "test"}, routes)
+
+	ids := doc.OperationIDs()
+	if len(ids) != len(routes) {
+		t.Errorf("got %d operations, want %d", len(ids), len(routes))
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] == ids[i-1] || ids[i] == "" {
+			t.Errorf("duplicate or empty operationId: %q", ids[i])
+		}
+	}
+
+	// Chaque réponse JSON 2xx référence le DTO encodé par le handler (pas un objet sans champs)
+	for path, operations := range doc.Paths {
+		for method, op := range operations {
+			for status, response := range op.Responses {
+				media, ok := response.Content["application/json"]
+				if !ok || status[0] != '2' {
+					continue
+				}
+				if media.Schema == nil || media.Schema.Ref == "" {
+					t.Errorf("%s %s %s: JSON response without a typed schema", method, path, status)
+				}
+			}
+		}
+	}
+	if segments := doc.Components.Schemas["CustomerSegmentsResponse"]; segments != nil {
+		for _, optional := range []string{"segment", "customers"} {
+			for _, required := range segments.Required {
+				if required == optional {
+					t.Errorf("CustomerSegmentsResponse.%s should be optional (omitzero)", optional)
+				}
+			}
+		}
+	} else {
+		t.Error("CustomerSegmentsResponse missing from components")
+	}
+
+	stats := doc.Components.Schemas["StatsDTO"]
+	if stats == nil {
+		t.Fatal("StatsDTO missing from components")
+	}
+	for _, section := range []string{"global", "categories", "top_products", "top_stores", "payment_methods"} {
+		if _, ok := stats.Properties[section]; !ok {
+			t.Errorf("StatsDTO.%s missing", section)
+		}
+	}
+}
+
+// TestRoutes_ValidationBeforeHandler vérifie qu'un paramètre invalide est rejeté sans appeler le service
+// (les services sont nil: le handler paniquerait s'il était appelé)
+func TestRoutes_ValidationBeforeHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	for _, route := range (&Handlers{}).Routes() {
+		mux.HandleFunc(route.Pattern(), openapi.Validate(route))
+	}
+
+	for _, target := range []string{
+		"/api/v2/stats?days=abc",
+		"/api/v2/stats?dayz=30",
+		"/api/v2/stats/timeseries?granularity=year",
+		"/api/v2/stats/forecast?horizon_days=1000",
+		"/api/v2/customers/top?page_size=10000",
+		"/api/v2/customers/abc/lifetime-value",
+		"/api/v2/stats/basket",
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want 400", target, w.Code)
+		}
+	}
+}
+
+// TestHandlers_ProblemResponses vérifie qu'une règle métier violée (paramètres valides un à un)
+// produit une réponse problem+json avec le code de l'erreur du domaine
+func TestHandlers_ProblemResponses(t *testing.T) {
+	mux := http.NewServeMux()
+	for _, route := range (&Handlers{}).Routes() {
+		mux.HandleFunc(route.Pattern(), openapi.Validate(route))
+	}
+	handler := problem.RequestID(mux)
+
+	tests := []struct {
+		method, target, body string
+		code                 string
+	}{
+		{http.MethodGet, "/api/v2/stats?from=2025-09-30&to=2025-09-01", "", "invalid_date_range"},
+		{http.MethodGet, "/api/v2/stats?period=2025-Q5", "", "invalid_period"},
+		{http.MethodGet, "/api/v2/stats?tz=Mars/Olympus_Mons", "", "invalid_tz"},
+		{http.MethodPost, "/api/v2/exports", "{", "invalid_body"},
+		{http.MethodPost, "/api/v2/exports", `{"type":"stats","format":"xlsx"}`, "invalid_format"},
+		{http.MethodPost, "/api/v2/exports", `{"days":-5}`, "invalid_date_range"},
+		{http.MethodPost, "/api/v2/exports", `{"days":0}`, "invalid_date_range"},
+		{http.MethodPost, "/api/v2/exports", `{"status":"all"}`, "invalid_body"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+
+		var body problem.Problem
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("%s %s: %v", tt.method, tt.target, err)
+		}
+		if w.Code != http.StatusBadRequest || body.Code != tt.code || body.RequestID == "" {
+			t.Errorf("%s %s = %d %+v, want 400 %s with a request_id", tt.method, tt.target, w.Code, body, tt.code)
+		}
+	}
+}
+
+// TestAbortStream vérifie qu'une erreur d'export ne produit un problème que si rien n'est encore parti
+func TestAbortStream(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/v2/export/csv", nil)
+	exportErr := errors.New("pq: connection reset")
+
+	// rien d'écrit: réponse problem+json
+	w := httptest.NewRecorder()
+	abortStream(&streamWriter{ResponseWriter: w}, r, exportErr)
+	if w.Code != http.StatusInternalServerError || w.Header().Get("Content-Type") != problem.ContentType {
+		t.Errorf("before output: status = %d, Content-Type = %q, want a 500 problem", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	// une partie du CSV est partie: connexion coupée, rien n'est ajouté au fichier
+	w = httptest.NewRecorder()
+	stream := &streamWriter{ResponseWriter: w}
+	stream.Write([]byte("order_id,order_date\n"))
+	func() {
+		defer func() {
+			if recovered := recover(); recovered != http.ErrAbortHandler {
+				t.Errorf("after output: recovered %v, want http.ErrAbortHandler", recovered)
+			}
+		}()
+		abortStream(stream, r, exportErr)
+	}()
+	if got := w.Body.String(); got != "order_id,order_date\n" {
+		t.Errorf("after output: body = %q, want only the CSV already written", got)
+	}
+}
@@ -0,0 +1,314 @@
+package v2
+
+import (
+	analyticsdomain "eval/internal/analytics/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// ========================================
+// DTOs DE RÉPONSE - GET /api/v2/stats
+// ========================================
+// Le domaine n'expose que des getters (champs non exportés): encoding/json ne voit aucun champ
+// et sérialisait Stats en {}. Les DTOs figent le contrat JSON de la v2:
+//   - noms snake_case stables, indépendants des noms Go du domaine
+//   - mapping explicite depuis les getters (renommer un champ du domaine ne casse pas l'API)
+//   - listes toujours présentes ([] et non null quand elles sont vides)
+//
+// Toute modification de forme est visible dans testdata/*.golden.json (go test ./api/v2 -update)
+
+// statsSchemaVersion version du schéma de réponse (champ "version")
+const statsSchemaVersion = "v2"
+
+// StatsResponse réponse de GET /api/v2/stats (montants exprimés dans Currency)
+type StatsResponse struct {
+	Version         string                `json:"version"`
+	Message         string                `json:"message"`
+	Period          PeriodDTO             `json:"period"`
+	Currency        shareddomain.Currency `json:"currency"`
+	Stats           StatsDTO              `json:"stats"`
+	StatusBreakdown []OrderStatusStatsDTO `json:"status_breakdown"`
+	Comparison      []PeriodComparisonDTO `json:"comparison,omitempty"` // compare=true uniquement
+}
+
+// PeriodDTO période effectivement utilisée (bornes normalisées, incluses)
+type PeriodDTO struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Days int    `json:"days"`
+}
+
+// StatsDTO sections des statistiques
+type StatsDTO struct {
+	Global         GlobalStatsDTO          `json:"global"`
+	Categories     []CategoryStatsDTO      `json:"categories"`
+	TopProducts    []ProductStatsDTO       `json:"top_products"`
+	TopStores      []StoreStatsDTO         `json:"top_stores"`
+	PaymentMethods []PaymentMethodStatsDTO `json:"payment_methods"`
+}
+
+// GlobalStatsDTO KPI globaux de la période
+type GlobalStatsDTO struct {
+	TotalRevenue      float64 `json:"total_revenue"`
+	TotalOrders       int     `json:"total_orders"`
+	AverageOrderValue float64 `json:"average_order_value"`
+}
+
+// CategoryStatsDTO CA et commandes d'une catégorie
+type CategoryStatsDTO struct {
+	CategoryID   int64   `json:"category_id"`
+	Name         string  `json:"name"`
+	TotalRevenue float64 `json:"total_revenue"`
+	TotalOrders  int     `json:"total_orders"`
+}
+
+// ProductStatsDTO ventes d'un produit du top
+type ProductStatsDTO struct {
+	ProductID     int64   `json:"product_id"`
+	Name          string  `json:"name"`
+	TotalRevenue  float64 `json:"total_revenue"`
+	TotalOrders   int     `json:"total_orders"`
+	TotalQuantity int     `json:"total_quantity"`
+}
+
+// StoreStatsDTO ventes d'un magasin du top
+type StoreStatsDTO struct {
+	StoreID      int64   `json:"store_id"`
+	Name         string  `json:"name"`
+	TotalRevenue float64 `json:"total_revenue"`
+	TotalOrders  int     `json:"total_orders"`
+}
+
+// PaymentMethodStatsDTO répartition des ventes par moyen de paiement
+type PaymentMethodStatsDTO struct {
+	PaymentMethodID int64   `json:"payment_method_id"`
+	Name            string  `json:"name"`
+	TotalRevenue    float64 `json:"total_revenue"`
+	TotalOrders     int     `json:"total_orders"`
+	Percentage      float64 `json:"percentage"`
+}
+
+// OrderStatusStatsDTO volume de commandes d'un statut (tous statuts, quel que soit le filtre)
+type OrderStatusStatsDTO struct {
+	Status          string  `json:"status"`
+	Revenue         float64 `json:"revenue"`
+	OrderCount      int     `json:"order_count"`
+	OrderPercentage float64 `json:"order_percentage"`
+}
+
+// PeriodComparisonDTO comparaison à une période de référence (compare=true)
+type PeriodComparisonDTO struct {
+	Baseline        string            `json:"baseline"`
+	Period          PeriodDTO         `json:"period"`
+	KPIs            ComparisonKPIsDTO `json:"kpis"`
+	Categories      []RankedDeltaDTO  `json:"categories"`
+	TopProducts     []RankedDeltaDTO  `json:"top_products"`
+	TopStores       []RankedDeltaDTO  `json:"top_stores"`
+	LeftTopProducts []RankingExitDTO  `json:"left_top_products"`
+	LeftTopStores   []RankingExitDTO  `json:"left_top_stores"`
+	PaymentMethods  []RankedDeltaDTO  `json:"payment_methods"`
+}
+
+// ComparisonKPIsDTO KPI globaux comparés
+type ComparisonKPIsDTO struct {
+	TotalRevenue      MetricDeltaDTO `json:"total_revenue"`
+	TotalOrders       MetricDeltaDTO `json:"total_orders"`
+	AverageOrderValue MetricDeltaDTO `json:"average_order_value"`
+}
+
+// MetricDeltaDTO valeur comparée (delta_pct = null si la référence vaut 0)
+type MetricDeltaDTO struct {
+	Current  float64  `json:"current"`
+	Previous float64  `json:"previous"`
+	Delta    float64  `json:"delta"`
+	DeltaPct *float64 `json:"delta_pct"`
+}
+
+// RankedDeltaDTO entrée d'un classement avec son mouvement de rang (previous_rank = null si absent)
+// status: kept, new (aucune vente sur la référence) ou entered_top (hors du top N de référence:
+// revenue.previous et orders.previous valent alors 0 faute de valeur connue)
+type RankedDeltaDTO struct {
+	ID           int64          `json:"id"`
+	Name         string         `json:"name"`
+	Rank         int            `json:"rank"`
+	PreviousRank *int           `json:"previous_rank"`
+	RankChange   int            `json:"rank_change"`
+	Status       string         `json:"status"`
+	NewEntry     bool           `json:"new_entry"`
+	Revenue      MetricDeltaDTO `json:"revenue"`
+	Orders       MetricDeltaDTO `json:"orders"`
+}
+
+// RankingExitDTO entrée du top N de référence sortie du top courant (seules les valeurs de référence sont connues)
+type RankingExitDTO struct {
+	ID              int64   `json:"id"`
+	Name            string  `json:"name"`
+	PreviousRank    int     `json:"previous_rank"`
+	PreviousRevenue float64 `json:"previous_revenue"`
+	PreviousOrders  int     `json:"previous_orders"`
+}
+
+// newStatsResponse construit la réponse de /api/v2/stats depuis le domaine
+// MÉMOIRE: slices préallouées à la taille exacte (make(..., 0, len)), [] et non null si vides
+func newStatsResponse(
+	stats *analyticsdomain.Stats,
+	dateRange shareddomain.DateRange,
+	currency shareddomain.Currency,
+) StatsResponse {
+	categories := make([]CategoryStatsDTO, 0, len(stats.CategoryStats()))
+	for _, cs := range stats.CategoryStats() {
+		categories = append(categories, CategoryStatsDTO{
+			CategoryID:   int64(cs.CategoryID()),
+			Name:         cs.CategoryName(),
+			TotalRevenue: cs.TotalRevenue().Amount(),
+			TotalOrders:  cs.TotalOrders(),
+		})
+	}
+
+	products := make([]ProductStatsDTO, 0, len(stats.TopProducts()))
+	for _, ps := range stats.TopProducts() {
+		products = append(products, ProductStatsDTO{
+			ProductID:     int64(ps.ProductID()),
+			Name:          ps.ProductName(),
+			TotalRevenue:  ps.TotalRevenue().Amount(),
+			TotalOrders:   ps.TotalOrders(),
+			TotalQuantity: ps.TotalQuantity().Value(),
+		})
+	}
+
+	stores := make([]StoreStatsDTO, 0, len(stats.TopStores()))
+	for _, ss := range stats.TopStores() {
+		stores = append(stores, StoreStatsDTO{
+			StoreID:      int64(ss.StoreID()),
+			Name:         ss.StoreName(),
+			TotalRevenue: ss.TotalRevenue().Amount(),
+			TotalOrders:  ss.TotalOrders(),
+		})
+	}
+
+	payments := make([]PaymentMethodStatsDTO, 0, len(stats.PaymentDistribution()))
+	for _, pms := range stats.PaymentDistribution() {
+		payments = append(payments, PaymentMethodStatsDTO{
+			PaymentMethodID: int64(pms.PaymentMethodID()),
+			Name:            pms.PaymentMethodName(),
+			TotalRevenue:    pms.TotalRevenue().Amount(),
+			TotalOrders:     pms.TotalOrders(),
+			Percentage:      pms.Percentage(),
+		})
+	}
+
+	return StatsResponse{
+		Version:  statsSchemaVersion,
+		Message:  "Stats calculated with V2 (optimized: cached + parallel SQL queries)",
+		Period:   periodToJSON(dateRange),
+		Currency: currency,
+		Stats: StatsDTO{
+			Global: GlobalStatsDTO{
+				TotalRevenue:      stats.TotalRevenue().Amount(),
+				TotalOrders:       stats.TotalOrders(),
+				AverageOrderValue: stats.AverageOrderValue().Amount(),
+			},
+			Categories:     categories,
+			TopProducts:    products,
+			TopStores:      stores,
+			PaymentMethods: payments,
+		},
+		StatusBreakdown: statusBreakdownToJSON(stats.StatusBreakdown()),
+	}
+}
+
+// statusBreakdownToJSON décrit le volume de commandes par statut (tous statuts, quel que soit le filtre)
+func statusBreakdownToJSON(breakdown []*analyticsdomain.OrderStatusStats) []OrderStatusStatsDTO {
+	result := make([]OrderStatusStatsDTO, 0, len(breakdown))
+	for _, oss := range breakdown {
+		result = append(result, OrderStatusStatsDTO{
+			Status:          string(oss.Status()),
+			Revenue:         oss.TotalRevenue().Amount(),
+			OrderCount:      oss.TotalOrders(),
+			OrderPercentage: oss.OrderPercentage(),
+		})
+	}
+	return result
+}
+
+// periodToJSON décrit la période effectivement utilisée (bornes normalisées)
+func periodToJSON(dateRange shareddomain.DateRange) PeriodDTO {
+	return PeriodDTO{
+		From: dateRange.Start().Format(dateParamLayout),
+		To:   dateRange.End().Format(dateParamLayout),
+		Days: dateRange.Days(),
+	}
+}
+
+// comparisonToJSON convertit les comparaisons de périodes
+func comparisonToJSON(comparison *analyticsdomain.StatsComparison) []PeriodComparisonDTO {
+	result := make([]PeriodComparisonDTO, 0, len(comparison.Comparisons()))
+	for _, pc := range comparison.Comparisons() {
+		result = append(result, PeriodComparisonDTO{
+			Baseline: string(pc.Baseline()),
+			Period:   periodToJSON(pc.DateRange()),
+			KPIs: ComparisonKPIsDTO{
+				TotalRevenue:      deltaToJSON(pc.TotalRevenue()),
+				TotalOrders:       deltaToJSON(pc.TotalOrders()),
+				AverageOrderValue: deltaToJSON(pc.AverageOrderValue()),
+			},
+			Categories:      rankingToJSON(pc.Categories()),
+			TopProducts:     rankingToJSON(pc.TopProducts()),
+			TopStores:       rankingToJSON(pc.TopStores()),
+			LeftTopProducts: exitsToJSON(pc.LeftTopProducts()),
+			LeftTopStores:   exitsToJSON(pc.LeftTopStores()),
+			PaymentMethods:  rankingToJSON(pc.PaymentMethods()),
+		})
+	}
+	return result
+}
+
+// deltaToJSON décrit une valeur comparée (DeltaPct nil = null si la référence vaut 0)
+func deltaToJSON(delta analyticsdomain.MetricDelta) MetricDeltaDTO {
+	dto := MetricDeltaDTO{
+		Current:  delta.Current(),
+		Previous: delta.Previous(),
+		Delta:    delta.Absolute(),
+	}
+	if pct, ok := delta.Percent(); ok {
+		dto.DeltaPct = &pct
+	}
+	return dto
+}
+
+// rankingToJSON décrit un classement avec le mouvement de rang (PreviousRank nil = null si absent)
+func rankingToJSON(ranking []*analyticsdomain.RankedDelta) []RankedDeltaDTO {
+	result := make([]RankedDeltaDTO, 0, len(ranking))
+	for _, rd := range ranking {
+		dto := RankedDeltaDTO{
+			ID:         rd.ID(),
+			Name:       rd.Name(),
+			Rank:       rd.Rank(),
+			RankChange: rd.RankChange(),
+			Status:     string(rd.Status()),
+			NewEntry:   rd.IsNewEntry(),
+			Revenue:    deltaToJSON(rd.Revenue()),
+			Orders:     deltaToJSON(rd.Orders()),
+		}
+		if rd.PreviousRank() > 0 {
+			previousRank := rd.PreviousRank()
+			dto.PreviousRank = &previousRank
+		}
+		result = append(result, dto)
+	}
+	return result
+}
+
+// exitsToJSON décrit les entrées sorties du top N (statut left_top)
+func exitsToJSON(exits []*analyticsdomain.RankedDelta) []RankingExitDTO {
+	result := make([]RankingExitDTO, 0, len(exits))
+	for _, rd := range exits {
+		result = append(result, RankingExitDTO{
+			ID:              rd.ID(),
+			Name:            rd.Name(),
+			PreviousRank:    rd.PreviousRank(),
+			PreviousRevenue: rd.Revenue().Previous(),
+			PreviousOrders:  int(rd.Orders().Previous()),
+		})
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	analyticsdomain "eval/internal/analytics/domain"
+	catalogdomain "eval/internal/catalog/domain"
+	ordersdomain "eval/internal/orders/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// update régénère les fichiers golden: go test ./api/v2 -update
+var update = flag.Bool("update", false, "update golden files")
+
+// assertGolden compare la réponse sérialisée à testdata/<name>.golden.json
+func assertGolden(t *testing.T, name string, response interface{}) {
+	t.Helper()
+
+	got, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test ./api/v2 -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s does not match the response:\n%s", path, got)
+	}
+}
+
+func money(t *testing.T, value string) shareddomain.Money {
+	t.Helper()
+	m, err := shareddomain.ParseMoney(value, "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// fixtureStats construit des Stats couvrant toutes les sections de la réponse
+func fixtureStats(t *testing.T, electronics, books string, orders int) *analyticsdomain.Stats {
+	stats := analyticsdomain.NewStats()
+	stats.SetTotalRevenue(money(t, "1500.00"))
+	stats.SetTotalOrders(orders)
+	stats.SetAverageOrderValue(money(t, "500.00"))
+	stats.SetCategoryStats([]*analyticsdomain.CategoryStats{
+		analyticsdomain.NewCategoryStats(catalogdomain.CategoryID(1), "Electronics", money(t, electronics), 2),
+		analyticsdomain.NewCategoryStats(catalogdomain.CategoryID(2), "Books", money(t, books), 1),
+	})
+	stats.SetTopProducts([]*analyticsdomain.ProductStats{
+		analyticsdomain.NewProductStats(catalogdomain.ProductID(10), "Laptop", money(t, "1200.00"), 1, shareddomain.MustNewQuantity(1)),
+	})
+	stats.SetTopStores([]*analyticsdomain.StoreStats{
+		analyticsdomain.NewStoreStats(ordersdomain.StoreID(3), "Paris Centre", money(t, "1500.00"), orders),
+	})
+	stats.SetPaymentDistribution([]*analyticsdomain.PaymentMethodStats{
+		analyticsdomain.NewPaymentMethodStats(ordersdomain.PaymentMethodID(1), "Credit Card", money(t, "1500.00"), orders, 100),
+	})
+	stats.SetStatusBreakdown([]*analyticsdomain.OrderStatusStats{
+		analyticsdomain.NewOrderStatusStats(ordersdomain.OrderStatusCompleted, money(t, "1500.00"), orders, 75),
+		analyticsdomain.NewOrderStatusStats(ordersdomain.OrderStatusCancelled, money(t, "80.00"), 1, 25),
+	})
+	return stats
+}
+
+func january2024(t *testing.T) shareddomain.DateRange {
+	dateRange, err := shareddomain.NewDateRange(
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dateRange
+}
+
+// TestStatsResponse_Golden fige la forme de GET /api/v2/stats
+func TestStatsResponse_Golden(t *testing.T) {
+	response := newStatsResponse(fixtureStats(t, "1200.00", "300.00", 3), january2024(t), "EUR")
+	assertGolden(t, "stats_response", response)
+}
+
+// TestStatsResponse_EmptyGolden vérifie que les sections vides sont des listes vides (et non null)
+func TestStatsResponse_EmptyGolden(t *testing.T) {
+	response := newStatsResponse(analyticsdomain.NewStats(), january2024(t), "USD")
+	assertGolden(t, "stats_response_empty", response)
+}
+
+// TestStatsResponse_ComparisonGolden fige la forme de GET /api/v2/stats?compare=true
+func TestStatsResponse_ComparisonGolden(t *testing.T) {
+	dateRange := january2024(t)
+	current := fixtureStats(t, "1200.00", "300.00", 3)
+	previous := fixtureStats(t, "900.00", "600.00", 2)
+	previous.SetTopProducts(nil)
+
+	comparison := analyticsdomain.NewStatsComparison(current, dateRange,
+		analyticsdomain.NewPeriodComparison(analyticsdomain.BaselinePreviousPeriod, dateRange.Previous(), current, previous))
+
+	response := newStatsResponse(comparison.Current(), dateRange, "EUR")
+	response.Comparison = comparisonToJSON(comparison)
+	assertGolden(t, "stats_response_comparison", response)
+}
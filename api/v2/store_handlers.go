@@ -0,0 +1,205 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"eval/api/problem"
+	analyticsdomain "eval/internal/analytics/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// GetStoreDashboard handler pour GET /api/v2/stats/stores
+// Tous les magasins sur la période (30 jours par défaut) et l'agrégation par région:
+//   - region= restreint la liste des magasins (le rang reste celui du classement global)
+//   - sort=revenue|orders|revenue_per_order|items_per_order|rank_change|name, order=asc|desc
+//   - page, page_size (20 par défaut), status (completed par défaut), currency (EUR par défaut)
+func (h *Handlers) GetStoreDashboard(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	dateRange, err := parseDateRange(params, 30)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	status, err := parseStatusFilter(params)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	currency, err := parseCurrency(params)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	sortBy, err := analyticsdomain.ParseStoreSort(params.Get("sort"))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	ascending, err := parseSortOrder(params, sortBy.DefaultAscending())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	pagination, err := parsePagination(params, 20)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	dashboard, err := h.storeService.GetStoreDashboard(dateRange, status, currency)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	page := dashboard.Page(params.Get("region"), sortBy, ascending, pagination)
+
+	stores := make([]StorePerformanceDTO, 0, len(page.Stores()))
+	for _, s := range page.Stores() {
+		var previousRank *int
+		if s.PreviousRank() > 0 {
+			rank := s.PreviousRank()
+			previousRank = &rank
+		}
+
+		paymentMix := make([]StorePaymentShareDTO, 0, len(s.PaymentMix()))
+		for _, p := range s.PaymentMix() {
+			paymentMix = append(paymentMix, StorePaymentShareDTO{
+				PaymentMethodID: int64(p.PaymentMethodID()),
+				Name:            p.Name(),
+				OrderCount:      p.OrderCount(),
+				Revenue:         p.Revenue().Amount(),
+				Percentage:      p.Percentage(),
+			})
+		}
+
+		stores = append(stores, StorePerformanceDTO{
+			StoreID:         int64(s.StoreID()),
+			Name:            s.Name(),
+			City:            s.City(),
+			Region:          s.Region(),
+			Rank:            s.Rank(),
+			PreviousRank:    previousRank,
+			RankChange:      s.RankChange(),
+			NewEntry:        s.IsNewEntry(),
+			Revenue:         deltaToJSON(s.Revenue()),
+			Orders:          deltaToJSON(s.Orders()),
+			RevenueShare:    s.RevenueShare(),
+			RevenuePerOrder: s.RevenuePerOrder().Amount(),
+			ItemsSold:       s.ItemsSold(),
+			ItemsPerOrder:   s.ItemsPerOrder(),
+			PaymentMix:      paymentMix,
+		})
+	}
+
+	regions := make([]RegionPerformanceDTO, 0, len(dashboard.Regions()))
+	for _, rp := range dashboard.Regions() {
+		regions = append(regions, RegionPerformanceDTO{
+			Region:          rp.Region(),
+			StoreCount:      rp.StoreCount(),
+			Revenue:         deltaToJSON(rp.Revenue()),
+			Orders:          deltaToJSON(rp.Orders()),
+			RevenueShare:    rp.RevenueShare(),
+			RevenuePerOrder: rp.RevenuePerOrder().Amount(),
+			ItemsSold:       rp.ItemsSold(),
+			ItemsPerOrder:   rp.ItemsPerOrder(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(StoreDashboardResponse{
+		Version:        statsSchemaVersion,
+		Period:         periodToJSON(dateRange),
+		PreviousPeriod: periodToJSON(dashboard.PreviousRange()),
+		Currency:       currency,
+		TotalRevenue:   deltaToJSON(dashboard.TotalRevenue()),
+		Regions:        regions,
+		Sort:           string(sortBy),
+		Ascending:      ascending,
+		Page:           pagination.Page(),
+		PageSize:       pagination.PageSize(),
+		TotalStores:    page.TotalStores(),
+		TotalPages:     page.TotalPages(),
+		Stores:         stores,
+	})
+}
+
+// StoreDashboardResponse réponse de GET /api/v2/stats/stores
+type StoreDashboardResponse struct {
+	Version        string                 `json:"version"`
+	Period         PeriodDTO              `json:"period"`
+	PreviousPeriod PeriodDTO              `json:"previous_period"`
+	Currency       shareddomain.Currency  `json:"currency"`
+	TotalRevenue   MetricDeltaDTO         `json:"total_revenue"`
+	Regions        []RegionPerformanceDTO `json:"regions"`
+	Sort           string                 `json:"sort"`
+	Ascending      bool                   `json:"ascending"`
+	Page           int                    `json:"page"`
+	PageSize       int                    `json:"page_size"`
+	TotalStores    int                    `json:"total_stores"`
+	TotalPages     int                    `json:"total_pages"`
+	Stores         []StorePerformanceDTO  `json:"stores"`
+}
+
+// StorePerformanceDTO indicateurs d'un magasin (previous_rank = null si absent de la période précédente)
+type StorePerformanceDTO struct {
+	StoreID         int64                  `json:"store_id"`
+	Name            string                 `json:"name"`
+	City            string                 `json:"city"`
+	Region          string                 `json:"region"`
+	Rank            int                    `json:"rank"`
+	PreviousRank    *int                   `json:"previous_rank"`
+	RankChange      int                    `json:"rank_change"`
+	NewEntry        bool                   `json:"new_entry"`
+	Revenue         MetricDeltaDTO         `json:"revenue"`
+	Orders          MetricDeltaDTO         `json:"orders"`
+	RevenueShare    float64                `json:"revenue_share"`
+	RevenuePerOrder float64                `json:"revenue_per_order"`
+	ItemsSold       int                    `json:"items_sold"`
+	ItemsPerOrder   float64                `json:"items_per_order"`
+	PaymentMix      []StorePaymentShareDTO `json:"payment_mix"`
+}
+
+// StorePaymentShareDTO répartition des commandes d'un magasin par moyen de paiement
+type StorePaymentShareDTO struct {
+	PaymentMethodID int64   `json:"payment_method_id"`
+	Name            string  `json:"name"`
+	OrderCount      int     `json:"order_count"`
+	Revenue         float64 `json:"revenue"`
+	Percentage      float64 `json:"percentage"`
+}
+
+// RegionPerformanceDTO indicateurs agrégés des magasins d'une région
+type RegionPerformanceDTO struct {
+	Region          string         `json:"region"`
+	StoreCount      int            `json:"store_count"`
+	Revenue         MetricDeltaDTO `json:"revenue"`
+	Orders          MetricDeltaDTO `json:"orders"`
+	RevenueShare    float64        `json:"revenue_share"`
+	RevenuePerOrder float64        `json:"revenue_per_order"`
+	ItemsSold       int            `json:"items_sold"`
+	ItemsPerOrder   float64        `json:"items_per_order"`
+}
+
+// parseSortOrder lit order=asc|desc (defaultAscending si absent)
+func parseSortOrder(params url.Values, defaultAscending bool) (bool, error) {
+	switch value := params.Get("order"); value {
+	case "":
+		return defaultAscending, nil
+	case "asc":
+		return true, nil
+	case "desc":
+		return false, nil
+	default:
+		return false, shareddomain.NewValidationError("invalid_order", fmt.Sprintf("invalid order: %q (expected asc or desc)", value))
+	}
+}
@@ -0,0 +1,111 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"eval/api/problem"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// GetSupplierStats handler pour GET /api/v2/stats/suppliers
+// Ventes par fournisseur sur la période (30 jours par défaut, period= / from & to acceptés)
+// top_products = nombre de meilleurs produits par fournisseur (5 par défaut)
+func (h *Handlers) GetSupplierStats(w http.ResponseWriter, r *http.Request) {
+	dateRange, err := parseDateRange(r.URL.Query(), 30)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	topProducts := 5
+	if value := r.URL.Query().Get("top_products"); value != "" {
+		if topProducts, err = strconv.Atoi(value); err != nil || topProducts < 1 {
+			problem.Write(w, r, shareddomain.NewValidationError("invalid_top_products", fmt.Sprintf("invalid top_products: %q", value)))
+			return
+		}
+	}
+
+	currency, err := parseCurrency(r.URL.Query())
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	report, err := h.supplierService.GetSupplierReport(dateRange, topProducts, currency)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	suppliers := make([]SupplierPerformanceDTO, 0, len(report.Suppliers()))
+	for _, p := range report.Suppliers() {
+		products := make([]SupplierProductSalesDTO, 0, len(p.TopProducts()))
+		for _, tp := range p.TopProducts() {
+			products = append(products, SupplierProductSalesDTO{
+				ProductID: int64(tp.ProductID()),
+				Name:      tp.Name(),
+				UnitsSold: tp.UnitsSold(),
+				Revenue:   tp.Revenue().Amount(),
+			})
+		}
+
+		supplier := p.Supplier()
+		suppliers = append(suppliers, SupplierPerformanceDTO{
+			SupplierID:   int64(supplier.ID()),
+			Name:         supplier.Name(),
+			City:         supplier.City(),
+			Country:      supplier.Country(),
+			Revenue:      p.Revenue().Amount(),
+			UnitsSold:    p.UnitsSold(),
+			OrderCount:   p.OrderCount(),
+			ProductCount: p.ProductCount(),
+			ProductsSold: p.ProductsSold(),
+			SalesShare:   p.SalesShare(),
+			TopProducts:  products,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(SupplierReportResponse{
+		Version:    statsSchemaVersion,
+		Period:     periodToJSON(dateRange),
+		Currency:   currency,
+		TotalSales: report.TotalSales().Amount(),
+		Suppliers:  suppliers,
+	})
+}
+
+// SupplierReportResponse réponse de GET /api/v2/stats/suppliers
+type SupplierReportResponse struct {
+	Version    string                   `json:"version"`
+	Period     PeriodDTO                `json:"period"`
+	Currency   shareddomain.Currency    `json:"currency"`
+	TotalSales float64                  `json:"total_sales"`
+	Suppliers  []SupplierPerformanceDTO `json:"suppliers"`
+}
+
+// SupplierPerformanceDTO ventes d'un fournisseur et ses meilleurs produits
+type SupplierPerformanceDTO struct {
+	SupplierID   int64                     `json:"supplier_id"`
+	Name         string                    `json:"name"`
+	City         string                    `json:"city"`
+	Country      string                    `json:"country"`
+	Revenue      float64                   `json:"revenue"`
+	UnitsSold    int                       `json:"units_sold"`
+	OrderCount   int                       `json:"order_count"`
+	ProductCount int                       `json:"product_count"`
+	ProductsSold int                       `json:"products_sold"`
+	SalesShare   float64                   `json:"sales_share"`
+	TopProducts  []SupplierProductSalesDTO `json:"top_products"`
+}
+
+// SupplierProductSalesDTO ventes d'un produit du fournisseur
+type SupplierProductSalesDTO struct {
+	ProductID int64   `json:"product_id"`
+	Name      string  `json:"name"`
+	UnitsSold int     `json:"units_sold"`
+	Revenue   float64 `json:"revenue"`
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"eval/database"
+	sharedinfra "eval/internal/shared/infrastructure"
+
+	"github.com/joho/godotenv"
+)
+
+// Charge un fichier CSV de taux de change dans la table fx_rates
+//
+// Usage: go run ./cmd/fxrates rates.csv
+//
+// Format (1 unité de currency = rate_to_eur EUR):
+//
+//	currency,rate_date,rate_to_eur
+//	USD,2024-01-02,0.91240000
+//
+// Un taux existant (même devise, même date) est remplacé: le fichier peut être rechargé
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: go run ./cmd/fxrates <rates.csv>")
+		os.Exit(2)
+	}
+
+	// Charge .env
+	err := godotenv.Load()
+	if err != nil {
+		log.Println("Attention: fichier .env non trouvé, utilisation des valeurs par défaut")
+	}
+
+	file, err := os.Open(os.Args[1])
+	if err != nil {
+		log.Fatal("❌ Erreur ouverture du fichier:", err)
+	}
+	defer file.Close()
+
+	// Validation complète du fichier avant toute écriture
+	rates, err := sharedinfra.ReadExchangeRatesCSV(file)
+	if err != nil {
+		log.Fatal("❌ Fichier de taux invalide: ", err)
+	}
+
+	// Connexion PostgreSQL
+	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		getEnv("DB_HOST", "localhost"),
+		getEnv("DB_PORT", "5432"),
+		getEnv("DB_USER", "evaluser"),
+		getEnv("DB_PASSWORD", "evalpass"),
+		getEnv("DB_NAME", "evaldb"),
+		getEnv("DB_SSLMODE", "disable"),
+	)
+
+	err = database.Init(connStr)
+	if err != nil {
+		log.Fatal("❌ Erreur connexion DB:", err)
+	}
+	defer database.Close()
+
+	// fx_rates manque dans une base créée avant le multi-devises
+	if err := sharedinfra.Migrate(database.DB); err != nil {
+		log.Fatal("❌ Erreur de migration du schéma:", err)
+	}
+
+	saved, err := sharedinfra.NewFXRateRepository(database.DB).SaveExchangeRates(rates)
+	if err != nil {
+		log.Fatal("❌ Erreur lors du chargement des taux:", err)
+	}
+
+	fmt.Printf("✅ %d taux de change chargés depuis %s\n", saved, os.Args[1])
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
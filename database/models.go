@@ -176,21 +176,3 @@ type SaleComplete struct {
 	Subtotal        float64    `json:"subtotal"`
 	OrderTotal      float64    `json:"order_total"`
 }
-
-// ============================================================================
-// MODÈLES POUR EXPORT PARQUET
-// ============================================================================
-
-// SaleParquet - Structure optimisée pour export Parquet
-type SaleParquet struct {
-	OrderDate     string  `parquet:"name=order_date, type=BYTE_ARRAY, convertedtype=UTF8"`
-	OrderID       int64   `parquet:"name=order_id, type=INT64"`
-	ProductName   string  `parquet:"name=product_name, type=BYTE_ARRAY, convertedtype=UTF8"`
-	CustomerName  string  `parquet:"name=customer_name, type=BYTE_ARRAY, convertedtype=UTF8"`
-	StoreName     string  `parquet:"name=store_name, type=BYTE_ARRAY, convertedtype=UTF8"`
-	StoreCity     string  `parquet:"name=store_city, type=BYTE_ARRAY, convertedtype=UTF8"`
-	PaymentMethod string  `parquet:"name=payment_method, type=BYTE_ARRAY, convertedtype=UTF8"`
-	Quantity      int32   `parquet:"name=quantity, type=INT32"`
-	UnitPrice     float64 `parquet:"name=unit_price, type=DOUBLE"`
-	Subtotal      float64 `parquet:"name=subtotal, type=DOUBLE"`
-}
@@ -2,6 +2,9 @@
 -- SCHÉMA DE BASE DE DONNÉES NORMALISÉ (3NF+)
 -- Projet d'évaluation : Comparaison V1 (non optimisé) vs V2 (optimisé)
 -- ============================================================================
+-- Exécuté uniquement à la création du volume postgres_data: toute évolution du schéma
+-- est aussi écrite en migration idempotente (internal/shared/infrastructure/migrations),
+-- appliquée aux bases existantes au démarrage de l'application
 
 -- ============================================================================
 -- 1. TABLE CATEGORIES - Catégories de produits
@@ -90,6 +93,7 @@ CREATE TABLE IF NOT EXISTS stores (
     region VARCHAR(100),
     country VARCHAR(100) DEFAULT 'France',
     address TEXT,
+    currency CHAR(3) NOT NULL DEFAULT 'EUR',
     created_at TIMESTAMP DEFAULT NOW()
 );
 
@@ -138,6 +142,7 @@ CREATE TABLE IF NOT EXISTS orders (
     promotion_id INTEGER REFERENCES promotions(id) ON DELETE SET NULL,
     order_date DATE NOT NULL,
     total_amount NUMERIC(12, 2) NOT NULL CHECK (total_amount >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'EUR', -- devise de total_amount et des lignes (celle du magasin)
     status VARCHAR(50) DEFAULT 'completed',
     created_at TIMESTAMP DEFAULT NOW()
 );
@@ -164,6 +169,20 @@ CREATE TABLE IF NOT EXISTS order_items (
 CREATE INDEX idx_order_items_order ON order_items(order_id);
 CREATE INDEX idx_order_items_product ON order_items(product_id);
 
+-- ============================================================================
+-- 11. TABLE FX_RATES - Taux de change (chargés depuis un CSV: go run ./cmd/fxrates rates.csv)
+-- ============================================================================
+-- 1 unité de currency = rate_to_eur EUR à rate_date (l'euro est la devise pivot, sans ligne)
+CREATE TABLE IF NOT EXISTS fx_rates (
+    currency CHAR(3) NOT NULL CHECK (currency <> 'EUR'),
+    rate_date DATE NOT NULL,
+    rate_to_eur NUMERIC(18, 8) NOT NULL CHECK (rate_to_eur > 0),
+    PRIMARY KEY (currency, rate_date)
+);
+
+-- Conversion d'un montant: jointures LATERAL sur la clé primaire (dernier taux à la date ou avant),
+-- écrites par ExchangeRateJoinSQL (internal/shared/infrastructure/fx_rate_repository.go)
+
 -- ============================================================================
 -- VUES UTILES POUR L'ANALYSE
 -- ============================================================================
@@ -249,3 +268,4 @@ ANALYZE payment_methods;
 ANALYZE promotions;
 ANALYZE orders;
 ANALYZE order_items;
+ANALYZE fx_rates;
@@ -0,0 +1,96 @@
+package application
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"eval/internal/analytics/domain"
+	"eval/internal/analytics/infrastructure"
+	ordersdomain "eval/internal/orders/domain"
+	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
+)
+
+// AnomalyService détection d'anomalies sur le CA et les commandes quotidiens
+// par magasin et par catégorie
+// Le rapport complet est mis en cache par période, paramètres et statuts: les filtres
+// dimension / gravité sont appliqués ensuite par AnomalyReport.Filter
+type AnomalyService struct {
+	statsRepo *infrastructure.StatsQueryRepository
+	cache     sharedinfra.Cache
+	cacheTTL  time.Duration
+}
+
+// NewAnomalyService crée une nouvelle instance de AnomalyService
+func NewAnomalyService(
+	statsRepo *infrastructure.StatsQueryRepository,
+	cache sharedinfra.Cache,
+) *AnomalyService {
+	return &AnomalyService{
+		statsRepo: statsRepo,
+		cache:     cache,
+		cacheTTL:  5 * time.Minute,
+	}
+}
+
+// GetAnomalyReport analyse chaque jour de dateRange face aux settings.BaselineDays() jours précédents
+//
+// PARALLÉLISME: les séries magasins et catégories sont chargées simultanément,
+// sur la période étendue de la baseline
+func (s *AnomalyService) GetAnomalyReport(
+	dateRange shareddomain.DateRange,
+	settings domain.AnomalySettings,
+	status ordersdomain.StatusFilter,
+	currency shareddomain.Currency,
+) (*domain.AnomalyReport, error) {
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("stats").
+		Add("v2").
+		Add("anomalies").
+		Add(settings.Key()).
+		Add(dateRange.Key()).
+		Add(status.Key()).
+		Add(currency.String()).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.AnomalyReport), nil
+	}
+
+	loadRange := domain.AnomalyBaselineRange(dateRange, settings)
+
+	var stores, categories []*domain.DailyActivity
+	var wg sync.WaitGroup
+	errChan := make(chan error, 2)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		var err error
+		if stores, err = s.statsRepo.GetDailyStoreActivity(loadRange, status, currency); err != nil {
+			errChan <- fmt.Errorf("daily store activity error: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if categories, err = s.statsRepo.GetDailyCategoryActivity(loadRange, status, currency); err != nil {
+			errChan <- fmt.Errorf("daily category activity error: %w", err)
+		}
+	}()
+
+	wg.Wait()
+	close(errChan)
+
+	// Retourner la première erreur rencontrée
+	for err := range errChan {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := domain.NewAnomalyReport(dateRange, settings, append(stores, categories...))
+	s.cache.Set(cacheKey, report, s.cacheTTL)
+
+	return report, nil
+}
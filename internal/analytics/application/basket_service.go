@@ -0,0 +1,187 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"eval/internal/analytics/domain"
+	"eval/internal/analytics/infrastructure"
+	catalogdomain "eval/internal/catalog/domain"
+	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
+)
+
+// BasketService analyse du panier (produits achetés ensemble)
+//
+// COÛT: sur 5 ans de données seedées, ~110k paniers à lire et à combiner
+//   - Le comptage des couples/triplets est réparti sur un WorkerPool dédié à l'analyse du panier
+//     (pas celui des jobs d'export: un export occupe un worker plusieurs minutes, le curseur
+//     des paniers resterait ouvert derrière eux)
+//   - Le résultat (compteurs) est mis en cache: les requêtes suivantes ne font que dériver des règles
+//   - Des requêtes simultanées sur la même clé (cache froid) partagent un seul calcul (singleflight)
+type BasketService struct {
+	statsRepo  *infrastructure.StatsQueryRepository
+	cache      sharedinfra.Cache
+	cacheTTL   time.Duration
+	workerPool *sharedinfra.WorkerPool
+	mining     singleflight.Group
+	batchSize  int
+}
+
+// NewBasketService crée une nouvelle instance de BasketService
+// workerPool est démarré par l'appelant, qui l'arrête (le service ne le possède pas)
+// Son arrêt interrompt le comptage en cours: GetBasketAnalysis retourne une erreur au lieu d'attendre
+func NewBasketService(
+	statsRepo *infrastructure.StatsQueryRepository,
+	cache sharedinfra.Cache,
+	workerPool *sharedinfra.WorkerPool,
+) *BasketService {
+	return &BasketService{
+		statsRepo:  statsRepo,
+		cache:      cache,
+		cacheTTL:   5 * time.Minute,
+		workerPool: workerPool,
+		batchSize:  5000,
+	}
+}
+
+// GetBasketAnalysis compte les couples (et triplets si withTriples) de produits achetés ensemble sur la période
+// Les compteurs sont mis en cache par période et taille d'ensemble
+//
+// SINGLEFLIGHT: à cache froid, N requêtes identiques lanceraient N lectures des ~110k paniers
+//   - La première requête calcule, les suivantes attendent son résultat (même clé de cache)
+//   - PIÈGE: le calcul partagé ne dépend pas du contexte de la requête qui l'a lancé
+//     (context.WithoutCancel): sa déconnexion n'annule pas les autres; chaque appelant
+//     cesse d'attendre quand son propre contexte est annulé, le calcul finit et remplit le cache
+//
+// WORKER POOL: les paniers sont lus en streaming et découpés en lots de batchSize
+//   - Chaque lot est compté par un worker dans ses propres compteurs (aucun verrou pendant le comptage)
+//   - Les compteurs d'un lot sont fusionnés dans le total sous mutex (fusion courte, ~5k couples)
+//   - La lecture SQL continue pendant que les workers comptent les lots précédents
+func (s *BasketService) GetBasketAnalysis(
+	ctx context.Context,
+	dateRange shareddomain.DateRange,
+	withTriples bool,
+) (*domain.BasketAnalysis, error) {
+	maxSize := 2
+	if withTriples {
+		maxSize = 3
+	}
+
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("stats").
+		Add("v2").
+		Add("basket").
+		Add(strconv.Itoa(maxSize)).
+		Add(dateRange.Key()).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.BasketAnalysis), nil
+	}
+
+	mining := s.mining.DoChan(cacheKey, func() (interface{}, error) {
+		analysis, err := s.mineBaskets(context.WithoutCancel(ctx), dateRange, maxSize)
+		if err != nil {
+			return nil, err
+		}
+		s.cache.Set(cacheKey, analysis, s.cacheTTL)
+		return analysis, nil
+	})
+
+	select {
+	case result := <-mining:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*domain.BasketAnalysis), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// mineBaskets lit les paniers de la période et compte les ensembles de au plus maxSize produits
+func (s *BasketService) mineBaskets(
+	ctx context.Context,
+	dateRange shareddomain.DateRange,
+	maxSize int,
+) (*domain.BasketAnalysis, error) {
+	total, err := domain.NewItemsetCounts(maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// WaitGroup local: le pool est partagé entre requêtes, on attend uniquement nos lots
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	submit := func(batch [][]catalogdomain.ProductID) error {
+		wg.Add(1)
+		task := func() error {
+			defer wg.Done()
+
+			counts, err := domain.NewItemsetCounts(maxSize)
+			if err != nil {
+				return err
+			}
+			for _, basket := range batch {
+				counts.AddBasket(basket)
+			}
+
+			mu.Lock()
+			total.Merge(counts)
+			mu.Unlock()
+			return nil
+		}
+
+		if err := s.workerPool.Submit(task); err != nil {
+			wg.Done()
+			return err
+		}
+		return nil
+	}
+
+	batch := make([][]catalogdomain.ProductID, 0, s.batchSize)
+	streamErr := s.statsRepo.StreamOrderBaskets(ctx, dateRange, func(products []catalogdomain.ProductID) error {
+		batch = append(batch, products)
+		if len(batch) < s.batchSize {
+			return nil
+		}
+		full := batch
+		batch = make([][]catalogdomain.ProductID, 0, s.batchSize)
+		return submit(full)
+	})
+	if streamErr == nil && len(batch) > 0 {
+		streamErr = submit(batch)
+	}
+
+	// Attendre les lots déjà soumis (ils écrivent dans total), ou l'arrêt du pool:
+	// les lots encore en file à l'arrêt ne sont jamais exécutés, wg.Wait() ne reviendrait pas
+	// CONCURRENCE: la goroutine d'attente se termine quand les lots en cours ont fini
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-s.workerPool.Done():
+		return nil, fmt.Errorf("basket mining error: worker pool is stopped")
+	}
+	if streamErr != nil {
+		return nil, fmt.Errorf("basket mining error: %w", streamErr)
+	}
+
+	names, err := s.statsRepo.GetProductNames(total.ProductIDs())
+	if err != nil {
+		return nil, fmt.Errorf("product names error: %w", err)
+	}
+
+	return domain.NewBasketAnalysis(dateRange, total, names), nil
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"eval/internal/analytics/domain"
+	"eval/internal/analytics/infrastructure"
+	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
+)
+
+// CohortService analyse de rétention par cohortes d'acquisition mensuelles
+// Même stratégie que StatsServiceV2: une requête SQL agrégée, résultat mis en cache
+type CohortService struct {
+	statsRepo *infrastructure.StatsQueryRepository
+	cache     sharedinfra.Cache
+	cacheTTL  time.Duration
+}
+
+// NewCohortService crée une nouvelle instance de CohortService
+func NewCohortService(
+	statsRepo *infrastructure.StatsQueryRepository,
+	cache sharedinfra.Cache,
+) *CohortService {
+	return &CohortService{
+		statsRepo: statsRepo,
+		cache:     cache,
+		cacheTTL:  5 * time.Minute,
+	}
+}
+
+// GetCohortRetention retourne la matrice de rétention des clients acquis sur la période
+// Les mois d'activité observés s'arrêtent à la fin de la période, les montants sont convertis dans currency
+func (s *CohortService) GetCohortRetention(
+	dateRange shareddomain.DateRange,
+	currency shareddomain.Currency,
+) (*domain.CohortAnalysis, error) {
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("stats").
+		Add("v2").
+		Add("cohorts").
+		Add(dateRange.Key()).
+		Add(currency.String()).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.CohortAnalysis), nil
+	}
+
+	activity, err := s.statsRepo.GetCohortActivity(dateRange, currency)
+	if err != nil {
+		return nil, fmt.Errorf("cohort activity error: %w", err)
+	}
+
+	analysis := domain.NewCohortAnalysis(dateRange, currency, activity)
+	s.cache.Set(cacheKey, analysis, s.cacheTTL)
+
+	return analysis, nil
+}
+
+// ExportCohortsToCSV exporte la matrice au format long: une ligne par (cohorte, offset)
+// Format plus simple à pivoter dans un tableur que le triangle (nombre de colonnes variable)
+func (s *CohortService) ExportCohortsToCSV(
+	dateRange shareddomain.DateRange,
+	currency shareddomain.Currency,
+) ([]byte, error) {
+	analysis, err := s.GetCohortRetention(dateRange, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := bytes.NewBuffer(make([]byte, 0, 16*1024)) // 16 KB
+	writer := csv.NewWriter(buffer)
+
+	writer.Write([]string{
+		"cohort", "cohort_size", "month_offset", "month",
+		"active_customers", "retention_rate", "revenue",
+		"revenue_per_customer", "cumulative_revenue_per_customer",
+	})
+	for _, cohort := range analysis.Cohorts() {
+		for _, cell := range cohort.Cells() {
+			writer.Write([]string{
+				cohort.Month().Format("2006-01"),
+				strconv.Itoa(cohort.Size()),
+				strconv.Itoa(cell.MonthOffset()),
+				cell.Month().Format("2006-01"),
+				strconv.Itoa(cell.ActiveCustomers()),
+				fmt.Sprintf("%.2f", cell.RetentionRate()),
+				cell.Revenue().Decimal(),
+				cell.RevenuePerCustomer().Decimal(),
+				cell.CumulativeRevenuePerCustomer().Decimal(),
+			})
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
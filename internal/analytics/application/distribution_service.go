@@ -0,0 +1,102 @@
+package application
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"eval/internal/analytics/domain"
+	"eval/internal/analytics/infrastructure"
+	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
+)
+
+// DistributionService distribution des montants de commande (percentiles, histogramme)
+// et du nombre d'articles par commande
+type DistributionService struct {
+	statsRepo *infrastructure.StatsQueryRepository
+	cache     sharedinfra.Cache
+	cacheTTL  time.Duration
+}
+
+// NewDistributionService crée une nouvelle instance de DistributionService
+func NewDistributionService(
+	statsRepo *infrastructure.StatsQueryRepository,
+	cache sharedinfra.Cache,
+) *DistributionService {
+	return &DistributionService{
+		statsRepo: statsRepo,
+		cache:     cache,
+		cacheTTL:  5 * time.Minute,
+	}
+}
+
+// GetOrderValueDistribution calcule la distribution sur la période
+// Même filtre de commandes que /api/v2/stats (StatsFilter), les bornes font partie de la clé de cache
+//
+// PARALLÉLISME: résumé (percentiles), histogramme et répartition des articles sont 3 requêtes
+// indépendantes lancées simultanément
+func (s *DistributionService) GetOrderValueDistribution(
+	dateRange shareddomain.DateRange,
+	boundaries domain.HistogramBoundaries,
+	filter domain.StatsFilter,
+) (*domain.OrderValueDistribution, error) {
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("stats").
+		Add("v2").
+		Add("distribution").
+		Add(boundaries.Key()).
+		Add(dateRange.Key()).
+		Add(filter.Key()).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.OrderValueDistribution), nil
+	}
+
+	var (
+		summary       *domain.OrderValueSummary
+		itemsSummary  domain.ItemsPerOrderSummary
+		histogram     []*domain.HistogramBucket
+		itemsPerOrder []*domain.ItemsPerOrderBucket
+	)
+	var wg sync.WaitGroup
+	errChan := make(chan error, 3)
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		var err error
+		if summary, itemsSummary, err = s.statsRepo.GetOrderValueSummary(dateRange, filter); err != nil {
+			errChan <- fmt.Errorf("order value summary error: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if histogram, err = s.statsRepo.GetOrderValueHistogram(dateRange, boundaries, filter); err != nil {
+			errChan <- fmt.Errorf("order value histogram error: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if itemsPerOrder, err = s.statsRepo.GetItemsPerOrderDistribution(dateRange, filter); err != nil {
+			errChan <- fmt.Errorf("items per order error: %w", err)
+		}
+	}()
+
+	wg.Wait()
+	close(errChan)
+
+	// Retourner la première erreur rencontrée
+	for err := range errChan {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	distribution := domain.NewOrderValueDistribution(dateRange, boundaries, summary, itemsSummary, histogram, itemsPerOrder)
+	s.cache.Set(cacheKey, distribution, s.cacheTTL)
+
+	return distribution, nil
+}
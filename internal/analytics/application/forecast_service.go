@@ -0,0 +1,77 @@
+package application
+
+import (
+	"strconv"
+	"time"
+
+	"eval/internal/analytics/domain"
+	"eval/internal/analytics/infrastructure"
+	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
+)
+
+// ForecastService prévision du CA par jour ou par mois (Holt-Winters)
+// Entraîné sur la même série que /api/v2/stats/timeseries, les filtres ont donc la même sémantique:
+//   - store_id, region, payment_method_id, ...: CA des commandes correspondant au filtre
+//   - category_id, supplier_id: CA des seules lignes de la catégorie / du fournisseur
+//     (une commande mixte n'apporte pas ses autres articles à la prévision de la catégorie)
+type ForecastService struct {
+	statsRepo *infrastructure.StatsQueryRepository
+	cache     sharedinfra.Cache
+	cacheTTL  time.Duration
+}
+
+// NewForecastService crée une nouvelle instance de ForecastService
+func NewForecastService(
+	statsRepo *infrastructure.StatsQueryRepository,
+	cache sharedinfra.Cache,
+) *ForecastService {
+	return &ForecastService{
+		statsRepo: statsRepo,
+		cache:     cache,
+		cacheTTL:  5 * time.Minute,
+	}
+}
+
+// GetRevenueForecast entraîne un modèle sur trainingRange et prévoit les horizonDays jours suivants
+// La clé de cache porte la fenêtre d'apprentissage ajustée (mois complets en granularité month):
+// deux fenêtres demandées qui donnent les mêmes mois partagent le même modèle
+//
+// PERFORMANCE: l'ajustement (recherche sur grille) est fait une fois par fenêtre, pas à chaque requête
+func (s *ForecastService) GetRevenueForecast(
+	trainingRange shareddomain.DateRange,
+	granularity domain.Granularity,
+	filter domain.StatsFilter,
+	horizonDays int,
+) (*domain.RevenueForecast, error) {
+	trainingRange, err := domain.ForecastTrainingRange(trainingRange, granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("stats").
+		Add("v2").
+		Add("forecast").
+		Add(string(granularity)).
+		Add(strconv.Itoa(horizonDays)).
+		Add(trainingRange.Key()).
+		Add(filter.Key()).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.RevenueForecast), nil
+	}
+
+	points, err := s.statsRepo.GetRevenueTimeSeries(trainingRange, granularity, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := domain.NewRevenueForecast(domain.NewTimeSeries(granularity, trainingRange, filter.Currency(), points), horizonDays)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Set(cacheKey, forecast, s.cacheTTL)
+
+	return forecast, nil
+}
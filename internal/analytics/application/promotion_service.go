@@ -0,0 +1,59 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"eval/internal/analytics/domain"
+	"eval/internal/analytics/infrastructure"
+	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
+)
+
+// PromotionService rapport d'efficacité des promotions
+// Même stratégie que StatsServiceV2: une requête SQL agrégée, résultat mis en cache
+type PromotionService struct {
+	statsRepo *infrastructure.StatsQueryRepository
+	cache     sharedinfra.Cache
+	cacheTTL  time.Duration
+}
+
+// NewPromotionService crée une nouvelle instance de PromotionService
+func NewPromotionService(
+	statsRepo *infrastructure.StatsQueryRepository,
+	cache sharedinfra.Cache,
+) *PromotionService {
+	return &PromotionService{
+		statsRepo: statsRepo,
+		cache:     cache,
+		cacheTTL:  5 * time.Minute,
+	}
+}
+
+// GetPromotionReport mesure les promotions dont la fenêtre d'activité chevauche la période
+// Les montants sont convertis dans currency
+func (s *PromotionService) GetPromotionReport(
+	dateRange shareddomain.DateRange,
+	currency shareddomain.Currency,
+) (*domain.PromotionReport, error) {
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("stats").
+		Add("v2").
+		Add("promotions").
+		Add(dateRange.Key()).
+		Add(currency.String()).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.PromotionReport), nil
+	}
+
+	promotions, err := s.statsRepo.GetPromotionEffectiveness(dateRange, currency)
+	if err != nil {
+		return nil, fmt.Errorf("promotion effectiveness error: %w", err)
+	}
+
+	report := domain.NewPromotionReport(dateRange, currency, promotions)
+	s.cache.Set(cacheKey, report, s.cacheTTL)
+
+	return report, nil
+}
@@ -0,0 +1,693 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"eval/internal/analytics/domain"
+	ordersdomain "eval/internal/orders/domain"
+	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
+	"eval/internal/testhelpers"
+)
+
+// ========================================
+// REGRESSION TESTS - JEU DE DONNÉES CONNU
+// ========================================
+// Totaux exacts attendus sur le jeu de testhelpers.SetupFixtureContext
+// Régression couverte: le filtre de période placé dans un LEFT JOIN orders laissait
+// les articles hors période (et des commandes annulées) dans les totaux catégories/produits
+
+// fixtureRange construit une période du jeu de régression (UTC, bornes incluses)
+func fixtureRange(t *testing.T, from, to string) shareddomain.DateRange {
+	t.Helper()
+	start, _ := time.Parse("2006-01-02", from)
+	end, _ := time.Parse("2006-01-02", to)
+	dateRange, err := shareddomain.NewDateRange(start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dateRange
+}
+
+func assertAmount(t *testing.T, label string, got shareddomain.Money, want float64) {
+	t.Helper()
+	if got.Amount() != want {
+		t.Errorf("%s = %v, want %v", label, got.Amount(), want)
+	}
+}
+
+// TestStatsServiceV2_FixtureTotals vérifie les KPI et classements par période
+func TestStatsServiceV2_FixtureTotals(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	_, service := setupStatsServices(ctx)
+
+	t.Run("January2024", func(t *testing.T) {
+		stats, err := service.GetStatsForRange(fixtureRange(t, "2024-01-01", "2024-01-31"), domain.StatsFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Commandes 1 et 2 uniquement: la 3 est annulée, la 5 est en décembre
+		assertAmount(t, "total revenue", stats.TotalRevenue(), 1070)
+		if stats.TotalOrders() != 2 {
+			t.Errorf("total orders = %d, want 2", stats.TotalOrders())
+		}
+		assertAmount(t, "average order value", stats.AverageOrderValue(), 535)
+
+		wantCategories := map[string]struct {
+			revenue float64
+			orders  int
+		}{
+			"Électronique": {1000, 1},
+			"Vêtements":    {60, 2},
+			"Alimentation": {10, 1},
+		}
+		for _, category := range stats.CategoryStats() {
+			want := wantCategories[category.CategoryName()] // catégories sans vente: 0, 0
+			assertAmount(t, "category "+category.CategoryName(), category.TotalRevenue(), want.revenue)
+			if category.TotalOrders() != want.orders {
+				t.Errorf("category %s orders = %d, want %d", category.CategoryName(), category.TotalOrders(), want.orders)
+			}
+		}
+
+		products := stats.TopProducts()
+		wantProducts := []struct {
+			name     string
+			revenue  float64
+			orders   int
+			quantity int
+		}{
+			{"Laptop", 1000, 1, 1},
+			{"T-shirt", 60, 2, 3},
+			{"Pâtes", 10, 1, 5},
+		}
+		if len(products) != len(wantProducts) {
+			t.Fatalf("top products = %d, want %d", len(products), len(wantProducts))
+		}
+		for i, want := range wantProducts {
+			got := products[i]
+			if got.ProductName() != want.name {
+				t.Errorf("product #%d = %s, want %s", i+1, got.ProductName(), want.name)
+				continue
+			}
+			assertAmount(t, "product "+want.name, got.TotalRevenue(), want.revenue)
+			if got.TotalOrders() != want.orders || got.TotalQuantity().Value() != want.quantity {
+				t.Errorf("product %s = %d orders / %d units, want %d / %d",
+					want.name, got.TotalOrders(), got.TotalQuantity().Value(), want.orders, want.quantity)
+			}
+		}
+
+		stores := stats.TopStores()
+		if len(stores) != 2 || stores[0].StoreID() != 1 || stores[1].StoreID() != 2 {
+			t.Fatalf("top stores = %v, want Paris then Lyon", stores)
+		}
+		assertAmount(t, "store Paris", stores[0].TotalRevenue(), 1040)
+		assertAmount(t, "store Lyon", stores[1].TotalRevenue(), 30)
+	})
+
+	t.Run("February2024", func(t *testing.T) {
+		stats, err := service.GetStatsForRange(fixtureRange(t, "2024-02-01", "2024-02-29"), domain.StatsFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertAmount(t, "total revenue", stats.TotalRevenue(), 2000)
+
+		for _, category := range stats.CategoryStats() {
+			want := 0.0
+			if category.CategoryName() == "Électronique" {
+				want = 2000
+			}
+			assertAmount(t, "category "+category.CategoryName(), category.TotalRevenue(), want)
+		}
+	})
+
+	t.Run("Q1_2024", func(t *testing.T) {
+		stats, err := service.GetStatsForRange(fixtureRange(t, "2024-01-01", "2024-03-31"), domain.StatsFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertAmount(t, "total revenue", stats.TotalRevenue(), 3070)
+		if stats.TotalOrders() != 3 {
+			t.Errorf("total orders = %d, want 3", stats.TotalOrders())
+		}
+		if top := stats.TopProducts()[0]; top.ProductName() != "Laptop" || top.TotalRevenue().Amount() != 3000 {
+			t.Errorf("top product = %s %v, want Laptop 3000", top.ProductName(), top.TotalRevenue().Amount())
+		}
+	})
+
+	t.Run("January2024_StatusBreakdown", func(t *testing.T) {
+		stats, err := service.GetStatsForRange(fixtureRange(t, "2024-01-01", "2024-01-31"), domain.StatsFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := map[ordersdomain.OrderStatus]struct {
+			revenue float64
+			orders  int
+		}{
+			ordersdomain.OrderStatusPending:   {0, 0},
+			ordersdomain.OrderStatusCompleted: {1070, 2},
+			ordersdomain.OrderStatusCancelled: {1000, 1},
+		}
+		breakdown := stats.StatusBreakdown()
+		if len(breakdown) != len(want) {
+			t.Fatalf("status breakdown = %d statuses, want %d", len(breakdown), len(want))
+		}
+		for _, oss := range breakdown {
+			assertAmount(t, "status "+string(oss.Status()), oss.TotalRevenue(), want[oss.Status()].revenue)
+			if oss.TotalOrders() != want[oss.Status()].orders {
+				t.Errorf("status %s orders = %d, want %d", oss.Status(), oss.TotalOrders(), want[oss.Status()].orders)
+			}
+		}
+	})
+
+	t.Run("January2024_AllStatuses", func(t *testing.T) {
+		filter, _ := domain.NewStatsFilter(domain.StatsFilterCriteria{Status: ordersdomain.AllStatuses()})
+		stats, err := service.GetStatsForRange(fixtureRange(t, "2024-01-01", "2024-01-31"), filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertAmount(t, "total revenue", stats.TotalRevenue(), 2070)
+		if top := stats.TopProducts()[0]; top.ProductName() != "Laptop" || top.TotalRevenue().Amount() != 2000 {
+			t.Errorf("top product = %s %v, want Laptop 2000", top.ProductName(), top.TotalRevenue().Amount())
+		}
+	})
+
+	t.Run("January2024_StoreFilter", func(t *testing.T) {
+		filter, _ := domain.NewStatsFilter(domain.StatsFilterCriteria{StoreID: 2})
+		stats, err := service.GetStatsForRange(fixtureRange(t, "2024-01-01", "2024-01-31"), filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertAmount(t, "total revenue", stats.TotalRevenue(), 30)
+
+		for _, product := range stats.TopProducts() {
+			if product.ProductName() == "Laptop" && !product.TotalRevenue().IsZero() {
+				t.Errorf("Laptop revenue = %v, want 0 (not sold in Lyon in January)", product.TotalRevenue().Amount())
+			}
+		}
+	})
+
+	t.Run("January2024_ItemFilters", func(t *testing.T) {
+		// Commandes mixtes: seul le CA des lignes de la catégorie / du fournisseur compte
+		tests := []struct {
+			name     string
+			criteria domain.StatsFilterCriteria
+			revenue  float64
+			orders   int
+			average  float64
+		}{
+			{"category Vêtements", domain.StatsFilterCriteria{CategoryID: 2}, 60, 2, 30},     // 40 (commande 1) + 20 (commande 2)
+			{"supplier Épicerie Test", domain.StatsFilterCriteria{SupplierID: 2}, 10, 1, 10}, // Pâtes de la commande 2
+		}
+		for _, tt := range tests {
+			filter, _ := domain.NewStatsFilter(tt.criteria)
+			stats, err := service.GetStatsForRange(fixtureRange(t, "2024-01-01", "2024-01-31"), filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertAmount(t, tt.name+" revenue", stats.TotalRevenue(), tt.revenue)
+			assertAmount(t, tt.name+" average order value", stats.AverageOrderValue(), tt.average)
+			if stats.TotalOrders() != tt.orders {
+				t.Errorf("%s orders = %d, want %d", tt.name, stats.TotalOrders(), tt.orders)
+			}
+
+			// Magasins et moyens de paiement ventilent le même CA que les KPI globaux
+			var storesRevenue, paymentsRevenue float64
+			for _, s := range stats.TopStores() {
+				storesRevenue += s.TotalRevenue().Amount()
+			}
+			for _, p := range stats.PaymentDistribution() {
+				paymentsRevenue += p.TotalRevenue().Amount()
+			}
+			if math.Abs(storesRevenue-tt.revenue) > 1e-9 || math.Abs(paymentsRevenue-tt.revenue) > 1e-9 {
+				t.Errorf("%s stores revenue = %v, payments revenue = %v, want %v",
+					tt.name, storesRevenue, paymentsRevenue, tt.revenue)
+			}
+		}
+	})
+}
+
+// TestStatsServiceV2_FixtureTimeSeries vérifie les buckets mensuels du premier trimestre 2024
+func TestStatsServiceV2_FixtureTimeSeries(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	_, service := setupStatsServices(ctx)
+
+	series, err := service.GetTimeSeries(fixtureRange(t, "2024-01-01", "2024-03-31"), domain.GranularityMonth, domain.StatsFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []float64{1070, 2000, 0}
+	points := series.Points()
+	if len(points) != len(want) {
+		t.Fatalf("points = %d, want %d", len(points), len(want))
+	}
+	for i, revenue := range want {
+		assertAmount(t, points[i].BucketStart().Format("2006-01"), points[i].Revenue(), revenue)
+	}
+
+	// Catégorie Vêtements: lignes T-shirt des commandes 1 (2 × 20) et 2 (1 × 20) en janvier,
+	// pas le total des commandes mixtes (série d'apprentissage de la prévision)
+	clothing, _ := domain.NewStatsFilter(domain.StatsFilterCriteria{CategoryID: 2})
+	series, err = service.GetTimeSeries(fixtureRange(t, "2024-01-01", "2024-03-31"), domain.GranularityMonth, clothing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	january := series.Points()[0]
+	assertAmount(t, "clothing January", january.Revenue(), 60)
+	if january.OrderCount() != 2 || january.Quantity().Value() != 3 {
+		t.Errorf("clothing January = %d orders / %d units, want 2 / 3", january.OrderCount(), january.Quantity().Value())
+	}
+}
+
+// TestStatsServiceV2_FixtureCurrency vérifie la conversion au taux du jour de chaque commande
+// Janvier en USD: commande 1 (10/01, taux 0.90) = 1040 / 0.9, commande 2 (20/01, taux 0.80) = 30 / 0.8
+func TestStatsServiceV2_FixtureCurrency(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	_, service := setupStatsServices(ctx)
+
+	usd, err := domain.NewStatsFilter(domain.StatsFilterCriteria{Currency: "USD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := service.GetStatsForRange(fixtureRange(t, "2024-01-01", "2024-01-31"), usd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1155.5555… + 37.50 = 1193.0555…, arrondi au centime
+	assertAmount(t, "total revenue (USD)", stats.TotalRevenue(), 1193.06)
+	assertAmount(t, "average order value (USD)", stats.AverageOrderValue(), 596.53)
+	if stats.TotalRevenue().Currency() != "USD" {
+		t.Errorf("currency = %s, want USD", stats.TotalRevenue().Currency())
+	}
+
+	// Les buckets vides sont aussi en USD (sinon l'addition avec les autres buckets échouerait)
+	series, err := service.GetTimeSeries(fixtureRange(t, "2024-01-01", "2024-03-31"), domain.GranularityMonth, usd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []float64{1193.06, 2500, 0} {
+		point := series.Points()[i]
+		assertAmount(t, point.BucketStart().Format("2006-01"), point.Revenue(), want)
+		if point.Revenue().Currency() != "USD" {
+			t.Errorf("%s currency = %s, want USD", point.BucketStart().Format("2006-01"), point.Revenue().Currency())
+		}
+	}
+
+	// Aucun taux GBP: erreur du domaine (409 fx_rate_missing) plutôt qu'un montant faux
+	gbp, _ := domain.NewStatsFilter(domain.StatsFilterCriteria{Currency: "GBP"})
+	_, err = service.GetStatsForRange(fixtureRange(t, "2024-01-01", "2024-01-31"), gbp)
+	if domainErr, ok := shareddomain.AsError(err); !ok || domainErr.Code() != "fx_rate_missing" {
+		t.Errorf("GBP error = %v, want fx_rate_missing", err)
+	}
+
+	// Commande 5 (2023-12-31) antérieure au premier taux USD (2024-01-01)
+	_, err = service.GetStatsForRange(fixtureRange(t, "2023-12-01", "2024-01-31"), usd)
+	if domainErr, ok := shareddomain.AsError(err); !ok || domainErr.Message() != "no USD exchange rate on or before 2023-12-31" {
+		t.Errorf("December USD error = %v, want the missing rate of 2023-12-31", err)
+	}
+
+	// Commande en CHF dans un magasin en euros: aucune cotation CHF, la commande ne doit pas
+	// disparaître du CA en euros (conversion NULL ignorée par SUM)
+	if _, err := ctx.DB.Exec(`
+		INSERT INTO orders (id, customer_id, store_id, payment_method_id, order_date, total_amount, status, currency)
+		VALUES (20, 1, 1, 1, '2024-03-10', 50.00, 'completed', 'CHF')
+	`); err != nil {
+		t.Fatal(err)
+	}
+	_, err = service.GetStatsForRange(fixtureRange(t, "2024-03-01", "2024-03-31"), domain.StatsFilter{})
+	if domainErr, ok := shareddomain.AsError(err); !ok || domainErr.Message() != "no CHF exchange rate on or before 2024-03-10" {
+		t.Errorf("March CHF order error = %v, want the missing CHF rate of 2024-03-10", err)
+	}
+}
+
+// TestCohortService_FixtureRetention vérifie la matrice de cohortes du premier trimestre 2024
+// Alice (première commande le 2023-12-31) n'appartient à aucune cohorte du trimestre,
+// Bruno forme seul la cohorte de janvier et recommande en février
+func TestCohortService_FixtureRetention(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	service := NewCohortService(ctx.StatsQueryRepo, ctx.Cache)
+
+	analysis, err := service.GetCohortRetention(fixtureRange(t, "2024-01-01", "2024-03-31"), shareddomain.DefaultCurrency)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cohorts := analysis.Cohorts()
+	if len(cohorts) != 3 || analysis.TotalCustomers() != 1 {
+		t.Fatalf("cohorts = %d, customers = %d, want 3 cohorts / 1 customer", len(cohorts), analysis.TotalCustomers())
+	}
+
+	january := cohorts[0].Cells()
+	if cohorts[0].Size() != 1 || len(january) != 3 {
+		t.Fatalf("january cohort = %d customers / %d months, want 1 / 3", cohorts[0].Size(), len(january))
+	}
+	for i, want := range []float64{100, 100, 0} {
+		if january[i].RetentionRate() != want {
+			t.Errorf("january M+%d retention = %v, want %v", i, january[i].RetentionRate(), want)
+		}
+	}
+	assertAmount(t, "january M+0 revenue", january[0].Revenue(), 30)
+	assertAmount(t, "january M+1 revenue", january[1].Revenue(), 2000)
+	assertAmount(t, "january cumulative revenue per customer", january[2].CumulativeRevenuePerCustomer(), 2030)
+
+	if cohorts[1].Size() != 0 || cohorts[2].Size() != 0 {
+		t.Errorf("february/march cohorts = %d/%d customers, want 0/0", cohorts[1].Size(), cohorts[2].Size())
+	}
+}
+
+// TestBasketService_FixtureBaskets vérifie la lecture des paniers du premier trimestre 2024
+// Paniers completed: {Laptop, T-shirt}, {Pâtes, T-shirt}, {Laptop}. La commande 3 (annulée) est exclue
+// Aucun couple n'apparaît dans 2 commandes: aucune règle au-dessus de MinItemsetOrders
+//
+// Juin 2024 (commandes ajoutées par le test, hors des périodes des autres tests):
+// {Laptop, T-shirt} ×2, {Laptop}, {Pâtes} → 4 commandes, le couple est vu dans 2 d'entre elles
+//   - support = 2/4 = 0.5
+//   - Laptop → T-shirt: confiance = 2/3 (Laptop dans 3 commandes), lift = (2/3) / (2/4) = 4/3
+//   - T-shirt → Laptop: confiance = 2/2 = 1, lift = 1 / (3/4) = 4/3
+func TestBasketService_FixtureBaskets(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	workerPool := sharedinfra.NewWorkerPool(4)
+	workerPool.Start()
+	defer workerPool.Stop()
+
+	service := NewBasketService(ctx.StatsQueryRepo, ctx.Cache, workerPool)
+
+	analysis, err := service.GetBasketAnalysis(context.Background(), fixtureRange(t, "2024-01-01", "2024-03-31"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.TotalOrders() != 3 || !analysis.IncludesTriples() {
+		t.Errorf("orders = %d (triples %v), want 3 (true)", analysis.TotalOrders(), analysis.IncludesTriples())
+	}
+	if rules := analysis.TopRules(0, domain.RuleSortLift, 0); len(rules) != 0 {
+		t.Errorf("rules = %d, want 0", len(rules))
+	}
+
+	if _, err := ctx.DB.Exec(`
+		INSERT INTO orders (id, customer_id, store_id, payment_method_id, promotion_id, order_date, total_amount, status) VALUES
+		    (10, 1, 1, 1, NULL, '2024-06-03', 1020.00, 'completed'),
+		    (11, 2, 2, 1, NULL, '2024-06-10', 1020.00, 'completed'),
+		    (12, 1, 1, 2, NULL, '2024-06-17', 1000.00, 'completed'),
+		    (13, 2, 2, 2, NULL, '2024-06-24', 2.00, 'completed');
+		INSERT INTO order_items (order_id, product_id, quantity, unit_price, subtotal) VALUES
+		    (10, 1, 1, 1000.00, 1000.00), (10, 2, 1, 20.00, 20.00),
+		    (11, 1, 1, 1000.00, 1000.00), (11, 2, 1, 20.00, 20.00),
+		    (12, 1, 1, 1000.00, 1000.00),
+		    (13, 3, 1, 2.00, 2.00);`); err != nil {
+		t.Fatal(err)
+	}
+
+	// Requêtes simultanées à cache froid: un seul calcul partagé, donc la même analyse pour tous
+	june := fixtureRange(t, "2024-06-01", "2024-06-30")
+	results := make([]*domain.BasketAnalysis, 4)
+	errs := make([]error, len(results))
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = service.GetBasketAnalysis(context.Background(), june, false)
+		}()
+	}
+	wg.Wait()
+	for i := range results {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		if results[i] != results[0] {
+			t.Error("concurrent requests computed separate analyses, want one shared result")
+		}
+	}
+
+	analysis = results[0]
+	if analysis.TotalOrders() != 4 {
+		t.Errorf("june orders = %d, want 4", analysis.TotalOrders())
+	}
+	rules := analysis.TopRules(0, domain.RuleSortConfidence, 0)
+	if len(rules) != 2 {
+		t.Fatalf("june rules = %d, want 2 (Laptop ↔ T-shirt)", len(rules))
+	}
+	for _, want := range []struct {
+		antecedent, consequent string
+		confidence             float64
+	}{
+		{"T-shirt", "Laptop", 1},
+		{"Laptop", "T-shirt", 2.0 / 3},
+	} {
+		var rule *domain.AssociationRule
+		for _, r := range rules {
+			if len(r.Antecedent()) == 1 && r.Antecedent()[0].Name() == want.antecedent && r.Consequent().Name() == want.consequent {
+				rule = r
+			}
+		}
+		if rule == nil {
+			t.Errorf("rule %s → %s missing", want.antecedent, want.consequent)
+			continue
+		}
+		if rule.OrderCount() != 2 || math.Abs(rule.Support()-0.5) > 1e-9 ||
+			math.Abs(rule.Confidence()-want.confidence) > 1e-9 || math.Abs(rule.Lift()-4.0/3) > 1e-9 {
+			t.Errorf("%s → %s: orders %d, support %v, confidence %v, lift %v, want 2, 0.5, %v, 4/3",
+				want.antecedent, want.consequent, rule.OrderCount(), rule.Support(), rule.Confidence(), rule.Lift(), want.confidence)
+		}
+	}
+}
+
+// TestPromotionService_FixtureReport vérifie le rapport de la promotion HIVER10 (15-25 janvier 2024)
+// Fenêtre: commande 2 (30€, avec le code), la commande 3 est annulée
+// Référence: les 11 jours précédents (4-14 janvier), soit la commande 1 (1040€)
+func TestPromotionService_FixtureReport(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	service := NewPromotionService(ctx.StatsQueryRepo, ctx.Cache)
+
+	report, err := service.GetPromotionReport(fixtureRange(t, "2024-01-01", "2024-01-31"), shareddomain.DefaultCurrency)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	promotions := report.Promotions()
+	if len(promotions) != 1 {
+		t.Fatalf("promotions = %d, want 1", len(promotions))
+	}
+
+	p := promotions[0]
+	if p.Promotion().Code() != "HIVER10" || p.OrderCount() != 1 || p.OrdersWithoutAnyPromotion() != 0 {
+		t.Errorf("%s = %d orders with / %d without, want HIVER10 1 / 0",
+			p.Promotion().Code(), p.OrderCount(), p.OrdersWithoutAnyPromotion())
+	}
+	assertAmount(t, "revenue", p.Revenue(), 30)
+	assertAmount(t, "average basket", p.AverageBasket(), 30)
+	assertAmount(t, "estimated discount cost", p.EstimatedDiscountCost(), 3)
+
+	if got := p.Promotion().BaselineWindow().Key(); got != "2024-01-04..2024-01-14" {
+		t.Errorf("baseline window = %s, want 2024-01-04..2024-01-14", got)
+	}
+	if uplift, ok := p.DailyRevenue().Percent(); !ok || math.Abs(uplift-(30.0-1040)/1040*100) > 1e-9 {
+		t.Errorf("uplift = %v (ok=%v), want %v", uplift, ok, (30.0-1040)/1040*100)
+	}
+
+	// Une période sans chevauchement ne retourne aucune promotion
+	report, err = service.GetPromotionReport(fixtureRange(t, "2024-02-01", "2024-02-29"), shareddomain.DefaultCurrency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Promotions()) != 0 {
+		t.Errorf("february promotions = %d, want 0", len(report.Promotions()))
+	}
+}
+
+// TestStoreService_FixtureDashboard vérifie le tableau de bord magasins de janvier 2024
+// Période précédente: décembre 2023 (commande 5 à Paris uniquement)
+func TestStoreService_FixtureDashboard(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	service := NewStoreService(ctx.StatsQueryRepo, ctx.Cache)
+
+	dashboard, err := service.GetStoreDashboard(
+		fixtureRange(t, "2024-01-01", "2024-01-31"), ordersdomain.StatusFilter{}, shareddomain.DefaultCurrency)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pagination, _ := shareddomain.NewPagination(1, 20)
+	stores := dashboard.Page("", domain.StoreSortRevenue, false, pagination).Stores()
+	if len(stores) != 2 {
+		t.Fatalf("stores = %d, want 2", len(stores))
+	}
+
+	// Paris: commande 1 (1040€, 3 articles), la commande 3 annulée est exclue
+	paris, lyon := stores[0], stores[1]
+	if paris.Name() != "Magasin Paris" || paris.Rank() != 1 || paris.PreviousRank() != 1 || paris.ItemsSold() != 3 {
+		t.Errorf("Paris = %s rank %d (previous %d), %d items", paris.Name(), paris.Rank(), paris.PreviousRank(), paris.ItemsSold())
+	}
+	if paris.Revenue().Current() != 1040 || paris.Revenue().Previous() != 60 {
+		t.Errorf("Paris revenue = %v vs %v, want 1040 vs 60", paris.Revenue().Current(), paris.Revenue().Previous())
+	}
+	if mix := paris.PaymentMix(); len(mix) != 1 || mix[0].PaymentMethodID() != 1 || mix[0].Percentage() != 100 {
+		t.Errorf("Paris payment mix = %v", mix)
+	}
+
+	// Lyon: commande 2 (30€, 6 articles), aucune commande en décembre
+	if !lyon.IsNewEntry() || lyon.ItemsPerOrder() != 6 || lyon.RevenuePerOrder().Amount() != 30 {
+		t.Errorf("Lyon = new entry %v, %v items/order, %v €/order", lyon.IsNewEntry(), lyon.ItemsPerOrder(), lyon.RevenuePerOrder().Amount())
+	}
+
+	regions := dashboard.Regions()
+	if len(regions) != 2 || regions[0].Region() != "Île-de-France" || regions[0].StoreCount() != 1 {
+		t.Errorf("regions = %v", regions)
+	}
+}
+
+// TestForecastService_FixtureForecast vérifie l'entraînement sur la série quotidienne de janvier 2024
+// et le rejet d'une fenêtre mensuelle trop courte (3 mois < deux saisons de 12 mois)
+func TestForecastService_FixtureForecast(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	service := NewForecastService(ctx.StatsQueryRepo, ctx.Cache)
+
+	forecast, err := service.GetRevenueForecast(fixtureRange(t, "2024-01-01", "2024-01-31"), domain.GranularityDay, domain.StatsFilter{}, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	points := forecast.Points()
+	if len(points) != 7 {
+		t.Fatalf("points = %d, want 7", len(points))
+	}
+	if first := points[0].BucketStart().Format("2006-01-02"); first != "2024-02-01" {
+		t.Errorf("first bucket = %s, want 2024-02-01", first)
+	}
+	for _, p := range points {
+		if p.LowerBound().Amount() > p.Revenue().Amount() || p.Revenue().Amount() > p.UpperBound().Amount() {
+			t.Errorf("%s: interval [%v, %v] does not contain %v", p.BucketStart().Format("2006-01-02"),
+				p.LowerBound().Amount(), p.UpperBound().Amount(), p.Revenue().Amount())
+		}
+	}
+
+	_, err = service.GetRevenueForecast(fixtureRange(t, "2024-01-01", "2024-03-31"), domain.GranularityMonth, domain.StatsFilter{}, 30)
+	if !errors.Is(err, domain.ErrInsufficientHistory) {
+		t.Errorf("monthly forecast on 3 months: err = %v, want ErrInsufficientHistory", err)
+	}
+}
+
+// TestAnomalyService_FixtureReport vérifie le chargement des séries quotidiennes
+// Février 2024 avec 28 jours de baseline (4 janvier → 29 février): magasins Paris et Lyon,
+// catégories Électronique, Vêtements et Alimentation. Toutes sont creuses (1 ou 2 jours de vente):
+// aucune n'est évaluée, donc aucune anomalie
+func TestAnomalyService_FixtureReport(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	categories, err := ctx.StatsQueryRepo.GetDailyCategoryActivity(
+		fixtureRange(t, "2024-01-01", "2024-01-31"), ordersdomain.StatusFilter{}, shareddomain.DefaultCurrency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Électronique le 10, Vêtements le 10 et le 20, Alimentation le 20 (commande 3 annulée exclue)
+	if len(categories) != 4 {
+		t.Errorf("daily category rows = %d, want 4", len(categories))
+	}
+
+	settings, _ := domain.NewAnomalySettings(domain.AnomalyMethodMAD, domain.AnomalyMethodMAD.DefaultThreshold(), 28)
+	service := NewAnomalyService(ctx.StatsQueryRepo, ctx.Cache)
+
+	report, err := service.GetAnomalyReport(
+		fixtureRange(t, "2024-02-01", "2024-02-29"), settings, ordersdomain.StatusFilter{}, shareddomain.DefaultCurrency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.SeriesCount() != 5 {
+		t.Errorf("series = %d, want 5", report.SeriesCount())
+	}
+	if len(report.Anomalies()) != 0 {
+		t.Errorf("anomalies = %d, want 0 (sparse series)", len(report.Anomalies()))
+	}
+}
+
+// TestDistributionService_FixtureDistribution vérifie percentiles et histogramme de janvier 2024
+// Commandes completed: 1040€ (3 articles) et 30€ (6 articles)
+//   - médiane = 535, p90 = 30 + 0.9 × 1010 = 939 (interpolation percentile_cont)
+//   - écart-type (échantillon) = 1010 / √2
+func TestDistributionService_FixtureDistribution(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	service := NewDistributionService(ctx.StatsQueryRepo, ctx.Cache)
+
+	distribution, err := service.GetOrderValueDistribution(fixtureRange(t, "2024-01-01", "2024-01-31"),
+		domain.DefaultHistogramBoundaries(), domain.StatsFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary := distribution.Summary()
+	if summary.OrderCount() != 2 {
+		t.Errorf("orders = %d, want 2", summary.OrderCount())
+	}
+	assertAmount(t, "median", summary.Median(), 535)
+	assertAmount(t, "p90", summary.P90(), 939)
+	assertAmount(t, "min", summary.Min(), 30)
+	assertAmount(t, "max", summary.Max(), 1040)
+	if math.Abs(summary.StdDev().Amount()-1010/math.Sqrt2) > 0.01 {
+		t.Errorf("std dev = %v, want %v", summary.StdDev().Amount(), 1010/math.Sqrt2)
+	}
+
+	// 30€ dans [25, 50), 1040€ dans [1000, 2500)
+	for i, b := range distribution.Histogram() {
+		want := 0
+		if i == 1 || i == 6 {
+			want = 1
+		}
+		if b.OrderCount() != want {
+			t.Errorf("histogram bucket %d (from %v) = %d orders, want %d", i, b.Lower(), b.OrderCount(), want)
+		}
+	}
+
+	if items := distribution.ItemsSummary(); items.Mean() != 4.5 || items.Median() != 4.5 {
+		t.Errorf("items per order mean/median = %v/%v, want 4.5/4.5", items.Mean(), items.Median())
+	}
+	if buckets := distribution.ItemsPerOrder(); len(buckets) != 6 || buckets[2].OrderCount() != 1 || buckets[5].OrderCount() != 1 {
+		t.Errorf("items buckets = %d, want 1..6 with one order at 3 and 6", len(buckets))
+	}
+}
@@ -1,9 +1,13 @@
 package application
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
+	"eval/internal/analytics/domain"
 	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
 	"eval/internal/testhelpers"
 )
 
@@ -144,6 +148,39 @@ func BenchmarkStatsServiceV2_365Days(b *testing.B) {
 	}
 }
 
+// BenchmarkStatsServiceV2_TimeSeries_365Days mesure la série temporelle par granularité (cache miss)
+func BenchmarkStatsServiceV2_TimeSeries_365Days(b *testing.B) {
+	testhelpers.SkipIfNoDatabase(b)
+
+	ctx := testhelpers.SetupTestContext(b)
+	defer ctx.Cleanup()
+
+	_, statsServiceV2 := setupStatsServices(ctx)
+
+	dateRange, err := shareddomain.NewDateRangeFromDays(365)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, granularity := range []domain.Granularity{domain.GranularityDay, domain.GranularityWeek, domain.GranularityMonth} {
+		b.Run(string(granularity), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				ctx.ClearCache()
+				b.StartTimer()
+
+				series, err := statsServiceV2.GetTimeSeries(dateRange, granularity, domain.StatsFilter{})
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.ReportMetric(float64(len(series.Points())), "buckets")
+			}
+		})
+	}
+}
+
 // ========================================
 // Repository Benchmarks
 // ========================================
@@ -164,7 +201,7 @@ func BenchmarkStatsRepo_GetGlobalStats_30Days(b *testing.B) {
 			b.Fatal(err)
 		}
 
-		revenue, orders, avg, err := ctx.StatsQueryRepo.GetGlobalStats(dateRange)
+		revenue, orders, avg, err := ctx.StatsQueryRepo.GetGlobalStats(dateRange, domain.StatsFilter{})
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -174,3 +211,40 @@ func BenchmarkStatsRepo_GetGlobalStats_30Days(b *testing.B) {
 		_ = avg
 	}
 }
+
+// BenchmarkBasketService_365Days mesure le comptage des couples et triplets sur le worker pool (cache vidé)
+func BenchmarkBasketService_365Days(b *testing.B) {
+	testhelpers.SkipIfNoDatabase(b)
+
+	ctx := testhelpers.SetupTestContext(b)
+	defer ctx.Cleanup()
+
+	workerPool := sharedinfra.NewWorkerPool(4)
+	workerPool.Start()
+	defer workerPool.Stop()
+
+	service := NewBasketService(ctx.StatsQueryRepo, ctx.Cache, workerPool)
+
+	dateRange, err := shareddomain.NewDateRangeFromDays(365)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, triples := range []bool{false, true} {
+		b.Run(fmt.Sprintf("Triples_%v", triples), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				ctx.ClearCache()
+				b.StartTimer()
+
+				analysis, err := service.GetBasketAnalysis(context.Background(), dateRange, triples)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.ReportMetric(float64(analysis.TotalOrders()), "orders")
+			}
+		})
+	}
+}
@@ -202,19 +202,19 @@ func (s *StatsServiceV1) calculateStatsInefficient(dateRange shareddomain.DateRa
 	// (sinon ce serait trop long à implémenter toutes les inefficacités)
 	// Dans le vrai V1, elles utilisaient aussi des boucles imbriquées
 
-	categoryStats, err := s.statsRepo.GetCategoryStats(dateRange)
+	categoryStats, err := s.statsRepo.GetCategoryStats(dateRange, domain.StatsFilter{})
 	if err != nil {
 		return nil, err
 	}
 	stats.SetCategoryStats(categoryStats)
 
-	topStores, err := s.statsRepo.GetTopStores(dateRange, 5)
+	topStores, err := s.statsRepo.GetTopStores(dateRange, domain.StatsFilter{}, 5)
 	if err != nil {
 		return nil, err
 	}
 	stats.SetTopStores(topStores)
 
-	paymentDistrib, err := s.statsRepo.GetPaymentMethodDistribution(dateRange)
+	paymentDistrib, err := s.statsRepo.GetPaymentMethodDistribution(dateRange, domain.StatsFilter{})
 	if err != nil {
 		return nil, err
 	}
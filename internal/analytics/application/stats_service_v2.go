@@ -52,28 +52,128 @@ func NewStatsServiceV2(
 // - Permet de scaler horizontalement sans surcharger la DB
 // ============================================================================
 func (s *StatsServiceV2) GetStats(days int) (*domain.Stats, error) {
+	dateRange, err := shareddomain.NewDateRangeFromDays(days)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetStatsForRange(dateRange, domain.StatsFilter{})
+}
+
+// GetStatsForRange calcule les stats d'une période explicite (from/to, mois, trimestre, semaine ISO)
+// restreintes par filter (magasin, région, catégorie, ...; StatsFilter{} = aucune restriction)
+// La clé de cache est construite à partir des bornes normalisées de la période et du filtre
+func (s *StatsServiceV2) GetStatsForRange(
+	dateRange shareddomain.DateRange,
+	filter domain.StatsFilter,
+) (*domain.Stats, error) {
 	// Vérifier le cache en premier (hot path optimization)
-	cacheKey := s.buildCacheKey(days)
+	cacheKey := s.buildCacheKey(dateRange, filter)
 	if cached, found := s.cache.Get(cacheKey); found {
 		// Cache hit: retour immédiat sans toucher la DB
 		return cached.(*domain.Stats), nil
 	}
 
 	// Cache miss: calculer les stats
-	dateRange, err := shareddomain.NewDateRangeFromDays(days)
+	stats, err := s.calculateStatsOptimized(dateRange, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	stats, err := s.calculateStatsOptimized(dateRange)
+	// Stocker en cache pour les prochaines requêtes
+	s.cache.Set(cacheKey, stats, s.cacheTTL)
+
+	return stats, nil
+}
+
+// GetStatsWithComparison calcule les stats de la période et les compare à
+// la période précédente et à la même période l'année dernière
+//
+// PARALLÉLISME: les 3 périodes sont calculées simultanément (même principe que calculateStatsOptimized)
+//   - Chaque GetStatsForRange lance lui-même ses 6 requêtes en parallèle: 18 requêtes au maximum,
+//     sous la limite du pool de connexions (25)
+//   - Chaque période est mise en cache séparément: le mois précédent calculé pour la comparaison
+//     de mars sert directement de période courante pour ?period=2025-02
+func (s *StatsServiceV2) GetStatsWithComparison(
+	dateRange shareddomain.DateRange,
+	filter domain.StatsFilter,
+) (*domain.StatsComparison, error) {
+	previousRange := dateRange.Previous()
+	lastYearRange := dateRange.SamePeriodLastYear()
+
+	var current, previous, lastYear *domain.Stats
+	var wg sync.WaitGroup
+	errChan := make(chan error, 3)
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		var err error
+		if current, err = s.GetStatsForRange(dateRange, filter); err != nil {
+			errChan <- fmt.Errorf("current period error: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if previous, err = s.GetStatsForRange(previousRange, filter); err != nil {
+			errChan <- fmt.Errorf("previous period error: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if lastYear, err = s.GetStatsForRange(lastYearRange, filter); err != nil {
+			errChan <- fmt.Errorf("same period last year error: %w", err)
+		}
+	}()
+
+	wg.Wait()
+	close(errChan)
+
+	// Retourner la première erreur rencontrée
+	for err := range errChan {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return domain.NewStatsComparison(
+		current,
+		dateRange,
+		domain.NewPeriodComparison(domain.BaselinePreviousPeriod, previousRange, current, previous),
+		domain.NewPeriodComparison(domain.BaselineSamePeriodLastYear, lastYearRange, current, lastYear),
+	), nil
+}
+
+// GetTimeSeries retourne l'évolution du CA par jour, semaine ou mois sur la période
+// Même stratégie de cache que GetStats: clé = granularité + bornes normalisées
+func (s *StatsServiceV2) GetTimeSeries(
+	dateRange shareddomain.DateRange,
+	granularity domain.Granularity,
+	filter domain.StatsFilter,
+) (*domain.TimeSeries, error) {
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("stats").
+		Add("v2").
+		Add("timeseries").
+		Add(string(granularity)).
+		Add(dateRange.Key()).
+		Add(filter.Key()).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.TimeSeries), nil
+	}
+
+	points, err := s.statsRepo.GetRevenueTimeSeries(dateRange, granularity, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	// Stocker en cache pour les prochaines requêtes
-	s.cache.Set(cacheKey, stats, s.cacheTTL)
+	series := domain.NewTimeSeries(granularity, dateRange, filter.Currency(), points)
+	s.cache.Set(cacheKey, series, s.cacheTTL)
 
-	return stats, nil
+	return series, nil
 }
 
 // ============================================================================
@@ -102,7 +202,7 @@ func (s *StatsServiceV2) GetStats(days int) (*domain.Stats, error) {
 // - Utilisation efficace des CPU multi-cores
 // - Throughput: 3-5x meilleur
 // ============================================================================
-func (s *StatsServiceV2) calculateStatsOptimized(dateRange shareddomain.DateRange) (*domain.Stats, error) {
+func (s *StatsServiceV2) calculateStatsOptimized(dateRange shareddomain.DateRange, filter domain.StatsFilter) (*domain.Stats, error) {
 	stats := domain.NewStats()
 
 	// WaitGroup: mécanisme de synchronisation pour attendre plusieurs goroutines
@@ -112,8 +212,8 @@ func (s *StatsServiceV2) calculateStatsOptimized(dateRange shareddomain.DateRang
 	var wg sync.WaitGroup
 
 	// Canal bufferisé pour collecter les erreurs de toutes les goroutines
-	// Taille 5 = nombre de goroutines (évite les blocages)
-	errChan := make(chan error, 5)
+	// Taille 6 = nombre de goroutines (évite les blocages)
+	errChan := make(chan error, 6)
 
 	// ========================================================================
 	// GOROUTINE 1: Stats globales (revenue, orders, average)
@@ -137,7 +237,7 @@ func (s *StatsServiceV2) calculateStatsOptimized(dateRange shareddomain.DateRang
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		revenue, orders, avgOrder, err := s.statsRepo.GetGlobalStats(dateRange)
+		revenue, orders, avgOrder, err := s.statsRepo.GetGlobalStats(dateRange, filter)
 		if err != nil {
 			errChan <- fmt.Errorf("global stats error: %w", err)
 			return
@@ -167,7 +267,7 @@ func (s *StatsServiceV2) calculateStatsOptimized(dateRange shareddomain.DateRang
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		categoryStats, err := s.statsRepo.GetCategoryStats(dateRange)
+		categoryStats, err := s.statsRepo.GetCategoryStats(dateRange, filter)
 		if err != nil {
 			errChan <- fmt.Errorf("category stats error: %w", err)
 			return
@@ -221,7 +321,7 @@ func (s *StatsServiceV2) calculateStatsOptimized(dateRange shareddomain.DateRang
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		topProducts, err := s.statsRepo.GetTopProducts(dateRange, 10)
+		topProducts, err := s.statsRepo.GetTopProducts(dateRange, filter, domain.TopProductsLimit)
 		if err != nil {
 			errChan <- fmt.Errorf("top products error: %w", err)
 			return
@@ -238,7 +338,7 @@ func (s *StatsServiceV2) calculateStatsOptimized(dateRange shareddomain.DateRang
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		topStores, err := s.statsRepo.GetTopStores(dateRange, 5)
+		topStores, err := s.statsRepo.GetTopStores(dateRange, filter, domain.TopStoresLimit)
 		if err != nil {
 			errChan <- fmt.Errorf("top stores error: %w", err)
 			return
@@ -255,7 +355,7 @@ func (s *StatsServiceV2) calculateStatsOptimized(dateRange shareddomain.DateRang
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		paymentDistrib, err := s.statsRepo.GetPaymentMethodDistribution(dateRange)
+		paymentDistrib, err := s.statsRepo.GetPaymentMethodDistribution(dateRange, filter)
 		if err != nil {
 			errChan <- fmt.Errorf("payment distribution error: %w", err)
 			return
@@ -263,7 +363,24 @@ func (s *StatsServiceV2) calculateStatsOptimized(dateRange shareddomain.DateRang
 		stats.SetPaymentDistribution(paymentDistrib)
 	}()
 
-	// Attendre que toutes les 5 goroutines se terminent
+	// ========================================================================
+	// GOROUTINE 6: Ventilation par statut de commande
+	//
+	// Seule requête qui ignore le filtre de statut: elle montre le CA annulé / en attente
+	// écarté des 5 autres agrégats (completed uniquement par défaut)
+	// ========================================================================
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		statusBreakdown, err := s.statsRepo.GetOrderStatusBreakdown(dateRange, filter)
+		if err != nil {
+			errChan <- fmt.Errorf("status breakdown error: %w", err)
+			return
+		}
+		stats.SetStatusBreakdown(statusBreakdown)
+	}()
+
+	// Attendre que toutes les 6 goroutines se terminent
 	// Bloque jusqu'à ce que tous les wg.Done() soient appelés
 	wg.Wait()
 	close(errChan)
@@ -298,17 +415,18 @@ func (s *StatsServiceV2) calculateStatsOptimized(dateRange shareddomain.DateRang
 // GAIN: N-1 allocations évitées (où N = nombre de parties)
 // Important car appelé à chaque GetStats() (fréquent)
 // ============================================================================
-func (s *StatsServiceV2) buildCacheKey(days int) string {
+func (s *StatsServiceV2) buildCacheKey(dateRange shareddomain.DateRange, filter domain.StatsFilter) string {
 	return sharedinfra.NewCacheKeyBuilder().
 		Add("stats").
 		Add("v2").
-		AddInt(days).
+		Add(dateRange.Key()).
+		Add(filter.Key()).
 		Build()
 }
 
-// InvalidateCache invalide le cache pour un nombre de jours donné
-func (s *StatsServiceV2) InvalidateCache(days int) {
-	cacheKey := s.buildCacheKey(days)
+// InvalidateCache invalide le cache pour une période et un filtre donnés
+func (s *StatsServiceV2) InvalidateCache(dateRange shareddomain.DateRange, filter domain.StatsFilter) {
+	cacheKey := s.buildCacheKey(dateRange, filter)
 	s.cache.Delete(cacheKey)
 }
 
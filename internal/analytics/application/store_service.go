@@ -0,0 +1,104 @@
+package application
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"eval/internal/analytics/domain"
+	"eval/internal/analytics/infrastructure"
+	ordersdomain "eval/internal/orders/domain"
+	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
+)
+
+// StoreService tableau de bord des magasins et des régions
+// Le tableau complet est mis en cache par période et statuts: tri, filtre et pagination
+// sont appliqués ensuite par StoreDashboard.Page, sans nouvelle requête
+type StoreService struct {
+	statsRepo *infrastructure.StatsQueryRepository
+	cache     sharedinfra.Cache
+	cacheTTL  time.Duration
+}
+
+// NewStoreService crée une nouvelle instance de StoreService
+func NewStoreService(
+	statsRepo *infrastructure.StatsQueryRepository,
+	cache sharedinfra.Cache,
+) *StoreService {
+	return &StoreService{
+		statsRepo: statsRepo,
+		cache:     cache,
+		cacheTTL:  5 * time.Minute,
+	}
+}
+
+// GetStoreDashboard calcule les indicateurs de tous les magasins et leur évolution de rang
+// vs la période précédente (même référence que la comparaison previous_period des stats)
+//
+// PARALLÉLISME: les 3 requêtes (période courante, période précédente, moyens de paiement)
+// sont indépendantes et lancées simultanément
+func (s *StoreService) GetStoreDashboard(
+	dateRange shareddomain.DateRange,
+	status ordersdomain.StatusFilter,
+	currency shareddomain.Currency,
+) (*domain.StoreDashboard, error) {
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("stats").
+		Add("v2").
+		Add("stores").
+		Add(dateRange.Key()).
+		Add(status.Key()).
+		Add(currency.String()).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.StoreDashboard), nil
+	}
+
+	previousRange := dateRange.Previous()
+
+	var (
+		current, previous []*domain.StoreActivity
+		payments          map[ordersdomain.StoreID][]*domain.StorePaymentShare
+	)
+	var wg sync.WaitGroup
+	errChan := make(chan error, 3)
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		var err error
+		if current, err = s.statsRepo.GetStoreActivity(dateRange, status, currency); err != nil {
+			errChan <- fmt.Errorf("store activity error: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if previous, err = s.statsRepo.GetStoreActivity(previousRange, status, currency); err != nil {
+			errChan <- fmt.Errorf("previous store activity error: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if payments, err = s.statsRepo.GetStorePaymentMix(dateRange, status, currency); err != nil {
+			errChan <- fmt.Errorf("store payment mix error: %w", err)
+		}
+	}()
+
+	wg.Wait()
+	close(errChan)
+
+	// Retourner la première erreur rencontrée
+	for err := range errChan {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dashboard := domain.NewStoreDashboard(dateRange, previousRange, current, previous, payments)
+	s.cache.Set(cacheKey, dashboard, s.cacheTTL)
+
+	return dashboard, nil
+}
@@ -0,0 +1,522 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// ========================================
+// DÉTECTION D'ANOMALIES SUR LES VENTES QUOTIDIENNES
+// ========================================
+// Chaque série (magasin ou catégorie × CA ou nombre de commandes) est comparée, jour par jour,
+// à ses baselineDays jours précédents (fenêtre glissante, le jour testé exclu)
+//
+// Deux scores:
+//   - zscore: (x - moyenne) / écart-type
+//   - mad: (x - médiane) / (1.4826 × MAD), z-score "robuste" (Iglewicz & Hoaglin)
+//     Une seule journée aberrante dans la baseline gonfle l'écart-type et masque les suivantes,
+//     pas la médiane: c'est la méthode par défaut
+//
+// LIMITE: la baseline mélange les jours de la semaine: un magasin fermé le dimanche
+// a une baseline tirée vers le bas, mais son dimanche à 0 reste dans la dispersion normale
+
+// AnomalyDimension dimension d'une série analysée
+type AnomalyDimension string
+
+const (
+	AnomalyDimensionStore    AnomalyDimension = "store"
+	AnomalyDimensionCategory AnomalyDimension = "category"
+)
+
+// ParseAnomalyDimension convertit un paramètre de requête ("" = toutes les dimensions)
+func ParseAnomalyDimension(value string) (AnomalyDimension, error) {
+	switch dimension := AnomalyDimension(strings.ToLower(value)); dimension {
+	case "", AnomalyDimensionStore, AnomalyDimensionCategory:
+		return dimension, nil
+	default:
+		return "", domain.NewValidationError("invalid_dimension", fmt.Sprintf("invalid dimension: %q (expected store or category)", value))
+	}
+}
+
+// AnomalyMetric indicateur quotidien analysé
+type AnomalyMetric string
+
+const (
+	AnomalyMetricRevenue    AnomalyMetric = "revenue"
+	AnomalyMetricOrderCount AnomalyMetric = "order_count"
+)
+
+// AnomalyMethod méthode de score
+type AnomalyMethod string
+
+const (
+	AnomalyMethodZScore AnomalyMethod = "zscore"
+	AnomalyMethodMAD    AnomalyMethod = "mad"
+)
+
+// ParseAnomalyMethod convertit un paramètre de requête (mad par défaut)
+func ParseAnomalyMethod(value string) (AnomalyMethod, error) {
+	switch method := AnomalyMethod(strings.ToLower(value)); method {
+	case "":
+		return AnomalyMethodMAD, nil
+	case AnomalyMethodZScore, AnomalyMethodMAD:
+		return method, nil
+	default:
+		return "", domain.NewValidationError("invalid_method", fmt.Sprintf("invalid method: %q (expected zscore or mad)", value))
+	}
+}
+
+// DefaultThreshold retourne le seuil usuel de la méthode
+// 3 pour le z-score, 3.5 pour le z-score robuste (recommandation Iglewicz & Hoaglin)
+func (m AnomalyMethod) DefaultThreshold() float64 {
+	if m == AnomalyMethodZScore {
+		return 3
+	}
+	return 3.5
+}
+
+// AnomalySeverity gravité d'un écart, relative au seuil
+type AnomalySeverity string
+
+const (
+	AnomalySeverityWarning  AnomalySeverity = "warning"  // |score| >= seuil
+	AnomalySeverityMajor    AnomalySeverity = "major"    // |score| >= 1.5 × seuil
+	AnomalySeverityCritical AnomalySeverity = "critical" // |score| >= 2 × seuil
+)
+
+// ParseAnomalySeverity convertit un paramètre de requête (warning par défaut: tout afficher)
+func ParseAnomalySeverity(value string) (AnomalySeverity, error) {
+	switch severity := AnomalySeverity(strings.ToLower(value)); severity {
+	case "":
+		return AnomalySeverityWarning, nil
+	case AnomalySeverityWarning, AnomalySeverityMajor, AnomalySeverityCritical:
+		return severity, nil
+	default:
+		return "", domain.NewValidationError("invalid_severity",
+			fmt.Sprintf("invalid severity: %q (expected warning, major or critical)", value))
+	}
+}
+
+// rank ordre de gravité (warning < major < critical)
+func (s AnomalySeverity) rank() int {
+	switch s {
+	case AnomalySeverityCritical:
+		return 3
+	case AnomalySeverityMajor:
+		return 2
+	default:
+		return 1
+	}
+}
+
+const (
+	// DefaultAnomalyBaselineDays baseline par défaut: 4 semaines
+	DefaultAnomalyBaselineDays = 28
+	// MinAnomalyBaselineDays en dessous, médiane et écart-type ne veulent plus rien dire
+	MinAnomalyBaselineDays = 7
+	// MaxAnomalyBaselineDays limite la plage chargée en plus de la période analysée
+	MaxAnomalyBaselineDays = 365
+)
+
+// AnomalySettings paramètres de détection
+type AnomalySettings struct {
+	method       AnomalyMethod
+	threshold    float64
+	baselineDays int
+}
+
+// NewAnomalySettings valide les paramètres de détection
+func NewAnomalySettings(method AnomalyMethod, threshold float64, baselineDays int) (AnomalySettings, error) {
+	if method != AnomalyMethodZScore && method != AnomalyMethodMAD {
+		return AnomalySettings{}, domain.NewValidationError("invalid_method", fmt.Sprintf("invalid method: %q", method))
+	}
+	if threshold <= 0 || math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+		return AnomalySettings{}, domain.NewValidationError("invalid_threshold", fmt.Sprintf("threshold must be positive, got %v", threshold))
+	}
+	if baselineDays < MinAnomalyBaselineDays || baselineDays > MaxAnomalyBaselineDays {
+		return AnomalySettings{}, domain.NewValidationError("invalid_baseline_days", fmt.Sprintf("baseline days must be between %d and %d, got %d",
+			MinAnomalyBaselineDays, MaxAnomalyBaselineDays, baselineDays))
+	}
+	return AnomalySettings{method: method, threshold: threshold, baselineDays: baselineDays}, nil
+}
+
+// Method retourne la méthode de score
+func (s AnomalySettings) Method() AnomalyMethod {
+	return s.method
+}
+
+// Threshold retourne le seuil de |score| à partir duquel un jour est anormal
+func (s AnomalySettings) Threshold() float64 {
+	return s.threshold
+}
+
+// BaselineDays retourne la longueur de la fenêtre de référence
+func (s AnomalySettings) BaselineDays() int {
+	return s.baselineDays
+}
+
+// Key retourne une représentation stable pour les clés de cache
+func (s AnomalySettings) Key() string {
+	return fmt.Sprintf("%s:%g:%d", s.method, s.threshold, s.baselineDays)
+}
+
+// severity classe un score (ok=false sous le seuil)
+func (s AnomalySettings) severity(score float64) (AnomalySeverity, bool) {
+	switch abs := math.Abs(score); {
+	case abs >= 2*s.threshold:
+		return AnomalySeverityCritical, true
+	case abs >= 1.5*s.threshold:
+		return AnomalySeverityMajor, true
+	case abs >= s.threshold:
+		return AnomalySeverityWarning, true
+	default:
+		return "", false
+	}
+}
+
+// DailyActivity activité d'un magasin ou d'une catégorie sur un jour
+type DailyActivity struct {
+	dimension   AnomalyDimension
+	dimensionID int64
+	name        string
+	day         time.Time
+	orderCount  int
+	revenue     domain.Money
+}
+
+// NewDailyActivity crée une activité quotidienne (jours sans commande absents: complétés à zéro par le rapport)
+func NewDailyActivity(
+	dimension AnomalyDimension,
+	dimensionID int64,
+	name string,
+	day time.Time,
+	orderCount int,
+	revenue domain.Money,
+) *DailyActivity {
+	return &DailyActivity{
+		dimension:   dimension,
+		dimensionID: dimensionID,
+		name:        name,
+		day:         day,
+		orderCount:  orderCount,
+		revenue:     revenue,
+	}
+}
+
+// AnomalyContribution écart d'une série sur le jour de l'anomalie
+type AnomalyContribution struct {
+	dimension   AnomalyDimension
+	dimensionID int64
+	name        string
+	metric      AnomalyMetric
+	value       float64
+	expected    float64
+	score       float64
+	severity    AnomalySeverity
+}
+
+// Dimension retourne la dimension de la série (store ou category)
+func (c *AnomalyContribution) Dimension() AnomalyDimension {
+	return c.dimension
+}
+
+// DimensionID retourne l'identifiant du magasin ou de la catégorie
+func (c *AnomalyContribution) DimensionID() int64 {
+	return c.dimensionID
+}
+
+// Name retourne le nom du magasin ou de la catégorie
+func (c *AnomalyContribution) Name() string {
+	return c.name
+}
+
+// Metric retourne l'indicateur en écart
+func (c *AnomalyContribution) Metric() AnomalyMetric {
+	return c.metric
+}
+
+// Value retourne la valeur observée
+func (c *AnomalyContribution) Value() float64 {
+	return c.value
+}
+
+// Expected retourne la valeur de référence (moyenne ou médiane de la baseline)
+func (c *AnomalyContribution) Expected() float64 {
+	return c.expected
+}
+
+// Score retourne le score signé (négatif = baisse)
+func (c *AnomalyContribution) Score() float64 {
+	return c.score
+}
+
+// Severity retourne la gravité de l'écart
+func (c *AnomalyContribution) Severity() AnomalySeverity {
+	return c.severity
+}
+
+// IsDrop indique une baisse (caisse en panne, chargement manquant) plutôt qu'un pic
+func (c *AnomalyContribution) IsDrop() bool {
+	return c.score < 0
+}
+
+// Anomaly jour anormal et séries qui y contribuent
+type Anomaly struct {
+	day           time.Time
+	severity      AnomalySeverity
+	contributions []*AnomalyContribution
+}
+
+// Day retourne le jour concerné
+func (a *Anomaly) Day() time.Time {
+	return a.day
+}
+
+// Severity retourne la gravité maximale des contributions
+func (a *Anomaly) Severity() AnomalySeverity {
+	return a.severity
+}
+
+// Contributions retourne les séries en écart, de la plus forte à la plus faible (|score|)
+func (a *Anomaly) Contributions() []*AnomalyContribution {
+	return append([]*AnomalyContribution{}, a.contributions...)
+}
+
+// AnomalyReport anomalies détectées sur une période
+type AnomalyReport struct {
+	dateRange   domain.DateRange
+	settings    AnomalySettings
+	seriesCount int
+	anomalies   []*Anomaly
+}
+
+// dailySeries série quotidienne complétée d'un magasin ou d'une catégorie
+type dailySeries struct {
+	dimension   AnomalyDimension
+	dimensionID int64
+	name        string
+	revenue     []float64
+	orders      []float64
+}
+
+// NewAnomalyReport analyse chaque jour de dateRange
+// activity doit couvrir les settings.BaselineDays() jours qui précèdent dateRange.Start()
+// (AnomalyBaselineRange) en plus de la période elle-même
+//
+// PIÈGE: une série creuse (catégorie vendue un jour sur dix) a une médiane et une MAD à 0:
+// n'importe quelle vente serait un pic. Une série n'est évaluée un jour donné que si sa baseline
+// compte au moins la moitié de jours avec commande
+func NewAnomalyReport(dateRange domain.DateRange, settings AnomalySettings, activity []*DailyActivity) *AnomalyReport {
+	const keyLayout = "2006-01-02"
+
+	baselineRange := AnomalyBaselineRange(dateRange, settings)
+	days := baselineRange.Days()
+	dayIndex := make(map[string]int, days)
+	dates := make([]time.Time, 0, days)
+	for day := baselineRange.Start(); !day.After(baselineRange.End()); day = day.AddDate(0, 0, 1) {
+		dayIndex[day.Format(keyLayout)] = len(dates)
+		dates = append(dates, day)
+	}
+
+	// Séries dans l'ordre de première apparition: résultat stable pour un même jeu de lignes
+	type seriesKey struct {
+		dimension AnomalyDimension
+		id        int64
+	}
+	seriesByKey := make(map[seriesKey]*dailySeries)
+	var series []*dailySeries
+	for _, a := range activity {
+		// Date SQL (UTC): seule la date calendaire compte
+		i, ok := dayIndex[a.day.Format(keyLayout)]
+		if !ok {
+			continue
+		}
+		key := seriesKey{a.dimension, a.dimensionID}
+		s, found := seriesByKey[key]
+		if !found {
+			s = &dailySeries{
+				dimension:   a.dimension,
+				dimensionID: a.dimensionID,
+				name:        a.name,
+				revenue:     make([]float64, len(dates)),
+				orders:      make([]float64, len(dates)),
+			}
+			seriesByKey[key] = s
+			series = append(series, s)
+		}
+		s.revenue[i] += a.revenue.Amount()
+		s.orders[i] += float64(a.orderCount)
+	}
+
+	byDay := make(map[int]*Anomaly)
+	baselineDays := settings.baselineDays
+	for _, s := range series {
+		for i := baselineDays; i < len(dates); i++ {
+			if activeDays(s.orders[i-baselineDays:i])*2 < baselineDays {
+				continue
+			}
+			for _, m := range []struct {
+				metric AnomalyMetric
+				values []float64
+			}{
+				{AnomalyMetricRevenue, s.revenue},
+				{AnomalyMetricOrderCount, s.orders},
+			} {
+				expected, score, ok := anomalyScore(settings.method, m.values[i-baselineDays:i], m.values[i])
+				if !ok {
+					continue
+				}
+				severity, flagged := settings.severity(score)
+				if !flagged {
+					continue
+				}
+
+				anomaly, found := byDay[i]
+				if !found {
+					anomaly = &Anomaly{day: dates[i], severity: severity}
+					byDay[i] = anomaly
+				}
+				if severity.rank() > anomaly.severity.rank() {
+					anomaly.severity = severity
+				}
+				anomaly.contributions = append(anomaly.contributions, &AnomalyContribution{
+					dimension:   s.dimension,
+					dimensionID: s.dimensionID,
+					name:        s.name,
+					metric:      m.metric,
+					value:       m.values[i],
+					expected:    expected,
+					score:       score,
+					severity:    severity,
+				})
+			}
+		}
+	}
+
+	anomalies := make([]*Anomaly, 0, len(byDay))
+	for _, anomaly := range byDay {
+		sort.SliceStable(anomaly.contributions, func(i, j int) bool {
+			return math.Abs(anomaly.contributions[i].score) > math.Abs(anomaly.contributions[j].score)
+		})
+		anomalies = append(anomalies, anomaly)
+	}
+	// Les plus récentes d'abord: ce sont celles qui appellent une action
+	sort.Slice(anomalies, func(i, j int) bool {
+		return anomalies[i].day.After(anomalies[j].day)
+	})
+
+	return &AnomalyReport{
+		dateRange:   dateRange,
+		settings:    settings,
+		seriesCount: len(series),
+		anomalies:   anomalies,
+	}
+}
+
+// AnomalyBaselineRange retourne la plage à charger: la période analysée précédée de sa baseline
+func AnomalyBaselineRange(dateRange domain.DateRange, settings AnomalySettings) domain.DateRange {
+	extended, _ := domain.NewDateRange(dateRange.Start().AddDate(0, 0, -settings.baselineDays), dateRange.End())
+	return extended
+}
+
+// activeDays compte les jours avec au moins une commande
+func activeDays(orders []float64) int {
+	count := 0
+	for _, o := range orders {
+		if o > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// anomalyScore calcule la valeur de référence et le score de x face à baseline
+// ok=false si la baseline n'a aucune dispersion (score indéfini)
+func anomalyScore(method AnomalyMethod, baseline []float64, x float64) (expected, score float64, ok bool) {
+	var scale float64
+	if method == AnomalyMethodZScore {
+		expected = mean(baseline)
+		var sumSquares float64
+		for _, v := range baseline {
+			sumSquares += (v - expected) * (v - expected)
+		}
+		scale = math.Sqrt(sumSquares / float64(len(baseline)))
+	} else {
+		expected = median(baseline)
+		deviations := make([]float64, len(baseline))
+		for i, v := range baseline {
+			deviations[i] = math.Abs(v - expected)
+		}
+		// 1.4826 × MAD estime l'écart-type d'une loi normale
+		// MAD nulle (plus de la moitié des jours identiques): repli sur l'écart absolu moyen
+		if mad := median(deviations); mad > 0 {
+			scale = 1.4826 * mad
+		} else {
+			scale = 1.2533 * mean(deviations)
+		}
+	}
+
+	if scale == 0 {
+		return expected, 0, false
+	}
+	return expected, (x - expected) / scale, true
+}
+
+// median médiane (values non vide, non modifié)
+func median(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// DateRange retourne la période analysée (hors baseline)
+func (r *AnomalyReport) DateRange() domain.DateRange {
+	return r.dateRange
+}
+
+// Settings retourne les paramètres de détection
+func (r *AnomalyReport) Settings() AnomalySettings {
+	return r.settings
+}
+
+// SeriesCount retourne le nombre de magasins et catégories analysés
+func (r *AnomalyReport) SeriesCount() int {
+	return r.seriesCount
+}
+
+// Anomalies retourne tous les jours anormaux, du plus récent au plus ancien
+func (r *AnomalyReport) Anomalies() []*Anomaly {
+	return append([]*Anomaly{}, r.anomalies...)
+}
+
+// Filter retourne les jours ayant au moins une contribution de la dimension ("" = toutes)
+// et de gravité >= minSeverity; seules ces contributions sont gardées
+func (r *AnomalyReport) Filter(dimension AnomalyDimension, minSeverity AnomalySeverity) []*Anomaly {
+	var filtered []*Anomaly
+	for _, a := range r.anomalies {
+		var contributions []*AnomalyContribution
+		severity := minSeverity
+		for _, c := range a.contributions {
+			if (dimension != "" && c.dimension != dimension) || c.severity.rank() < minSeverity.rank() {
+				continue
+			}
+			if c.severity.rank() > severity.rank() {
+				severity = c.severity
+			}
+			contributions = append(contributions, c)
+		}
+		if len(contributions) > 0 {
+			filtered = append(filtered, &Anomaly{day: a.day, severity: severity, contributions: contributions})
+		}
+	}
+	return filtered
+}
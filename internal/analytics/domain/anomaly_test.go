@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// TestAnomalyReport_DetectsBrokenTill vérifie la détection d'une journée sans vente
+// Magasin 1: ~100€ et ~10 commandes par jour, aucune ligne le 2025-03-05 (caisse en panne)
+// Catégorie 7: vendue un jour sur dix, une grosse vente ce même jour (série creuse: ignorée)
+func TestAnomalyReport_DetectsBrokenTill(t *testing.T) {
+	eur := func(amount float64) domain.Money {
+		m, _ := domain.NewMoney(amount, "EUR")
+		return m
+	}
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	dateRange, err := domain.NewDateRange(start, start.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings, err := NewAnomalySettings(AnomalyMethodMAD, AnomalyMethodMAD.DefaultThreshold(), 28)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	brokenDay := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	var activity []*DailyActivity
+	for day := start.AddDate(0, 0, -28); !day.After(dateRange.End()); day = day.AddDate(0, 0, 1) {
+		if !day.Equal(brokenDay) {
+			i := day.YearDay()
+			activity = append(activity, NewDailyActivity(AnomalyDimensionStore, 1, "Magasin Paris", day,
+				10+i%3, eur(100+float64(i%5)*10)))
+		}
+		if day.YearDay()%10 == 0 || day.Equal(brokenDay) {
+			activity = append(activity, NewDailyActivity(AnomalyDimensionCategory, 7, "Jardin", day, 1, eur(5000)))
+		}
+	}
+
+	report := NewAnomalyReport(dateRange, settings, activity)
+	if report.SeriesCount() != 2 {
+		t.Errorf("series = %d, want 2", report.SeriesCount())
+	}
+
+	anomalies := report.Anomalies()
+	if len(anomalies) != 1 {
+		t.Fatalf("anomalies = %d, want 1", len(anomalies))
+	}
+	got := anomalies[0]
+	if !got.Day().Equal(brokenDay) || got.Severity() != AnomalySeverityCritical {
+		t.Errorf("anomaly = %s %s, want 2025-03-05 critical", got.Day().Format("2006-01-02"), got.Severity())
+	}
+	if len(got.Contributions()) != 2 {
+		t.Fatalf("contributions = %d, want 2 (revenue and order count)", len(got.Contributions()))
+	}
+	for _, c := range got.Contributions() {
+		if c.Dimension() != AnomalyDimensionStore || c.DimensionID() != 1 || !c.IsDrop() || c.Value() != 0 {
+			t.Errorf("contribution = %s %d %s value %v score %.2f, want store 1 drop to 0",
+				c.Dimension(), c.DimensionID(), c.Metric(), c.Value(), c.Score())
+		}
+	}
+
+	if filtered := report.Filter(AnomalyDimensionCategory, AnomalySeverityWarning); len(filtered) != 0 {
+		t.Errorf("category anomalies = %d, want 0", len(filtered))
+	}
+}
+
+// TestAnomalyScore_MADResistsOutliers vérifie que le score robuste n'est pas masqué par un pic passé
+func TestAnomalyScore_MADResistsOutliers(t *testing.T) {
+	baseline := []float64{100, 102, 98, 101, 99, 100, 1000, 103, 97, 100}
+
+	_, zscore, _ := anomalyScore(AnomalyMethodZScore, baseline, 200)
+	_, robust, _ := anomalyScore(AnomalyMethodMAD, baseline, 200)
+	if zscore >= 3 {
+		t.Errorf("z-score = %.2f, expected below 3 (inflated standard deviation)", zscore)
+	}
+	if robust < 3.5 {
+		t.Errorf("robust score = %.2f, want >= 3.5", robust)
+	}
+
+	if _, _, ok := anomalyScore(AnomalyMethodMAD, []float64{5, 5, 5, 5}, 8); ok {
+		t.Error("constant baseline should not be scored")
+	}
+}
@@ -0,0 +1,396 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+
+	catalogdomain "eval/internal/catalog/domain"
+	"eval/internal/shared/domain"
+)
+
+// ========================================
+// ANALYSE DU PANIER (produits achetés ensemble)
+// ========================================
+// Une règle d'association A → B se lit "les commandes contenant A contiennent aussi B":
+//   - support    = commandes contenant A et B / commandes de la période
+//   - confidence = commandes contenant A et B / commandes contenant A  (P(B|A))
+//   - lift       = confidence / support(B)
+//     lift > 1: A et B sont achetés ensemble plus souvent que le hasard ne le voudrait
+//
+// Avec les triplets, l'antécédent compte deux produits: {A, B} → C.
+// Un panier est l'ensemble des produits DISTINCTS d'une commande (la quantité ne compte pas).
+
+// MinItemsetOrders nombre minimal de commandes pour qu'un ensemble de produits soit retenu
+// Un couple vu dans une seule commande a un lift énorme mais ne signifie rien
+const MinItemsetOrders = 2
+
+// ItemsetCounts compteurs de produits, couples et triplets sur un ensemble de paniers
+// Chaque worker remplit ses propres compteurs, fusionnés ensuite avec Merge
+type ItemsetCounts struct {
+	maxSize int
+	orders  int
+	items   map[catalogdomain.ProductID]int
+	pairs   map[[2]catalogdomain.ProductID]int
+	triples map[[3]catalogdomain.ProductID]int
+}
+
+// NewItemsetCounts crée des compteurs vides
+// maxSize = taille maximale des ensembles comptés: 2 (couples) ou 3 (couples et triplets)
+func NewItemsetCounts(maxSize int) (*ItemsetCounts, error) {
+	if maxSize != 2 && maxSize != 3 {
+		return nil, fmt.Errorf("itemset size must be 2 or 3, got %d", maxSize)
+	}
+
+	counts := &ItemsetCounts{
+		maxSize: maxSize,
+		items:   make(map[catalogdomain.ProductID]int),
+		pairs:   make(map[[2]catalogdomain.ProductID]int),
+	}
+	if maxSize == 3 {
+		counts.triples = make(map[[3]catalogdomain.ProductID]int)
+	}
+	return counts, nil
+}
+
+// AddBasket compte les produits d'une commande
+// Les doublons sont ignorés et les produits triés: {B, A} et {A, B} sont le même couple
+//
+// PERFORMANCE: un panier de k produits génère k(k-1)/2 couples et k(k-1)(k-2)/6 triplets
+//   - Négligeable pour des paniers de 1 à 5 produits (10 triplets au plus)
+func (c *ItemsetCounts) AddBasket(products []catalogdomain.ProductID) {
+	basket := append([]catalogdomain.ProductID{}, products...)
+	sort.Slice(basket, func(i, j int) bool { return basket[i] < basket[j] })
+
+	unique := basket[:0]
+	for i, id := range basket {
+		if i == 0 || id != basket[i-1] {
+			unique = append(unique, id)
+		}
+	}
+
+	c.orders++
+	for i, a := range unique {
+		c.items[a]++
+		for j := i + 1; j < len(unique); j++ {
+			c.pairs[[2]catalogdomain.ProductID{a, unique[j]}]++
+			if c.maxSize < 3 {
+				continue
+			}
+			for k := j + 1; k < len(unique); k++ {
+				c.triples[[3]catalogdomain.ProductID{a, unique[j], unique[k]}]++
+			}
+		}
+	}
+}
+
+// Merge ajoute les compteurs de other (même maxSize attendu)
+func (c *ItemsetCounts) Merge(other *ItemsetCounts) {
+	c.orders += other.orders
+	for id, n := range other.items {
+		c.items[id] += n
+	}
+	for pair, n := range other.pairs {
+		c.pairs[pair] += n
+	}
+	for triple, n := range other.triples {
+		c.triples[triple] += n
+	}
+}
+
+// Orders retourne le nombre de paniers comptés
+func (c *ItemsetCounts) Orders() int {
+	return c.orders
+}
+
+// MaxSize retourne la taille maximale des ensembles comptés
+func (c *ItemsetCounts) MaxSize() int {
+	return c.maxSize
+}
+
+// ProductIDs retourne les produits vus au moins une fois (pour charger leurs noms)
+func (c *ItemsetCounts) ProductIDs() []catalogdomain.ProductID {
+	ids := make([]catalogdomain.ProductID, 0, len(c.items))
+	for id := range c.items {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// RuleSort critère de tri des règles d'association
+type RuleSort string
+
+const (
+	RuleSortLift       RuleSort = "lift"
+	RuleSortConfidence RuleSort = "confidence"
+	RuleSortSupport    RuleSort = "support"
+)
+
+// ParseRuleSort convertit un paramètre utilisateur en RuleSort (lift par défaut)
+func ParseRuleSort(value string) (RuleSort, error) {
+	switch RuleSort(value) {
+	case "", RuleSortLift:
+		return RuleSortLift, nil
+	case RuleSortConfidence:
+		return RuleSortConfidence, nil
+	case RuleSortSupport:
+		return RuleSortSupport, nil
+	default:
+		return "", domain.NewValidationError("invalid_sort", fmt.Sprintf("unsupported sort: %q (expected lift, confidence or support)", value))
+	}
+}
+
+// BasketProduct produit d'une règle (identifiant et nom)
+type BasketProduct struct {
+	id   catalogdomain.ProductID
+	name string
+}
+
+// ID retourne l'identifiant du produit
+func (p BasketProduct) ID() catalogdomain.ProductID {
+	return p.id
+}
+
+// Name retourne le nom du produit
+func (p BasketProduct) Name() string {
+	return p.name
+}
+
+// AssociationRule règle "antécédent → conséquent" et ses indicateurs
+type AssociationRule struct {
+	antecedent []BasketProduct
+	consequent BasketProduct
+	orderCount int
+	support    float64
+	confidence float64
+	lift       float64
+}
+
+// Antecedent retourne le ou les produits déjà dans le panier
+func (r *AssociationRule) Antecedent() []BasketProduct {
+	return append([]BasketProduct{}, r.antecedent...)
+}
+
+// Consequent retourne le produit recommandé
+func (r *AssociationRule) Consequent() BasketProduct {
+	return r.consequent
+}
+
+// OrderCount retourne le nombre de commandes contenant tous les produits de la règle
+func (r *AssociationRule) OrderCount() int {
+	return r.orderCount
+}
+
+// Support retourne la part des commandes contenant tous les produits de la règle (0-1)
+func (r *AssociationRule) Support() float64 {
+	return r.support
+}
+
+// Confidence retourne P(conséquent | antécédent) (0-1)
+func (r *AssociationRule) Confidence() float64 {
+	return r.confidence
+}
+
+// Lift retourne confidence / support(conséquent) (1 = indépendance)
+func (r *AssociationRule) Lift() float64 {
+	return r.lift
+}
+
+// BasketAnalysis compteurs d'une période, d'où les règles sont dérivées à la demande
+//
+// MÉMOIRE: on conserve les compteurs (quelques Mo), pas les règles:
+//   - 100 produits = 4 950 couples et 161 700 triplets possibles, 3 règles par triplet
+//   - Les règles d'un produit ou le top N se recalculent en quelques ms à partir des compteurs
+type BasketAnalysis struct {
+	dateRange domain.DateRange
+	counts    *ItemsetCounts
+	names     map[catalogdomain.ProductID]string
+}
+
+// NewBasketAnalysis crée l'analyse en écartant les ensembles vus dans moins de MinItemsetOrders commandes
+// names associe chaque produit à son nom (produits inconnus: nom vide)
+// counts est élagué sur place: il ne doit plus être modifié ensuite
+func NewBasketAnalysis(
+	dateRange domain.DateRange,
+	counts *ItemsetCounts,
+	names map[catalogdomain.ProductID]string,
+) *BasketAnalysis {
+	for pair, n := range counts.pairs {
+		if n < MinItemsetOrders {
+			delete(counts.pairs, pair)
+		}
+	}
+	for triple, n := range counts.triples {
+		if n < MinItemsetOrders {
+			delete(counts.triples, triple)
+		}
+	}
+
+	return &BasketAnalysis{
+		dateRange: dateRange,
+		counts:    counts,
+		names:     names,
+	}
+}
+
+// DateRange retourne la période analysée
+func (a *BasketAnalysis) DateRange() domain.DateRange {
+	return a.dateRange
+}
+
+// TotalOrders retourne le nombre de commandes analysées
+func (a *BasketAnalysis) TotalOrders() int {
+	return a.counts.orders
+}
+
+// IncludesTriples indique si les triplets ont été comptés
+func (a *BasketAnalysis) IncludesTriples() bool {
+	return a.counts.maxSize == 3
+}
+
+// RulesFor retourne les règles dont l'antécédent contient productID
+// ("les clients qui achètent ce produit achètent aussi...")
+// minSupport filtre les ensembles trop rares (0 = aucun filtre), limit borne le résultat
+func (a *BasketAnalysis) RulesFor(
+	productID catalogdomain.ProductID,
+	minSupport float64,
+	sortBy RuleSort,
+	limit int,
+) []*AssociationRule {
+	var rules []*AssociationRule
+
+	for pair, n := range a.counts.pairs {
+		switch productID {
+		case pair[0]:
+			rules = a.appendRule(rules, n, minSupport, pair[1], pair[0])
+		case pair[1]:
+			rules = a.appendRule(rules, n, minSupport, pair[0], pair[1])
+		}
+	}
+	for triple, n := range a.counts.triples {
+		for i, consequent := range triple {
+			antecedent := withoutIndex(triple, i)
+			if antecedent[0] == productID || antecedent[1] == productID {
+				rules = a.appendRule(rules, n, minSupport, consequent, antecedent[0], antecedent[1])
+			}
+		}
+	}
+
+	return sortAndLimitRules(rules, sortBy, limit)
+}
+
+// TopRules retourne les meilleures règles de la période, tous produits confondus
+// Chaque couple donne 2 règles (A → B et B → A), chaque triplet 3 règles
+func (a *BasketAnalysis) TopRules(minSupport float64, sortBy RuleSort, limit int) []*AssociationRule {
+	rules := make([]*AssociationRule, 0, 2*len(a.counts.pairs)+3*len(a.counts.triples))
+
+	for pair, n := range a.counts.pairs {
+		rules = a.appendRule(rules, n, minSupport, pair[1], pair[0])
+		rules = a.appendRule(rules, n, minSupport, pair[0], pair[1])
+	}
+	for triple, n := range a.counts.triples {
+		for i, consequent := range triple {
+			antecedent := withoutIndex(triple, i)
+			rules = a.appendRule(rules, n, minSupport, consequent, antecedent[0], antecedent[1])
+		}
+	}
+
+	return sortAndLimitRules(rules, sortBy, limit)
+}
+
+// appendRule calcule la règle antecedent → consequent pour un ensemble vu dans n commandes
+// et l'ajoute à rules si son support atteint minSupport
+func (a *BasketAnalysis) appendRule(
+	rules []*AssociationRule,
+	n int,
+	minSupport float64,
+	consequent catalogdomain.ProductID,
+	antecedent ...catalogdomain.ProductID,
+) []*AssociationRule {
+	orders := float64(a.counts.orders)
+	support := float64(n) / orders
+	if support < minSupport {
+		return rules
+	}
+
+	var antecedentCount int
+	if len(antecedent) == 1 {
+		antecedentCount = a.counts.items[antecedent[0]]
+	} else {
+		antecedentCount = a.counts.pairs[[2]catalogdomain.ProductID{antecedent[0], antecedent[1]}]
+	}
+	// Antécédent écarté par MinItemsetOrders: impossible pour un triplet retenu
+	// (un couple est au moins aussi fréquent que les triplets qui le contiennent)
+	if antecedentCount == 0 {
+		return rules
+	}
+
+	confidence := float64(n) / float64(antecedentCount)
+	rule := &AssociationRule{
+		consequent: a.product(consequent),
+		orderCount: n,
+		support:    support,
+		confidence: confidence,
+		lift:       confidence / (float64(a.counts.items[consequent]) / orders),
+	}
+	for _, id := range antecedent {
+		rule.antecedent = append(rule.antecedent, a.product(id))
+	}
+
+	return append(rules, rule)
+}
+
+func (a *BasketAnalysis) product(id catalogdomain.ProductID) BasketProduct {
+	return BasketProduct{id: id, name: a.names[id]}
+}
+
+// withoutIndex retourne les deux produits d'un triplet autres que triple[i] (ordre conservé)
+func withoutIndex(triple [3]catalogdomain.ProductID, i int) [2]catalogdomain.ProductID {
+	switch i {
+	case 0:
+		return [2]catalogdomain.ProductID{triple[1], triple[2]}
+	case 1:
+		return [2]catalogdomain.ProductID{triple[0], triple[2]}
+	default:
+		return [2]catalogdomain.ProductID{triple[0], triple[1]}
+	}
+}
+
+// sortAndLimitRules trie par critère décroissant, puis par identifiants pour un ordre stable
+// PIÈGE: l'itération sur une map Go est aléatoire, sans départage deux appels donneraient deux ordres
+func sortAndLimitRules(rules []*AssociationRule, sortBy RuleSort, limit int) []*AssociationRule {
+	metric := func(r *AssociationRule) float64 {
+		switch sortBy {
+		case RuleSortConfidence:
+			return r.confidence
+		case RuleSortSupport:
+			return r.support
+		default:
+			return r.lift
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		ri, rj := rules[i], rules[j]
+		if mi, mj := metric(ri), metric(rj); mi != mj {
+			return mi > mj
+		}
+		if ri.orderCount != rj.orderCount {
+			return ri.orderCount > rj.orderCount
+		}
+		if len(ri.antecedent) != len(rj.antecedent) {
+			return len(ri.antecedent) < len(rj.antecedent)
+		}
+		for k := range ri.antecedent {
+			if ri.antecedent[k].id != rj.antecedent[k].id {
+				return ri.antecedent[k].id < rj.antecedent[k].id
+			}
+		}
+		return ri.consequent.id < rj.consequent.id
+	})
+
+	if limit > 0 && len(rules) > limit {
+		rules = rules[:limit]
+	}
+	return rules
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"math"
+	"testing"
+
+	catalogdomain "eval/internal/catalog/domain"
+	"eval/internal/shared/domain"
+)
+
+// TestBasketAnalysis_Rules vérifie support, confidence et lift sur 4 paniers
+//
+//	{1, 2, 3}  {1, 2}  {1, 2, 3}  {3, 4}
+func TestBasketAnalysis_Rules(t *testing.T) {
+	counts, err := NewItemsetCounts(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Les paniers sont répartis sur deux compteurs fusionnés, comme entre deux workers
+	other, _ := NewItemsetCounts(3)
+	counts.AddBasket([]catalogdomain.ProductID{3, 1, 2})
+	counts.AddBasket([]catalogdomain.ProductID{1, 2, 2}) // doublon: une seule occurrence de 2
+	other.AddBasket([]catalogdomain.ProductID{1, 2, 3})
+	other.AddBasket([]catalogdomain.ProductID{3, 4})
+	counts.Merge(other)
+
+	dateRange, _ := domain.NewDateRangeFromDays(30)
+	analysis := NewBasketAnalysis(dateRange, counts, map[catalogdomain.ProductID]string{1: "Laptop", 2: "Souris"})
+	if analysis.TotalOrders() != 4 {
+		t.Fatalf("orders = %d, want 4", analysis.TotalOrders())
+	}
+
+	// Produit 1: 1 → 2 (3 commandes), 1 → 3 (2 commandes), {1,2} → 3 et {1,3} → 2 (2 commandes)
+	// {3, 4} n'apparaît qu'une fois: écarté par MinItemsetOrders
+	rules := analysis.RulesFor(1, 0, RuleSortConfidence, 0)
+	if len(rules) != 4 {
+		t.Fatalf("rules for product 1 = %d, want 4", len(rules))
+	}
+
+	best := rules[0]
+	if best.Consequent().ID() != 2 || best.Consequent().Name() != "Souris" || len(best.Antecedent()) != 1 {
+		t.Fatalf("best rule = %v → %d, want 1 → 2", best.Antecedent(), best.Consequent().ID())
+	}
+	// support = 3/4, confidence = 3/3, lift = 1 / (3/4)
+	if best.Support() != 0.75 || best.Confidence() != 1 || math.Abs(best.Lift()-4.0/3) > 1e-9 {
+		t.Errorf("1 → 2 = support %v, confidence %v, lift %v", best.Support(), best.Confidence(), best.Lift())
+	}
+
+	// {1,3} → 2: confidence = 2/2
+	for _, r := range rules {
+		if len(r.Antecedent()) == 2 && r.Consequent().ID() == 2 && r.Confidence() != 1 {
+			t.Errorf("{1,3} → 2 confidence = %v, want 1", r.Confidence())
+		}
+	}
+
+	// minSupport: seule la règle 1 → 2 (75%) dépasse 60%
+	if got := analysis.RulesFor(1, 0.6, RuleSortLift, 0); len(got) != 1 {
+		t.Errorf("rules with support >= 0.6 = %d, want 1", len(got))
+	}
+
+	// Top: couples {1,2} et {1,3}, {2,3} dans les deux sens + 3 règles du triplet
+	if got := analysis.TopRules(0, RuleSortSupport, 0); len(got) != 9 {
+		t.Errorf("top rules = %d, want 9", len(got))
+	}
+	if got := analysis.TopRules(0, RuleSortLift, 2); len(got) != 2 {
+		t.Errorf("limited top rules = %d, want 2", len(got))
+	}
+}
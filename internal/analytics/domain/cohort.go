@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// ========================================
+// COHORTES D'ACQUISITION MENSUELLES
+// ========================================
+// Une cohorte regroupe les clients dont la PREMIÈRE commande (sur tout l'historique)
+// tombe dans le même mois. Pour chaque mois suivant (offset 0, 1, 2, ...), on mesure:
+//   - la rétention: part des clients de la cohorte ayant recommandé ce mois-là
+//   - le CA par client: CA du mois / taille de la cohorte
+//
+// Le résultat est une matrice triangulaire: la cohorte de janvier a 12 mois observables
+// sur une année, celle de décembre un seul.
+
+// CohortActivity agrégat brut d'une cohorte pour un mois d'activité (une ligne du GROUP BY SQL)
+type CohortActivity struct {
+	cohortMonth     time.Time
+	monthOffset     int
+	activeCustomers int
+	revenue         domain.Money
+}
+
+// NewCohortActivity crée l'activité d'une cohorte monthOffset mois après son acquisition
+func NewCohortActivity(cohortMonth time.Time, monthOffset, activeCustomers int, revenue domain.Money) *CohortActivity {
+	return &CohortActivity{
+		cohortMonth:     cohortMonth,
+		monthOffset:     monthOffset,
+		activeCustomers: activeCustomers,
+		revenue:         revenue,
+	}
+}
+
+// CohortCell une case de la matrice: une cohorte, un mois après acquisition
+type CohortCell struct {
+	monthOffset                  int
+	month                        time.Time
+	activeCustomers              int
+	retentionRate                float64
+	revenue                      domain.Money
+	revenuePerCustomer           domain.Money
+	cumulativeRevenuePerCustomer domain.Money
+}
+
+// MonthOffset retourne le nombre de mois depuis l'acquisition (0 = mois d'acquisition)
+func (c *CohortCell) MonthOffset() int {
+	return c.monthOffset
+}
+
+// Month retourne le mois calendaire de la case (1er du mois)
+func (c *CohortCell) Month() time.Time {
+	return c.month
+}
+
+// ActiveCustomers retourne le nombre de clients de la cohorte ayant commandé ce mois-là
+func (c *CohortCell) ActiveCustomers() int {
+	return c.activeCustomers
+}
+
+// RetentionRate retourne la part de la cohorte active ce mois-là (en %)
+func (c *CohortCell) RetentionRate() float64 {
+	return c.retentionRate
+}
+
+// Revenue retourne le CA réalisé par la cohorte ce mois-là
+func (c *CohortCell) Revenue() domain.Money {
+	return c.revenue
+}
+
+// RevenuePerCustomer retourne le CA du mois rapporté à la taille de la cohorte
+func (c *CohortCell) RevenuePerCustomer() domain.Money {
+	return c.revenuePerCustomer
+}
+
+// CumulativeRevenuePerCustomer retourne le CA par client cumulé depuis l'acquisition
+func (c *CohortCell) CumulativeRevenuePerCustomer() domain.Money {
+	return c.cumulativeRevenuePerCustomer
+}
+
+// Cohort une ligne de la matrice: les clients acquis un mois donné
+type Cohort struct {
+	month        time.Time
+	size         int
+	totalRevenue domain.Money
+	cells        []*CohortCell
+}
+
+// Month retourne le mois d'acquisition (1er du mois)
+func (c *Cohort) Month() time.Time {
+	return c.month
+}
+
+// Size retourne le nombre de clients acquis ce mois-là
+func (c *Cohort) Size() int {
+	return c.size
+}
+
+// TotalRevenue retourne le CA total de la cohorte sur les mois observés
+func (c *Cohort) TotalRevenue() domain.Money {
+	return c.totalRevenue
+}
+
+// Cells retourne les mois observés, de l'offset 0 jusqu'à la fin de la période
+func (c *Cohort) Cells() []*CohortCell {
+	return append([]*CohortCell{}, c.cells...)
+}
+
+// CohortAnalysis matrice de rétention des cohortes acquises sur une période
+type CohortAnalysis struct {
+	dateRange domain.DateRange
+	cohorts   []*Cohort
+}
+
+// NewCohortAnalysis construit la matrice triangulaire à partir des lignes SQL
+// activity ne contient que les couples (cohorte, offset) ayant des commandes:
+//   - chaque mois de la période a sa cohorte, vide si aucun client n'y a été acquis
+//   - chaque cohorte a une case par mois jusqu'à la fin de la période, à zéro si personne n'a commandé
+//
+// La taille d'une cohorte est le nombre de clients actifs à l'offset 0 (mois de la première commande)
+// Les montants sont exprimés dans currency (devise de reporting), y compris les cases vides
+//
+// PERFORMANCE: map indexée par "2006-01" puis offset → O(n) (même approche que NewTimeSeries)
+func NewCohortAnalysis(
+	dateRange domain.DateRange,
+	currency domain.Currency,
+	activity []*CohortActivity,
+) *CohortAnalysis {
+	const keyLayout = "2006-01"
+
+	byCohort := make(map[string]map[int]*CohortActivity)
+	for _, a := range activity {
+		key := a.cohortMonth.Format(keyLayout)
+		if byCohort[key] == nil {
+			byCohort[key] = make(map[int]*CohortActivity)
+		}
+		byCohort[key][a.monthOffset] = a
+	}
+
+	zero := currency.Zero()
+	lastMonth := GranularityMonth.BucketStart(dateRange.End())
+
+	var cohorts []*Cohort
+	for month := GranularityMonth.BucketStart(dateRange.Start()); !month.After(lastMonth); month = month.AddDate(0, 1, 0) {
+		offsets := byCohort[month.Format(keyLayout)]
+
+		cohort := &Cohort{month: month}
+		if first, ok := offsets[0]; ok {
+			cohort.size = first.activeCustomers
+		}
+
+		total := zero
+		var cumulativePerCustomer float64
+		for offset := 0; offset <= monthsBetween(month, lastMonth); offset++ {
+			cell := &CohortCell{
+				monthOffset:        offset,
+				month:              month.AddDate(0, offset, 0),
+				revenue:            zero,
+				revenuePerCustomer: zero,
+			}
+
+			if a, ok := offsets[offset]; ok {
+				cell.activeCustomers = a.activeCustomers
+				cell.revenue = a.revenue
+				total, _ = total.Add(a.revenue)
+			}
+			if cohort.size > 0 {
+				perCustomer := cell.revenue.Amount() / float64(cohort.size)
+				cumulativePerCustomer += perCustomer
+				cell.retentionRate = float64(cell.activeCustomers) / float64(cohort.size) * 100
+				cell.revenuePerCustomer, _ = domain.NewMoney(perCustomer, currency.String())
+			}
+			cell.cumulativeRevenuePerCustomer, _ = domain.NewMoney(cumulativePerCustomer, currency.String())
+
+			cohort.cells = append(cohort.cells, cell)
+		}
+		cohort.totalRevenue = total
+
+		cohorts = append(cohorts, cohort)
+	}
+
+	return &CohortAnalysis{
+		dateRange: dateRange,
+		cohorts:   cohorts,
+	}
+}
+
+// monthsBetween retourne le nombre de mois calendaires entre deux débuts de mois
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+}
+
+// DateRange retourne la période d'acquisition analysée
+func (a *CohortAnalysis) DateRange() domain.DateRange {
+	return a.dateRange
+}
+
+// Cohorts retourne les cohortes dans l'ordre chronologique
+func (a *CohortAnalysis) Cohorts() []*Cohort {
+	return append([]*Cohort{}, a.cohorts...)
+}
+
+// TotalCustomers retourne le nombre de clients acquis sur la période
+func (a *CohortAnalysis) TotalCustomers() int {
+	total := 0
+	for _, c := range a.cohorts {
+		total += c.size
+	}
+	return total
+}
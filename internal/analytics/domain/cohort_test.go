@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// TestNewCohortAnalysis_Triangle vérifie la forme triangulaire et le remplissage à zéro
+func TestNewCohortAnalysis_Triangle(t *testing.T) {
+	dateRange, err := domain.NewDateRange(
+		time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	money := func(amount float64) domain.Money {
+		m, _ := domain.NewMoney(amount, "EUR")
+		return m
+	}
+	january := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	analysis := NewCohortAnalysis(dateRange, domain.DefaultCurrency, []*CohortActivity{
+		NewCohortActivity(january, 0, 4, money(400)),
+		NewCohortActivity(january, 2, 1, money(50)),
+		NewCohortActivity(march, 0, 2, money(100)),
+	})
+
+	cohorts := analysis.Cohorts()
+	if len(cohorts) != 3 {
+		t.Fatalf("cohorts = %d, want 3 (janvier à mars)", len(cohorts))
+	}
+	if analysis.TotalCustomers() != 6 {
+		t.Errorf("total customers = %d, want 6", analysis.TotalCustomers())
+	}
+
+	// Triangle: 3 mois observables pour janvier, 2 pour février, 1 pour mars
+	for i, want := range []int{3, 2, 1} {
+		if got := len(cohorts[i].Cells()); got != want {
+			t.Errorf("cohort %s cells = %d, want %d", cohorts[i].Month().Format("2006-01"), got, want)
+		}
+	}
+
+	jan := cohorts[0].Cells()
+	if jan[0].RetentionRate() != 100 || jan[1].ActiveCustomers() != 0 || jan[2].RetentionRate() != 25 {
+		t.Errorf("january retention = %v / %v / %v, want 100 / 0 / 25",
+			jan[0].RetentionRate(), jan[1].RetentionRate(), jan[2].RetentionRate())
+	}
+	if jan[2].RevenuePerCustomer().Amount() != 12.5 || jan[2].CumulativeRevenuePerCustomer().Amount() != 112.5 {
+		t.Errorf("january M+2 revenue per customer = %v (cumulative %v), want 12.5 (112.5)",
+			jan[2].RevenuePerCustomer().Amount(), jan[2].CumulativeRevenuePerCustomer().Amount())
+	}
+	if !jan[2].Month().Equal(march) || cohorts[0].TotalRevenue().Amount() != 450 {
+		t.Errorf("january M+2 = %s, total revenue = %v", jan[2].Month().Format("2006-01"), cohorts[0].TotalRevenue().Amount())
+	}
+
+	feb := cohorts[1]
+	if feb.Size() != 0 || feb.Cells()[0].RetentionRate() != 0 {
+		t.Errorf("empty february cohort = %d customers / %v%%", feb.Size(), feb.Cells()[0].RetentionRate())
+	}
+}
@@ -0,0 +1,364 @@
+package domain
+
+import (
+	"eval/internal/shared/domain"
+)
+
+// ComparisonBaseline identifie la période de référence d'une comparaison
+type ComparisonBaseline string
+
+const (
+	// BaselinePreviousPeriod période de même durée juste avant (mois précédent, trimestre précédent, ...)
+	BaselinePreviousPeriod ComparisonBaseline = "previous_period"
+	// BaselineSamePeriodLastYear même période un an plus tôt (N-1)
+	BaselineSamePeriodLastYear ComparisonBaseline = "same_period_last_year"
+)
+
+// RankingStatus situe une entrée de classement par rapport au classement de référence
+type RankingStatus string
+
+const (
+	// RankingStatusKept entrée présente dans les deux classements
+	RankingStatusKept RankingStatus = "kept"
+	// RankingStatusNew entrée absente d'un classement de référence complet: aucune vente sur la période de référence
+	RankingStatusNew RankingStatus = "new"
+	// RankingStatusEnteredTop entrée absente d'un top N de référence tronqué:
+	// elle a pu vendre sur la période de référence, sous la N-ième place (rang et valeurs de référence inconnus)
+	RankingStatusEnteredTop RankingStatus = "entered_top"
+	// RankingStatusLeftTop entrée du top N de référence absente du classement courant
+	RankingStatusLeftTop RankingStatus = "left_top"
+)
+
+// MetricDelta représente une valeur comparée à sa valeur de référence
+// DESIGN PATTERN: Value Object (immutable)
+type MetricDelta struct {
+	current  float64
+	previous float64
+}
+
+// NewMetricDelta crée une comparaison valeur courante / valeur de référence
+func NewMetricDelta(current, previous float64) MetricDelta {
+	return MetricDelta{current: current, previous: previous}
+}
+
+// Current retourne la valeur sur la période courante
+func (d MetricDelta) Current() float64 {
+	return d.current
+}
+
+// Previous retourne la valeur sur la période de référence
+func (d MetricDelta) Previous() float64 {
+	return d.previous
+}
+
+// Absolute retourne l'écart absolu (courant - référence)
+func (d MetricDelta) Absolute() float64 {
+	return d.current - d.previous
+}
+
+// Percent retourne l'écart relatif en pourcentage
+// ok = false si la référence vaut 0: une progression depuis zéro n'a pas de pourcentage
+// (l'afficher comme +100% ou +Inf% induirait le lecteur en erreur)
+func (d MetricDelta) Percent() (percent float64, ok bool) {
+	if d.previous == 0 {
+		return 0, false
+	}
+	return (d.current - d.previous) / d.previous * 100, true
+}
+
+// RankedDelta représente une entrée de classement (catégorie, produit, magasin, moyen de paiement)
+// comparée à sa position et son CA sur la période de référence
+type RankedDelta struct {
+	id           int64
+	name         string
+	rank         int
+	previousRank int
+	status       RankingStatus
+	revenue      MetricDelta
+	orders       MetricDelta
+}
+
+// ID retourne l'identifiant de l'entrée classée
+func (rd *RankedDelta) ID() int64 {
+	return rd.id
+}
+
+// Name retourne le libellé de l'entrée classée
+func (rd *RankedDelta) Name() string {
+	return rd.name
+}
+
+// Rank retourne la position sur la période courante (1 = premier)
+func (rd *RankedDelta) Rank() int {
+	return rd.rank
+}
+
+// PreviousRank retourne la position sur la période de référence (0 = absent du classement)
+func (rd *RankedDelta) PreviousRank() int {
+	return rd.previousRank
+}
+
+// RankChange retourne le nombre de places gagnées (positif) ou perdues (négatif)
+// 0 pour une entrée absente de l'un des deux classements (voir Status)
+func (rd *RankedDelta) RankChange() int {
+	if rd.previousRank == 0 || rd.rank == 0 {
+		return 0
+	}
+	return rd.previousRank - rd.rank
+}
+
+// Status situe l'entrée par rapport au classement de référence
+func (rd *RankedDelta) Status() RankingStatus {
+	return rd.status
+}
+
+// IsNewEntry vérifie si l'entrée n'a rien vendu sur la période de référence
+// PIÈGE: une entrée absente d'un top N tronqué n'est pas nouvelle (RankingStatusEnteredTop)
+func (rd *RankedDelta) IsNewEntry() bool {
+	return rd.status == RankingStatusNew
+}
+
+// Revenue retourne la comparaison du CA
+func (rd *RankedDelta) Revenue() MetricDelta {
+	return rd.revenue
+}
+
+// Orders retourne la comparaison du nombre de commandes
+func (rd *RankedDelta) Orders() MetricDelta {
+	return rd.orders
+}
+
+// PeriodComparison compare les stats courantes à une période de référence
+type PeriodComparison struct {
+	baseline          ComparisonBaseline
+	dateRange         domain.DateRange
+	totalRevenue      MetricDelta
+	totalOrders       MetricDelta
+	averageOrderValue MetricDelta
+	categories        []*RankedDelta
+	topProducts       []*RankedDelta
+	topStores         []*RankedDelta
+	leftTopProducts   []*RankedDelta
+	leftTopStores     []*RankedDelta
+	paymentMethods    []*RankedDelta
+}
+
+// NewPeriodComparison calcule les écarts entre current et les stats de la période de référence
+func NewPeriodComparison(
+	baseline ComparisonBaseline,
+	dateRange domain.DateRange,
+	current *Stats,
+	reference *Stats,
+) *PeriodComparison {
+	return &PeriodComparison{
+		baseline:          baseline,
+		dateRange:         dateRange,
+		totalRevenue:      NewMetricDelta(current.TotalRevenue().Amount(), reference.TotalRevenue().Amount()),
+		totalOrders:       NewMetricDelta(float64(current.TotalOrders()), float64(reference.TotalOrders())),
+		averageOrderValue: NewMetricDelta(current.AverageOrderValue().Amount(), reference.AverageOrderValue().Amount()),
+		categories:        compareRankings(categoryEntries(current.CategoryStats()), categoryEntries(reference.CategoryStats()), 0),
+		topProducts:       compareRankings(productEntries(current.TopProducts()), productEntries(reference.TopProducts()), TopProductsLimit),
+		topStores:         compareRankings(storeEntries(current.TopStores()), storeEntries(reference.TopStores()), TopStoresLimit),
+		leftTopProducts:   leftRankings(productEntries(current.TopProducts()), productEntries(reference.TopProducts())),
+		leftTopStores:     leftRankings(storeEntries(current.TopStores()), storeEntries(reference.TopStores())),
+		paymentMethods:    compareRankings(paymentEntries(current.PaymentDistribution()), paymentEntries(reference.PaymentDistribution()), 0),
+	}
+}
+
+// Baseline retourne le type de période de référence
+func (pc *PeriodComparison) Baseline() ComparisonBaseline {
+	return pc.baseline
+}
+
+// DateRange retourne les bornes de la période de référence
+func (pc *PeriodComparison) DateRange() domain.DateRange {
+	return pc.dateRange
+}
+
+// TotalRevenue retourne la comparaison du CA total
+func (pc *PeriodComparison) TotalRevenue() MetricDelta {
+	return pc.totalRevenue
+}
+
+// TotalOrders retourne la comparaison du nombre de commandes
+func (pc *PeriodComparison) TotalOrders() MetricDelta {
+	return pc.totalOrders
+}
+
+// AverageOrderValue retourne la comparaison du panier moyen
+func (pc *PeriodComparison) AverageOrderValue() MetricDelta {
+	return pc.averageOrderValue
+}
+
+// Categories retourne le classement des catégories avec leur évolution
+func (pc *PeriodComparison) Categories() []*RankedDelta {
+	return append([]*RankedDelta{}, pc.categories...)
+}
+
+// TopProducts retourne le top produits avec son évolution
+func (pc *PeriodComparison) TopProducts() []*RankedDelta {
+	return append([]*RankedDelta{}, pc.topProducts...)
+}
+
+// TopStores retourne le top magasins avec son évolution
+func (pc *PeriodComparison) TopStores() []*RankedDelta {
+	return append([]*RankedDelta{}, pc.topStores...)
+}
+
+// LeftTopProducts retourne les produits du top de référence sortis du top courant
+func (pc *PeriodComparison) LeftTopProducts() []*RankedDelta {
+	return append([]*RankedDelta{}, pc.leftTopProducts...)
+}
+
+// LeftTopStores retourne les magasins du top de référence sortis du top courant
+func (pc *PeriodComparison) LeftTopStores() []*RankedDelta {
+	return append([]*RankedDelta{}, pc.leftTopStores...)
+}
+
+// PaymentMethods retourne la distribution des moyens de paiement avec son évolution
+func (pc *PeriodComparison) PaymentMethods() []*RankedDelta {
+	return append([]*RankedDelta{}, pc.paymentMethods...)
+}
+
+// StatsComparison regroupe les stats courantes et leurs comparaisons
+// (période précédente et même période l'année dernière)
+type StatsComparison struct {
+	current     *Stats
+	dateRange   domain.DateRange
+	comparisons []*PeriodComparison
+}
+
+// NewStatsComparison crée le résultat d'une comparaison de périodes
+func NewStatsComparison(current *Stats, dateRange domain.DateRange, comparisons ...*PeriodComparison) *StatsComparison {
+	return &StatsComparison{
+		current:     current,
+		dateRange:   dateRange,
+		comparisons: comparisons,
+	}
+}
+
+// Current retourne les stats de la période demandée
+func (sc *StatsComparison) Current() *Stats {
+	return sc.current
+}
+
+// DateRange retourne la période demandée
+func (sc *StatsComparison) DateRange() domain.DateRange {
+	return sc.dateRange
+}
+
+// Comparisons retourne les comparaisons dans l'ordre (période précédente, puis N-1)
+func (sc *StatsComparison) Comparisons() []*PeriodComparison {
+	return append([]*PeriodComparison{}, sc.comparisons...)
+}
+
+// rankingEntry vue commune des lignes de classement (catégorie, produit, magasin, paiement)
+type rankingEntry struct {
+	id      int64
+	name    string
+	revenue float64
+	orders  int
+}
+
+// compareRankings associe chaque entrée courante à sa position et ses valeurs de référence
+// Les classements arrivent déjà triés par CA décroissant (ORDER BY SQL): rang = index + 1
+//
+// limit est la taille du top N chargé (0 = classement complet). Une référence qui atteint
+// limit est tronquée: une entrée qui n'y figure pas est "entered_top" et non "new",
+// car elle a pu vendre sous la N-ième place
+//
+// PERFORMANCE: map id → index de la référence, O(n + m) au lieu d'une double boucle O(n × m)
+func compareRankings(current, reference []rankingEntry, limit int) []*RankedDelta {
+	missing := RankingStatusNew
+	if limit > 0 && len(reference) >= limit {
+		missing = RankingStatusEnteredTop
+	}
+
+	referenceIndex := make(map[int64]int, len(reference))
+	for i, e := range reference {
+		referenceIndex[e.id] = i
+	}
+
+	deltas := make([]*RankedDelta, 0, len(current))
+	for i, e := range current {
+		delta := &RankedDelta{
+			id:      e.id,
+			name:    e.name,
+			rank:    i + 1,
+			status:  missing,
+			revenue: NewMetricDelta(e.revenue, 0),
+			orders:  NewMetricDelta(float64(e.orders), 0),
+		}
+		if j, ok := referenceIndex[e.id]; ok {
+			ref := reference[j]
+			delta.previousRank = j + 1
+			delta.status = RankingStatusKept
+			delta.revenue = NewMetricDelta(e.revenue, ref.revenue)
+			delta.orders = NewMetricDelta(float64(e.orders), float64(ref.orders))
+		}
+		deltas = append(deltas, delta)
+	}
+	return deltas
+}
+
+// leftRankings liste les entrées de la référence absentes du classement courant (statut "left_top")
+// Seules les valeurs de référence sont connues: Current() vaut 0, que l'entrée n'ait plus rien
+// vendu ou qu'elle soit simplement passée sous la N-ième place
+func leftRankings(current, reference []rankingEntry) []*RankedDelta {
+	currentIDs := make(map[int64]struct{}, len(current))
+	for _, e := range current {
+		currentIDs[e.id] = struct{}{}
+	}
+
+	left := make([]*RankedDelta, 0)
+	for j, ref := range reference {
+		if _, ok := currentIDs[ref.id]; ok {
+			continue
+		}
+		left = append(left, &RankedDelta{
+			id:           ref.id,
+			name:         ref.name,
+			previousRank: j + 1,
+			status:       RankingStatusLeftTop,
+			revenue:      NewMetricDelta(0, ref.revenue),
+			orders:       NewMetricDelta(0, float64(ref.orders)),
+		})
+	}
+	return left
+}
+
+// categoryEntries convertit les stats catégories en lignes de classement
+func categoryEntries(stats []*CategoryStats) []rankingEntry {
+	entries := make([]rankingEntry, len(stats))
+	for i, s := range stats {
+		entries[i] = rankingEntry{int64(s.CategoryID()), s.CategoryName(), s.TotalRevenue().Amount(), s.TotalOrders()}
+	}
+	return entries
+}
+
+// productEntries convertit les stats produits en lignes de classement
+func productEntries(stats []*ProductStats) []rankingEntry {
+	entries := make([]rankingEntry, len(stats))
+	for i, s := range stats {
+		entries[i] = rankingEntry{int64(s.ProductID()), s.ProductName(), s.TotalRevenue().Amount(), s.TotalOrders()}
+	}
+	return entries
+}
+
+// storeEntries convertit les stats magasins en lignes de classement
+func storeEntries(stats []*StoreStats) []rankingEntry {
+	entries := make([]rankingEntry, len(stats))
+	for i, s := range stats {
+		entries[i] = rankingEntry{int64(s.StoreID()), s.StoreName(), s.TotalRevenue().Amount(), s.TotalOrders()}
+	}
+	return entries
+}
+
+// paymentEntries convertit la distribution des paiements en lignes de classement
+func paymentEntries(stats []*PaymentMethodStats) []rankingEntry {
+	entries := make([]rankingEntry, len(stats))
+	for i, s := range stats {
+		entries[i] = rankingEntry{int64(s.PaymentMethodID()), s.PaymentMethodName(), s.TotalRevenue().Amount(), s.TotalOrders()}
+	}
+	return entries
+}
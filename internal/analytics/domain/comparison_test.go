@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"testing"
+
+	catalogdomain "eval/internal/catalog/domain"
+	ordersdomain "eval/internal/orders/domain"
+	"eval/internal/shared/domain"
+)
+
+// statsWithCategories construit des Stats avec un CA global et un classement de catégories
+func statsWithCategories(revenue float64, orders int, categories ...*CategoryStats) *Stats {
+	stats := NewStats()
+	money, _ := domain.NewMoney(revenue, "EUR")
+	stats.SetTotalRevenue(money)
+	stats.SetTotalOrders(orders)
+	stats.SetCategoryStats(categories)
+	return stats
+}
+
+func category(id int64, name string, revenue float64) *CategoryStats {
+	money, _ := domain.NewMoney(revenue, "EUR")
+	return NewCategoryStats(catalogdomain.CategoryID(id), name, money, 1)
+}
+
+// TestNewPeriodComparison vérifie les écarts de KPI et le mouvement dans les classements
+func TestNewPeriodComparison(t *testing.T) {
+	current := statsWithCategories(1200, 12,
+		category(2, "Books", 700),
+		category(1, "Electronics", 400),
+		category(3, "Toys", 100),
+	)
+	previous := statsWithCategories(1000, 10,
+		category(1, "Electronics", 600),
+		category(2, "Books", 400),
+	)
+
+	dateRange, _ := domain.NewDateRangeFromDays(30)
+	pc := NewPeriodComparison(BaselinePreviousPeriod, dateRange.Previous(), current, previous)
+
+	if got := pc.TotalRevenue().Absolute(); got != 200 {
+		t.Errorf("revenue delta = %v, want 200", got)
+	}
+	if pct, ok := pc.TotalRevenue().Percent(); !ok || pct != 20 {
+		t.Errorf("revenue delta pct = %v (%v), want 20", pct, ok)
+	}
+	if _, ok := pc.AverageOrderValue().Percent(); ok {
+		t.Error("delta pct from a zero baseline should be undefined")
+	}
+
+	categories := pc.Categories()
+	if categories[0].Name() != "Books" || categories[0].RankChange() != 1 {
+		t.Errorf("Books rank change = %d, want +1", categories[0].RankChange())
+	}
+	if categories[1].RankChange() != -1 {
+		t.Errorf("Electronics rank change = %d, want -1", categories[1].RankChange())
+	}
+	if !categories[2].IsNewEntry() || categories[2].Revenue().Previous() != 0 {
+		t.Error("Toys should be a new entry with no previous revenue")
+	}
+	if categories[2].Status() != RankingStatusNew || categories[0].Status() != RankingStatusKept {
+		t.Errorf("statuses = %s, %s, want new, kept", categories[2].Status(), categories[0].Status())
+	}
+}
+
+// TestNewPeriodComparison_TruncatedTop vérifie qu'une entrée absente d'un top N de référence
+// complet (N lignes) entre dans le top au lieu d'être nouvelle, et que la sortante est listée
+func TestNewPeriodComparison_TruncatedTop(t *testing.T) {
+	store := func(id int64, name string, revenue float64) *StoreStats {
+		money, _ := domain.NewMoney(revenue, "EUR")
+		return NewStoreStats(ordersdomain.StoreID(id), name, money, 1)
+	}
+
+	reference := NewStats()
+	current := NewStats()
+	previousTop := make([]*StoreStats, 0, TopStoresLimit)
+	currentTop := make([]*StoreStats, 0, TopStoresLimit)
+	for i := int64(1); i <= TopStoresLimit; i++ {
+		previousTop = append(previousTop, store(i, "Magasin", float64(1000-i)))
+	}
+	currentTop = append(currentTop, store(99, "Magasin Nice", 2000))
+	currentTop = append(currentTop, previousTop[:TopStoresLimit-1]...)
+	reference.SetTopStores(previousTop)
+	current.SetTopStores(currentTop)
+
+	dateRange, _ := domain.NewDateRangeFromDays(30)
+	pc := NewPeriodComparison(BaselinePreviousPeriod, dateRange.Previous(), current, reference)
+
+	nice := pc.TopStores()[0]
+	if nice.Status() != RankingStatusEnteredTop || nice.IsNewEntry() || nice.PreviousRank() != 0 {
+		t.Errorf("Nice = status %s, new entry %v, previous rank %d, want entered_top", nice.Status(), nice.IsNewEntry(), nice.PreviousRank())
+	}
+	if kept := pc.TopStores()[1]; kept.Status() != RankingStatusKept || kept.RankChange() != -1 {
+		t.Errorf("store 1 = status %s, rank change %d, want kept, -1", kept.Status(), kept.RankChange())
+	}
+
+	left := pc.LeftTopStores()
+	if len(left) != 1 || left[0].ID() != TopStoresLimit || left[0].Status() != RankingStatusLeftTop ||
+		left[0].PreviousRank() != TopStoresLimit || left[0].Revenue().Previous() != 1000-TopStoresLimit {
+		t.Errorf("left top stores = %+v, want store %d leaving from rank %d", left, TopStoresLimit, TopStoresLimit)
+	}
+	if len(pc.LeftTopProducts()) != 0 {
+		t.Errorf("left top products = %d, want 0", len(pc.LeftTopProducts()))
+	}
+}
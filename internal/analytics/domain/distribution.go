@@ -0,0 +1,362 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"eval/internal/shared/domain"
+)
+
+// ========================================
+// DISTRIBUTION DES MONTANTS DE COMMANDE
+// ========================================
+// Le panier moyen cache l'asymétrie: quelques grosses commandes B2B suffisent à le tirer
+// au-dessus de ce que paie un client "typique". Médiane et percentiles (p90, p95, p99)
+// décrivent la forme de la distribution, l'histogramme la montre
+
+// MaxHistogramBoundaries nombre maximum de bornes d'histogramme
+const MaxHistogramBoundaries = 50
+
+// MaxItemsPerOrderBucket à partir de ce nombre d'articles, les commandes sont regroupées ("10+")
+const MaxItemsPerOrderBucket = 10
+
+// HistogramBoundaries bornes des buckets d'un histogramme de montants
+// Bornes b1 < b2 < ... < bn: buckets [0, b1), [b1, b2), ..., [bn, +∞)
+type HistogramBoundaries struct {
+	values []float64
+}
+
+// DefaultHistogramBoundaries bornes par défaut (€), resserrées sur les petits paniers
+func DefaultHistogramBoundaries() HistogramBoundaries {
+	return HistogramBoundaries{values: []float64{25, 50, 100, 250, 500, 1000, 2500}}
+}
+
+// NewHistogramBoundaries valide des bornes strictement positives et strictement croissantes
+func NewHistogramBoundaries(values []float64) (HistogramBoundaries, error) {
+	if len(values) == 0 || len(values) > MaxHistogramBoundaries {
+		return HistogramBoundaries{}, domain.NewValidationError("invalid_buckets", fmt.Sprintf("between 1 and %d histogram boundaries required, got %d",
+			MaxHistogramBoundaries, len(values)))
+	}
+	for i, v := range values {
+		if v <= 0 {
+			return HistogramBoundaries{}, domain.NewValidationError("invalid_buckets", fmt.Sprintf("histogram boundaries must be positive, got %v", v))
+		}
+		if i > 0 && v <= values[i-1] {
+			return HistogramBoundaries{}, domain.NewValidationError("invalid_buckets",
+				fmt.Sprintf("histogram boundaries must be strictly increasing (%v after %v)", v, values[i-1]))
+		}
+	}
+	return HistogramBoundaries{values: append([]float64{}, values...)}, nil
+}
+
+// ParseHistogramBoundaries convertit un paramètre "25,50,100" ("" = bornes par défaut)
+func ParseHistogramBoundaries(value string) (HistogramBoundaries, error) {
+	if value == "" {
+		return DefaultHistogramBoundaries(), nil
+	}
+
+	parts := strings.Split(value, ",")
+	values := make([]float64, 0, len(parts))
+	for _, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return HistogramBoundaries{}, domain.NewValidationError("invalid_buckets", fmt.Sprintf("invalid histogram boundary: %q", part))
+		}
+		values = append(values, v)
+	}
+	return NewHistogramBoundaries(values)
+}
+
+// Values retourne les bornes (copie)
+func (b HistogramBoundaries) Values() []float64 {
+	return append([]float64{}, b.values...)
+}
+
+// BucketCount retourne le nombre de buckets (une borne de plus que de buckets fermés)
+func (b HistogramBoundaries) BucketCount() int {
+	return len(b.values) + 1
+}
+
+// Key retourne une représentation stable pour les clés de cache
+func (b HistogramBoundaries) Key() string {
+	parts := make([]string, len(b.values))
+	for i, v := range b.values {
+		parts[i] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
+// OrderValueSummary statistiques descriptives des montants de commande
+// Calculées par PostgreSQL (percentile_cont: interpolation linéaire entre les deux valeurs encadrantes)
+type OrderValueSummary struct {
+	orderCount int
+	mean       domain.Money
+	stdDev     domain.Money
+	min        domain.Money
+	max        domain.Money
+	median     domain.Money
+	p90        domain.Money
+	p95        domain.Money
+	p99        domain.Money
+}
+
+// NewOrderValueSummary crée un résumé (percentiles = p50, p90, p95, p99 dans cet ordre)
+func NewOrderValueSummary(
+	orderCount int,
+	mean, stdDev, minValue, maxValue domain.Money,
+	percentiles [4]domain.Money,
+) *OrderValueSummary {
+	return &OrderValueSummary{
+		orderCount: orderCount,
+		mean:       mean,
+		stdDev:     stdDev,
+		min:        minValue,
+		max:        maxValue,
+		median:     percentiles[0],
+		p90:        percentiles[1],
+		p95:        percentiles[2],
+		p99:        percentiles[3],
+	}
+}
+
+// OrderCount retourne le nombre de commandes
+func (s *OrderValueSummary) OrderCount() int {
+	return s.orderCount
+}
+
+// Mean retourne le montant moyen (panier moyen)
+func (s *OrderValueSummary) Mean() domain.Money {
+	return s.mean
+}
+
+// StdDev retourne l'écart-type des montants (échantillon, 0 avec moins de 2 commandes)
+func (s *OrderValueSummary) StdDev() domain.Money {
+	return s.stdDev
+}
+
+// Min retourne le plus petit montant
+func (s *OrderValueSummary) Min() domain.Money {
+	return s.min
+}
+
+// Max retourne le plus gros montant
+func (s *OrderValueSummary) Max() domain.Money {
+	return s.max
+}
+
+// Median retourne le montant médian (p50)
+func (s *OrderValueSummary) Median() domain.Money {
+	return s.median
+}
+
+// P90 retourne le 90e percentile
+func (s *OrderValueSummary) P90() domain.Money {
+	return s.p90
+}
+
+// P95 retourne le 95e percentile
+func (s *OrderValueSummary) P95() domain.Money {
+	return s.p95
+}
+
+// P99 retourne le 99e percentile
+func (s *OrderValueSummary) P99() domain.Money {
+	return s.p99
+}
+
+// ItemsPerOrderSummary statistiques du nombre d'articles (somme des quantités) par commande
+type ItemsPerOrderSummary struct {
+	mean   float64
+	median float64
+	p90    float64
+}
+
+// NewItemsPerOrderSummary crée un résumé du nombre d'articles par commande
+func NewItemsPerOrderSummary(mean, median, p90 float64) ItemsPerOrderSummary {
+	return ItemsPerOrderSummary{mean: mean, median: median, p90: p90}
+}
+
+// Mean retourne le nombre moyen d'articles par commande
+func (s ItemsPerOrderSummary) Mean() float64 {
+	return s.mean
+}
+
+// Median retourne le nombre médian d'articles par commande
+func (s ItemsPerOrderSummary) Median() float64 {
+	return s.median
+}
+
+// P90 retourne le 90e percentile du nombre d'articles par commande
+func (s ItemsPerOrderSummary) P90() float64 {
+	return s.p90
+}
+
+// HistogramBucket bucket d'histogramme des montants
+type HistogramBucket struct {
+	index      int
+	lower      float64
+	upper      float64 // 0 pour le dernier bucket (ouvert)
+	open       bool
+	orderCount int
+	revenue    domain.Money
+	share      float64
+}
+
+// NewHistogramBucket crée le bucket n° index (0 = [0, b1)) tel que retourné par le repository
+func NewHistogramBucket(index, orderCount int, revenue domain.Money) *HistogramBucket {
+	return &HistogramBucket{index: index, orderCount: orderCount, revenue: revenue}
+}
+
+// Lower retourne la borne basse (incluse)
+func (b *HistogramBucket) Lower() float64 {
+	return b.lower
+}
+
+// Upper retourne la borne haute (exclue), ok=false pour le dernier bucket
+func (b *HistogramBucket) Upper() (float64, bool) {
+	return b.upper, !b.open
+}
+
+// OrderCount retourne le nombre de commandes du bucket
+func (b *HistogramBucket) OrderCount() int {
+	return b.orderCount
+}
+
+// Revenue retourne le CA des commandes du bucket
+func (b *HistogramBucket) Revenue() domain.Money {
+	return b.revenue
+}
+
+// Share retourne la part des commandes du bucket (%)
+func (b *HistogramBucket) Share() float64 {
+	return b.share
+}
+
+// ItemsPerOrderBucket nombre de commandes ayant un nombre d'articles donné
+type ItemsPerOrderBucket struct {
+	items      int
+	orderCount int
+	share      float64
+}
+
+// NewItemsPerOrderBucket crée un bucket (items = MaxItemsPerOrderBucket regroupe "10 et plus")
+func NewItemsPerOrderBucket(items, orderCount int) *ItemsPerOrderBucket {
+	return &ItemsPerOrderBucket{items: items, orderCount: orderCount}
+}
+
+// Items retourne le nombre d'articles
+func (b *ItemsPerOrderBucket) Items() int {
+	return b.items
+}
+
+// IsOpen indique le bucket "MaxItemsPerOrderBucket et plus"
+func (b *ItemsPerOrderBucket) IsOpen() bool {
+	return b.items >= MaxItemsPerOrderBucket
+}
+
+// OrderCount retourne le nombre de commandes
+func (b *ItemsPerOrderBucket) OrderCount() int {
+	return b.orderCount
+}
+
+// Share retourne la part des commandes (%)
+func (b *ItemsPerOrderBucket) Share() float64 {
+	return b.share
+}
+
+// OrderValueDistribution distribution des montants et du nombre d'articles des commandes d'une période
+type OrderValueDistribution struct {
+	dateRange     domain.DateRange
+	summary       *OrderValueSummary
+	itemsSummary  ItemsPerOrderSummary
+	histogram     []*HistogramBucket
+	itemsPerOrder []*ItemsPerOrderBucket
+}
+
+// NewOrderValueDistribution assemble la distribution
+// histogram et itemsPerOrder contiennent uniquement les buckets non vides (résultat du GROUP BY SQL):
+//   - histogramme: tous les buckets des bornes, vides à zéro
+//   - articles: de 1 au plus grand nombre observé (plafonné à MaxItemsPerOrderBucket), vides à zéro
+func NewOrderValueDistribution(
+	dateRange domain.DateRange,
+	boundaries HistogramBoundaries,
+	summary *OrderValueSummary,
+	itemsSummary ItemsPerOrderSummary,
+	histogram []*HistogramBucket,
+	itemsPerOrder []*ItemsPerOrderBucket,
+) *OrderValueDistribution {
+	// Buckets vides dans la devise de reporting (celle des montants du résumé)
+	zero := domain.Currency(summary.mean.Currency()).Zero()
+
+	byIndex := make(map[int]*HistogramBucket, len(histogram))
+	for _, b := range histogram {
+		byIndex[b.index] = b
+	}
+	filledHistogram := make([]*HistogramBucket, 0, boundaries.BucketCount())
+	for i := 0; i < boundaries.BucketCount(); i++ {
+		bucket := &HistogramBucket{index: i, revenue: zero}
+		if b, ok := byIndex[i]; ok {
+			bucket.orderCount, bucket.revenue = b.orderCount, b.revenue
+		}
+		if i > 0 {
+			bucket.lower = boundaries.values[i-1]
+		}
+		if i < len(boundaries.values) {
+			bucket.upper = boundaries.values[i]
+		} else {
+			bucket.open = true
+		}
+		bucket.share = percentageOf(float64(bucket.orderCount), float64(summary.orderCount))
+		filledHistogram = append(filledHistogram, bucket)
+	}
+
+	// Le bucket 0 n'apparaît que s'il existe des commandes sans ligne
+	byItems := make(map[int]int, len(itemsPerOrder))
+	first, last := 1, 0
+	for _, b := range itemsPerOrder {
+		byItems[b.items] += b.orderCount
+		first, last = min(first, b.items), max(last, b.items)
+	}
+	filledItems := make([]*ItemsPerOrderBucket, 0, last-first+1)
+	for items := first; items <= last; items++ {
+		filledItems = append(filledItems, &ItemsPerOrderBucket{
+			items:      items,
+			orderCount: byItems[items],
+			share:      percentageOf(float64(byItems[items]), float64(summary.orderCount)),
+		})
+	}
+
+	return &OrderValueDistribution{
+		dateRange:     dateRange,
+		summary:       summary,
+		itemsSummary:  itemsSummary,
+		histogram:     filledHistogram,
+		itemsPerOrder: filledItems,
+	}
+}
+
+// DateRange retourne la période analysée
+func (d *OrderValueDistribution) DateRange() domain.DateRange {
+	return d.dateRange
+}
+
+// Summary retourne les statistiques des montants
+func (d *OrderValueDistribution) Summary() *OrderValueSummary {
+	return d.summary
+}
+
+// ItemsSummary retourne les statistiques du nombre d'articles par commande
+func (d *OrderValueDistribution) ItemsSummary() ItemsPerOrderSummary {
+	return d.itemsSummary
+}
+
+// Histogram retourne les buckets de montants, du plus petit au plus grand
+func (d *OrderValueDistribution) Histogram() []*HistogramBucket {
+	return append([]*HistogramBucket{}, d.histogram...)
+}
+
+// ItemsPerOrder retourne la répartition des commandes par nombre d'articles
+func (d *OrderValueDistribution) ItemsPerOrder() []*ItemsPerOrderBucket {
+	return append([]*ItemsPerOrderBucket{}, d.itemsPerOrder...)
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+
+	"eval/internal/shared/domain"
+)
+
+// TestParseHistogramBoundaries vérifie le paramètre buckets (bornes positives et croissantes)
+func TestParseHistogramBoundaries(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", "25,50,100,250,500,1000,2500", false},
+		{"10, 20.5,100", "10,20.5,100", false},
+		{"50,20", "", true},
+		{"0,10", "", true},
+		{"10,abc", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseHistogramBoundaries(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseHistogramBoundaries(%q) err = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.Key() != tt.want {
+			t.Errorf("ParseHistogramBoundaries(%q) = %s, want %s", tt.value, got.Key(), tt.want)
+		}
+	}
+}
+
+// TestNewOrderValueDistribution_FillsBuckets vérifie le remplissage des buckets vides
+// 4 commandes: 10€ (1 article), 30€ (1), 40€ (3), 700€ (12 → bucket "10+"), bornes 25,50,100
+func TestNewOrderValueDistribution_FillsBuckets(t *testing.T) {
+	eur := func(amount float64) domain.Money {
+		m, _ := domain.NewMoney(amount, "EUR")
+		return m
+	}
+
+	boundaries, _ := NewHistogramBoundaries([]float64{25, 50, 100})
+	dateRange, _ := domain.NewDateRangeFromDays(30)
+	summary := NewOrderValueSummary(4, eur(195), eur(337), eur(10), eur(700),
+		[4]domain.Money{eur(35), eur(502), eur(601), eur(680)})
+
+	distribution := NewOrderValueDistribution(dateRange, boundaries, summary, NewItemsPerOrderSummary(4.25, 2, 9),
+		[]*HistogramBucket{NewHistogramBucket(0, 1, eur(10)), NewHistogramBucket(1, 2, eur(70)), NewHistogramBucket(3, 1, eur(700))},
+		[]*ItemsPerOrderBucket{NewItemsPerOrderBucket(1, 2), NewItemsPerOrderBucket(3, 1), NewItemsPerOrderBucket(MaxItemsPerOrderBucket, 1)},
+	)
+
+	histogram := distribution.Histogram()
+	wantCounts := []int{1, 2, 0, 1}
+	if len(histogram) != len(wantCounts) {
+		t.Fatalf("histogram buckets = %d, want %d", len(histogram), len(wantCounts))
+	}
+	for i, b := range histogram {
+		if b.OrderCount() != wantCounts[i] {
+			t.Errorf("bucket %d orders = %d, want %d", i, b.OrderCount(), wantCounts[i])
+		}
+	}
+	if upper, ok := histogram[2].Upper(); !ok || histogram[2].Lower() != 50 || upper != 100 {
+		t.Errorf("bucket 2 = [%v, %v), want [50, 100)", histogram[2].Lower(), upper)
+	}
+	if _, ok := histogram[3].Upper(); ok || histogram[3].Lower() != 100 {
+		t.Errorf("last bucket should be open from 100, got lower %v", histogram[3].Lower())
+	}
+	if histogram[1].Share() != 50 {
+		t.Errorf("bucket 1 share = %v, want 50", histogram[1].Share())
+	}
+
+	items := distribution.ItemsPerOrder()
+	if len(items) != MaxItemsPerOrderBucket {
+		t.Fatalf("items buckets = %d, want 1..%d", len(items), MaxItemsPerOrderBucket)
+	}
+	if items[1].OrderCount() != 0 || items[2].OrderCount() != 1 {
+		t.Errorf("2 items = %d orders, 3 items = %d orders, want 0 and 1", items[1].OrderCount(), items[2].OrderCount())
+	}
+	if last := items[len(items)-1]; !last.IsOpen() || last.OrderCount() != 1 {
+		t.Errorf("last items bucket = %d (open %v) with %d orders, want 10+ with 1", last.Items(), last.IsOpen(), last.OrderCount())
+	}
+}
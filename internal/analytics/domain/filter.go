@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	catalogdomain "eval/internal/catalog/domain"
+	ordersdomain "eval/internal/orders/domain"
+	"eval/internal/shared/domain"
+)
+
+// StatsFilterCriteria paramètres bruts d'un filtre (0 ou "" = dimension non filtrée)
+type StatsFilterCriteria struct {
+	StoreID         int64
+	Region          string
+	City            string
+	CategoryID      int64
+	SupplierID      int64
+	PaymentMethodID int64
+	PromotionCode   string
+	Status          ordersdomain.StatusFilter // valeur zéro = commandes completed uniquement
+	Currency        domain.Currency           // "" = domain.DefaultCurrency
+}
+
+// StatsFilter représente un découpage des KPI par dimension (magasin, région, catégorie, ...)
+// DESIGN PATTERN: Value Object
+//   - Immutable, validé à la création
+//   - La valeur zéro (StatsFilter{}) ne filtre aucune dimension: toutes les commandes completed de la période
+//
+// SÉMANTIQUE:
+//   - store, region, city, payment method, promotion: filtrent les commandes
+//   - category, supplier: retiennent les commandes contenant au moins un article correspondant;
+//     le CA (KPI globaux, classements produits/catégories/magasins, moyens de paiement) est celui
+//     des articles correspondants
+//   - status: statuts de commande retenus (completed par défaut, les annulations ne sont pas du CA)
+//   - currency: devise de reporting (pas un filtre: les commandes de toutes devises sont converties
+//     au taux du jour de la commande)
+type StatsFilter struct {
+	storeID         ordersdomain.StoreID
+	region          string
+	city            string
+	categoryID      catalogdomain.CategoryID
+	supplierID      catalogdomain.SupplierID
+	paymentMethodID ordersdomain.PaymentMethodID
+	promotionCode   string
+	status          ordersdomain.StatusFilter
+	currency        domain.Currency // "" = devise par défaut (la valeur zéro reste le filtre vide)
+}
+
+// NewStatsFilter crée un filtre avec validation des identifiants
+func NewStatsFilter(criteria StatsFilterCriteria) (StatsFilter, error) {
+	if criteria.StoreID < 0 || criteria.CategoryID < 0 || criteria.SupplierID < 0 || criteria.PaymentMethodID < 0 {
+		return StatsFilter{}, domain.NewValidationError("invalid_filter", "filter identifiers cannot be negative")
+	}
+	currency, err := domain.ParseCurrency(criteria.Currency.String())
+	if err != nil {
+		return StatsFilter{}, err
+	}
+	if currency.IsDefault() {
+		currency = ""
+	}
+
+	return StatsFilter{
+		storeID:         ordersdomain.StoreID(criteria.StoreID),
+		region:          strings.TrimSpace(criteria.Region),
+		city:            strings.TrimSpace(criteria.City),
+		categoryID:      catalogdomain.CategoryID(criteria.CategoryID),
+		supplierID:      catalogdomain.SupplierID(criteria.SupplierID),
+		paymentMethodID: ordersdomain.PaymentMethodID(criteria.PaymentMethodID),
+		promotionCode:   strings.TrimSpace(criteria.PromotionCode),
+		status:          criteria.Status,
+		currency:        currency,
+	}, nil
+}
+
+// StoreID retourne le magasin filtré (0 = tous)
+func (f StatsFilter) StoreID() ordersdomain.StoreID {
+	return f.storeID
+}
+
+// Region retourne la région des magasins filtrée ("" = toutes)
+func (f StatsFilter) Region() string {
+	return f.region
+}
+
+// City retourne la ville des magasins filtrée ("" = toutes)
+func (f StatsFilter) City() string {
+	return f.city
+}
+
+// CategoryID retourne la catégorie filtrée (0 = toutes)
+func (f StatsFilter) CategoryID() catalogdomain.CategoryID {
+	return f.categoryID
+}
+
+// SupplierID retourne le fournisseur filtré (0 = tous)
+func (f StatsFilter) SupplierID() catalogdomain.SupplierID {
+	return f.supplierID
+}
+
+// PaymentMethodID retourne le moyen de paiement filtré (0 = tous)
+func (f StatsFilter) PaymentMethodID() ordersdomain.PaymentMethodID {
+	return f.paymentMethodID
+}
+
+// PromotionCode retourne le code promotion filtré ("" = toutes les commandes)
+func (f StatsFilter) PromotionCode() string {
+	return f.promotionCode
+}
+
+// Status retourne les statuts de commande retenus
+func (f StatsFilter) Status() ordersdomain.StatusFilter {
+	return f.status
+}
+
+// Currency retourne la devise de reporting des montants
+func (f StatsFilter) Currency() domain.Currency {
+	if f.currency == "" {
+		return domain.DefaultCurrency
+	}
+	return f.currency
+}
+
+// WithStatus retourne une copie du filtre sur d'autres statuts (mêmes dimensions)
+// Utilisé par la ventilation par statut, qui doit voir toutes les commandes
+func (f StatsFilter) WithStatus(status ordersdomain.StatusFilter) StatsFilter {
+	f.status = status
+	return f
+}
+
+// HasItemCriteria vérifie si le filtre porte sur les articles (catégorie, fournisseur)
+func (f StatsFilter) HasItemCriteria() bool {
+	return f.categoryID != 0 || f.supplierID != 0
+}
+
+// IsEmpty vérifie si aucune dimension n'est filtrée (et les statuts et la devise par défaut)
+func (f StatsFilter) IsEmpty() bool {
+	return f == StatsFilter{}
+}
+
+// Key retourne une représentation canonique du filtre pour les clés de cache
+// Ordre fixe des dimensions: ?region=X&store_id=1 et ?store_id=1&region=X donnent la même clé
+// PIÈGE: region, city et promotion sont du texte libre, échappés (url.QueryEscape) pour que
+// region="A;city=B" ne donne pas la clé de region="A"&city="B" (résultat d'un autre filtre en cache)
+func (f StatsFilter) Key() string {
+	if f.IsEmpty() {
+		return "all"
+	}
+
+	var sb strings.Builder
+	add := func(name, value string) {
+		if sb.Len() > 0 {
+			sb.WriteByte(';')
+		}
+		sb.WriteString(name)
+		sb.WriteByte('=')
+		sb.WriteString(value)
+	}
+
+	if f.storeID != 0 {
+		add("store", strconv.FormatInt(int64(f.storeID), 10))
+	}
+	if f.region != "" {
+		add("region", url.QueryEscape(f.region))
+	}
+	if f.city != "" {
+		add("city", url.QueryEscape(f.city))
+	}
+	if f.categoryID != 0 {
+		add("category", strconv.FormatInt(int64(f.categoryID), 10))
+	}
+	if f.supplierID != 0 {
+		add("supplier", strconv.FormatInt(int64(f.supplierID), 10))
+	}
+	if f.paymentMethodID != 0 {
+		add("payment", strconv.FormatInt(int64(f.paymentMethodID), 10))
+	}
+	if f.promotionCode != "" {
+		add("promotion", url.QueryEscape(f.promotionCode))
+	}
+	if !f.status.IsDefault() {
+		add("status", f.status.Key())
+	}
+	if f.currency != "" {
+		add("currency", f.currency.String())
+	}
+	return sb.String()
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "testing"
+
+// TestStatsFilter_Key vérifie que la clé de cache est canonique et sans collision entre filtres distincts
+func TestStatsFilter_Key(t *testing.T) {
+	newFilter := func(criteria StatsFilterCriteria) StatsFilter {
+		f, err := NewStatsFilter(criteria)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	if got := newFilter(StatsFilterCriteria{}).Key(); got != "all" {
+		t.Errorf("empty filter Key = %q, want all", got)
+	}
+	if got := newFilter(StatsFilterCriteria{StoreID: 3, Region: "Île-de-France"}).Key(); got != "store=3;region=%C3%8Ele-de-France" {
+		t.Errorf("Key = %q", got)
+	}
+
+	// Valeurs libres contenant les séparateurs de la clé
+	distinct := []StatsFilterCriteria{
+		{Region: "Nord", City: "Lille"},
+		{Region: "Nord;city=Lille"},
+		{Region: "Nord", PromotionCode: "X"},
+		{Region: "Nord;promotion=X"},
+		{City: "Lille;category=2"},
+		{City: "Lille", CategoryID: 2},
+	}
+	seen := make(map[string]StatsFilterCriteria)
+	for _, criteria := range distinct {
+		key := newFilter(criteria).Key()
+		if other, exists := seen[key]; exists {
+			t.Errorf("%+v and %+v share the cache key %q", criteria, other, key)
+		}
+		seen[key] = criteria
+	}
+}
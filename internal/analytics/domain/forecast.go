@@ -0,0 +1,331 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// ========================================
+// PRÉVISION DU CA (HOLT-WINTERS ADDITIF)
+// ========================================
+// Lissage exponentiel triple: niveau + tendance + saisonnalité
+//   - niveau  L(t) = α·(x(t) - S(t-m)) + (1-α)·(L(t-1) + T(t-1))
+//   - tendance T(t) = β·(L(t) - L(t-1)) + (1-β)·T(t-1)
+//   - saison  S(t) = γ·(x(t) - L(t)) + (1-γ)·S(t-m)
+//   - prévision à h pas: L(n) + h·T(n) + S(n+h-m)
+//
+// Saison m: 7 jours (cycle hebdomadaire) en granularité day, 12 mois en granularité month
+// α, β, γ sont choisis par recherche sur grille: minimum de la somme des carrés des erreurs
+// de prévision à un pas sur l'historique
+//
+// Modèle additif: l'amplitude saisonnière est supposée constante (en €), pas proportionnelle au niveau
+
+const (
+	// MaxForecastHorizonDays horizon maximum d'une prévision
+	MaxForecastHorizonDays = 365
+	// ForecastConfidence niveau de confiance des intervalles de prévision
+	ForecastConfidence = 0.95
+	// forecastZ quantile de la loi normale pour ForecastConfidence
+	forecastZ = 1.96
+)
+
+// ErrInsufficientHistory la fenêtre d'apprentissage ne contient pas deux saisons complètes
+var ErrInsufficientHistory = domain.NewValidationError("insufficient_history", "insufficient history for forecast")
+
+// smoothingGrid valeurs candidates de α, β et γ (7³ = 343 combinaisons)
+// PERFORMANCE: 343 passes de O(n) sur ~365 points ≈ 125k itérations, négligeable devant la requête SQL
+var smoothingGrid = []float64{0.05, 0.1, 0.2, 0.3, 0.5, 0.7, 0.9}
+
+// SeasonLength retourne la longueur de saison de la granularité (0 si non supportée)
+func (g Granularity) SeasonLength() int {
+	switch g {
+	case GranularityDay:
+		return 7
+	case GranularityMonth:
+		return 12
+	default:
+		return 0
+	}
+}
+
+// ForecastTrainingRange ajuste la fenêtre d'apprentissage à la granularité
+// En granularité month, seuls les mois complets sont gardés:
+// un mois partiel (ex: mois en cours) passerait pour une chute du CA et tirerait la prévision vers le bas
+func ForecastTrainingRange(dateRange domain.DateRange, granularity Granularity) (domain.DateRange, error) {
+	if granularity.SeasonLength() == 0 {
+		return domain.DateRange{}, domain.NewValidationError("invalid_granularity",
+			fmt.Sprintf("unsupported forecast granularity: %q (expected day or month)", granularity))
+	}
+	if granularity != GranularityMonth {
+		return dateRange, nil
+	}
+
+	start := dateRange.Start()
+	if start.Day() != 1 {
+		start = GranularityMonth.next(GranularityMonth.BucketStart(start))
+	}
+	// Dernier jour du dernier mois complet
+	end := GranularityMonth.next(GranularityMonth.BucketStart(dateRange.End()))
+	if !dateRange.End().Equal(end.AddDate(0, 0, -1)) {
+		end = GranularityMonth.BucketStart(dateRange.End())
+	}
+	end = end.AddDate(0, 0, -1)
+
+	if end.Before(start) {
+		return domain.DateRange{}, fmt.Errorf("%w: training window contains no complete month", ErrInsufficientHistory)
+	}
+	return domain.NewDateRange(start, end)
+}
+
+// HoltWintersModel modèle ajusté sur une série
+type HoltWintersModel struct {
+	alpha          float64
+	beta           float64
+	gamma          float64
+	seasonLength   int
+	level          float64
+	trend          float64
+	seasonals      []float64 // seasonals[t % m] = composante saisonnière du pas t
+	observations   int
+	residualStdDev float64
+}
+
+// FitHoltWinters ajuste le modèle sur values (au moins deux saisons complètes)
+//
+// Initialisation classique:
+//   - tendance = écart moyen entre la deuxième et la première saison, par pas
+//   - saisonnalité = écart de chaque pas de la première saison à la droite de tendance
+//   - niveau = droite de tendance ramenée au pas précédant la série
+//
+// PIÈGE: la moyenne de la première saison correspond à son milieu, pas à son début:
+// sans ce recentrage, la tendance de la première saison serait prise pour de la saisonnalité
+func FitHoltWinters(values []float64, seasonLength int) (*HoltWintersModel, error) {
+	if seasonLength < 2 {
+		return nil, errors.New("season length must be at least 2")
+	}
+	if len(values) < 2*seasonLength {
+		return nil, fmt.Errorf("%w: at least %d observations required (two seasons), got %d",
+			ErrInsufficientHistory, 2*seasonLength, len(values))
+	}
+
+	var best *HoltWintersModel
+	bestSSE := math.Inf(1)
+	for _, alpha := range smoothingGrid {
+		for _, beta := range smoothingGrid {
+			for _, gamma := range smoothingGrid {
+				model, sse := runHoltWinters(values, seasonLength, alpha, beta, gamma)
+				if sse < bestSSE {
+					best, bestSSE = model, sse
+				}
+			}
+		}
+	}
+	return best, nil
+}
+
+// runHoltWinters applique les équations de lissage et retourne le modèle et la somme des carrés
+// des erreurs à un pas (la première saison, qui sert à l'initialisation, n'est pas comptée)
+func runHoltWinters(values []float64, m int, alpha, beta, gamma float64) (*HoltWintersModel, float64) {
+	firstSeason, secondSeason := mean(values[:m]), mean(values[m:2*m])
+	trend := (secondSeason - firstSeason) / float64(m)
+	center := float64(m-1) / 2
+	seasonals := make([]float64, m)
+	for i := 0; i < m; i++ {
+		seasonals[i] = values[i] - (firstSeason + (float64(i)-center)*trend)
+	}
+	level := firstSeason - (center+1)*trend
+
+	var sse float64
+	for t, x := range values {
+		s := seasonals[t%m]
+		if t >= m {
+			err := x - (level + trend + s)
+			sse += err * err
+		}
+
+		previousLevel := level
+		level = alpha*(x-s) + (1-alpha)*(level+trend)
+		trend = beta*(level-previousLevel) + (1-beta)*trend
+		seasonals[t%m] = gamma*(x-level) + (1-gamma)*s
+	}
+
+	return &HoltWintersModel{
+		alpha:          alpha,
+		beta:           beta,
+		gamma:          gamma,
+		seasonLength:   m,
+		level:          level,
+		trend:          trend,
+		seasonals:      seasonals,
+		observations:   len(values),
+		residualStdDev: math.Sqrt(sse / float64(len(values)-m)),
+	}, sse
+}
+
+// mean moyenne arithmétique (values non vide)
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// Alpha retourne le coefficient de lissage du niveau
+func (m *HoltWintersModel) Alpha() float64 {
+	return m.alpha
+}
+
+// Beta retourne le coefficient de lissage de la tendance
+func (m *HoltWintersModel) Beta() float64 {
+	return m.beta
+}
+
+// Gamma retourne le coefficient de lissage de la saisonnalité
+func (m *HoltWintersModel) Gamma() float64 {
+	return m.gamma
+}
+
+// SeasonLength retourne la longueur de saison (en pas)
+func (m *HoltWintersModel) SeasonLength() int {
+	return m.seasonLength
+}
+
+// ResidualStdDev retourne l'écart-type des erreurs de prévision à un pas (RMSE)
+func (m *HoltWintersModel) ResidualStdDev() float64 {
+	return m.residualStdDev
+}
+
+// Predict retourne la prévision à h pas (h >= 1) et son intervalle à ForecastConfidence
+//
+// Variance à h pas (approximation du modèle ETS(A,A,A), Hyndman et al.):
+//
+//	σ²(h) = σ² · (1 + Σ_{j=1..h-1} c_j²), c_j = α·(1 + j·β) + γ·(1-α)·[j multiple de m]
+//
+// PIÈGE: un CA négatif n'a pas de sens: prévision et borne basse sont ramenées à 0
+func (m *HoltWintersModel) Predict(h int) (point, lower, upper float64) {
+	point = m.level + float64(h)*m.trend + m.seasonals[(m.observations+h-1)%m.seasonLength]
+
+	variance := 1.0
+	for j := 1; j < h; j++ {
+		c := m.alpha * (1 + float64(j)*m.beta)
+		if j%m.seasonLength == 0 {
+			c += m.gamma * (1 - m.alpha)
+		}
+		variance += c * c
+	}
+	margin := forecastZ * m.residualStdDev * math.Sqrt(variance)
+
+	return math.Max(point, 0), math.Max(point-margin, 0), math.Max(point+margin, 0)
+}
+
+// ForecastPoint prévision d'un bucket
+type ForecastPoint struct {
+	bucketStart time.Time
+	revenue     domain.Money
+	lowerBound  domain.Money
+	upperBound  domain.Money
+}
+
+// BucketStart retourne le premier jour du bucket prévu
+func (p *ForecastPoint) BucketStart() time.Time {
+	return p.bucketStart
+}
+
+// Revenue retourne le CA prévu
+func (p *ForecastPoint) Revenue() domain.Money {
+	return p.revenue
+}
+
+// LowerBound retourne la borne basse de l'intervalle de prévision
+func (p *ForecastPoint) LowerBound() domain.Money {
+	return p.lowerBound
+}
+
+// UpperBound retourne la borne haute de l'intervalle de prévision
+func (p *ForecastPoint) UpperBound() domain.Money {
+	return p.upperBound
+}
+
+// RevenueForecast prévision du CA à partir d'une série d'apprentissage
+type RevenueForecast struct {
+	training    *TimeSeries
+	horizonDays int
+	model       *HoltWintersModel
+	points      []*ForecastPoint
+}
+
+// NewRevenueForecast ajuste un modèle Holt-Winters sur le CA de training et prévoit
+// les buckets qui commencent dans les horizonDays jours suivant la fin de l'apprentissage
+// (en granularité month, un horizon de 30 jours après le 31 janvier couvre février et mars)
+func NewRevenueForecast(training *TimeSeries, horizonDays int) (*RevenueForecast, error) {
+	if horizonDays < 1 || horizonDays > MaxForecastHorizonDays {
+		return nil, domain.NewValidationError("invalid_horizon", fmt.Sprintf("horizon must be between 1 and %d days", MaxForecastHorizonDays))
+	}
+
+	granularity := training.Granularity()
+	history := training.Points()
+	values := make([]float64, len(history))
+	for i, p := range history {
+		values[i] = p.Revenue().Amount()
+	}
+
+	model, err := FitHoltWinters(values, granularity.SeasonLength())
+	if err != nil {
+		return nil, err
+	}
+
+	horizonEnd := training.DateRange().End().AddDate(0, 0, horizonDays)
+	var points []*ForecastPoint
+	currency := history[0].Revenue().Currency()
+	bucket := granularity.next(history[len(history)-1].BucketStart())
+	for h := 1; !bucket.After(horizonEnd); h++ {
+		point, lower, upper := model.Predict(h)
+		revenue, _ := domain.NewMoney(point, currency)
+		lowerBound, _ := domain.NewMoney(lower, currency)
+		upperBound, _ := domain.NewMoney(upper, currency)
+		points = append(points, &ForecastPoint{
+			bucketStart: bucket,
+			revenue:     revenue,
+			lowerBound:  lowerBound,
+			upperBound:  upperBound,
+		})
+		bucket = granularity.next(bucket)
+	}
+
+	return &RevenueForecast{
+		training:    training,
+		horizonDays: horizonDays,
+		model:       model,
+		points:      points,
+	}, nil
+}
+
+// Granularity retourne la granularité des buckets
+func (f *RevenueForecast) Granularity() Granularity {
+	return f.training.Granularity()
+}
+
+// TrainingRange retourne la fenêtre d'apprentissage
+func (f *RevenueForecast) TrainingRange() domain.DateRange {
+	return f.training.DateRange()
+}
+
+// HorizonDays retourne l'horizon demandé (jours)
+func (f *RevenueForecast) HorizonDays() int {
+	return f.horizonDays
+}
+
+// Model retourne le modèle ajusté
+func (f *RevenueForecast) Model() *HoltWintersModel {
+	return f.model
+}
+
+// Points retourne les buckets prévus dans l'ordre chronologique
+func (f *RevenueForecast) Points() []*ForecastPoint {
+	return append([]*ForecastPoint{}, f.points...)
+}
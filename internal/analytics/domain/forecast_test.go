@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// TestRevenueForecast_WeeklySeasonality vérifie la prévision d'une série sans bruit
+// CA quotidien = 1000 + 10 × jour + motif hebdomadaire (week-end fort): le modèle doit le prolonger
+func TestRevenueForecast_WeeklySeasonality(t *testing.T) {
+	weekly := []float64{-100, -50, 0, 50, 100, 300, -300}
+	truth := func(day int) float64 {
+		return 1000 + 10*float64(day) + weekly[day%7]
+	}
+
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	dateRange, err := domain.NewDateRange(start, start.AddDate(0, 0, 55)) // 8 semaines
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var points []*TimeSeriesPoint
+	for day := 0; day < 56; day++ {
+		revenue, _ := domain.NewMoney(truth(day), "EUR")
+		points = append(points, NewTimeSeriesPoint(start.AddDate(0, 0, day), revenue, 1, revenue, domain.MustNewQuantity(1)))
+	}
+
+	forecast, err := NewRevenueForecast(NewTimeSeries(GranularityDay, dateRange, domain.DefaultCurrency, points), 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := forecast.Points()
+	if len(got) != 14 {
+		t.Fatalf("points = %d, want 14", len(got))
+	}
+	if !got[0].BucketStart().Equal(start.AddDate(0, 0, 56)) {
+		t.Errorf("first bucket = %s, want day after training", got[0].BucketStart().Format("2006-01-02"))
+	}
+	for h, p := range got {
+		want := truth(56 + h)
+		if math.Abs(p.Revenue().Amount()-want) > 1 {
+			t.Errorf("day +%d forecast = %.2f, want %.2f", h+1, p.Revenue().Amount(), want)
+		}
+		if p.LowerBound().Amount() > p.Revenue().Amount() || p.UpperBound().Amount() < p.Revenue().Amount() {
+			t.Errorf("day +%d interval [%.2f, %.2f] does not contain %.2f",
+				h+1, p.LowerBound().Amount(), p.UpperBound().Amount(), p.Revenue().Amount())
+		}
+	}
+}
+
+// TestHoltWinters_IntervalWidens vérifie que l'incertitude croît avec l'horizon et que rien n'est négatif
+func TestHoltWinters_IntervalWidens(t *testing.T) {
+	values := []float64{105, 100, 112, 103, 108, 100, 120, 106, 101, 115, 102, 109, 100, 125, 104, 100, 110, 107, 106, 101, 118}
+	model, err := FitHoltWinters(values, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previousWidth := -1.0
+	for h := 1; h <= 21; h++ {
+		point, lower, upper := model.Predict(h)
+		if point < 0 || lower < 0 {
+			t.Errorf("h=%d: negative forecast (point %.2f, lower %.2f)", h, point, lower)
+		}
+		if width := upper - point; width < previousWidth-1e-9 {
+			t.Errorf("h=%d: interval narrowed (%.2f < %.2f)", h, width, previousWidth)
+		} else {
+			previousWidth = width
+		}
+	}
+}
+
+// TestFitHoltWinters_InsufficientHistory vérifie l'erreur si moins de deux saisons
+func TestFitHoltWinters_InsufficientHistory(t *testing.T) {
+	_, err := FitHoltWinters(make([]float64, 13), 7)
+	if !errors.Is(err, ErrInsufficientHistory) {
+		t.Errorf("err = %v, want ErrInsufficientHistory", err)
+	}
+}
+
+// TestForecastTrainingRange_CompleteMonths vérifie qu'en granularité month les mois partiels sont exclus
+func TestForecastTrainingRange_CompleteMonths(t *testing.T) {
+	tests := []struct {
+		from, to         string
+		wantFrom, wantTo string
+		wantInsufficient bool
+	}{
+		{"2023-01-01", "2024-12-31", "2023-01-01", "2024-12-31", false},
+		{"2023-01-15", "2024-12-20", "2023-02-01", "2024-11-30", false},
+		{"2024-02-01", "2024-02-29", "2024-02-01", "2024-02-29", false},
+		{"2024-02-10", "2024-03-20", "", "", true},
+	}
+
+	for _, tt := range tests {
+		from, _ := time.Parse("2006-01-02", tt.from)
+		to, _ := time.Parse("2006-01-02", tt.to)
+		dateRange, err := domain.NewDateRange(from, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ForecastTrainingRange(dateRange, GranularityMonth)
+		if tt.wantInsufficient {
+			if !errors.Is(err, ErrInsufficientHistory) {
+				t.Errorf("%s..%s: err = %v, want ErrInsufficientHistory", tt.from, tt.to, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s..%s: %v", tt.from, tt.to, err)
+		}
+		if got.Start().Format("2006-01-02") != tt.wantFrom || got.End().Format("2006-01-02") != tt.wantTo {
+			t.Errorf("%s..%s: got %s..%s, want %s..%s", tt.from, tt.to,
+				got.Start().Format("2006-01-02"), got.End().Format("2006-01-02"), tt.wantFrom, tt.wantTo)
+		}
+	}
+
+	if _, err := ForecastTrainingRange(domain.DateRange{}, GranularityWeek); err == nil {
+		t.Error("week granularity should be rejected")
+	}
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	ordersdomain "eval/internal/orders/domain"
+	"eval/internal/shared/domain"
+)
+
+// ========================================
+// EFFICACITÉ DES PROMOTIONS
+// ========================================
+// Pour chaque promotion, mesurée sur sa fenêtre d'activité (start_date..end_date):
+//   - commandes et CA réalisés avec le code promo
+//   - panier moyen avec le code vs sans aucun code sur la même fenêtre
+//   - coût estimé de la remise (CA avec le code × discount_percent)
+//   - uplift: CA quotidien moyen (toutes commandes) pendant la fenêtre
+//     vs la fenêtre de référence de même durée juste avant (Promotion.BaselineWindow)
+//
+// LIMITE: l'uplift est une comparaison avant/après, pas une expérience contrôlée:
+// saisonnalité et promotions concurrentes pendant l'une des deux fenêtres le faussent.
+
+// PromotionEffectiveness indicateurs d'une promotion sur sa fenêtre d'activité
+type PromotionEffectiveness struct {
+	promotion                    *ordersdomain.Promotion
+	orderCount                   int
+	revenue                      domain.Money
+	averageBasket                domain.Money
+	ordersWithoutAnyPromotion    int
+	averageBasketWithoutAnyPromo domain.Money
+	discountCost                 domain.Money
+	dailyRevenue                 MetricDelta
+}
+
+// NewPromotionEffectiveness calcule les indicateurs à partir des agrégats SQL
+//   - promoOrders/promoRevenue: commandes de la fenêtre utilisant la promotion
+//   - regularOrders/regularRevenue: commandes de la fenêtre sans aucune promotion
+//   - windowRevenue/baselineRevenue: CA de toutes les commandes, fenêtre active et fenêtre de référence
+func NewPromotionEffectiveness(
+	promotion *ordersdomain.Promotion,
+	promoOrders int,
+	promoRevenue domain.Money,
+	regularOrders int,
+	regularRevenue domain.Money,
+	windowRevenue domain.Money,
+	baselineRevenue domain.Money,
+) *PromotionEffectiveness {
+	days := float64(promotion.ActiveWindow().Days())
+
+	return &PromotionEffectiveness{
+		promotion:                    promotion,
+		orderCount:                   promoOrders,
+		revenue:                      promoRevenue,
+		averageBasket:                averageBasket(promoRevenue, promoOrders),
+		ordersWithoutAnyPromotion:    regularOrders,
+		averageBasketWithoutAnyPromo: averageBasket(regularRevenue, regularOrders),
+		discountCost:                 promotion.EstimatedDiscountCost(promoRevenue),
+		// Fenêtres de même durée: comparer les moyennes quotidiennes revient à comparer les totaux,
+		// mais la moyenne reste lisible ("X € par jour pendant la promotion")
+		dailyRevenue: NewMetricDelta(windowRevenue.Amount()/days, baselineRevenue.Amount()/days),
+	}
+}
+
+// averageBasket retourne revenue / orders (0 si aucune commande)
+func averageBasket(revenue domain.Money, orders int) domain.Money {
+	if orders == 0 {
+		return domain.Currency(revenue.Currency()).Zero()
+	}
+	avg, _ := domain.NewMoney(revenue.Amount()/float64(orders), revenue.Currency())
+	return avg
+}
+
+// Promotion retourne la promotion analysée
+func (p *PromotionEffectiveness) Promotion() *ordersdomain.Promotion {
+	return p.promotion
+}
+
+// OrderCount retourne le nombre de commandes utilisant la promotion pendant sa fenêtre
+func (p *PromotionEffectiveness) OrderCount() int {
+	return p.orderCount
+}
+
+// Revenue retourne le CA des commandes utilisant la promotion
+func (p *PromotionEffectiveness) Revenue() domain.Money {
+	return p.revenue
+}
+
+// AverageBasket retourne le panier moyen des commandes avec la promotion
+func (p *PromotionEffectiveness) AverageBasket() domain.Money {
+	return p.averageBasket
+}
+
+// OrdersWithoutAnyPromotion retourne le nombre de commandes sans aucune promotion pendant la fenêtre
+// PIÈGE: les commandes passées avec une autre promotion concurrente ne sont comptées ni ici
+// ni dans OrderCount: la référence est "sans promotion", pas "toutes les autres commandes"
+func (p *PromotionEffectiveness) OrdersWithoutAnyPromotion() int {
+	return p.ordersWithoutAnyPromotion
+}
+
+// AverageBasketWithoutAnyPromotion retourne le panier moyen des commandes sans aucune promotion pendant la fenêtre
+func (p *PromotionEffectiveness) AverageBasketWithoutAnyPromotion() domain.Money {
+	return p.averageBasketWithoutAnyPromo
+}
+
+// EstimatedDiscountCost retourne la remise estimée accordée via la promotion
+func (p *PromotionEffectiveness) EstimatedDiscountCost() domain.Money {
+	return p.discountCost
+}
+
+// DailyRevenue retourne le CA quotidien moyen, fenêtre active (Current) vs référence (Previous)
+// DailyRevenue().Percent() est l'uplift de la promotion
+func (p *PromotionEffectiveness) DailyRevenue() MetricDelta {
+	return p.dailyRevenue
+}
+
+// PromotionReport rapport des promotions actives sur une période
+type PromotionReport struct {
+	dateRange  domain.DateRange
+	currency   domain.Currency
+	promotions []*PromotionEffectiveness
+}
+
+// NewPromotionReport crée le rapport (promotions dans l'ordre chronologique de début)
+// currency = devise de reporting des montants, y compris des totaux d'un rapport vide
+func NewPromotionReport(
+	dateRange domain.DateRange,
+	currency domain.Currency,
+	promotions []*PromotionEffectiveness,
+) *PromotionReport {
+	return &PromotionReport{
+		dateRange:  dateRange,
+		currency:   currency,
+		promotions: promotions,
+	}
+}
+
+// DateRange retourne la période du rapport
+func (r *PromotionReport) DateRange() domain.DateRange {
+	return r.dateRange
+}
+
+// Promotions retourne les indicateurs de chaque promotion
+func (r *PromotionReport) Promotions() []*PromotionEffectiveness {
+	return append([]*PromotionEffectiveness{}, r.promotions...)
+}
+
+// TotalRevenue retourne le CA réalisé avec l'ensemble des promotions
+func (r *PromotionReport) TotalRevenue() domain.Money {
+	total := r.currency.Zero()
+	for _, p := range r.promotions {
+		total, _ = total.Add(p.revenue)
+	}
+	return total
+}
+
+// TotalDiscountCost retourne la remise estimée cumulée de l'ensemble des promotions
+func (r *PromotionReport) TotalDiscountCost() domain.Money {
+	total := r.currency.Zero()
+	for _, p := range r.promotions {
+		total, _ = total.Add(p.discountCost)
+	}
+	return total
+}
@@ -6,6 +6,14 @@ import (
 	"eval/internal/shared/domain"
 )
 
+const (
+	// TopProductsLimit taille du top produits de GET /api/v2/stats
+	TopProductsLimit = 10
+	// TopStoresLimit taille du top magasins de GET /api/v2/stats
+	// PIÈGE: la comparaison de périodes s'appuie sur ces limites pour savoir si le top de référence est tronqué
+	TopStoresLimit = 5
+)
+
 // Stats représente les statistiques globales
 type Stats struct {
 	totalRevenue      domain.Money
@@ -15,6 +23,7 @@ type Stats struct {
 	topProducts       []*ProductStats
 	topStores         []*StoreStats
 	paymentDistrib    []*PaymentMethodStats
+	statusBreakdown   []*OrderStatusStats
 }
 
 // NewStats crée une nouvelle instance de Stats
@@ -30,6 +39,7 @@ func NewStats() *Stats {
 		topProducts:       make([]*ProductStats, 0),
 		topStores:         make([]*StoreStats, 0),
 		paymentDistrib:    make([]*PaymentMethodStats, 0),
+		statusBreakdown:   make([]*OrderStatusStats, 0),
 	}
 }
 
@@ -68,6 +78,11 @@ func (s *Stats) PaymentDistribution() []*PaymentMethodStats {
 	return append([]*PaymentMethodStats{}, s.paymentDistrib...)
 }
 
+// StatusBreakdown retourne la ventilation des commandes par statut
+func (s *Stats) StatusBreakdown() []*OrderStatusStats {
+	return append([]*OrderStatusStats{}, s.statusBreakdown...)
+}
+
 // SetTotalRevenue définit le chiffre d'affaires total
 func (s *Stats) SetTotalRevenue(revenue domain.Money) {
 	s.totalRevenue = revenue
@@ -103,6 +118,11 @@ func (s *Stats) SetPaymentDistribution(distrib []*PaymentMethodStats) {
 	s.paymentDistrib = distrib
 }
 
+// SetStatusBreakdown définit la ventilation par statut
+func (s *Stats) SetStatusBreakdown(breakdown []*OrderStatusStats) {
+	s.statusBreakdown = breakdown
+}
+
 // CategoryStats représente les statistiques pour une catégorie
 type CategoryStats struct {
 	categoryID   catalogdomain.CategoryID
@@ -290,3 +310,48 @@ func (pms *PaymentMethodStats) TotalOrders() int {
 func (pms *PaymentMethodStats) Percentage() float64 {
 	return pms.percentage
 }
+
+// OrderStatusStats représente le volume de commandes d'un statut (completed, pending, cancelled)
+// Contrairement aux autres KPI, la ventilation couvre TOUS les statuts: c'est elle qui montre
+// le CA annulé ou en attente écarté des totaux
+type OrderStatusStats struct {
+	status          ordersdomain.OrderStatus
+	totalRevenue    domain.Money
+	totalOrders     int
+	orderPercentage float64
+}
+
+// NewOrderStatusStats crée une nouvelle instance de OrderStatusStats
+func NewOrderStatusStats(
+	status ordersdomain.OrderStatus,
+	totalRevenue domain.Money,
+	totalOrders int,
+	orderPercentage float64,
+) *OrderStatusStats {
+	return &OrderStatusStats{
+		status:          status,
+		totalRevenue:    totalRevenue,
+		totalOrders:     totalOrders,
+		orderPercentage: orderPercentage,
+	}
+}
+
+// Status retourne le statut de commande
+func (oss *OrderStatusStats) Status() ordersdomain.OrderStatus {
+	return oss.status
+}
+
+// TotalRevenue retourne le montant total des commandes de ce statut
+func (oss *OrderStatusStats) TotalRevenue() domain.Money {
+	return oss.totalRevenue
+}
+
+// TotalOrders retourne le nombre de commandes de ce statut
+func (oss *OrderStatusStats) TotalOrders() int {
+	return oss.totalOrders
+}
+
+// OrderPercentage retourne la part des commandes de la période (ex: taux d'annulation)
+func (oss *OrderStatusStats) OrderPercentage() float64 {
+	return oss.orderPercentage
+}
@@ -0,0 +1,460 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ordersdomain "eval/internal/orders/domain"
+	"eval/internal/shared/domain"
+)
+
+// ========================================
+// TABLEAU DE BORD MAGASINS / RÉGIONS
+// ========================================
+// Tous les magasins (y compris ceux sans vente sur la période), avec:
+//   - CA, commandes, panier moyen (CA / commande) et articles par commande
+//   - répartition des moyens de paiement du magasin
+//   - rang par CA et évolution du rang vs la période précédente
+//   - agrégation par région
+//
+// Le tableau complet est calculé puis mis en cache: tri, filtre par région et pagination
+// se font en mémoire (quelques dizaines de magasins) sans nouvelle requête SQL
+
+// StoreActivity agrégats d'un magasin sur une période (ligne brute du repository)
+type StoreActivity struct {
+	storeID    ordersdomain.StoreID
+	name       string
+	city       string
+	region     string
+	orderCount int
+	revenue    domain.Money
+	itemsSold  int
+}
+
+// NewStoreActivity crée les agrégats d'un magasin (region vide si non renseignée)
+func NewStoreActivity(
+	storeID ordersdomain.StoreID,
+	name, city, region string,
+	orderCount int,
+	revenue domain.Money,
+	itemsSold int,
+) *StoreActivity {
+	return &StoreActivity{
+		storeID:    storeID,
+		name:       name,
+		city:       city,
+		region:     region,
+		orderCount: orderCount,
+		revenue:    revenue,
+		itemsSold:  itemsSold,
+	}
+}
+
+// StoreID retourne l'identifiant du magasin
+func (a *StoreActivity) StoreID() ordersdomain.StoreID {
+	return a.storeID
+}
+
+// StorePaymentShare part d'un moyen de paiement dans le CA d'un magasin
+type StorePaymentShare struct {
+	paymentMethodID ordersdomain.PaymentMethodID
+	name            string
+	orderCount      int
+	revenue         domain.Money
+	percentage      float64
+}
+
+// NewStorePaymentShare crée une ligne de répartition (le pourcentage est calculé par NewStoreDashboard)
+func NewStorePaymentShare(
+	paymentMethodID ordersdomain.PaymentMethodID,
+	name string,
+	orderCount int,
+	revenue domain.Money,
+) *StorePaymentShare {
+	return &StorePaymentShare{
+		paymentMethodID: paymentMethodID,
+		name:            name,
+		orderCount:      orderCount,
+		revenue:         revenue,
+	}
+}
+
+// PaymentMethodID retourne l'identifiant du moyen de paiement
+func (s *StorePaymentShare) PaymentMethodID() ordersdomain.PaymentMethodID {
+	return s.paymentMethodID
+}
+
+// Name retourne le nom du moyen de paiement
+func (s *StorePaymentShare) Name() string {
+	return s.name
+}
+
+// OrderCount retourne le nombre de commandes payées avec ce moyen
+func (s *StorePaymentShare) OrderCount() int {
+	return s.orderCount
+}
+
+// Revenue retourne le CA payé avec ce moyen
+func (s *StorePaymentShare) Revenue() domain.Money {
+	return s.revenue
+}
+
+// Percentage retourne la part du CA du magasin (%)
+func (s *StorePaymentShare) Percentage() float64 {
+	return s.percentage
+}
+
+// StorePerformance indicateurs d'un magasin et son évolution de rang
+// Embarque le RankedDelta du classement par CA: Rank, PreviousRank, RankChange, IsNewEntry, Revenue, Orders
+type StorePerformance struct {
+	*RankedDelta
+	city         string
+	region       string
+	itemsSold    int
+	revenueShare float64
+	paymentMix   []*StorePaymentShare
+	currency     domain.Currency // devise de reporting (le RankedDelta ne garde que les montants)
+}
+
+// StoreID retourne l'identifiant du magasin
+func (p *StorePerformance) StoreID() ordersdomain.StoreID {
+	return ordersdomain.StoreID(p.ID())
+}
+
+// City retourne la ville du magasin
+func (p *StorePerformance) City() string {
+	return p.city
+}
+
+// Region retourne la région du magasin (vide si non renseignée)
+func (p *StorePerformance) Region() string {
+	return p.region
+}
+
+// ItemsSold retourne le nombre d'articles vendus sur la période
+func (p *StorePerformance) ItemsSold() int {
+	return p.itemsSold
+}
+
+// RevenuePerOrder retourne le panier moyen (0 si aucune commande)
+func (p *StorePerformance) RevenuePerOrder() domain.Money {
+	avg, _ := domain.NewMoney(ratio(p.Revenue().Current(), p.Orders().Current()), p.currency.String())
+	return avg
+}
+
+// ItemsPerOrder retourne le nombre moyen d'articles par commande (0 si aucune commande)
+func (p *StorePerformance) ItemsPerOrder() float64 {
+	return ratio(float64(p.itemsSold), p.Orders().Current())
+}
+
+// RevenueShare retourne la part du magasin dans le CA de tous les magasins (%)
+func (p *StorePerformance) RevenueShare() float64 {
+	return p.revenueShare
+}
+
+// PaymentMix retourne la répartition des moyens de paiement, par CA décroissant
+func (p *StorePerformance) PaymentMix() []*StorePaymentShare {
+	return append([]*StorePaymentShare{}, p.paymentMix...)
+}
+
+// RegionPerformance agrégation des magasins d'une région
+type RegionPerformance struct {
+	region       string
+	storeCount   int
+	revenue      MetricDelta
+	orders       MetricDelta
+	itemsSold    int
+	revenueShare float64
+	currency     domain.Currency
+}
+
+// Region retourne le nom de la région (vide pour les magasins sans région)
+func (r *RegionPerformance) Region() string {
+	return r.region
+}
+
+// StoreCount retourne le nombre de magasins de la région
+func (r *RegionPerformance) StoreCount() int {
+	return r.storeCount
+}
+
+// Revenue retourne le CA de la région, période courante vs période précédente
+func (r *RegionPerformance) Revenue() MetricDelta {
+	return r.revenue
+}
+
+// Orders retourne le nombre de commandes de la région, période courante vs période précédente
+func (r *RegionPerformance) Orders() MetricDelta {
+	return r.orders
+}
+
+// ItemsSold retourne le nombre d'articles vendus dans la région
+func (r *RegionPerformance) ItemsSold() int {
+	return r.itemsSold
+}
+
+// RevenuePerOrder retourne le panier moyen de la région
+func (r *RegionPerformance) RevenuePerOrder() domain.Money {
+	avg, _ := domain.NewMoney(ratio(r.revenue.Current(), r.orders.Current()), r.currency.String())
+	return avg
+}
+
+// ItemsPerOrder retourne le nombre moyen d'articles par commande dans la région
+func (r *RegionPerformance) ItemsPerOrder() float64 {
+	return ratio(float64(r.itemsSold), r.orders.Current())
+}
+
+// RevenueShare retourne la part de la région dans le CA de tous les magasins (%)
+func (r *RegionPerformance) RevenueShare() float64 {
+	return r.revenueShare
+}
+
+// StoreSort critère de tri des magasins
+type StoreSort string
+
+const (
+	StoreSortRevenue         StoreSort = "revenue"
+	StoreSortOrders          StoreSort = "orders"
+	StoreSortRevenuePerOrder StoreSort = "revenue_per_order"
+	StoreSortItemsPerOrder   StoreSort = "items_per_order"
+	StoreSortRankChange      StoreSort = "rank_change"
+	StoreSortName            StoreSort = "name"
+)
+
+// ParseStoreSort convertit un paramètre utilisateur en StoreSort (revenue par défaut)
+func ParseStoreSort(value string) (StoreSort, error) {
+	switch sortBy := StoreSort(value); sortBy {
+	case "":
+		return StoreSortRevenue, nil
+	case StoreSortRevenue, StoreSortOrders, StoreSortRevenuePerOrder,
+		StoreSortItemsPerOrder, StoreSortRankChange, StoreSortName:
+		return sortBy, nil
+	default:
+		return "", domain.NewValidationError("invalid_sort",
+			fmt.Sprintf("unsupported sort: %q (expected revenue, orders, revenue_per_order, items_per_order, rank_change or name)", value))
+	}
+}
+
+// DefaultAscending indique le sens de tri par défaut: alphabétique pour name, décroissant sinon
+func (s StoreSort) DefaultAscending() bool {
+	return s == StoreSortName
+}
+
+// StoreDashboard tableau de bord de tous les magasins sur une période
+type StoreDashboard struct {
+	dateRange     domain.DateRange
+	previousRange domain.DateRange
+	stores        []*StorePerformance
+	regions       []*RegionPerformance
+}
+
+// NewStoreDashboard assemble le tableau de bord
+//   - current: tous les magasins, triés par CA décroissant (ORDER BY SQL): rang = index + 1
+//   - previous: mêmes agrégats sur previousRange, même tri
+//   - payments: répartition des paiements par magasin
+//
+// Un magasin sans commande sur la période précédente n'y est pas classé (IsNewEntry):
+// sinon un magasin ouvert en cours de période "gagnerait" des places sur des magasins à 0€
+func NewStoreDashboard(
+	dateRange domain.DateRange,
+	previousRange domain.DateRange,
+	current []*StoreActivity,
+	previous []*StoreActivity,
+	payments map[ordersdomain.StoreID][]*StorePaymentShare,
+) *StoreDashboard {
+	reference := make([]rankingEntry, 0, len(previous))
+	for _, a := range previous {
+		if a.orderCount > 0 {
+			reference = append(reference, activityEntry(a))
+		}
+	}
+
+	entries := make([]rankingEntry, len(current))
+	var totalRevenue float64
+	for i, a := range current {
+		entries[i] = activityEntry(a)
+		totalRevenue += a.revenue.Amount()
+	}
+
+	ranking := compareRankings(entries, reference, 0)
+	stores := make([]*StorePerformance, len(current))
+	for i, a := range current {
+		mix := payments[a.storeID]
+		for _, share := range mix {
+			share.percentage = percentageOf(share.revenue.Amount(), a.revenue.Amount())
+		}
+
+		stores[i] = &StorePerformance{
+			RankedDelta:  ranking[i],
+			city:         a.city,
+			region:       a.region,
+			itemsSold:    a.itemsSold,
+			revenueShare: percentageOf(a.revenue.Amount(), totalRevenue),
+			paymentMix:   mix,
+			currency:     domain.Currency(a.revenue.Currency()),
+		}
+	}
+
+	return &StoreDashboard{
+		dateRange:     dateRange,
+		previousRange: previousRange,
+		stores:        stores,
+		regions:       aggregateRegions(stores, totalRevenue),
+	}
+}
+
+// activityEntry convertit les agrégats d'un magasin en ligne de classement
+func activityEntry(a *StoreActivity) rankingEntry {
+	return rankingEntry{int64(a.storeID), a.name, a.revenue.Amount(), a.orderCount}
+}
+
+// aggregateRegions regroupe les magasins par région, par CA décroissant
+func aggregateRegions(stores []*StorePerformance, totalRevenue float64) []*RegionPerformance {
+	byRegion := make(map[string]*RegionPerformance)
+	var regions []*RegionPerformance
+
+	for _, s := range stores {
+		r, ok := byRegion[s.region]
+		if !ok {
+			r = &RegionPerformance{region: s.region, currency: s.currency}
+			byRegion[s.region] = r
+			regions = append(regions, r)
+		}
+		r.storeCount++
+		r.itemsSold += s.itemsSold
+		r.revenue = NewMetricDelta(r.revenue.Current()+s.Revenue().Current(), r.revenue.Previous()+s.Revenue().Previous())
+		r.orders = NewMetricDelta(r.orders.Current()+s.Orders().Current(), r.orders.Previous()+s.Orders().Previous())
+	}
+
+	for _, r := range regions {
+		r.revenueShare = percentageOf(r.revenue.Current(), totalRevenue)
+	}
+	sort.SliceStable(regions, func(i, j int) bool {
+		if regions[i].revenue.Current() != regions[j].revenue.Current() {
+			return regions[i].revenue.Current() > regions[j].revenue.Current()
+		}
+		return regions[i].region < regions[j].region
+	})
+	return regions
+}
+
+// DateRange retourne la période du tableau de bord
+func (d *StoreDashboard) DateRange() domain.DateRange {
+	return d.dateRange
+}
+
+// PreviousRange retourne la période de référence de l'évolution des rangs
+func (d *StoreDashboard) PreviousRange() domain.DateRange {
+	return d.previousRange
+}
+
+// Regions retourne l'agrégation par région, par CA décroissant
+func (d *StoreDashboard) Regions() []*RegionPerformance {
+	return append([]*RegionPerformance{}, d.regions...)
+}
+
+// TotalRevenue retourne le CA de tous les magasins, période courante vs période précédente
+func (d *StoreDashboard) TotalRevenue() MetricDelta {
+	var current, previous float64
+	for _, r := range d.regions {
+		current += r.revenue.Current()
+		previous += r.revenue.Previous()
+	}
+	return NewMetricDelta(current, previous)
+}
+
+// Page retourne une page des magasins de la région (toutes si vide), triés par sortBy
+// Le rang reste celui du classement global par CA, quel que soit le tri ou le filtre
+//
+// PIÈGE: tri stable sur des magasins déjà rangés par CA: à valeur égale (ex: rank_change = 0),
+// l'ordre du classement est conservé et la pagination reste déterministe d'une page à l'autre
+func (d *StoreDashboard) Page(
+	region string,
+	sortBy StoreSort,
+	ascending bool,
+	pagination domain.Pagination,
+) *StorePerformancePage {
+	stores := make([]*StorePerformance, 0, len(d.stores))
+	for _, s := range d.stores {
+		if region == "" || strings.EqualFold(s.region, region) {
+			stores = append(stores, s)
+		}
+	}
+
+	less := storeLess(sortBy)
+	sort.SliceStable(stores, func(i, j int) bool {
+		if ascending {
+			return less(stores[i], stores[j])
+		}
+		return less(stores[j], stores[i])
+	})
+
+	total := len(stores)
+	start := min(pagination.Offset(), total)
+	end := min(start+pagination.Limit(), total)
+
+	return &StorePerformancePage{
+		pagination:  pagination,
+		totalStores: total,
+		stores:      stores[start:end],
+	}
+}
+
+// storeLess ordre croissant des magasins selon le critère
+func storeLess(sortBy StoreSort) func(a, b *StorePerformance) bool {
+	switch sortBy {
+	case StoreSortOrders:
+		return func(a, b *StorePerformance) bool { return a.Orders().Current() < b.Orders().Current() }
+	case StoreSortRevenuePerOrder:
+		return func(a, b *StorePerformance) bool { return a.RevenuePerOrder().Amount() < b.RevenuePerOrder().Amount() }
+	case StoreSortItemsPerOrder:
+		return func(a, b *StorePerformance) bool { return a.ItemsPerOrder() < b.ItemsPerOrder() }
+	case StoreSortRankChange:
+		return func(a, b *StorePerformance) bool { return a.RankChange() < b.RankChange() }
+	case StoreSortName:
+		return func(a, b *StorePerformance) bool { return a.Name() < b.Name() }
+	default:
+		return func(a, b *StorePerformance) bool { return a.Revenue().Current() < b.Revenue().Current() }
+	}
+}
+
+// StorePerformancePage une page du tableau de bord magasins
+type StorePerformancePage struct {
+	pagination  domain.Pagination
+	totalStores int
+	stores      []*StorePerformance
+}
+
+// Pagination retourne la page demandée
+func (p *StorePerformancePage) Pagination() domain.Pagination {
+	return p.pagination
+}
+
+// TotalStores retourne le nombre de magasins après filtre par région (toutes pages confondues)
+func (p *StorePerformancePage) TotalStores() int {
+	return p.totalStores
+}
+
+// TotalPages retourne le nombre de pages
+func (p *StorePerformancePage) TotalPages() int {
+	return p.pagination.TotalPages(p.totalStores)
+}
+
+// Stores retourne les magasins de la page
+func (p *StorePerformancePage) Stores() []*StorePerformance {
+	return append([]*StorePerformance{}, p.stores...)
+}
+
+// ratio retourne value / count (0 si count vaut 0)
+func ratio(value, count float64) float64 {
+	if count == 0 {
+		return 0
+	}
+	return value / count
+}
+
+// percentageOf retourne part / total en pourcentage (0 si total vaut 0)
+func percentageOf(part, total float64) float64 {
+	return ratio(part, total) * 100
+}
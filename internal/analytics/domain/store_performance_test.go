@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"testing"
+
+	ordersdomain "eval/internal/orders/domain"
+	"eval/internal/shared/domain"
+)
+
+// TestStoreDashboard_RanksAndPages vérifie rangs, régions, tri et pagination sur 3 magasins
+//
+//	magasin  région  courant          précédent
+//	Paris    IDF     300€ / 3 cmd     400€ / 2 cmd
+//	Lyon     ARA     500€ / 2 cmd     100€ / 1 cmd
+//	Nice     PACA    0€               aucune commande
+func TestStoreDashboard_RanksAndPages(t *testing.T) {
+	eur := func(amount float64) domain.Money {
+		m, _ := domain.NewMoney(amount, "EUR")
+		return m
+	}
+
+	// Triés par CA décroissant, comme le retourne le repository
+	current := []*StoreActivity{
+		NewStoreActivity(2, "Lyon", "Lyon", "Auvergne-Rhône-Alpes", 2, eur(500), 2),
+		NewStoreActivity(1, "Paris", "Paris", "Île-de-France", 3, eur(300), 6),
+		NewStoreActivity(3, "Nice", "Nice", "Provence-Alpes-Côte d'Azur", 0, eur(0), 0),
+	}
+	previous := []*StoreActivity{
+		NewStoreActivity(1, "Paris", "Paris", "Île-de-France", 2, eur(400), 4),
+		NewStoreActivity(2, "Lyon", "Lyon", "Auvergne-Rhône-Alpes", 1, eur(100), 1),
+		NewStoreActivity(3, "Nice", "Nice", "Provence-Alpes-Côte d'Azur", 0, eur(0), 0),
+	}
+	payments := map[ordersdomain.StoreID][]*StorePaymentShare{
+		2: {NewStorePaymentShare(1, "Carte", 1, eur(400)), NewStorePaymentShare(2, "Espèces", 1, eur(100))},
+	}
+
+	dateRange, _ := domain.NewDateRangeFromDays(30)
+	dashboard := NewStoreDashboard(dateRange, dateRange.Previous(), current, previous, payments)
+
+	pagination, _ := domain.NewPagination(1, 10)
+	stores := dashboard.Page("", StoreSortRevenue, false, pagination).Stores()
+	if len(stores) != 3 {
+		t.Fatalf("stores = %d, want 3", len(stores))
+	}
+
+	lyon, paris, nice := stores[0], stores[1], stores[2]
+	if lyon.Rank() != 1 || lyon.RankChange() != 1 || paris.RankChange() != -1 {
+		t.Errorf("rank changes = Lyon %d (+%d), Paris %d, want 1 (+1), -1", lyon.Rank(), lyon.RankChange(), paris.RankChange())
+	}
+	// Nice n'avait aucune commande: absent du classement précédent
+	if !nice.IsNewEntry() || nice.Rank() != 3 {
+		t.Errorf("Nice = rank %d, new entry %v, want 3, true", nice.Rank(), nice.IsNewEntry())
+	}
+	if lyon.RevenuePerOrder().Amount() != 250 || paris.ItemsPerOrder() != 2 || nice.ItemsPerOrder() != 0 {
+		t.Errorf("ratios = %v €/order, %v items/order, %v", lyon.RevenuePerOrder().Amount(), paris.ItemsPerOrder(), nice.ItemsPerOrder())
+	}
+	if lyon.RevenueShare() != 62.5 {
+		t.Errorf("Lyon revenue share = %v, want 62.5", lyon.RevenueShare())
+	}
+	if mix := lyon.PaymentMix(); len(mix) != 2 || mix[0].Percentage() != 80 || mix[1].Percentage() != 20 {
+		t.Errorf("Lyon payment mix = %v", mix)
+	}
+
+	regions := dashboard.Regions()
+	if len(regions) != 3 || regions[0].Region() != "Auvergne-Rhône-Alpes" || regions[0].Revenue().Previous() != 100 {
+		t.Errorf("regions = %v", regions)
+	}
+	if total := dashboard.TotalRevenue(); total.Current() != 800 || total.Previous() != 500 {
+		t.Errorf("total revenue = %v vs %v, want 800 vs 500", total.Current(), total.Previous())
+	}
+
+	// Tri par nom, 2 par page: Lyon, Nice | Paris
+	small, _ := domain.NewPagination(2, 2)
+	page := dashboard.Page("", StoreSortName, true, small)
+	if page.TotalPages() != 2 || len(page.Stores()) != 1 || page.Stores()[0].Name() != "Paris" {
+		t.Errorf("page 2 by name = %d pages, %v", page.TotalPages(), page.Stores())
+	}
+
+	// Filtre par région insensible à la casse, le rang global est conservé
+	page = dashboard.Page("île-de-france", StoreSortRevenue, false, pagination)
+	if page.TotalStores() != 1 || page.Stores()[0].Rank() != 2 {
+		t.Errorf("Île-de-France = %d stores, %v", page.TotalStores(), page.Stores())
+	}
+
+	// rank_change décroissant: Lyon (+1), Nice (nouveau, 0), Paris (-1)
+	byChange := dashboard.Page("", StoreSortRankChange, false, pagination).Stores()
+	if byChange[0].Name() != "Lyon" || byChange[2].Name() != "Paris" {
+		t.Errorf("by rank change = %s, %s, %s", byChange[0].Name(), byChange[1].Name(), byChange[2].Name())
+	}
+}
+
+func TestParseStoreSort(t *testing.T) {
+	if sortBy, err := ParseStoreSort(""); err != nil || sortBy != StoreSortRevenue {
+		t.Errorf(`ParseStoreSort("") = %q, %v, want revenue`, sortBy, err)
+	}
+	if _, err := ParseStoreSort("city"); err == nil {
+		t.Error(`ParseStoreSort("city") should fail`)
+	}
+	if !StoreSortName.DefaultAscending() || StoreSortRevenue.DefaultAscending() {
+		t.Error("only name should sort ascending by default")
+	}
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// Granularity représente la taille d'un bucket de série temporelle
+type Granularity string
+
+const (
+	GranularityDay   Granularity = "day"
+	GranularityWeek  Granularity = "week"
+	GranularityMonth Granularity = "month"
+)
+
+// ParseGranularity convertit un paramètre utilisateur en Granularity (jour par défaut)
+func ParseGranularity(value string) (Granularity, error) {
+	switch Granularity(value) {
+	case "", GranularityDay:
+		return GranularityDay, nil
+	case GranularityWeek:
+		return GranularityWeek, nil
+	case GranularityMonth:
+		return GranularityMonth, nil
+	default:
+		return "", domain.NewValidationError("invalid_granularity", fmt.Sprintf("unsupported granularity: %q (expected day, week or month)", value))
+	}
+}
+
+// BucketStart retourne le début du bucket contenant t (minuit, dans le fuseau de t)
+// Aligné sur date_trunc de PostgreSQL:
+//   - week: lundi (semaine ISO 8601)
+//   - month: 1er du mois
+func (g Granularity) BucketStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	switch g {
+	case GranularityWeek:
+		day := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+		// Weekday(): dimanche = 0 → (weekday+6)%7 = nombre de jours depuis lundi
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case GranularityMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// next retourne le début du bucket suivant
+func (g Granularity) next(bucketStart time.Time) time.Time {
+	switch g {
+	case GranularityWeek:
+		return bucketStart.AddDate(0, 0, 7)
+	case GranularityMonth:
+		return bucketStart.AddDate(0, 1, 0)
+	default:
+		return bucketStart.AddDate(0, 0, 1)
+	}
+}
+
+// TimeSeriesPoint représente les agrégats d'un bucket (jour, semaine ou mois)
+type TimeSeriesPoint struct {
+	bucketStart       time.Time
+	revenue           domain.Money
+	orderCount        int
+	averageOrderValue domain.Money
+	quantity          domain.Quantity
+}
+
+// NewTimeSeriesPoint crée un nouveau point de série temporelle
+func NewTimeSeriesPoint(
+	bucketStart time.Time,
+	revenue domain.Money,
+	orderCount int,
+	averageOrderValue domain.Money,
+	quantity domain.Quantity,
+) *TimeSeriesPoint {
+	return &TimeSeriesPoint{
+		bucketStart:       bucketStart,
+		revenue:           revenue,
+		orderCount:        orderCount,
+		averageOrderValue: averageOrderValue,
+		quantity:          quantity,
+	}
+}
+
+// BucketStart retourne le premier jour du bucket
+func (p *TimeSeriesPoint) BucketStart() time.Time {
+	return p.bucketStart
+}
+
+// Revenue retourne le chiffre d'affaires du bucket
+func (p *TimeSeriesPoint) Revenue() domain.Money {
+	return p.revenue
+}
+
+// OrderCount retourne le nombre de commandes du bucket
+func (p *TimeSeriesPoint) OrderCount() int {
+	return p.orderCount
+}
+
+// AverageOrderValue retourne le panier moyen du bucket
+func (p *TimeSeriesPoint) AverageOrderValue() domain.Money {
+	return p.averageOrderValue
+}
+
+// Quantity retourne le nombre d'articles vendus dans le bucket
+func (p *TimeSeriesPoint) Quantity() domain.Quantity {
+	return p.quantity
+}
+
+// TimeSeries représente une série temporelle continue sur une période
+// Les buckets sans commande sont présents avec des valeurs à zéro:
+// un graphique ne doit pas relier directement le 3 au 5 si le 4 n'a rien vendu
+type TimeSeries struct {
+	granularity Granularity
+	dateRange   domain.DateRange
+	points      []*TimeSeriesPoint
+}
+
+// NewTimeSeries crée une série en complétant les buckets manquants par des zéros
+// points contient uniquement les buckets ayant des commandes (résultat du GROUP BY SQL),
+// les buckets complétés sont à zéro dans currency (devise de reporting)
+//
+// PERFORMANCE: map indexée par date "2006-01-02" → O(n) au lieu de O(n × m)
+//   - La clé texte évite les pièges de comparaison de time.Time (fuseaux différents, monotonic clock)
+func NewTimeSeries(
+	granularity Granularity,
+	dateRange domain.DateRange,
+	currency domain.Currency,
+	points []*TimeSeriesPoint,
+) *TimeSeries {
+	const keyLayout = "2006-01-02"
+
+	byBucket := make(map[string]*TimeSeriesPoint, len(points))
+	for _, p := range points {
+		byBucket[p.bucketStart.Format(keyLayout)] = p
+	}
+
+	zero := currency.Zero()
+	loc := dateRange.Location()
+
+	filled := make([]*TimeSeriesPoint, 0, len(points))
+	for bucket := granularity.BucketStart(dateRange.Start()); !bucket.After(dateRange.End()); bucket = granularity.next(bucket) {
+		if p, ok := byBucket[bucket.Format(keyLayout)]; ok {
+			// Date SQL (UTC) ramenée dans le fuseau de la période
+			y, m, d := p.bucketStart.Date()
+			filled = append(filled, NewTimeSeriesPoint(
+				time.Date(y, m, d, 0, 0, 0, 0, loc),
+				p.revenue, p.orderCount, p.averageOrderValue, p.quantity,
+			))
+			continue
+		}
+		filled = append(filled, NewTimeSeriesPoint(bucket, zero, 0, zero, domain.MustNewQuantity(0)))
+	}
+
+	return &TimeSeries{
+		granularity: granularity,
+		dateRange:   dateRange,
+		points:      filled,
+	}
+}
+
+// Granularity retourne la taille des buckets
+func (ts *TimeSeries) Granularity() Granularity {
+	return ts.granularity
+}
+
+// DateRange retourne la période couverte
+func (ts *TimeSeries) DateRange() domain.DateRange {
+	return ts.dateRange
+}
+
+// Points retourne les buckets dans l'ordre chronologique
+func (ts *TimeSeries) Points() []*TimeSeriesPoint {
+	return append([]*TimeSeriesPoint{}, ts.points...)
+}
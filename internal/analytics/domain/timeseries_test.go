@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// TestNewTimeSeries_ZeroFill vérifie que les buckets sans commande sont présents à zéro
+func TestNewTimeSeries_ZeroFill(t *testing.T) {
+	dateRange, err := domain.NewDateRange(
+		time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 9, 5, 0, 0, 0, 0, time.UTC),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revenue, _ := domain.NewMoney(120, "EUR")
+	points := []*TimeSeriesPoint{
+		NewTimeSeriesPoint(time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC), revenue, 2, revenue, domain.MustNewQuantity(3)),
+		NewTimeSeriesPoint(time.Date(2025, 9, 5, 0, 0, 0, 0, time.UTC), revenue, 1, revenue, domain.MustNewQuantity(1)),
+	}
+
+	series := NewTimeSeries(GranularityDay, dateRange, domain.DefaultCurrency, points)
+	got := series.Points()
+	if len(got) != 5 {
+		t.Fatalf("points = %d, want 5", len(got))
+	}
+
+	wantOrders := []int{0, 2, 0, 0, 1}
+	for i, p := range got {
+		if p.OrderCount() != wantOrders[i] {
+			t.Errorf("bucket %s orders = %d, want %d", p.BucketStart().Format("2006-01-02"), p.OrderCount(), wantOrders[i])
+		}
+	}
+}
+
+// TestGranularity_BucketStart vérifie l'alignement sur date_trunc (lundi ISO, 1er du mois)
+func TestGranularity_BucketStart(t *testing.T) {
+	sunday := time.Date(2025, 9, 7, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		granularity Granularity
+		want        string
+	}{
+		{GranularityDay, "2025-09-07"},
+		{GranularityWeek, "2025-09-01"},
+		{GranularityMonth, "2025-09-01"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.granularity.BucketStart(sunday).Format("2006-01-02"); got != tt.want {
+			t.Errorf("%s bucket = %s, want %s", tt.granularity, got, tt.want)
+		}
+	}
+
+	// Une période du 3 au 20 septembre en semaines: buckets des lundis 1, 8 et 15
+	dateRange, _ := domain.NewDateRange(time.Date(2025, 9, 3, 0, 0, 0, 0, time.UTC), time.Date(2025, 9, 20, 0, 0, 0, 0, time.UTC))
+	if n := len(NewTimeSeries(GranularityWeek, dateRange, domain.DefaultCurrency, nil).Points()); n != 3 {
+		t.Errorf("weekly buckets = %d, want 3", n)
+	}
+}
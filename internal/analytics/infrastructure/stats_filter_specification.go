@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"eval/internal/analytics/domain"
+	ordersinfra "eval/internal/orders/infrastructure"
+	"eval/internal/shared/infrastructure"
+)
+
+// orderFilterSpecification traduit un StatsFilter en prédicat sur la table orders (alias o)
+// Statuts retenus (completed par défaut) puis dimensions du filtre
+func orderFilterSpecification(filter domain.StatsFilter) infrastructure.Specification {
+	specs := []infrastructure.Specification{ordersinfra.StatusSpecification("o", filter.Status())}
+	return infrastructure.And(append(specs, orderDimensionSpecs(filter)...)...)
+}
+
+// orderDimensionSpecification prédicat des dimensions seules, sans condition de statut
+// (ventilation par statut: toutes les commandes des magasins / catégories filtrés)
+func orderDimensionSpecification(filter domain.StatsFilter) infrastructure.Specification {
+	return infrastructure.And(orderDimensionSpecs(filter)...)
+}
+
+// orderDimensionSpecs liste les prédicats des dimensions du filtre
+// Les dimensions portées par d'autres tables passent par des sous-requêtes IN / EXISTS:
+//   - PostgreSQL les transforme en semi-join, sans dupliquer les lignes de orders
+//     (un JOIN stores + GROUP BY ferait le même travail mais compliquerait chaque requête)
+func orderDimensionSpecs(filter domain.StatsFilter) []infrastructure.Specification {
+	var specs []infrastructure.Specification
+
+	if filter.StoreID() != 0 {
+		specs = append(specs, infrastructure.NewSQLSpecification(
+			"o.store_id = ?", int64(filter.StoreID())))
+	}
+	if filter.Region() != "" {
+		specs = append(specs, infrastructure.NewSQLSpecification(
+			"o.store_id IN (SELECT id FROM stores WHERE region = ?)", filter.Region()))
+	}
+	if filter.City() != "" {
+		specs = append(specs, infrastructure.NewSQLSpecification(
+			"o.store_id IN (SELECT id FROM stores WHERE city = ?)", filter.City()))
+	}
+	if filter.PaymentMethodID() != 0 {
+		specs = append(specs, infrastructure.NewSQLSpecification(
+			"o.payment_method_id = ?", int64(filter.PaymentMethodID())))
+	}
+	if filter.PromotionCode() != "" {
+		specs = append(specs, infrastructure.NewSQLSpecification(
+			"o.promotion_id IN (SELECT id FROM promotions WHERE code = ?)", filter.PromotionCode()))
+	}
+	if filter.HasItemCriteria() {
+		// Commandes contenant au moins un article de la catégorie / du fournisseur
+		itemPredicate, itemArgs := itemFilterSpecification(filter, "ef").ToSQL()
+		specs = append(specs, infrastructure.NewSQLSpecification(
+			"EXISTS (SELECT 1 FROM order_items ef WHERE ef.order_id = o.id AND "+itemPredicate+")", itemArgs...))
+	}
+
+	return specs
+}
+
+// itemFilterSpecification traduit les critères article (catégorie, fournisseur) en prédicat
+// sur une table order_items d'alias itemAlias
+func itemFilterSpecification(filter domain.StatsFilter, itemAlias string) infrastructure.Specification {
+	var specs []infrastructure.Specification
+
+	if filter.CategoryID() != 0 {
+		specs = append(specs, infrastructure.NewSQLSpecification(
+			itemAlias+".product_id IN (SELECT product_id FROM product_categories WHERE category_id = ?)",
+			int64(filter.CategoryID())))
+	}
+	if filter.SupplierID() != 0 {
+		specs = append(specs, infrastructure.NewSQLSpecification(
+			itemAlias+".product_id IN (SELECT id FROM products WHERE supplier_id = ?)",
+			int64(filter.SupplierID())))
+	}
+
+	return infrastructure.And(specs...)
+}
+
+// salesFilterSpecification combine les critères commande (alias o) et article (alias oi)
+// pour les requêtes qui agrègent des lignes de vente (catégories, produits)
+func salesFilterSpecification(filter domain.StatsFilter) infrastructure.Specification {
+	return infrastructure.And(orderFilterSpecification(filter), itemFilterSpecification(filter, "oi"))
+}
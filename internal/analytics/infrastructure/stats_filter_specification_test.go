@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"reflect"
+	"testing"
+
+	"eval/internal/analytics/domain"
+	ordersdomain "eval/internal/orders/domain"
+	"eval/internal/shared/infrastructure"
+)
+
+// TestOrderFilterSpecification_Bind vérifie la numérotation des paramètres après ceux de la période
+func TestOrderFilterSpecification_Bind(t *testing.T) {
+	filter, err := domain.NewStatsFilter(domain.StatsFilterCriteria{
+		StoreID:    3,
+		Region:     "Île-de-France",
+		CategoryID: 7,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	where, args := infrastructure.BindSpecification(orderFilterSpecification(filter), 3)
+
+	wantWhere := "(COALESCE(o.status, 'completed') IN ($3)) AND (o.store_id = $4) AND (o.store_id IN (SELECT id FROM stores WHERE region = $5)) AND " +
+		"(EXISTS (SELECT 1 FROM order_items ef WHERE ef.order_id = o.id AND " +
+		"(ef.product_id IN (SELECT product_id FROM product_categories WHERE category_id = $6))))"
+	if where != wantWhere {
+		t.Errorf("where =\n%s\nwant\n%s", where, wantWhere)
+	}
+	if want := []interface{}{"completed", int64(3), "Île-de-France", int64(7)}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+}
+
+// TestOrderFilterSpecification_Empty vérifie qu'un filtre vide retient seulement les commandes completed
+func TestOrderFilterSpecification_Empty(t *testing.T) {
+	where, args := infrastructure.BindSpecification(orderFilterSpecification(domain.StatsFilter{}), 3)
+	if where != "(COALESCE(o.status, 'completed') IN ($3))" || !reflect.DeepEqual(args, []interface{}{"completed"}) {
+		t.Errorf("empty filter = %q %v, want only the completed status", where, args)
+	}
+}
+
+// TestOrderFilterSpecification_Statuses vérifie le filtre multi-statuts et la ventilation sans statut
+func TestOrderFilterSpecification_Statuses(t *testing.T) {
+	status, _ := ordersdomain.ParseStatusFilter("pending,cancelled")
+	filter, _ := domain.NewStatsFilter(domain.StatsFilterCriteria{StoreID: 2, Status: status})
+
+	where, args := infrastructure.BindSpecification(orderFilterSpecification(filter), 3)
+	if want := "(COALESCE(o.status, 'completed') IN ($3, $4)) AND (o.store_id = $5)"; where != want {
+		t.Errorf("where = %q, want %q", where, want)
+	}
+	if want := []interface{}{"pending", "cancelled", int64(2)}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+
+	where, _ = infrastructure.BindSpecification(orderDimensionSpecification(filter), 3)
+	if want := "(o.store_id = $3)"; where != want {
+		t.Errorf("dimensions only = %q, want %q", where, want)
+	}
+}
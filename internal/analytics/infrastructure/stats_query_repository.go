@@ -1,11 +1,17 @@
 package infrastructure
 
 import (
+	"context"
 	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
 
 	"eval/internal/analytics/domain"
 	catalogdomain "eval/internal/catalog/domain"
 	ordersdomain "eval/internal/orders/domain"
+	ordersinfra "eval/internal/orders/infrastructure"
 	shareddomain "eval/internal/shared/domain"
 	"eval/internal/shared/infrastructure"
 )
@@ -23,45 +29,138 @@ func NewStatsQueryRepository(db *sql.DB) *StatsQueryRepository {
 }
 
 // GetGlobalStats récupère les statistiques globales de manière optimisée
-func (r *StatsQueryRepository) GetGlobalStats(dateRange shareddomain.DateRange) (shareddomain.Money, int, shareddomain.Money, error) {
-	query := `
-		SELECT COALESCE(SUM(total_amount), 0) as total_revenue,
-		       COALESCE(COUNT(*), 0) as total_orders,
-		       COALESCE(AVG(total_amount), 0) as avg_order_value
-		FROM orders
-		WHERE order_date >= $1 AND order_date <= $2
-	`
+// filter restreint les commandes prises en compte (StatsFilter{} = toutes)
+// Les montants sont convertis dans filter.Currency() ($3) au taux du jour de chaque commande,
+// comme dans toutes les requêtes de ce repository qui agrègent des montants
+//
+// PIÈGE: avec un filtre catégorie / fournisseur, le CA est celui des lignes correspondantes
+// (order_items.subtotal), pas orders.total_amount: une commande mixte ne doit pas compter
+// ses articles des autres catégories dans le CA de la catégorie (voir globalStatsQuery)
+func (r *StatsQueryRepository) GetGlobalStats(
+	dateRange shareddomain.DateRange,
+	filter domain.StatsFilter,
+) (shareddomain.Money, int, shareddomain.Money, error) {
+	query, filterArgs := globalStatsQuery(filter)
 
-	var totalRevenue, avgOrderValue float64
+	// PERFORMANCE: NUMERIC scanné directement dans Money (centimes exacts, pas de float64 intermédiaire)
+	var revenue, avgOrder shareddomain.Money
 	var totalOrders int
 
-	err := r.QueryRow(query, dateRange.Start(), dateRange.End()).Scan(&totalRevenue, &totalOrders, &avgOrderValue)
+	currency := filter.Currency()
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, filterArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		var emptyMoney shareddomain.Money
+		return emptyMoney, 0, emptyMoney, err
+	}
+	err := r.QueryRow(query, args...).Scan(
+		shareddomain.MoneyIn(&revenue, currency), &totalOrders, shareddomain.MoneyIn(&avgOrder, currency))
 	if err != nil {
 		var emptyMoney shareddomain.Money
 		return emptyMoney, 0, emptyMoney, err
 	}
 
-	revenue, _ := shareddomain.NewMoney(totalRevenue, "EUR")
-	avgOrder, _ := shareddomain.NewMoney(avgOrderValue, "EUR")
-
 	return revenue, totalOrders, avgOrder, nil
 }
 
+// globalStatsQuery requête de GetGlobalStats: CA, nombre de commandes et panier moyen
+// Paramètres: $1/$2 = période, $3 = devise, filtre à partir de $4
+//   - sans critère article: une ligne par commande, montant orders.total_amount
+//   - catégorie / fournisseur: CA des lignes correspondantes, sommé par commande puis agrégé;
+//     le panier moyen est le CA de ces lignes par commande qui en contient au moins une
+func globalStatsQuery(filter domain.StatsFilter) (string, []interface{}) {
+	if !filter.HasItemCriteria() {
+		orderWhere, filterArgs := infrastructure.BindSpecification(orderFilterSpecification(filter), 4)
+		amount := infrastructure.ConvertedAmountSQL("o.total_amount", "o", 3)
+		return `
+		SELECT COALESCE(SUM(` + amount + `), 0) as total_revenue,
+		       COALESCE(COUNT(*), 0) as total_orders,
+		       COALESCE(AVG(` + amount + `), 0) as avg_order_value
+		FROM orders o` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+		WHERE o.order_date >= $1 AND o.order_date <= $2
+		  AND ` + orderWhere, filterArgs
+	}
+
+	salesWhere, filterArgs := infrastructure.BindSpecification(salesFilterSpecification(filter), 4)
+	amount := infrastructure.ConvertedAmountSQL("oi.subtotal", "o", 3)
+	return `
+		SELECT COALESCE(SUM(order_revenue), 0) as total_revenue,
+		       COUNT(*) as total_orders,
+		       COALESCE(AVG(order_revenue), 0) as avg_order_value
+		FROM (
+			SELECT SUM(` + amount + `) AS order_revenue
+			FROM orders o` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+			INNER JOIN order_items oi ON oi.order_id = o.id
+			WHERE o.order_date >= $1 AND o.order_date <= $2
+			  AND ` + salesWhere + `
+			GROUP BY o.id
+		) matching_orders`, filterArgs
+}
+
+// matchingOrdersSQL sous-requête: une ligne par commande retenue par filter avec son CA
+// (colonnes id, store_id, payment_method_id, revenue), pour ventiler le CA par dimension de commande
+// Paramètres: $1/$2 = période, currencyParam = devise, filtre à partir de filterParam
+//   - sans critère article: montant orders.total_amount
+//   - catégorie / fournisseur: CA des lignes correspondantes sommé par commande, comme globalStatsQuery:
+//     la somme des magasins ou des moyens de paiement est égale au CA total de la réponse
+func matchingOrdersSQL(filter domain.StatsFilter, currencyParam, filterParam int) (string, []interface{}) {
+	if !filter.HasItemCriteria() {
+		orderWhere, filterArgs := infrastructure.BindSpecification(orderFilterSpecification(filter), filterParam)
+		return `
+			SELECT o.id, o.store_id, o.payment_method_id,
+			       ` + infrastructure.ConvertedAmountSQL("o.total_amount", "o", currencyParam) + ` AS revenue
+			FROM orders o` + infrastructure.ExchangeRateJoinSQL("o", currencyParam) + `
+			WHERE o.order_date >= $1 AND o.order_date <= $2
+			  AND ` + orderWhere, filterArgs
+	}
+
+	salesWhere, filterArgs := infrastructure.BindSpecification(salesFilterSpecification(filter), filterParam)
+	return `
+			SELECT o.id, o.store_id, o.payment_method_id,
+			       SUM(` + infrastructure.ConvertedAmountSQL("oi.subtotal", "o", currencyParam) + `) AS revenue
+			FROM orders o` + infrastructure.ExchangeRateJoinSQL("o", currencyParam) + `
+			INNER JOIN order_items oi ON oi.order_id = o.id
+			WHERE o.order_date >= $1 AND o.order_date <= $2
+			  AND ` + salesWhere + `
+			GROUP BY o.id, o.store_id, o.payment_method_id`, filterArgs
+}
+
 // GetCategoryStats récupère les statistiques par catégorie (optimisé)
-func (r *StatsQueryRepository) GetCategoryStats(dateRange shareddomain.DateRange) ([]*domain.CategoryStats, error) {
+// Les catégories sans vente sur la période sont retournées avec un CA de 0
+//
+// PIÈGE (corrigé): le filtre de période ne doit PAS être dans un "LEFT JOIN orders ... AND order_date"
+//   - Un LEFT JOIN garde la ligne order_item même si la commande ne correspond pas (o.* = NULL)
+//   - SUM(oi.subtotal) additionnait alors les ventes de TOUTES les périodes (et les commandes annulées)
+//   - Solution: sélectionner d'abord les lignes de vente valides (INNER JOIN + WHERE dans la CTE),
+//     puis LEFT JOIN des catégories vers ce résultat pour garder les catégories à 0
+func (r *StatsQueryRepository) GetCategoryStats(
+	dateRange shareddomain.DateRange,
+	filter domain.StatsFilter,
+) ([]*domain.CategoryStats, error) {
+	salesWhere, filterArgs := infrastructure.BindSpecification(salesFilterSpecification(filter), 4)
 	query := `
+		WITH sales AS (
+			SELECT oi.product_id, oi.order_id, ` + infrastructure.ConvertedAmountSQL("oi.subtotal", "o", 3) + ` AS subtotal
+			FROM order_items oi
+			INNER JOIN orders o ON oi.order_id = o.id` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+			WHERE o.order_date >= $1 AND o.order_date <= $2
+			  AND ` + salesWhere + `
+		)
 		SELECT c.id, c.name,
-		       COALESCE(SUM(oi.subtotal), 0) as total_revenue,
-		       COALESCE(COUNT(DISTINCT o.id), 0) as total_orders
+		       COALESCE(SUM(s.subtotal), 0) as total_revenue,
+		       COUNT(DISTINCT s.order_id) as total_orders
 		FROM categories c
 		LEFT JOIN product_categories pc ON c.id = pc.category_id
-		LEFT JOIN order_items oi ON pc.product_id = oi.product_id
-		LEFT JOIN orders o ON oi.order_id = o.id AND o.order_date >= $1 AND o.order_date <= $2
+		LEFT JOIN sales s ON pc.product_id = s.product_id
 		GROUP BY c.id, c.name
-		ORDER BY total_revenue DESC
+		ORDER BY total_revenue DESC, c.id
 	`
 
-	rows, err := r.Query(query, dateRange.Start(), dateRange.End())
+	currency := filter.Currency()
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, filterArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -71,17 +170,16 @@ func (r *StatsQueryRepository) GetCategoryStats(dateRange shareddomain.DateRange
 	for rows.Next() {
 		// Ces variables locales servent de tampons temporaires pour Scan.
 		var (
-			catID        int64
-			catName      string
-			totalRevenue float64
-			totalOrders  int
+			catID       int64
+			catName     string
+			revenue     shareddomain.Money
+			totalOrders int
 		)
 
-		if err := rows.Scan(&catID, &catName, &totalRevenue, &totalOrders); err != nil {
+		if err := rows.Scan(&catID, &catName, shareddomain.MoneyIn(&revenue, currency), &totalOrders); err != nil {
 			return nil, err
 		}
 
-		revenue, _ := shareddomain.NewMoney(totalRevenue, "EUR")
 		stat := domain.NewCategoryStats(
 			catalogdomain.CategoryID(catID),
 			catName,
@@ -99,7 +197,11 @@ func (r *StatsQueryRepository) GetCategoryStats(dateRange shareddomain.DateRange
 //   - Agrégation faite par PostgreSQL (moteur C optimisé)
 //   - Seulement les résultats agrégés sont transférés sur le réseau
 //   - Si 100k order_items → 1000 products: on transfère 1000 rows au lieu de 100k!
-func (r *StatsQueryRepository) GetTopProducts(dateRange shareddomain.DateRange, limit int) ([]*domain.ProductStats, error) {
+func (r *StatsQueryRepository) GetTopProducts(
+	dateRange shareddomain.DateRange,
+	filter domain.StatsFilter,
+	limit int,
+) ([]*domain.ProductStats, error) {
 	// SYNTAXE SQL optimisée:
 	//   - COALESCE(value, 0) = retourne 0 si value est NULL (évite NULL en Go)
 	//   - SUM() et COUNT() = agrégations faites par le moteur DB (très rapide)
@@ -107,20 +209,35 @@ func (r *StatsQueryRepository) GetTopProducts(dateRange shareddomain.DateRange,
 	//   - GROUP BY = une ligne de résultat par produit (agrégation)
 	//   - ORDER BY + LIMIT = tri et pagination côté DB (utilise index si disponible)
 	// PERFORMANCE: Query plan optimal si index sur (product_id, order_date)
+	// Même correction que GetCategoryStats: les lignes de vente sont filtrées avant le LEFT JOIN
+	// Tri secondaire par p.id: classement stable entre deux appels en cas d'égalité de CA
+	salesWhere, filterArgs := infrastructure.BindSpecification(salesFilterSpecification(filter), 5)
 	query := `
+		WITH sales AS (
+			SELECT oi.product_id, oi.order_id, ` + infrastructure.ConvertedAmountSQL("oi.subtotal", "o", 4) + ` AS subtotal,
+			       oi.quantity
+			FROM order_items oi
+			INNER JOIN orders o ON oi.order_id = o.id` + infrastructure.ExchangeRateJoinSQL("o", 4) + `
+			WHERE o.order_date >= $1 AND o.order_date <= $2
+			  AND ` + salesWhere + `
+		)
 		SELECT p.id, p.name,
-		       COALESCE(SUM(oi.subtotal), 0) as total_revenue,
-		       COALESCE(COUNT(DISTINCT oi.order_id), 0) as total_orders,
-		       COALESCE(SUM(oi.quantity), 0) as total_quantity
+		       COALESCE(SUM(s.subtotal), 0) as total_revenue,
+		       COUNT(DISTINCT s.order_id) as total_orders,
+		       COALESCE(SUM(s.quantity), 0) as total_quantity
 		FROM products p
-		LEFT JOIN order_items oi ON p.id = oi.product_id
-		LEFT JOIN orders o ON oi.order_id = o.id AND o.order_date >= $1 AND o.order_date <= $2
+		LEFT JOIN sales s ON p.id = s.product_id
 		GROUP BY p.id, p.name
-		ORDER BY total_revenue DESC
+		ORDER BY total_revenue DESC, p.id
 		LIMIT $3
 	`
 
-	rows, err := r.Query(query, dateRange.Start(), dateRange.End(), limit)
+	currency := filter.Currency()
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), limit, currency.String()}, filterArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -134,22 +251,21 @@ func (r *StatsQueryRepository) GetTopProducts(dateRange shareddomain.DateRange,
 		// SYNTAXE: var ( ... ) = déclaration de plusieurs variables
 		//   - Variables locales sur la STACK (scope de la boucle)
 		//   - Réutilisées à chaque itération (pas d'allocation répétée)
-		// MÉMOIRE: Total ~56 bytes sur STACK par itération
+		// MÉMOIRE: Total ~64 bytes sur STACK par itération
 		var (
-			prodID       int64   // 8 bytes
-			prodName     string  // 16 bytes (header)
-			totalRevenue float64 // 8 bytes
-			totalOrders  int     // 8 bytes
-			totalQty     int     // 8 bytes
+			prodID      int64              // 8 bytes
+			prodName    string             // 16 bytes (header)
+			revenue     shareddomain.Money // 24 bytes (int64 + header string)
+			totalOrders int                // 8 bytes
+			totalQty    int                // 8 bytes
 		)
 
 		// PERFORMANCE: Scan très rapide ici, seulement 'limit' rows (ex: 10)
 		//   - Vs V1 qui scannait potentiellement 100k rows!
-		if err := rows.Scan(&prodID, &prodName, &totalRevenue, &totalOrders, &totalQty); err != nil {
+		if err := rows.Scan(&prodID, &prodName, shareddomain.MoneyIn(&revenue, currency), &totalOrders, &totalQty); err != nil {
 			return nil, err
 		}
 
-		revenue, _ := shareddomain.NewMoney(totalRevenue, "EUR")
 		qty, _ := shareddomain.NewQuantity(totalQty)
 
 		stat := domain.NewProductStats(
@@ -166,19 +282,33 @@ func (r *StatsQueryRepository) GetTopProducts(dateRange shareddomain.DateRange,
 }
 
 // GetTopStores récupère les N meilleurs magasins (optimisé)
-func (r *StatsQueryRepository) GetTopStores(dateRange shareddomain.DateRange, limit int) ([]*domain.StoreStats, error) {
+// Avec un filtre catégorie / fournisseur, le CA d'un magasin est celui des lignes correspondantes
+// (voir matchingOrdersSQL); tri secondaire par s.id: top N stable en cas d'égalité de CA
+func (r *StatsQueryRepository) GetTopStores(
+	dateRange shareddomain.DateRange,
+	filter domain.StatsFilter,
+	limit int,
+) ([]*domain.StoreStats, error) {
+	matchingOrders, filterArgs := matchingOrdersSQL(filter, 4, 5)
 	query := `
+		WITH matching_orders AS (` + matchingOrders + `
+		)
 		SELECT s.id, s.name,
-		       COALESCE(SUM(o.total_amount), 0) as total_revenue,
-		       COALESCE(COUNT(o.id), 0) as total_orders
+		       COALESCE(SUM(m.revenue), 0) as total_revenue,
+		       COUNT(m.id) as total_orders
 		FROM stores s
-		LEFT JOIN orders o ON s.id = o.store_id AND o.order_date >= $1 AND o.order_date <= $2
+		LEFT JOIN matching_orders m ON m.store_id = s.id
 		GROUP BY s.id, s.name
-		ORDER BY total_revenue DESC
+		ORDER BY total_revenue DESC, s.id
 		LIMIT $3
 	`
 
-	rows, err := r.Query(query, dateRange.Start(), dateRange.End(), limit)
+	currency := filter.Currency()
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), limit, currency.String()}, filterArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -187,17 +317,16 @@ func (r *StatsQueryRepository) GetTopStores(dateRange shareddomain.DateRange, li
 	var stats []*domain.StoreStats
 	for rows.Next() {
 		var (
-			storeID      int64
-			storeName    string
-			totalRevenue float64
-			totalOrders  int
+			storeID     int64
+			storeName   string
+			revenue     shareddomain.Money
+			totalOrders int
 		)
 
-		if err := rows.Scan(&storeID, &storeName, &totalRevenue, &totalOrders); err != nil {
+		if err := rows.Scan(&storeID, &storeName, shareddomain.MoneyIn(&revenue, currency), &totalOrders); err != nil {
 			return nil, err
 		}
 
-		revenue, _ := shareddomain.NewMoney(totalRevenue, "EUR")
 		stat := domain.NewStoreStats(
 			ordersdomain.StoreID(storeID),
 			storeName,
@@ -210,19 +339,247 @@ func (r *StatsQueryRepository) GetTopStores(dateRange shareddomain.DateRange, li
 	return stats, nil
 }
 
+// GetStoreActivity agrège CA, commandes et articles vendus de chaque magasin sur la période
+// Tous les magasins sont retournés (0 si aucune vente), triés par CA décroissant puis id
+//
+// PIÈGE: joindre order_items puis SUM(o.total_amount) compterait chaque commande autant de fois
+// qu'elle a de lignes: commandes/CA et articles sont agrégés dans deux CTE séparées
+func (r *StatsQueryRepository) GetStoreActivity(
+	dateRange shareddomain.DateRange,
+	status ordersdomain.StatusFilter,
+	currency shareddomain.Currency,
+) ([]*domain.StoreActivity, error) {
+	// $3 = devise de reporting, les mêmes $4.. servent aux deux CTE
+	statusWhere, statusArgs := infrastructure.BindSpecification(ordersinfra.StatusSpecification("o", status), 4)
+	query := `
+		WITH order_totals AS (
+			SELECT o.store_id, COUNT(*) AS order_count,
+			       SUM(` + infrastructure.ConvertedAmountSQL("o.total_amount", "o", 3) + `) AS revenue
+			FROM orders o` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+			WHERE o.order_date >= $1 AND o.order_date <= $2 AND ` + statusWhere + `
+			GROUP BY o.store_id
+		),
+		item_totals AS (
+			SELECT o.store_id, SUM(oi.quantity) AS items_sold
+			FROM orders o
+			INNER JOIN order_items oi ON oi.order_id = o.id
+			WHERE o.order_date >= $1 AND o.order_date <= $2 AND ` + statusWhere + `
+			GROUP BY o.store_id
+		)
+		SELECT s.id, s.name, s.city, COALESCE(s.region, ''),
+		       COALESCE(ot.order_count, 0),
+		       COALESCE(ot.revenue, 0) AS revenue,
+		       COALESCE(it.items_sold, 0)
+		FROM stores s
+		LEFT JOIN order_totals ot ON ot.store_id = s.id
+		LEFT JOIN item_totals it ON it.store_id = s.id
+		ORDER BY revenue DESC, s.id
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, statusArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stores []*domain.StoreActivity
+	for rows.Next() {
+		var (
+			storeID    int64
+			name       string
+			city       string
+			region     string
+			orderCount int
+			revenue    shareddomain.Money
+			itemsSold  int
+		)
+
+		if err := rows.Scan(&storeID, &name, &city, &region, &orderCount, shareddomain.MoneyIn(&revenue, currency), &itemsSold); err != nil {
+			return nil, err
+		}
+
+		stores = append(stores, domain.NewStoreActivity(
+			ordersdomain.StoreID(storeID), name, city, region, orderCount, revenue, itemsSold))
+	}
+
+	return stores, rows.Err()
+}
+
+// GetStorePaymentMix répartit le CA de chaque magasin par moyen de paiement
+// Seuls les couples (magasin, moyen de paiement) ayant au moins une commande sont retournés
+func (r *StatsQueryRepository) GetStorePaymentMix(
+	dateRange shareddomain.DateRange,
+	status ordersdomain.StatusFilter,
+	currency shareddomain.Currency,
+) (map[ordersdomain.StoreID][]*domain.StorePaymentShare, error) {
+	statusWhere, statusArgs := infrastructure.BindSpecification(ordersinfra.StatusSpecification("o", status), 4)
+	query := `
+		SELECT o.store_id, pm.id, pm.name,
+		       COUNT(*) AS order_count,
+		       SUM(` + infrastructure.ConvertedAmountSQL("o.total_amount", "o", 3) + `) AS revenue
+		FROM orders o` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+		INNER JOIN payment_methods pm ON pm.id = o.payment_method_id
+		WHERE o.order_date >= $1 AND o.order_date <= $2 AND ` + statusWhere + `
+		GROUP BY o.store_id, pm.id, pm.name
+		ORDER BY o.store_id, revenue DESC, pm.id
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, statusArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mix := make(map[ordersdomain.StoreID][]*domain.StorePaymentShare)
+	for rows.Next() {
+		var (
+			storeID    int64
+			pmID       int64
+			pmName     string
+			orderCount int
+			revenue    shareddomain.Money
+		)
+
+		if err := rows.Scan(&storeID, &pmID, &pmName, &orderCount, shareddomain.MoneyIn(&revenue, currency)); err != nil {
+			return nil, err
+		}
+
+		id := ordersdomain.StoreID(storeID)
+		mix[id] = append(mix[id], domain.NewStorePaymentShare(
+			ordersdomain.PaymentMethodID(pmID), pmName, orderCount, revenue))
+	}
+
+	return mix, rows.Err()
+}
+
+// GetDailyStoreActivity agrège commandes et CA par magasin et par jour
+// Seuls les couples (magasin, jour) ayant au moins une commande sont retournés:
+// les jours à zéro (caisse en panne, chargement manquant) sont reconstitués par le domaine
+func (r *StatsQueryRepository) GetDailyStoreActivity(
+	dateRange shareddomain.DateRange,
+	status ordersdomain.StatusFilter,
+	currency shareddomain.Currency,
+) ([]*domain.DailyActivity, error) {
+	statusWhere, statusArgs := infrastructure.BindSpecification(ordersinfra.StatusSpecification("o", status), 4)
+	query := `
+		SELECT s.id, s.name, o.order_date,
+		       COUNT(*) AS order_count,
+		       SUM(` + infrastructure.ConvertedAmountSQL("o.total_amount", "o", 3) + `) AS revenue
+		FROM orders o` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+		INNER JOIN stores s ON s.id = o.store_id
+		WHERE o.order_date >= $1 AND o.order_date <= $2 AND ` + statusWhere + `
+		GROUP BY s.id, s.name, o.order_date
+		ORDER BY s.id, o.order_date
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, statusArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDailyActivity(rows, domain.AnomalyDimensionStore, currency)
+}
+
+// GetDailyCategoryActivity agrège commandes et CA (lignes de la catégorie) par catégorie et par jour
+// Même définition que GetCategoryStats: une commande compte pour chaque catégorie de ses articles
+func (r *StatsQueryRepository) GetDailyCategoryActivity(
+	dateRange shareddomain.DateRange,
+	status ordersdomain.StatusFilter,
+	currency shareddomain.Currency,
+) ([]*domain.DailyActivity, error) {
+	statusWhere, statusArgs := infrastructure.BindSpecification(ordersinfra.StatusSpecification("o", status), 4)
+	query := `
+		SELECT c.id, c.name, o.order_date,
+		       COUNT(DISTINCT o.id) AS order_count,
+		       SUM(` + infrastructure.ConvertedAmountSQL("oi.subtotal", "o", 3) + `) AS revenue
+		FROM order_items oi
+		INNER JOIN orders o ON oi.order_id = o.id` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+		INNER JOIN product_categories pc ON pc.product_id = oi.product_id
+		INNER JOIN categories c ON c.id = pc.category_id
+		WHERE o.order_date >= $1 AND o.order_date <= $2 AND ` + statusWhere + `
+		GROUP BY c.id, c.name, o.order_date
+		ORDER BY c.id, o.order_date
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, statusArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDailyActivity(rows, domain.AnomalyDimensionCategory, currency)
+}
+
+// scanDailyActivity lit les lignes (id, nom, jour, commandes, CA converti dans currency)
+// des requêtes d'activité quotidienne
+func scanDailyActivity(
+	rows *sql.Rows,
+	dimension domain.AnomalyDimension,
+	currency shareddomain.Currency,
+) ([]*domain.DailyActivity, error) {
+	var activity []*domain.DailyActivity
+	for rows.Next() {
+		var (
+			id         int64
+			name       string
+			day        time.Time
+			orderCount int
+			revenue    shareddomain.Money
+		)
+
+		if err := rows.Scan(&id, &name, &day, &orderCount, shareddomain.MoneyIn(&revenue, currency)); err != nil {
+			return nil, err
+		}
+
+		activity = append(activity, domain.NewDailyActivity(dimension, id, name, day, orderCount, revenue))
+	}
+
+	return activity, rows.Err()
+}
+
 // GetPaymentMethodDistribution récupère la distribution des moyens de paiement (optimisé)
-func (r *StatsQueryRepository) GetPaymentMethodDistribution(dateRange shareddomain.DateRange) ([]*domain.PaymentMethodStats, error) {
+// Avec un filtre catégorie / fournisseur, le CA d'un moyen de paiement est celui des lignes
+// correspondantes (voir matchingOrdersSQL): les pourcentages se rapportent au CA total de la réponse
+func (r *StatsQueryRepository) GetPaymentMethodDistribution(
+	dateRange shareddomain.DateRange,
+	filter domain.StatsFilter,
+) ([]*domain.PaymentMethodStats, error) {
+	matchingOrders, filterArgs := matchingOrdersSQL(filter, 3, 4)
 	query := `
+		WITH matching_orders AS (` + matchingOrders + `
+		)
 		SELECT pm.id, pm.name,
-		       COALESCE(SUM(o.total_amount), 0) as total_revenue,
-		       COALESCE(COUNT(o.id), 0) as total_orders
+		       COALESCE(SUM(m.revenue), 0) as total_revenue,
+		       COUNT(m.id) as total_orders
 		FROM payment_methods pm
-		LEFT JOIN orders o ON pm.id = o.payment_method_id AND o.order_date >= $1 AND o.order_date <= $2
+		LEFT JOIN matching_orders m ON m.payment_method_id = pm.id
 		GROUP BY pm.id, pm.name
-		ORDER BY total_revenue DESC
+		ORDER BY total_revenue DESC, pm.id
 	`
 
-	rows, err := r.Query(query, dateRange.Start(), dateRange.End())
+	currency := filter.Currency()
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, filterArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -241,24 +598,23 @@ func (r *StatsQueryRepository) GetPaymentMethodDistribution(dateRange shareddoma
 
 	for rows.Next() {
 		var (
-			pmID         int64
-			pmName       string
-			totalRevenue float64
-			totalOrders  int
+			pmID        int64
+			pmName      string
+			revenue     shareddomain.Money
+			totalOrders int
 		)
 
-		if err := rows.Scan(&pmID, &pmName, &totalRevenue, &totalOrders); err != nil {
+		if err := rows.Scan(&pmID, &pmName, shareddomain.MoneyIn(&revenue, currency), &totalOrders); err != nil {
 			return nil, err
 		}
 
-		revenue, _ := shareddomain.NewMoney(totalRevenue, "EUR")
 		data = append(data, pmData{
 			id:           ordersdomain.PaymentMethodID(pmID),
 			name:         pmName,
 			totalRevenue: revenue,
 			totalOrders:  totalOrders,
 		})
-		grandTotal += totalRevenue
+		grandTotal += revenue.Amount()
 	}
 
 	// Deuxième passage: calculer les pourcentages
@@ -282,6 +638,630 @@ func (r *StatsQueryRepository) GetPaymentMethodDistribution(dateRange shareddoma
 	return stats, nil
 }
 
+// GetOrderStatusBreakdown ventile les commandes de la période par statut
+// Les dimensions du filtre s'appliquent, mais PAS ses statuts: la ventilation montre
+// justement ce que le filtre de statut écarte (annulations, commandes en attente)
+//
+// Les statuts connus sans commande sont retournés à 0, dans l'ordre du cycle de vie,
+// suivis des éventuels statuts inconnus présents en base
+func (r *StatsQueryRepository) GetOrderStatusBreakdown(
+	dateRange shareddomain.DateRange,
+	filter domain.StatsFilter,
+) ([]*domain.OrderStatusStats, error) {
+	orderWhere, filterArgs := infrastructure.BindSpecification(orderDimensionSpecification(filter), 4)
+	query := `
+		SELECT COALESCE(o.status, 'completed') as status,
+		       COALESCE(SUM(` + infrastructure.ConvertedAmountSQL("o.total_amount", "o", 3) + `), 0) as total_revenue,
+		       COUNT(*) as total_orders
+		FROM orders o` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+		WHERE o.order_date >= $1 AND o.order_date <= $2
+		  AND ` + orderWhere + `
+		GROUP BY 1
+	`
+
+	currency := filter.Currency()
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, filterArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type statusData struct {
+		revenue shareddomain.Money
+		orders  int
+	}
+
+	byStatus := make(map[ordersdomain.OrderStatus]statusData)
+	var unknown []ordersdomain.OrderStatus
+	var grandTotal int
+
+	for rows.Next() {
+		var (
+			status       string
+			totalRevenue shareddomain.Money
+			totalOrders  int
+		)
+		if err := rows.Scan(&status, shareddomain.MoneyIn(&totalRevenue, currency), &totalOrders); err != nil {
+			return nil, err
+		}
+
+		orderStatus, err := ordersdomain.ParseOrderStatus(status)
+		if err != nil {
+			orderStatus = ordersdomain.OrderStatus(status)
+			unknown = append(unknown, orderStatus)
+		}
+		byStatus[orderStatus] = statusData{revenue: totalRevenue, orders: totalOrders}
+		grandTotal += totalOrders
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i] < unknown[j] })
+
+	var stats []*domain.OrderStatusStats
+	for _, status := range append(append([]ordersdomain.OrderStatus{}, ordersdomain.OrderStatuses...), unknown...) {
+		d := byStatus[status]
+		percentage := 0.0
+		if grandTotal > 0 {
+			percentage = float64(d.orders) / float64(grandTotal) * 100
+		}
+
+		// Statut connu sans commande: valeur zéro de statusData, sans devise
+		revenue := d.revenue
+		if _, ok := byStatus[status]; !ok {
+			revenue = currency.Zero()
+		}
+		stats = append(stats, domain.NewOrderStatusStats(status, revenue, d.orders, percentage))
+	}
+
+	return stats, nil
+}
+
+// GetRevenueTimeSeries agrège les commandes par bucket (jour, semaine ISO ou mois)
+// Seuls les buckets contenant au moins une commande sont retournés:
+// le remplissage des trous est fait côté domaine (NewTimeSeries)
+//
+// SYNTAXE SQL:
+//   - date_trunc('week', ...) = lundi de la semaine (ISO), 'month' = 1er du mois
+//   - order_date::timestamp évite la conversion implicite en timestamptz (dépendante du fuseau de session)
+//
+// Avec un filtre catégorie / fournisseur, CA et quantités sont ceux des lignes correspondantes,
+// comme dans GetGlobalStats (voir timeSeriesQuery)
+func (r *StatsQueryRepository) GetRevenueTimeSeries(
+	dateRange shareddomain.DateRange,
+	granularity domain.Granularity,
+	filter domain.StatsFilter,
+) ([]*domain.TimeSeriesPoint, error) {
+	query, filterArgs := timeSeriesQuery(filter)
+
+	currency := filter.Currency()
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), string(granularity), currency.String()}, filterArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []*domain.TimeSeriesPoint
+	for rows.Next() {
+		var (
+			bucket        time.Time
+			revenue       shareddomain.Money
+			totalOrders   int
+			avgOrder      shareddomain.Money
+			totalQuantity int
+		)
+
+		err := rows.Scan(
+			&bucket,
+			shareddomain.MoneyIn(&revenue, currency),
+			&totalOrders,
+			shareddomain.MoneyIn(&avgOrder, currency),
+			&totalQuantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		qty, _ := shareddomain.NewQuantity(totalQuantity)
+		points = append(points, domain.NewTimeSeriesPoint(bucket, revenue, totalOrders, avgOrder, qty))
+	}
+
+	return points, rows.Err()
+}
+
+// timeSeriesQuery requête de GetRevenueTimeSeries: CA, commandes, panier moyen et quantités par bucket
+// Paramètres: $1/$2 = période, $3 = granularité, $4 = devise, filtre à partir de $5
+//   - sans critère article: montant orders.total_amount; la CTE pré-agrège les quantités par commande
+//     (joindre order_items directement dupliquerait total_amount autant de fois que la commande a de lignes)
+//   - catégorie / fournisseur: CA et quantités des lignes correspondantes, sommés par commande
+//     puis agrégés par bucket (une commande mixte ne compte que ses articles correspondants)
+func timeSeriesQuery(filter domain.StatsFilter) (string, []interface{}) {
+	if !filter.HasItemCriteria() {
+		orderWhere, filterArgs := infrastructure.BindSpecification(orderFilterSpecification(filter), 5)
+		amount := infrastructure.ConvertedAmountSQL("o.total_amount", "o", 4)
+		return `
+		WITH` + orderQuantitiesCTE(orderWhere) + `
+		SELECT date_trunc($3, o.order_date::timestamp)::date AS bucket,
+		       COALESCE(SUM(` + amount + `), 0) as total_revenue,
+		       COUNT(o.id) as total_orders,
+		       COALESCE(AVG(` + amount + `), 0) as avg_order_value,
+		       COALESCE(SUM(q.quantity), 0) as total_quantity
+		FROM orders o` + infrastructure.ExchangeRateJoinSQL("o", 4) + `
+		LEFT JOIN order_quantities q ON q.order_id = o.id
+		WHERE o.order_date >= $1 AND o.order_date <= $2 AND ` + orderWhere + `
+		GROUP BY bucket
+		ORDER BY bucket
+	`, filterArgs
+	}
+
+	salesWhere, filterArgs := infrastructure.BindSpecification(salesFilterSpecification(filter), 5)
+	amount := infrastructure.ConvertedAmountSQL("oi.subtotal", "o", 4)
+	return `
+		SELECT date_trunc($3, m.order_date::timestamp)::date AS bucket,
+		       COALESCE(SUM(m.order_revenue), 0) as total_revenue,
+		       COUNT(*) as total_orders,
+		       COALESCE(AVG(m.order_revenue), 0) as avg_order_value,
+		       COALESCE(SUM(m.order_quantity), 0) as total_quantity
+		FROM (
+			SELECT o.order_date,
+			       SUM(` + amount + `) AS order_revenue,
+			       SUM(oi.quantity) AS order_quantity
+			FROM orders o` + infrastructure.ExchangeRateJoinSQL("o", 4) + `
+			INNER JOIN order_items oi ON oi.order_id = o.id
+			WHERE o.order_date >= $1 AND o.order_date <= $2 AND ` + salesWhere + `
+			GROUP BY o.id, o.order_date
+		) m
+		GROUP BY bucket
+		ORDER BY bucket
+	`, filterArgs
+}
+
+// orderQuantitiesCTE nombre d'articles (somme des quantités) par commande filtrée
+// Paramètres: $1/$2 = période, orderWhere = filtre des commandes (alias o)
+func orderQuantitiesCTE(orderWhere string) string {
+	return `
+		order_quantities AS (
+			SELECT oi.order_id, SUM(oi.quantity) AS quantity
+			FROM order_items oi
+			INNER JOIN orders o ON oi.order_id = o.id
+			WHERE o.order_date >= $1 AND o.order_date <= $2 AND ` + orderWhere + `
+			GROUP BY oi.order_id
+		)`
+}
+
+// GetOrderValueSummary calcule moyenne, écart-type, extrêmes et percentiles des montants de commande,
+// ainsi que moyenne, médiane et p90 du nombre d'articles par commande
+//
+// SYNTAXE SQL:
+//   - percentile_cont(ARRAY[...]) WITHIN GROUP (ORDER BY x): tous les percentiles en un seul tri
+//   - percentile_cont interpole entre les deux valeurs encadrantes (médiane de 10 et 20 = 15)
+//   - STDDEV_SAMP vaut NULL avec moins de 2 commandes, percentile_cont NULL sans commande: COALESCE à 0
+func (r *StatsQueryRepository) GetOrderValueSummary(
+	dateRange shareddomain.DateRange,
+	filter domain.StatsFilter,
+) (*domain.OrderValueSummary, domain.ItemsPerOrderSummary, error) {
+	orderWhere, filterArgs := infrastructure.BindSpecification(orderFilterSpecification(filter), 4)
+	amount := infrastructure.ConvertedAmountSQL("o.total_amount", "o", 3)
+	query := `
+		WITH ` + orderQuantitiesCTE(orderWhere) + `
+		SELECT COUNT(*),
+		       COALESCE(AVG(` + amount + `), 0),
+		       COALESCE(STDDEV_SAMP(` + amount + `), 0),
+		       COALESCE(MIN(` + amount + `), 0),
+		       COALESCE(MAX(` + amount + `), 0),
+		       COALESCE(percentile_cont(ARRAY[0.5, 0.9, 0.95, 0.99]) WITHIN GROUP (ORDER BY ` + amount + `),
+		                ARRAY[0, 0, 0, 0]),
+		       COALESCE(AVG(COALESCE(q.quantity, 0)), 0),
+		       COALESCE(percentile_cont(ARRAY[0.5, 0.9]) WITHIN GROUP (ORDER BY COALESCE(q.quantity, 0)),
+		                ARRAY[0, 0])
+		FROM orders o` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+		LEFT JOIN order_quantities q ON q.order_id = o.id
+		WHERE o.order_date >= $1 AND o.order_date <= $2 AND ` + orderWhere + `
+	`
+
+	var (
+		orderCount         int
+		mean, stdDev       shareddomain.Money
+		minValue, maxValue shareddomain.Money
+		valuePercentiles   pq.Float64Array
+		itemsMean          float64
+		itemsPercentiles   pq.Float64Array
+	)
+	currency := filter.Currency()
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, filterArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, domain.ItemsPerOrderSummary{}, err
+	}
+	err := r.QueryRow(query, args...).Scan(
+		&orderCount,
+		shareddomain.MoneyIn(&mean, currency),
+		shareddomain.MoneyIn(&stdDev, currency),
+		shareddomain.MoneyIn(&minValue, currency),
+		shareddomain.MoneyIn(&maxValue, currency),
+		&valuePercentiles, &itemsMean, &itemsPercentiles)
+	if err != nil {
+		return nil, domain.ItemsPerOrderSummary{}, err
+	}
+
+	// percentile_cont travaille en double precision: seuls les percentiles passent par float64
+	var percentiles [4]shareddomain.Money
+	for i := range percentiles {
+		percentiles[i], _ = shareddomain.NewMoney(valuePercentiles[i], currency.String())
+	}
+
+	summary := domain.NewOrderValueSummary(orderCount, mean, stdDev, minValue, maxValue, percentiles)
+	return summary, domain.NewItemsPerOrderSummary(itemsMean, itemsPercentiles[0], itemsPercentiles[1]), nil
+}
+
+// GetOrderValueHistogram compte commandes et CA par bucket de montant
+// Seuls les buckets non vides sont retournés (index 0 = [0, b1)), complétés par le domaine
+//
+// SYNTAXE SQL: width_bucket(x, ARRAY[0, b1, ..., bn]) retourne i si x est dans [borne i, borne i+1),
+// n+1 au-delà de bn: on retranche 1 pour obtenir l'index du domaine
+func (r *StatsQueryRepository) GetOrderValueHistogram(
+	dateRange shareddomain.DateRange,
+	boundaries domain.HistogramBoundaries,
+	filter domain.StatsFilter,
+) ([]*domain.HistogramBucket, error) {
+	orderWhere, filterArgs := infrastructure.BindSpecification(orderFilterSpecification(filter), 5)
+	amount := infrastructure.ConvertedAmountSQL("o.total_amount", "o", 4)
+	query := `
+		SELECT width_bucket(` + amount + `, $3::numeric[]) - 1 AS bucket,
+		       COUNT(*) AS order_count,
+		       SUM(` + amount + `) AS revenue
+		FROM orders o` + infrastructure.ExchangeRateJoinSQL("o", 4) + `
+		WHERE o.order_date >= $1 AND o.order_date <= $2 AND ` + orderWhere + `
+		GROUP BY bucket
+		ORDER BY bucket
+	`
+
+	thresholds := append([]float64{0}, boundaries.Values()...)
+	currency := filter.Currency()
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), pq.Array(thresholds), currency.String()}, filterArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var buckets []*domain.HistogramBucket
+	for rows.Next() {
+		var (
+			index      int
+			orderCount int
+			revenue    shareddomain.Money
+		)
+
+		if err := rows.Scan(&index, &orderCount, shareddomain.MoneyIn(&revenue, currency)); err != nil {
+			return nil, err
+		}
+
+		buckets = append(buckets, domain.NewHistogramBucket(index, orderCount, revenue))
+	}
+
+	return buckets, rows.Err()
+}
+
+// GetItemsPerOrderDistribution compte les commandes par nombre d'articles
+// Les commandes de plus de domain.MaxItemsPerOrderBucket articles sont regroupées dans ce bucket
+func (r *StatsQueryRepository) GetItemsPerOrderDistribution(
+	dateRange shareddomain.DateRange,
+	filter domain.StatsFilter,
+) ([]*domain.ItemsPerOrderBucket, error) {
+	orderWhere, filterArgs := infrastructure.BindSpecification(orderFilterSpecification(filter), 4)
+	query := `
+		WITH ` + orderQuantitiesCTE(orderWhere) + `
+		SELECT LEAST(COALESCE(q.quantity, 0), $3) AS items,
+		       COUNT(*) AS order_count
+		FROM orders o
+		LEFT JOIN order_quantities q ON q.order_id = o.id
+		WHERE o.order_date >= $1 AND o.order_date <= $2 AND ` + orderWhere + `
+		GROUP BY items
+		ORDER BY items
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), domain.MaxItemsPerOrderBucket}, filterArgs...)
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var buckets []*domain.ItemsPerOrderBucket
+	for rows.Next() {
+		var items, orderCount int
+		if err := rows.Scan(&items, &orderCount); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, domain.NewItemsPerOrderBucket(items, orderCount))
+	}
+
+	return buckets, rows.Err()
+}
+
+// GetCohortActivity agrège l'activité mensuelle des cohortes acquises sur la période
+// Une ligne par couple (mois d'acquisition, offset) ayant au moins une commande:
+// le remplissage du triangle est fait côté domaine (NewCohortAnalysis)
+// Seules les commandes completed comptent, y compris pour déterminer la première commande
+//
+// SYNTAXE SQL:
+//   - HAVING MIN(order_date) filtre sur la première commande de TOUT l'historique:
+//     un client acquis avant la période n'entre dans aucune cohorte, même s'il recommande pendant
+//   - L'offset est calculé en mois calendaires (année × 12 + mois), pas en jours
+//   - COUNT(DISTINCT customer_id): un client qui commande 3 fois dans le mois compte une fois
+//
+// PIÈGE: l'activité s'arrête à la fin de la période ($2), sinon les cases "futures" seraient
+// remplies pour une période passée et le triangle ne serait plus reproductible
+func (r *StatsQueryRepository) GetCohortActivity(
+	dateRange shareddomain.DateRange,
+	currency shareddomain.Currency,
+) ([]*domain.CohortActivity, error) {
+	// $3 = devise de reporting
+	statusWhere, statusArgs := infrastructure.BindSpecification(
+		ordersinfra.StatusSpecification("o", ordersdomain.StatusFilter{}), 4)
+	query := `
+		WITH first_orders AS (
+			SELECT o.customer_id,
+			       date_trunc('month', MIN(o.order_date)::timestamp)::date AS cohort_month
+			FROM orders o
+			WHERE ` + statusWhere + `
+			GROUP BY o.customer_id
+			HAVING MIN(o.order_date) >= $1 AND MIN(o.order_date) <= $2
+		),
+		activity AS (
+			SELECT f.cohort_month,
+			       date_trunc('month', o.order_date::timestamp)::date AS activity_month,
+			       o.customer_id,
+			       ` + infrastructure.ConvertedAmountSQL("o.total_amount", "o", 3) + ` AS total_amount
+			FROM orders o
+			INNER JOIN first_orders f ON f.customer_id = o.customer_id` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+			WHERE o.order_date <= $2 AND ` + statusWhere + `
+		)
+		SELECT cohort_month,
+		       ((EXTRACT(YEAR FROM activity_month) - EXTRACT(YEAR FROM cohort_month)) * 12
+		        + EXTRACT(MONTH FROM activity_month) - EXTRACT(MONTH FROM cohort_month))::int AS month_offset,
+		       COUNT(DISTINCT customer_id) AS active_customers,
+		       COALESCE(SUM(total_amount), 0) AS total_revenue
+		FROM activity
+		GROUP BY cohort_month, month_offset
+		ORDER BY cohort_month, month_offset
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, statusArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var activity []*domain.CohortActivity
+	for rows.Next() {
+		var (
+			cohortMonth     time.Time
+			monthOffset     int
+			activeCustomers int
+			revenue         shareddomain.Money
+		)
+
+		if err := rows.Scan(&cohortMonth, &monthOffset, &activeCustomers, shareddomain.MoneyIn(&revenue, currency)); err != nil {
+			return nil, err
+		}
+
+		activity = append(activity, domain.NewCohortActivity(cohortMonth, monthOffset, activeCustomers, revenue))
+	}
+
+	return activity, rows.Err()
+}
+
+// GetPromotionEffectiveness mesure chaque promotion dont la fenêtre d'activité chevauche la période
+// Les indicateurs portent sur la fenêtre de la promotion (pas sur la période demandée):
+// une promotion du 25 au 5 est mesurée en entier dans le rapport de janvier comme dans celui de décembre
+//
+// SYNTAXE SQL:
+//   - COUNT(*) FILTER (WHERE ...) agrège plusieurs sous-ensembles en une seule lecture des commandes
+//   - date - integer = date décalée de n jours: la fenêtre de référence a la même durée que la fenêtre active
+//     (mêmes bornes que Promotion.BaselineWindow)
+//
+// PERFORMANCE: une jointure par fenêtre de dates, servie par idx_orders_date
+//   - Quelques dizaines de promotions × quelques semaines de commandes: négligeable
+func (r *StatsQueryRepository) GetPromotionEffectiveness(
+	dateRange shareddomain.DateRange,
+	currency shareddomain.Currency,
+) ([]*domain.PromotionEffectiveness, error) {
+	// $3 = devise de reporting
+	statusWhere, statusArgs := infrastructure.BindSpecification(
+		ordersinfra.StatusSpecification("o", ordersdomain.StatusFilter{}), 4)
+	amount := infrastructure.ConvertedAmountSQL("o.total_amount", "o", 3)
+	query := `
+		WITH promos AS (
+			SELECT id, code, name, COALESCE(discount_percent, 0) AS discount_percent,
+			       start_date, end_date, COALESCE(active, TRUE) AS active
+			FROM promotions
+			WHERE start_date <= $2 AND end_date >= $1
+		),
+		window_sales AS (
+			SELECT p.id AS promotion_id,
+			       COUNT(*) FILTER (WHERE o.promotion_id = p.id) AS promo_orders,
+			       COALESCE(SUM(` + amount + `) FILTER (WHERE o.promotion_id = p.id), 0) AS promo_revenue,
+			       COUNT(*) FILTER (WHERE o.promotion_id IS NULL) AS no_promo_orders,
+			       COALESCE(SUM(` + amount + `) FILTER (WHERE o.promotion_id IS NULL), 0) AS no_promo_revenue,
+			       COALESCE(SUM(` + amount + `), 0) AS window_revenue
+			FROM promos p
+			INNER JOIN orders o ON o.order_date >= p.start_date AND o.order_date <= p.end_date` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+			WHERE ` + statusWhere + `
+			GROUP BY p.id
+		),
+		baseline_sales AS (
+			SELECT p.id AS promotion_id, COALESCE(SUM(` + amount + `), 0) AS baseline_revenue
+			FROM promos p
+			INNER JOIN orders o ON o.order_date >= p.start_date - (p.end_date - p.start_date + 1)
+			                   AND o.order_date < p.start_date` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+			WHERE ` + statusWhere + `
+			GROUP BY p.id
+		)
+		SELECT p.id, p.code, p.name, p.discount_percent, p.start_date, p.end_date, p.active,
+		       COALESCE(w.promo_orders, 0), COALESCE(w.promo_revenue, 0),
+		       COALESCE(w.no_promo_orders, 0), COALESCE(w.no_promo_revenue, 0),
+		       COALESCE(w.window_revenue, 0), COALESCE(b.baseline_revenue, 0)
+		FROM promos p
+		LEFT JOIN window_sales w ON w.promotion_id = p.id
+		LEFT JOIN baseline_sales b ON b.promotion_id = p.id
+		ORDER BY p.start_date, p.id
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, statusArgs...)
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var report []*domain.PromotionEffectiveness
+	var measuredFrom, measuredTo time.Time // fenêtres de référence et d'activité de toutes les promotions
+	for rows.Next() {
+		var (
+			id                         int64
+			code, name                 string
+			discountPercent            float64
+			startDate, endDate         time.Time
+			active                     bool
+			promoOrders, regularOrders int
+			promo, regular             shareddomain.Money
+			window, baseline           shareddomain.Money
+		)
+
+		if err := rows.Scan(
+			&id, &code, &name, &discountPercent, &startDate, &endDate, &active,
+			&promoOrders, shareddomain.MoneyIn(&promo, currency),
+			&regularOrders, shareddomain.MoneyIn(&regular, currency),
+			shareddomain.MoneyIn(&window, currency), shareddomain.MoneyIn(&baseline, currency),
+		); err != nil {
+			return nil, err
+		}
+
+		promotion, err := ordersdomain.NewPromotion(
+			ordersdomain.PromotionID(id), code, name, discountPercent, startDate, endDate, active)
+		if err != nil {
+			return nil, err
+		}
+
+		report = append(report, domain.NewPromotionEffectiveness(
+			promotion, promoOrders, promo, regularOrders, regular, window, baseline,
+		))
+		if from := promotion.BaselineWindow().Start(); measuredFrom.IsZero() || from.Before(measuredFrom) {
+			measuredFrom = from
+		}
+		if to := promotion.ActiveWindow().End(); to.After(measuredTo) {
+			measuredTo = to
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Les fenêtres mesurées ne sont connues qu'après lecture des promotions:
+	// les taux sont vérifiés après coup, le rapport est écarté s'il en manque un
+	if len(report) > 0 {
+		if err := r.CheckExchangeRates(currency, measuredFrom, measuredTo); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// StreamOrderBaskets parcourt les paniers (produits distincts de chaque commande completed de la période)
+// et appelle fn pour chacun
+//
+// SYNTAXE SQL: array_agg(DISTINCT ...) regroupe les produits d'une commande en un tableau PostgreSQL,
+// lu en Go avec pq.Int64Array
+//
+// MÉMOIRE: ✓ Un seul panier vivant à la fois côté repository (même principe que StreamSalesData)
+// ANNULATION: la requête est liée à ctx
+func (r *StatsQueryRepository) StreamOrderBaskets(
+	ctx context.Context,
+	dateRange shareddomain.DateRange,
+	fn func(products []catalogdomain.ProductID) error,
+) error {
+	statusWhere, statusArgs := infrastructure.BindSpecification(
+		ordersinfra.StatusSpecification("o", ordersdomain.StatusFilter{}), 3)
+	query := `
+		SELECT array_agg(DISTINCT oi.product_id)
+		FROM orders o
+		INNER JOIN order_items oi ON oi.order_id = o.id
+		WHERE o.order_date >= $1 AND o.order_date <= $2
+		  AND ` + statusWhere + `
+		GROUP BY o.id
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End()}, statusArgs...)
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var ids pq.Int64Array
+	for rows.Next() {
+		if err := rows.Scan(&ids); err != nil {
+			return err
+		}
+
+		products := make([]catalogdomain.ProductID, len(ids))
+		for i, id := range ids {
+			products[i] = catalogdomain.ProductID(id)
+		}
+		if err := fn(products); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetProductNames retourne le nom de chaque produit demandé (les identifiants inconnus sont absents)
+// SYNTAXE SQL: id = ANY($1) avec pq.Array, une seule requête quel que soit le nombre d'identifiants
+func (r *StatsQueryRepository) GetProductNames(ids []catalogdomain.ProductID) (map[catalogdomain.ProductID]string, error) {
+	values := make([]int64, len(ids))
+	for i, id := range ids {
+		values[i] = int64(id)
+	}
+
+	rows, err := r.Query(`SELECT id, name FROM products WHERE id = ANY($1)`, pq.Array(values))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[catalogdomain.ProductID]string, len(ids))
+	for rows.Next() {
+		var (
+			id   int64
+			name string
+		)
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		names[catalogdomain.ProductID(id)] = name
+	}
+
+	return names, rows.Err()
+}
+
 // GetAllOrderItems récupère tous les items de commande dans une période (pour V1 inefficace)
 // PERFORMANCE: ⚠️ Problème majeur - récupère TOUTES les lignes sans agrégation
 //   - Transfert réseau: Si 100k rows × 80 bytes = 8 MB de données transférées
@@ -290,19 +1270,30 @@ func (r *StatsQueryRepository) GetPaymentMethodDistribution(dateRange shareddoma
 func (r *StatsQueryRepository) GetAllOrderItems(dateRange shareddomain.DateRange) ([]OrderItemData, error) {
 	// SYNTAXE SQL: $1, $2 = paramètres positionnels (protection contre SQL injection)
 	// PERFORMANCE: INNER JOIN = ok, mais manque de GROUP BY
+	// Même définition des ventes que V2: commandes completed uniquement
+	// V1 n'a pas de devise de reporting: montants convertis en devise pivot (EUR)
+	statusWhere, statusArgs := infrastructure.BindSpecification(
+		ordersinfra.StatusSpecification("o", ordersdomain.StatusFilter{}), 3)
 	//   - ORDER BY est coûteux sur gros volumes (nécessite tri en mémoire ou index)
 	query := `
-		SELECT oi.id, oi.order_id, oi.product_id, oi.quantity, oi.unit_price, oi.subtotal,
+		SELECT oi.id, oi.order_id, oi.product_id, oi.quantity,
+		       ` + infrastructure.DefaultCurrencyAmountSQL("oi.unit_price", "o") + `,
+		       ` + infrastructure.DefaultCurrencyAmountSQL("oi.subtotal", "o") + `,
 		       o.order_date, o.customer_id, o.store_id, o.payment_method_id
 		FROM order_items oi
-		INNER JOIN orders o ON oi.order_id = o.id
+		INNER JOIN orders o ON oi.order_id = o.id` + infrastructure.DefaultCurrencyJoinSQL("o") + `
 		WHERE o.order_date >= $1 AND o.order_date <= $2
+		  AND ` + statusWhere + `
 		ORDER BY o.order_date DESC
 	`
 	// SYNTAXE: r.Query() exécute la requête et retourne un itérateur de lignes
 	// MÉMOIRE: rows est un curseur (léger), pas toutes les données en RAM immédiatement
 	//   - Mais on va tout charger dans []OrderItemData après (là c'est lourd!)
-	rows, err := r.Query(query, dateRange.Start(), dateRange.End())
+	args := append([]interface{}{dateRange.Start(), dateRange.End()}, statusArgs...)
+	if err := r.CheckExchangeRates(shareddomain.DefaultCurrency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,147 @@
+package application
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"eval/internal/catalog/domain"
+	"eval/internal/catalog/infrastructure"
+	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
+)
+
+// InventoryService analyse des stocks (couverture, ruptures, stock dormant, rotation) avec cache
+type InventoryService struct {
+	inventoryRepo *infrastructure.InventoryQueryRepository
+	cache         sharedinfra.Cache
+	cacheTTL      time.Duration
+}
+
+// NewInventoryService crée une nouvelle instance de InventoryService
+func NewInventoryService(
+	inventoryRepo *infrastructure.InventoryQueryRepository,
+	cache sharedinfra.Cache,
+) *InventoryService {
+	return &InventoryService{
+		inventoryRepo: inventoryRepo,
+		cache:         cache,
+		cacheTTL:      5 * time.Minute,
+	}
+}
+
+// GetInventoryReport classe les produits selon leurs ventes sur la fenêtre dateRange
+//
+// PARALLÉLISME: lignes produits et agrégats catégories sont indépendants (2 goroutines)
+//
+// PIÈGE: le stock change à chaque vente, mais le cache garde le rapport 5 minutes:
+// acceptable pour un tableau de bord, pas pour une décision de réapprovisionnement à l'unité
+func (s *InventoryService) GetInventoryReport(
+	dateRange shareddomain.DateRange,
+	thresholds domain.StockThresholds,
+	currency shareddomain.Currency,
+) (*domain.InventoryReport, error) {
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("catalog").
+		Add("v2").
+		Add("inventory").
+		Add(strconv.Itoa(thresholds.RiskDays())).
+		Add(strconv.Itoa(thresholds.DeadStockDays())).
+		Add(dateRange.Key()).
+		Add(currency.String()).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.InventoryReport), nil
+	}
+
+	var (
+		wg         sync.WaitGroup
+		products   []*domain.ProductStockActivity
+		categories []*domain.CategoryStockActivity
+	)
+	errChan := make(chan error, 2)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		var err error
+		if products, err = s.inventoryRepo.GetProductStockActivity(dateRange, currency); err != nil {
+			errChan <- fmt.Errorf("product stock error: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if categories, err = s.inventoryRepo.GetCategoryStockActivity(dateRange, currency); err != nil {
+			errChan <- fmt.Errorf("category stock error: %w", err)
+		}
+	}()
+
+	wg.Wait()
+	close(errChan)
+
+	// Retourner la première erreur rencontrée
+	for err := range errChan {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := domain.NewInventoryReport(dateRange, thresholds, currency, products, categories)
+	s.cache.Set(cacheKey, report, s.cacheTTL)
+
+	return report, nil
+}
+
+// ExportInventoryToCSV exporte une ligne par produit avec ses indicateurs et son statut
+// Colonnes days_of_cover et last_sale_date vides si non définies (aucune vente)
+func (s *InventoryService) ExportInventoryToCSV(
+	dateRange shareddomain.DateRange,
+	thresholds domain.StockThresholds,
+	currency shareddomain.Currency,
+) ([]byte, error) {
+	report, err := s.GetInventoryReport(dateRange, thresholds, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	products := report.Products()
+	buffer := bytes.NewBuffer(make([]byte, 0, 128*len(products)))
+	writer := csv.NewWriter(buffer)
+
+	writer.Write([]string{
+		"product_id", "name", "stock_quantity", "stock_value",
+		"units_sold", "daily_velocity", "days_of_cover", "last_sale_date", "status",
+	})
+	for _, p := range products {
+		var daysOfCover, lastSaleDate string
+		if days, ok := p.DaysOfCover(); ok {
+			daysOfCover = fmt.Sprintf("%.1f", days)
+		}
+		if date, ok := p.LastSaleDate(); ok {
+			lastSaleDate = date.Format("2006-01-02")
+		}
+
+		writer.Write([]string{
+			strconv.FormatInt(int64(p.ProductID()), 10),
+			p.Name(),
+			strconv.Itoa(p.StockQuantity().Value()),
+			p.StockValue().Decimal(),
+			strconv.Itoa(p.UnitsSold()),
+			fmt.Sprintf("%.3f", p.DailyVelocity()),
+			daysOfCover,
+			lastSaleDate,
+			string(p.Status()),
+		})
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
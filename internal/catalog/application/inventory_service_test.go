@@ -0,0 +1,70 @@
+package application
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"eval/internal/catalog/domain"
+	shareddomain "eval/internal/shared/domain"
+	"eval/internal/testhelpers"
+)
+
+// TestInventoryService_FixtureReport vérifie le classement des 3 produits du jeu de régression
+// Fenêtre: janvier 2024 (31 jours), couverture < 14 jours, dormant sans vente depuis 15 jours (16 janvier)
+//   - Laptop: stock 4, 1 vendu (la commande 3 annulée ne compte pas), dernière vente le 10 → dormant
+//   - T-shirt: stock 1, 3 vendus, dernière vente le 20 → couverture 31/3 ≈ 10.3 jours → à risque
+//   - Pâtes: stock 0 → rupture
+func TestInventoryService_FixtureReport(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	service := NewInventoryService(ctx.InventoryRepo, ctx.Cache)
+
+	dateRange, err := shareddomain.NewDateRangeForMonth(2024, time.January, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	thresholds, _ := domain.NewStockThresholds(14, 15)
+
+	report, err := service.GetInventoryReport(dateRange, thresholds, shareddomain.DefaultCurrency)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[domain.ProductID]domain.StockStatus{
+		1: domain.StockStatusDeadStock,
+		2: domain.StockStatusAtRisk,
+		3: domain.StockStatusOutOfStock,
+	}
+	for _, p := range report.Products() {
+		if p.Status() != want[p.ProductID()] {
+			t.Errorf("%s status = %s, want %s", p.Name(), p.Status(), want[p.ProductID()])
+		}
+	}
+
+	atRisk := report.ProductsWithStatus(domain.StockStatusAtRisk)
+	if len(atRisk) != 1 || atRisk[0].UnitsSold() != 3 {
+		t.Fatalf("at risk = %v, want [T-shirt]", atRisk)
+	}
+	if cover, ok := atRisk[0].DaysOfCover(); !ok || math.Abs(cover-31.0/3) > 1e-9 {
+		t.Errorf("T-shirt days of cover = %v (ok=%v), want %v", cover, ok, 31.0/3)
+	}
+	if report.TotalStockValue().Amount() != 4020 {
+		t.Errorf("total stock value = %v, want 4020", report.TotalStockValue().Amount())
+	}
+
+	// Catégories 1 à 3 du jeu de régression (les autres catégories d'init.sql sont vides)
+	categories := report.Categories()
+	if turnover, ok := categories[0].Turnover(); !ok || turnover != 0.25 {
+		t.Errorf("Électronique turnover = %v (ok=%v), want 0.25", turnover, ok)
+	}
+	if turnover, ok := categories[1].Turnover(); !ok || turnover != 3 {
+		t.Errorf("Vêtements turnover = %v (ok=%v), want 3", turnover, ok)
+	}
+	if _, ok := categories[2].Turnover(); ok {
+		t.Error("Alimentation turnover should be undefined without stock")
+	}
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"eval/internal/catalog/domain"
+	"eval/internal/catalog/infrastructure"
+	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
+)
+
+// SupplierAnalyticsService analyses des ventes par fournisseur avec cache
+// Même stratégie que StatsServiceV2: requêtes SQL agrégées, en parallèle, résultat mis en cache
+type SupplierAnalyticsService struct {
+	supplierRepo *infrastructure.SupplierQueryRepository
+	statsRepo    *infrastructure.SupplierStatsQueryRepository
+	cache        sharedinfra.Cache
+	cacheTTL     time.Duration
+}
+
+// NewSupplierAnalyticsService crée une nouvelle instance de SupplierAnalyticsService
+func NewSupplierAnalyticsService(
+	supplierRepo *infrastructure.SupplierQueryRepository,
+	statsRepo *infrastructure.SupplierStatsQueryRepository,
+	cache sharedinfra.Cache,
+) *SupplierAnalyticsService {
+	return &SupplierAnalyticsService{
+		supplierRepo: supplierRepo,
+		statsRepo:    statsRepo,
+		cache:        cache,
+		cacheTTL:     5 * time.Minute,
+	}
+}
+
+// GetSupplierReport calcule CA, unités, nombre de produits, part des ventes et
+// les topProducts meilleurs produits de chaque fournisseur sur la période (montants convertis dans currency)
+//
+// PARALLÉLISME: fournisseurs, agrégats et top produits sont indépendants (3 goroutines)
+func (s *SupplierAnalyticsService) GetSupplierReport(
+	dateRange shareddomain.DateRange,
+	topProducts int,
+	currency shareddomain.Currency,
+) (*domain.SupplierReport, error) {
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("catalog").
+		Add("v2").
+		Add("suppliers").
+		Add(strconv.Itoa(topProducts)).
+		Add(dateRange.Key()).
+		Add(currency.String()).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.SupplierReport), nil
+	}
+
+	var (
+		wg         sync.WaitGroup
+		suppliers  []*domain.Supplier
+		sales      []*domain.SupplierSales
+		totalSales shareddomain.Money
+		top        map[domain.SupplierID][]*domain.SupplierProductSales
+	)
+	errChan := make(chan error, 3)
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		var err error
+		if suppliers, err = s.supplierRepo.FindAll(); err != nil {
+			errChan <- fmt.Errorf("suppliers error: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if sales, totalSales, err = s.statsRepo.GetSupplierSales(dateRange, currency); err != nil {
+			errChan <- fmt.Errorf("supplier sales error: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if top, err = s.statsRepo.GetSupplierTopProducts(dateRange, topProducts, currency); err != nil {
+			errChan <- fmt.Errorf("supplier top products error: %w", err)
+		}
+	}()
+
+	wg.Wait()
+	close(errChan)
+
+	// Retourner la première erreur rencontrée
+	for err := range errChan {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := domain.NewSupplierReport(dateRange, suppliers, sales, top, totalSales)
+	s.cache.Set(cacheKey, report, s.cacheTTL)
+
+	return report, nil
+}
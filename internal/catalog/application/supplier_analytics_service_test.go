@@ -0,0 +1,59 @@
+package application
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	shareddomain "eval/internal/shared/domain"
+	"eval/internal/testhelpers"
+)
+
+// TestSupplierAnalyticsService_FixtureReport vérifie les ventes par fournisseur de janvier 2024
+// Lignes retenues: commande 1 (Laptop 1000 + 2 T-shirt 40) et commande 2 (5 Pâtes 10 + 1 T-shirt 20)
+func TestSupplierAnalyticsService_FixtureReport(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	service := NewSupplierAnalyticsService(ctx.SupplierQueryRepo, ctx.SupplierStatsRepo, ctx.Cache)
+
+	dateRange, err := shareddomain.NewDateRangeForMonth(2024, time.January, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := service.GetSupplierReport(dateRange, 1, shareddomain.DefaultCurrency)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.TotalSales().Amount() != 1070 {
+		t.Errorf("total sales = %v, want 1070", report.TotalSales().Amount())
+	}
+
+	suppliers := report.Suppliers()
+	if len(suppliers) != 2 {
+		t.Fatalf("suppliers = %d, want 2", len(suppliers))
+	}
+
+	first := suppliers[0]
+	if first.Supplier().Name() != "Fournisseur Test" || first.Revenue().Amount() != 1060 ||
+		first.UnitsSold() != 4 || first.OrderCount() != 2 || first.ProductCount() != 2 {
+		t.Errorf("%s = %v€, %d units, %d orders, %d products",
+			first.Supplier().Name(), first.Revenue().Amount(), first.UnitsSold(), first.OrderCount(), first.ProductCount())
+	}
+	if math.Abs(first.SalesShare()-1060.0/1070*100) > 1e-9 {
+		t.Errorf("sales share = %v, want %v", first.SalesShare(), 1060.0/1070*100)
+	}
+	if top := first.TopProducts(); len(top) != 1 || top[0].Name() != "Laptop" {
+		t.Errorf("top products = %v, want [Laptop]", top)
+	}
+
+	// Épicerie Test: email NULL en base, chargé sans erreur
+	second := suppliers[1]
+	if second.Supplier().Email().Value() != "" || second.Revenue().Amount() != 10 || second.UnitsSold() != 5 {
+		t.Errorf("%s = %v€, %d units", second.Supplier().Name(), second.Revenue().Amount(), second.UnitsSold())
+	}
+}
@@ -0,0 +1,382 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// ========================================
+// ANALYSE DES STOCKS
+// ========================================
+// À partir de products.stock_quantity (stock actuel) et des ventes récentes:
+//   - vélocité: unités vendues par jour sur la fenêtre de ventes (dateRange)
+//   - couverture: stock / vélocité = nombre de jours avant rupture au rythme actuel
+//   - risque de rupture: couverture inférieure à StockThresholds.RiskDays
+//   - stock dormant: aucune vente depuis StockThresholds.DeadStockDays jours
+//   - rotation par catégorie: unités vendues / unités en stock
+//
+// LIMITE: seul le stock actuel est connu (pas d'historique des mouvements):
+// il sert de stock moyen pour la rotation, et la couverture suppose un rythme de ventes constant
+
+// Défauts des seuils d'alerte
+const (
+	DefaultStockRiskDays = 14
+	DefaultDeadStockDays = 90
+)
+
+// StockThresholds seuils de classement des produits
+type StockThresholds struct {
+	riskDays      int
+	deadStockDays int
+}
+
+// NewStockThresholds crée les seuils (au moins 1 jour chacun)
+func NewStockThresholds(riskDays, deadStockDays int) (StockThresholds, error) {
+	if riskDays < 1 {
+		return StockThresholds{}, domain.NewValidationError("invalid_thresholds", "risk days must be at least 1")
+	}
+	if deadStockDays < 1 {
+		return StockThresholds{}, domain.NewValidationError("invalid_thresholds", "dead stock days must be at least 1")
+	}
+	return StockThresholds{riskDays: riskDays, deadStockDays: deadStockDays}, nil
+}
+
+// RiskDays retourne la couverture minimale (jours) en dessous de laquelle un produit est à risque
+func (t StockThresholds) RiskDays() int {
+	return t.riskDays
+}
+
+// DeadStockDays retourne le nombre de jours sans vente au-delà duquel un stock est dormant
+func (t StockThresholds) DeadStockDays() int {
+	return t.deadStockDays
+}
+
+// StockStatus classement d'un produit
+type StockStatus string
+
+const (
+	StockStatusOutOfStock StockStatus = "out_of_stock"
+	StockStatusAtRisk     StockStatus = "at_risk"
+	StockStatusDeadStock  StockStatus = "dead_stock"
+	StockStatusHealthy    StockStatus = "healthy"
+)
+
+// ProductStockActivity stock actuel et ventes d'un produit (ligne brute du repository)
+type ProductStockActivity struct {
+	productID    ProductID
+	name         string
+	stock        domain.Quantity
+	basePrice    domain.Money
+	unitsSold    int
+	lastSaleDate time.Time
+}
+
+// NewProductStockActivity crée la ligne d'un produit
+//   - unitsSold: unités vendues sur la fenêtre de ventes
+//   - lastSaleDate: dernière vente jusqu'à la fin de la fenêtre (zéro si jamais vendu)
+func NewProductStockActivity(
+	productID ProductID,
+	name string,
+	stock domain.Quantity,
+	basePrice domain.Money,
+	unitsSold int,
+	lastSaleDate time.Time,
+) *ProductStockActivity {
+	return &ProductStockActivity{
+		productID:    productID,
+		name:         name,
+		stock:        stock,
+		basePrice:    basePrice,
+		unitsSold:    unitsSold,
+		lastSaleDate: lastSaleDate,
+	}
+}
+
+// ProductStockLevel indicateurs de stock d'un produit
+type ProductStockLevel struct {
+	activity      *ProductStockActivity
+	dailyVelocity float64
+	status        StockStatus
+}
+
+// ProductID retourne l'identifiant du produit
+func (l *ProductStockLevel) ProductID() ProductID {
+	return l.activity.productID
+}
+
+// Name retourne le nom du produit
+func (l *ProductStockLevel) Name() string {
+	return l.activity.name
+}
+
+// StockQuantity retourne le stock actuel
+func (l *ProductStockLevel) StockQuantity() domain.Quantity {
+	return l.activity.stock
+}
+
+// IsInStock vérifie si le produit est en stock
+func (l *ProductStockLevel) IsInStock() bool {
+	return !l.activity.stock.IsZero()
+}
+
+// StockValue retourne la valeur du stock au prix de base
+func (l *ProductStockLevel) StockValue() domain.Money {
+	value, _ := l.activity.basePrice.Times(l.activity.stock.Value())
+	return value
+}
+
+// UnitsSold retourne les unités vendues sur la fenêtre de ventes
+func (l *ProductStockLevel) UnitsSold() int {
+	return l.activity.unitsSold
+}
+
+// DailyVelocity retourne le nombre moyen d'unités vendues par jour sur la fenêtre
+func (l *ProductStockLevel) DailyVelocity() float64 {
+	return l.dailyVelocity
+}
+
+// DaysOfCover retourne le nombre de jours de stock au rythme de ventes actuel
+// ok = false sans vente sur la fenêtre: la couverture est infinie, pas un nombre
+func (l *ProductStockLevel) DaysOfCover() (days float64, ok bool) {
+	if l.dailyVelocity == 0 {
+		return 0, false
+	}
+	return float64(l.activity.stock.Value()) / l.dailyVelocity, true
+}
+
+// LastSaleDate retourne la date de dernière vente (ok = false si jamais vendu)
+func (l *ProductStockLevel) LastSaleDate() (date time.Time, ok bool) {
+	return l.activity.lastSaleDate, !l.activity.lastSaleDate.IsZero()
+}
+
+// Status retourne le classement du produit
+func (l *ProductStockLevel) Status() StockStatus {
+	return l.status
+}
+
+// CategoryStockActivity stock et ventes agrégés d'une catégorie (ligne brute du repository)
+type CategoryStockActivity struct {
+	categoryID   CategoryID
+	name         string
+	productCount int
+	stockUnits   int
+	stockValue   domain.Money
+	unitsSold    int
+}
+
+// NewCategoryStockActivity crée la ligne d'une catégorie
+func NewCategoryStockActivity(
+	categoryID CategoryID,
+	name string,
+	productCount, stockUnits int,
+	stockValue domain.Money,
+	unitsSold int,
+) *CategoryStockActivity {
+	return &CategoryStockActivity{
+		categoryID:   categoryID,
+		name:         name,
+		productCount: productCount,
+		stockUnits:   stockUnits,
+		stockValue:   stockValue,
+		unitsSold:    unitsSold,
+	}
+}
+
+// CategoryTurnover rotation des stocks d'une catégorie
+type CategoryTurnover struct {
+	activity *CategoryStockActivity
+	days     int
+}
+
+// CategoryID retourne l'identifiant de la catégorie
+func (c *CategoryTurnover) CategoryID() CategoryID {
+	return c.activity.categoryID
+}
+
+// Name retourne le nom de la catégorie
+func (c *CategoryTurnover) Name() string {
+	return c.activity.name
+}
+
+// ProductCount retourne le nombre de produits de la catégorie
+func (c *CategoryTurnover) ProductCount() int {
+	return c.activity.productCount
+}
+
+// StockUnits retourne les unités en stock
+func (c *CategoryTurnover) StockUnits() int {
+	return c.activity.stockUnits
+}
+
+// StockValue retourne la valeur du stock au prix de base
+func (c *CategoryTurnover) StockValue() domain.Money {
+	return c.activity.stockValue
+}
+
+// UnitsSold retourne les unités vendues sur la fenêtre
+func (c *CategoryTurnover) UnitsSold() int {
+	return c.activity.unitsSold
+}
+
+// Turnover retourne la rotation sur la fenêtre (unités vendues / unités en stock)
+// ok = false sans stock: la rotation n'est pas définie
+func (c *CategoryTurnover) Turnover() (turnover float64, ok bool) {
+	if c.activity.stockUnits == 0 {
+		return 0, false
+	}
+	return float64(c.activity.unitsSold) / float64(c.activity.stockUnits), true
+}
+
+// AnnualizedTurnover retourne la rotation ramenée à un an (365 jours)
+// Compare des fenêtres de durées différentes: 0.5 sur 30 jours ≈ 6 rotations par an
+func (c *CategoryTurnover) AnnualizedTurnover() (turnover float64, ok bool) {
+	turnover, ok = c.Turnover()
+	if !ok {
+		return 0, false
+	}
+	return turnover * 365 / float64(c.days), true
+}
+
+// InventoryReport état des stocks à la fin de la fenêtre de ventes
+type InventoryReport struct {
+	dateRange  domain.DateRange
+	thresholds StockThresholds
+	currency   domain.Currency
+	products   []*ProductStockLevel
+	categories []*CategoryTurnover
+}
+
+// NewInventoryReport classe chaque produit selon ses ventes sur dateRange
+// La date de référence est la fin de la fenêtre: un rapport de ?period=2024 reste stable dans le temps
+// currency = devise des valeurs de stock (prix de base convertis par le repository)
+//
+// Ordre de priorité du classement:
+//  1. out_of_stock: stock nul
+//  2. dead_stock: aucune vente depuis deadStockDays jours (ou jamais vendu)
+//  3. at_risk: couverture < riskDays
+//  4. healthy
+func NewInventoryReport(
+	dateRange domain.DateRange,
+	thresholds StockThresholds,
+	currency domain.Currency,
+	products []*ProductStockActivity,
+	categories []*CategoryStockActivity,
+) *InventoryReport {
+	days := dateRange.Days()
+	deadStockCutoff := dateRange.End().AddDate(0, 0, -thresholds.deadStockDays)
+
+	levels := make([]*ProductStockLevel, len(products))
+	for i, p := range products {
+		level := &ProductStockLevel{
+			activity:      p,
+			dailyVelocity: float64(p.unitsSold) / float64(days),
+		}
+
+		cover, hasCover := level.DaysOfCover()
+		switch {
+		case p.stock.IsZero():
+			level.status = StockStatusOutOfStock
+		case p.lastSaleDate.IsZero() || calendarDay(p.lastSaleDate, dateRange.Location()).Before(deadStockCutoff):
+			level.status = StockStatusDeadStock
+		case hasCover && cover < float64(thresholds.riskDays):
+			level.status = StockStatusAtRisk
+		default:
+			level.status = StockStatusHealthy
+		}
+		levels[i] = level
+	}
+
+	turnovers := make([]*CategoryTurnover, len(categories))
+	for i, c := range categories {
+		turnovers[i] = &CategoryTurnover{activity: c, days: days}
+	}
+
+	return &InventoryReport{
+		dateRange:  dateRange,
+		thresholds: thresholds,
+		currency:   currency,
+		products:   levels,
+		categories: turnovers,
+	}
+}
+
+// calendarDay projette une date (colonne DATE, lue à minuit UTC) à minuit dans loc
+// PIÈGE: minuit UTC du 10 est encore le 9 au soir à New York: sans projection,
+// la comparaison avec la date limite serait décalée d'un jour
+func calendarDay(date time.Time, loc *time.Location) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// DateRange retourne la fenêtre de ventes
+func (r *InventoryReport) DateRange() domain.DateRange {
+	return r.dateRange
+}
+
+// Thresholds retourne les seuils de classement
+func (r *InventoryReport) Thresholds() StockThresholds {
+	return r.thresholds
+}
+
+// Products retourne tous les produits, par identifiant
+func (r *InventoryReport) Products() []*ProductStockLevel {
+	return append([]*ProductStockLevel{}, r.products...)
+}
+
+// ProductsWithStatus retourne les produits d'un statut, les plus urgents d'abord:
+//   - at_risk: couverture croissante (rupture la plus proche en tête)
+//   - dead_stock: valeur du stock décroissante (capital immobilisé)
+//   - out_of_stock et healthy: vélocité décroissante
+func (r *InventoryReport) ProductsWithStatus(status StockStatus) []*ProductStockLevel {
+	var result []*ProductStockLevel
+	for _, p := range r.products {
+		if p.status == status {
+			result = append(result, p)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		switch status {
+		case StockStatusAtRisk:
+			ci, _ := result[i].DaysOfCover()
+			cj, _ := result[j].DaysOfCover()
+			return ci < cj
+		case StockStatusDeadStock:
+			return result[i].StockValue().Amount() > result[j].StockValue().Amount()
+		default:
+			return result[i].dailyVelocity > result[j].dailyVelocity
+		}
+	})
+	return result
+}
+
+// CountByStatus retourne le nombre de produits par statut
+func (r *InventoryReport) CountByStatus() map[StockStatus]int {
+	counts := map[StockStatus]int{
+		StockStatusOutOfStock: 0,
+		StockStatusAtRisk:     0,
+		StockStatusDeadStock:  0,
+		StockStatusHealthy:    0,
+	}
+	for _, p := range r.products {
+		counts[p.status]++
+	}
+	return counts
+}
+
+// TotalStockValue retourne la valeur de tout le stock au prix de base
+func (r *InventoryReport) TotalStockValue() domain.Money {
+	total := r.currency.Zero()
+	for _, p := range r.products {
+		total, _ = total.Add(p.StockValue())
+	}
+	return total
+}
+
+// Categories retourne la rotation par catégorie, par identifiant
+// Un produit rattaché à plusieurs catégories compte dans chacune
+func (r *InventoryReport) Categories() []*CategoryTurnover {
+	return append([]*CategoryTurnover{}, r.categories...)
+}
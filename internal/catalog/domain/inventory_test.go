@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// TestInventoryReport_Status vérifie l'ordre de priorité du classement et la couverture
+// Fenêtre de 10 jours (1er au 10 mars), à risque sous 7 jours, dormant sans vente depuis 30 jours
+func TestInventoryReport_Status(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, time.March, d, 0, 0, 0, 0, time.UTC) }
+	price, _ := domain.NewMoney(10, "EUR")
+
+	dateRange, _ := domain.NewDateRange(day(1), day(10))
+	thresholds, err := NewStockThresholds(7, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	products := []*ProductStockActivity{
+		NewProductStockActivity(1, "rupture", domain.MustNewQuantity(0), price, 5, day(9)),
+		NewProductStockActivity(2, "jamais vendu", domain.MustNewQuantity(3), price, 0, time.Time{}),
+		NewProductStockActivity(3, "ancien", domain.MustNewQuantity(3), price, 0, time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)),
+		NewProductStockActivity(4, "à risque", domain.MustNewQuantity(5), price, 10, day(10)), // 1/jour → 5 jours
+		NewProductStockActivity(5, "sain", domain.MustNewQuantity(50), price, 10, day(10)),    // 50 jours
+		NewProductStockActivity(6, "lent", domain.MustNewQuantity(2), price, 0, day(1).AddDate(0, 0, -20)),
+	}
+
+	report := NewInventoryReport(dateRange, thresholds, domain.DefaultCurrency, products, nil)
+
+	want := []StockStatus{
+		StockStatusOutOfStock, StockStatusDeadStock, StockStatusDeadStock,
+		StockStatusAtRisk, StockStatusHealthy, StockStatusHealthy,
+	}
+	for i, p := range report.Products() {
+		if p.Status() != want[i] {
+			t.Errorf("%s = %s, want %s", p.Name(), p.Status(), want[i])
+		}
+	}
+
+	if cover, ok := report.Products()[3].DaysOfCover(); !ok || cover != 5 {
+		t.Errorf("days of cover = %v (ok=%v), want 5", cover, ok)
+	}
+	if _, ok := report.Products()[5].DaysOfCover(); ok {
+		t.Error("days of cover should be undefined without sales")
+	}
+	if counts := report.CountByStatus(); counts[StockStatusDeadStock] != 2 || counts[StockStatusHealthy] != 2 {
+		t.Errorf("counts = %v", counts)
+	}
+
+	if _, err := NewStockThresholds(0, 30); err == nil {
+		t.Error("NewStockThresholds(0, 30) should fail")
+	}
+}
@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"sort"
+
+	"eval/internal/shared/domain"
+)
+
+// SupplierSales agrégats des ventes des produits d'un fournisseur sur une période
+type SupplierSales struct {
+	supplierID   SupplierID
+	revenue      domain.Money
+	unitsSold    int
+	orderCount   int
+	productCount int
+	productsSold int
+}
+
+// NewSupplierSales crée les agrégats d'un fournisseur
+//   - productCount: produits du fournisseur au catalogue
+//   - productsSold: produits distincts vendus sur la période
+func NewSupplierSales(
+	supplierID SupplierID,
+	revenue domain.Money,
+	unitsSold, orderCount, productCount, productsSold int,
+) *SupplierSales {
+	return &SupplierSales{
+		supplierID:   supplierID,
+		revenue:      revenue,
+		unitsSold:    unitsSold,
+		orderCount:   orderCount,
+		productCount: productCount,
+		productsSold: productsSold,
+	}
+}
+
+// SupplierID retourne l'identifiant du fournisseur
+func (s *SupplierSales) SupplierID() SupplierID {
+	return s.supplierID
+}
+
+// SupplierProductSales ventes d'un produit d'un fournisseur (entrée du top produits)
+type SupplierProductSales struct {
+	productID ProductID
+	name      string
+	unitsSold int
+	revenue   domain.Money
+}
+
+// NewSupplierProductSales crée une entrée du top produits d'un fournisseur
+func NewSupplierProductSales(productID ProductID, name string, unitsSold int, revenue domain.Money) *SupplierProductSales {
+	return &SupplierProductSales{
+		productID: productID,
+		name:      name,
+		unitsSold: unitsSold,
+		revenue:   revenue,
+	}
+}
+
+// ProductID retourne l'identifiant du produit
+func (p *SupplierProductSales) ProductID() ProductID {
+	return p.productID
+}
+
+// Name retourne le nom du produit
+func (p *SupplierProductSales) Name() string {
+	return p.name
+}
+
+// UnitsSold retourne le nombre d'unités vendues
+func (p *SupplierProductSales) UnitsSold() int {
+	return p.unitsSold
+}
+
+// Revenue retourne le CA du produit (somme des sous-totaux des lignes de vente)
+func (p *SupplierProductSales) Revenue() domain.Money {
+	return p.revenue
+}
+
+// SupplierPerformance indicateurs d'un fournisseur sur une période
+type SupplierPerformance struct {
+	supplier    *Supplier
+	sales       *SupplierSales
+	salesShare  float64
+	topProducts []*SupplierProductSales
+}
+
+// Supplier retourne le fournisseur
+func (p *SupplierPerformance) Supplier() *Supplier {
+	return p.supplier
+}
+
+// Revenue retourne le CA des produits du fournisseur
+func (p *SupplierPerformance) Revenue() domain.Money {
+	return p.sales.revenue
+}
+
+// UnitsSold retourne le nombre d'unités vendues
+func (p *SupplierPerformance) UnitsSold() int {
+	return p.sales.unitsSold
+}
+
+// OrderCount retourne le nombre de commandes contenant au moins un produit du fournisseur
+func (p *SupplierPerformance) OrderCount() int {
+	return p.sales.orderCount
+}
+
+// ProductCount retourne le nombre de produits du fournisseur au catalogue
+func (p *SupplierPerformance) ProductCount() int {
+	return p.sales.productCount
+}
+
+// ProductsSold retourne le nombre de produits distincts vendus sur la période
+func (p *SupplierPerformance) ProductsSold() int {
+	return p.sales.productsSold
+}
+
+// SalesShare retourne la part du fournisseur dans le CA total des ventes (%)
+func (p *SupplierPerformance) SalesShare() float64 {
+	return p.salesShare
+}
+
+// TopProducts retourne les meilleurs produits du fournisseur, par CA décroissant
+func (p *SupplierPerformance) TopProducts() []*SupplierProductSales {
+	return append([]*SupplierProductSales{}, p.topProducts...)
+}
+
+// SupplierReport performance de tous les fournisseurs sur une période
+type SupplierReport struct {
+	dateRange  domain.DateRange
+	totalSales domain.Money
+	suppliers  []*SupplierPerformance
+}
+
+// NewSupplierReport associe chaque fournisseur à ses ventes et son top produits
+//   - totalSales: CA de toutes les lignes de vente de la période, y compris les produits
+//     sans fournisseur (supplier_id nullable): les parts ne somment pas forcément à 100%
+//   - un fournisseur sans vente apparaît avec des indicateurs à 0
+//
+// Tri: CA décroissant, puis identifiant (classement stable à CA égal)
+func NewSupplierReport(
+	dateRange domain.DateRange,
+	suppliers []*Supplier,
+	sales []*SupplierSales,
+	topProducts map[SupplierID][]*SupplierProductSales,
+	totalSales domain.Money,
+) *SupplierReport {
+	salesBySupplier := make(map[SupplierID]*SupplierSales, len(sales))
+	for _, s := range sales {
+		salesBySupplier[s.supplierID] = s
+	}
+
+	performances := make([]*SupplierPerformance, 0, len(suppliers))
+	for _, supplier := range suppliers {
+		s, ok := salesBySupplier[supplier.ID()]
+		if !ok {
+			// Fournisseur sans vente: 0 dans la devise du total (devise de reporting)
+			zero := domain.Currency(totalSales.Currency()).Zero()
+			s = NewSupplierSales(supplier.ID(), zero, 0, 0, 0, 0)
+		}
+
+		var share float64
+		if totalSales.Amount() > 0 {
+			share = s.revenue.Amount() / totalSales.Amount() * 100
+		}
+
+		performances = append(performances, &SupplierPerformance{
+			supplier:    supplier,
+			sales:       s,
+			salesShare:  share,
+			topProducts: topProducts[supplier.ID()],
+		})
+	}
+
+	sort.SliceStable(performances, func(i, j int) bool {
+		ri, rj := performances[i].Revenue().Amount(), performances[j].Revenue().Amount()
+		if ri != rj {
+			return ri > rj
+		}
+		return performances[i].supplier.ID() < performances[j].supplier.ID()
+	})
+
+	return &SupplierReport{
+		dateRange:  dateRange,
+		totalSales: totalSales,
+		suppliers:  performances,
+	}
+}
+
+// DateRange retourne la période du rapport
+func (r *SupplierReport) DateRange() domain.DateRange {
+	return r.dateRange
+}
+
+// TotalSales retourne le CA total des ventes de la période (tous produits)
+func (r *SupplierReport) TotalSales() domain.Money {
+	return r.totalSales
+}
+
+// Suppliers retourne les fournisseurs par CA décroissant
+func (r *SupplierReport) Suppliers() []*SupplierPerformance {
+	return append([]*SupplierPerformance{}, r.suppliers...)
+}
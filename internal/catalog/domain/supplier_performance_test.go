@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// TestSupplierReport_SharesAndOrder vérifie le tri par CA, les parts et le fournisseur sans vente
+// Total 1000€ dont 100€ de produits sans fournisseur: les parts somment à 90%
+func TestSupplierReport_SharesAndOrder(t *testing.T) {
+	eur := func(amount float64) domain.Money {
+		m, _ := domain.NewMoney(amount, "EUR")
+		return m
+	}
+	supplier := func(id SupplierID, name string) *Supplier {
+		s, err := NewSupplier(id, name, "", Email{}, "", "", "", "France", time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	suppliers := []*Supplier{supplier(1, "Alpha"), supplier(2, "Beta"), supplier(3, "Gamma")}
+	sales := []*SupplierSales{
+		NewSupplierSales(1, eur(300), 10, 4, 5, 3),
+		NewSupplierSales(2, eur(600), 2, 2, 1, 1),
+	}
+	top := map[SupplierID][]*SupplierProductSales{
+		2: {NewSupplierProductSales(7, "Serveur", 2, eur(600))},
+	}
+
+	dateRange, _ := domain.NewDateRangeFromDays(30)
+	report := NewSupplierReport(dateRange, suppliers, sales, top, eur(1000))
+
+	got := report.Suppliers()
+	if len(got) != 3 || got[0].Supplier().Name() != "Beta" || got[1].Supplier().Name() != "Alpha" {
+		t.Fatalf("order = %v", got)
+	}
+	if got[0].SalesShare() != 60 || got[1].SalesShare() != 30 || len(got[0].TopProducts()) != 1 {
+		t.Errorf("shares = %v, %v", got[0].SalesShare(), got[1].SalesShare())
+	}
+
+	gamma := got[2]
+	if gamma.Revenue().Amount() != 0 || gamma.UnitsSold() != 0 || gamma.SalesShare() != 0 || len(gamma.TopProducts()) != 0 {
+		t.Errorf("Gamma without sales = %v€, %d units", gamma.Revenue().Amount(), gamma.UnitsSold())
+	}
+}
@@ -0,0 +1,181 @@
+package infrastructure
+
+import (
+	"database/sql"
+
+	"eval/internal/catalog/domain"
+	ordersdomain "eval/internal/orders/domain"
+	ordersinfra "eval/internal/orders/infrastructure"
+	shareddomain "eval/internal/shared/domain"
+	"eval/internal/shared/infrastructure"
+)
+
+// InventoryQueryRepository repository pour l'analyse des stocks (products.stock_quantity)
+type InventoryQueryRepository struct {
+	infrastructure.BaseRepository
+}
+
+// NewInventoryQueryRepository crée un nouveau repository d'analyse des stocks
+func NewInventoryQueryRepository(db *sql.DB) *InventoryQueryRepository {
+	return &InventoryQueryRepository{
+		BaseRepository: infrastructure.NewBaseRepository(db),
+	}
+}
+
+// basePriceSQL prix de base du produit (alias p, catalogue en devise pivot) converti dans la devise
+// de reporting ($3) au taux de la fin de la période ($2): le stock est valorisé à une date, pas commande par commande
+// La requête doit joindre basePriceRateJoinSQL
+const basePriceSQL = "p.base_price / CASE WHEN $3::char(3) = '" + shareddomain.DefaultCurrency + "' THEN 1 ELSE price_fx.rate_to_eur END"
+
+// basePriceRateJoinSQL taux de la devise de reporting à la fin de la période (une seule ligne, NULL si inconnu)
+// PERFORMANCE: sous-requête sans référence aux produits, évaluée une fois pour toute la requête
+const basePriceRateJoinSQL = `
+		LEFT JOIN (
+			SELECT r.rate_to_eur FROM fx_rates r
+			WHERE r.currency = $3::char(3) AND r.rate_date <= $2::date
+			ORDER BY r.rate_date DESC
+			LIMIT 1
+		) price_fx ON TRUE`
+
+// GetProductStockActivity retourne le stock actuel de chaque produit, ses unités vendues
+// sur la période et sa dernière vente jusqu'à la fin de la période
+// Seules les commandes completed comptent (même définition des ventes que les stats)
+// Le prix de base (catalogue en devise pivot) est converti dans currency au taux de la fin de la période
+//
+// PIÈGE: la dernière vente est cherchée sur tout l'historique (pas seulement la période):
+// un produit sans vente sur 30 jours n'est pas dormant s'il s'est vendu il y a 40 jours
+func (r *InventoryQueryRepository) GetProductStockActivity(
+	dateRange shareddomain.DateRange,
+	currency shareddomain.Currency,
+) ([]*domain.ProductStockActivity, error) {
+	// $3 = devise de reporting, les mêmes $4.. servent aux deux CTE
+	statusWhere, statusArgs := infrastructure.BindSpecification(
+		ordersinfra.StatusSpecification("o", ordersdomain.StatusFilter{}), 4)
+	query := `
+		WITH window_sales AS (
+			SELECT oi.product_id, SUM(oi.quantity) AS units_sold
+			FROM order_items oi
+			INNER JOIN orders o ON oi.order_id = o.id
+			WHERE o.order_date >= $1 AND o.order_date <= $2
+			  AND ` + statusWhere + `
+			GROUP BY oi.product_id
+		),
+		last_sales AS (
+			SELECT oi.product_id, MAX(o.order_date) AS last_sale_date
+			FROM order_items oi
+			INNER JOIN orders o ON oi.order_id = o.id
+			WHERE o.order_date <= $2
+			  AND ` + statusWhere + `
+			GROUP BY oi.product_id
+		)
+		SELECT p.id, p.name, COALESCE(p.stock_quantity, 0), ` + basePriceSQL + `,
+		       COALESCE(ws.units_sold, 0),
+		       ls.last_sale_date
+		FROM products p` + basePriceRateJoinSQL + `
+		LEFT JOIN window_sales ws ON ws.product_id = p.id
+		LEFT JOIN last_sales ls ON ls.product_id = p.id
+		ORDER BY p.id
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, statusArgs...)
+	if err := r.CheckExchangeRate(currency, dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []*domain.ProductStockActivity
+	for rows.Next() {
+		var (
+			productID    int64
+			name         string
+			stock        int
+			price        shareddomain.Money
+			unitsSold    int
+			lastSaleDate sql.NullTime
+		)
+
+		err := rows.Scan(&productID, &name, &stock, shareddomain.MoneyIn(&price, currency), &unitsSold, &lastSaleDate)
+		if err != nil {
+			return nil, err
+		}
+
+		quantity, err := shareddomain.NewQuantity(stock)
+		if err != nil {
+			return nil, err
+		}
+		// lastSaleDate.Time vaut time.Time{} si NULL: "jamais vendu" pour le domaine
+		products = append(products, domain.NewProductStockActivity(
+			domain.ProductID(productID), name, quantity, price, unitsSold, lastSaleDate.Time))
+	}
+
+	return products, rows.Err()
+}
+
+// GetCategoryStockActivity agrège stock (unités et valeur) et unités vendues par catégorie
+//
+// PIÈGE: ventes et stock sont agrégés par produit avant la jointure avec les catégories:
+// joindre directement order_items dupliquerait le stock du produit pour chaque ligne de vente
+func (r *InventoryQueryRepository) GetCategoryStockActivity(
+	dateRange shareddomain.DateRange,
+	currency shareddomain.Currency,
+) ([]*domain.CategoryStockActivity, error) {
+	statusWhere, statusArgs := infrastructure.BindSpecification(
+		ordersinfra.StatusSpecification("o", ordersdomain.StatusFilter{}), 4)
+	query := `
+		WITH window_sales AS (
+			SELECT oi.product_id, SUM(oi.quantity) AS units_sold
+			FROM order_items oi
+			INNER JOIN orders o ON oi.order_id = o.id
+			WHERE o.order_date >= $1 AND o.order_date <= $2
+			  AND ` + statusWhere + `
+			GROUP BY oi.product_id
+		)
+		SELECT c.id, c.name,
+		       COUNT(p.id) AS product_count,
+		       COALESCE(SUM(p.stock_quantity), 0) AS stock_units,
+		       COALESCE(SUM(p.stock_quantity * ` + basePriceSQL + `), 0) AS stock_value,
+		       COALESCE(SUM(ws.units_sold), 0) AS units_sold
+		FROM categories c
+		LEFT JOIN product_categories pc ON pc.category_id = c.id
+		LEFT JOIN products p ON p.id = pc.product_id
+		LEFT JOIN window_sales ws ON ws.product_id = p.id` + basePriceRateJoinSQL + `
+		GROUP BY c.id, c.name
+		ORDER BY c.id
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, statusArgs...)
+	if err := r.CheckExchangeRate(currency, dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []*domain.CategoryStockActivity
+	for rows.Next() {
+		var (
+			categoryID   int64
+			name         string
+			productCount int
+			stockUnits   int
+			value        shareddomain.Money
+			unitsSold    int
+		)
+
+		err := rows.Scan(&categoryID, &name, &productCount, &stockUnits, shareddomain.MoneyIn(&value, currency), &unitsSold)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, domain.NewCategoryStockActivity(
+			domain.CategoryID(categoryID), name, productCount, stockUnits, value, unitsSold))
+	}
+
+	return categories, rows.Err()
+}
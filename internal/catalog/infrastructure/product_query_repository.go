@@ -37,12 +37,12 @@ func (r *ProductQueryRepository) FindByID(id domain.ProductID) (*domain.Product,
 		pid        int64
 		name       string
 		supplierID int64
-		basePrice  float64
+		money      shareddomain.Money
 		stockQty   int
 		createdAt  time.Time
 	)
 
-	err := r.QueryRow(query, int64(id)).Scan(&pid, &name, &supplierID, &basePrice, &stockQty, &createdAt)
+	err := r.QueryRow(query, int64(id)).Scan(&pid, &name, &supplierID, &money, &stockQty, &createdAt)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +53,6 @@ func (r *ProductQueryRepository) FindByID(id domain.ProductID) (*domain.Product,
 		return nil, err
 	}
 
-	money, _ := shareddomain.NewMoney(basePrice, "EUR")
 	quantity, _ := shareddomain.NewQuantity(stockQty)
 
 	return domain.NewProduct(
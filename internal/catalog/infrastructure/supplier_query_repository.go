@@ -0,0 +1,97 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"time"
+
+	"eval/internal/catalog/domain"
+	"eval/internal/shared/infrastructure"
+)
+
+// SupplierQueryRepository repository pour les requêtes de lecture sur les fournisseurs
+type SupplierQueryRepository struct {
+	infrastructure.BaseRepository
+}
+
+// NewSupplierQueryRepository crée un nouveau repository de lecture pour les fournisseurs
+func NewSupplierQueryRepository(db *sql.DB) *SupplierQueryRepository {
+	return &SupplierQueryRepository{
+		BaseRepository: infrastructure.NewBaseRepository(db),
+	}
+}
+
+// supplierColumns colonnes lues par scanSupplier, dans l'ordre
+// COALESCE: les colonnes de contact sont optionnelles, "" plutôt que sql.NullString dans le domaine
+const supplierColumns = `
+	s.id, s.name,
+	COALESCE(s.contact_name, ''), COALESCE(s.email, ''), COALESCE(s.phone, ''),
+	COALESCE(s.address, ''), COALESCE(s.city, ''), COALESCE(s.country, ''),
+	s.created_at`
+
+// FindByID trouve un fournisseur par son ID (sql.ErrNoRows si inconnu)
+func (r *SupplierQueryRepository) FindByID(id domain.SupplierID) (*domain.Supplier, error) {
+	query := `SELECT ` + supplierColumns + ` FROM suppliers s WHERE s.id = $1`
+	return scanSupplier(r.QueryRow(query, int64(id)))
+}
+
+// FindAll retourne tous les fournisseurs, par identifiant
+// PERFORMANCE: quelques dizaines de fournisseurs, chargés en une requête
+func (r *SupplierQueryRepository) FindAll() ([]*domain.Supplier, error) {
+	query := `SELECT ` + supplierColumns + ` FROM suppliers s ORDER BY s.id`
+
+	rows, err := r.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suppliers []*domain.Supplier
+	for rows.Next() {
+		supplier, err := scanSupplier(rows)
+		if err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, supplier)
+	}
+
+	return suppliers, rows.Err()
+}
+
+// rowScanner interface commune à *sql.Row et *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanSupplier lit une ligne produite par supplierColumns
+//
+// PIÈGE: suppliers.email n'est pas contraint en base
+//   - Un email absent donne un Email vide (pas d'erreur): le contact est optionnel
+//   - Un email présent mais invalide est une erreur, comme à la création d'un fournisseur
+func scanSupplier(row rowScanner) (*domain.Supplier, error) {
+	var (
+		id                                             int64
+		name, contactName, email, phone, address, city string
+		country                                        string
+		createdAt                                      time.Time
+	)
+
+	if err := row.Scan(&id, &name, &contactName, &email, &phone, &address, &city, &country, &createdAt); err != nil {
+		return nil, err
+	}
+
+	var validEmail domain.Email
+	if email != "" {
+		var err error
+		if validEmail, err = domain.NewEmail(email); err != nil {
+			return nil, err
+		}
+	}
+
+	return domain.NewSupplier(
+		domain.SupplierID(id),
+		name, contactName,
+		validEmail, phone,
+		address, city, country,
+		createdAt,
+	)
+}
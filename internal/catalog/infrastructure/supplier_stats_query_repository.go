@@ -0,0 +1,178 @@
+package infrastructure
+
+import (
+	"database/sql"
+
+	"eval/internal/catalog/domain"
+	ordersdomain "eval/internal/orders/domain"
+	ordersinfra "eval/internal/orders/infrastructure"
+	shareddomain "eval/internal/shared/domain"
+	"eval/internal/shared/infrastructure"
+)
+
+// SupplierStatsQueryRepository repository pour les analyses des ventes par fournisseur
+type SupplierStatsQueryRepository struct {
+	infrastructure.BaseRepository
+}
+
+// NewSupplierStatsQueryRepository crée un nouveau repository d'analyses fournisseurs
+func NewSupplierStatsQueryRepository(db *sql.DB) *SupplierStatsQueryRepository {
+	return &SupplierStatsQueryRepository{
+		BaseRepository: infrastructure.NewBaseRepository(db),
+	}
+}
+
+// GetSupplierSales agrège les lignes de vente de la période par fournisseur du produit
+// Seules les commandes completed comptent (même définition des ventes que les stats)
+// Retourne aussi le CA total de la période, produits sans fournisseur compris
+// Les montants sont convertis dans currency ($3) au taux du jour de chaque commande
+//
+// SYNTAXE SQL:
+//   - GROUP BY supplier_id regroupe aussi les produits sans fournisseur (supplier_id NULL)
+//     dans une ligne dédiée: elle compte dans le total mais n'est rattachée à aucun fournisseur
+//   - SUM(...) OVER () calcule le total sur toutes les lignes groupées, sans seconde requête
+func (r *SupplierStatsQueryRepository) GetSupplierSales(
+	dateRange shareddomain.DateRange,
+	currency shareddomain.Currency,
+) ([]*domain.SupplierSales, shareddomain.Money, error) {
+	statusWhere, statusArgs := infrastructure.BindSpecification(
+		ordersinfra.StatusSpecification("o", ordersdomain.StatusFilter{}), 4)
+	query := `
+		WITH supplier_sales AS (
+			SELECT p.supplier_id,
+			       SUM(` + infrastructure.ConvertedAmountSQL("oi.subtotal", "o", 3) + `) AS revenue,
+			       SUM(oi.quantity) AS units_sold,
+			       COUNT(DISTINCT oi.order_id) AS order_count,
+			       COUNT(DISTINCT oi.product_id) AS products_sold
+			FROM order_items oi
+			INNER JOIN orders o ON oi.order_id = o.id` + infrastructure.ExchangeRateJoinSQL("o", 3) + `
+			INNER JOIN products p ON oi.product_id = p.id
+			WHERE o.order_date >= $1 AND o.order_date <= $2
+			  AND ` + statusWhere + `
+			GROUP BY p.supplier_id
+		),
+		catalog AS (
+			SELECT supplier_id, COUNT(*) AS product_count
+			FROM products
+			GROUP BY supplier_id
+		)
+		SELECT ss.supplier_id, ss.revenue, ss.units_sold, ss.order_count, ss.products_sold,
+		       COALESCE(c.product_count, 0),
+		       SUM(ss.revenue) OVER () AS total_revenue
+		FROM supplier_sales ss
+		LEFT JOIN catalog c ON c.supplier_id IS NOT DISTINCT FROM ss.supplier_id
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), currency.String()}, statusArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, shareddomain.Money{}, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, shareddomain.Money{}, err
+	}
+	defer rows.Close()
+
+	var sales []*domain.SupplierSales
+	// Aucune vente: pas de ligne, le total reste à 0
+	total := currency.Zero()
+	for rows.Next() {
+		var (
+			supplierID   sql.NullInt64
+			money        shareddomain.Money
+			unitsSold    int
+			orderCount   int
+			productsSold int
+			productCount int
+		)
+
+		err := rows.Scan(
+			&supplierID,
+			shareddomain.MoneyIn(&money, currency),
+			&unitsSold, &orderCount, &productsSold, &productCount,
+			shareddomain.MoneyIn(&total, currency),
+		)
+		if err != nil {
+			return nil, shareddomain.Money{}, err
+		}
+		if !supplierID.Valid {
+			continue // Produits sans fournisseur: seulement dans le total
+		}
+
+		sales = append(sales, domain.NewSupplierSales(
+			domain.SupplierID(supplierID.Int64), money, unitsSold, orderCount, productCount, productsSold))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, shareddomain.Money{}, err
+	}
+
+	return sales, total, nil
+}
+
+// GetSupplierTopProducts retourne les limit meilleurs produits (par CA) de chaque fournisseur
+//
+// SYNTAXE SQL: ROW_NUMBER() OVER (PARTITION BY supplier_id ORDER BY revenue DESC)
+// numérote les produits de chaque fournisseur séparément: rn <= limit = top N par fournisseur
+// en une seule requête (au lieu d'une requête LIMIT par fournisseur)
+func (r *SupplierStatsQueryRepository) GetSupplierTopProducts(
+	dateRange shareddomain.DateRange,
+	limit int,
+	currency shareddomain.Currency,
+) (map[domain.SupplierID][]*domain.SupplierProductSales, error) {
+	// $3 = limit, $4 = devise de reporting
+	statusWhere, statusArgs := infrastructure.BindSpecification(
+		ordersinfra.StatusSpecification("o", ordersdomain.StatusFilter{}), 5)
+	query := `
+		WITH product_sales AS (
+			SELECT p.supplier_id, p.id, p.name,
+			       SUM(oi.quantity) AS units_sold,
+			       SUM(` + infrastructure.ConvertedAmountSQL("oi.subtotal", "o", 4) + `) AS revenue
+			FROM order_items oi
+			INNER JOIN orders o ON oi.order_id = o.id` + infrastructure.ExchangeRateJoinSQL("o", 4) + `
+			INNER JOIN products p ON oi.product_id = p.id
+			WHERE o.order_date >= $1 AND o.order_date <= $2
+			  AND p.supplier_id IS NOT NULL
+			  AND ` + statusWhere + `
+			GROUP BY p.supplier_id, p.id, p.name
+		),
+		ranked AS (
+			SELECT ps.*, ROW_NUMBER() OVER (PARTITION BY ps.supplier_id ORDER BY ps.revenue DESC, ps.id) AS rn
+			FROM product_sales ps
+		)
+		SELECT supplier_id, id, name, units_sold, revenue
+		FROM ranked
+		WHERE rn <= $3
+		ORDER BY supplier_id, rn
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End(), limit, currency.String()}, statusArgs...)
+	if err := r.CheckExchangeRates(currency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	topProducts := make(map[domain.SupplierID][]*domain.SupplierProductSales)
+	for rows.Next() {
+		var (
+			supplierID int64
+			productID  int64
+			name       string
+			unitsSold  int
+			money      shareddomain.Money
+		)
+
+		if err := rows.Scan(&supplierID, &productID, &name, &unitsSold, shareddomain.MoneyIn(&money, currency)); err != nil {
+			return nil, err
+		}
+
+		id := domain.SupplierID(supplierID)
+		topProducts[id] = append(topProducts[id], domain.NewSupplierProductSales(
+			domain.ProductID(productID), name, unitsSold, money))
+	}
+
+	return topProducts, rows.Err()
+}
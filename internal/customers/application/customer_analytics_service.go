@@ -0,0 +1,236 @@
+package application
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"eval/internal/customers/domain"
+	"eval/internal/customers/infrastructure"
+	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
+)
+
+// CustomerAnalyticsService analyses clients (segmentation RFM) avec cache
+// Même stratégie que StatsServiceV2: requêtes SQL agrégées, en parallèle, résultat mis en cache
+type CustomerAnalyticsService struct {
+	customerRepo *infrastructure.CustomerQueryRepository
+	statsRepo    *infrastructure.CustomerStatsQueryRepository
+	cache        sharedinfra.Cache
+	cacheTTL     time.Duration
+}
+
+// NewCustomerAnalyticsService crée une nouvelle instance de CustomerAnalyticsService
+func NewCustomerAnalyticsService(
+	customerRepo *infrastructure.CustomerQueryRepository,
+	statsRepo *infrastructure.CustomerStatsQueryRepository,
+	cache sharedinfra.Cache,
+) *CustomerAnalyticsService {
+	return &CustomerAnalyticsService{
+		customerRepo: customerRepo,
+		statsRepo:    statsRepo,
+		cache:        cache,
+		cacheTTL:     5 * time.Minute,
+	}
+}
+
+// GetRFMAnalysis segmente les clients selon leurs commandes de la période
+// La récence est mesurée par rapport à la fin de la période (pas à aujourd'hui):
+// une analyse de ?period=2024 reste stable dans le temps
+//
+// PARALLÉLISME: notation RFM et comptage des clients sont indépendants (2 goroutines)
+func (s *CustomerAnalyticsService) GetRFMAnalysis(dateRange shareddomain.DateRange) (*domain.RFMAnalysis, error) {
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("customers").
+		Add("v2").
+		Add("rfm").
+		Add(dateRange.Key()).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.RFMAnalysis), nil
+	}
+
+	var (
+		wg             sync.WaitGroup
+		customers      []*domain.CustomerRFM
+		totalCustomers int
+	)
+	errChan := make(chan error, 2)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		var err error
+		if customers, err = s.statsRepo.GetCustomerRFM(dateRange); err != nil {
+			errChan <- fmt.Errorf("customer RFM error: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if totalCustomers, err = s.customerRepo.Count(); err != nil {
+			errChan <- fmt.Errorf("customer count error: %w", err)
+		}
+	}()
+
+	wg.Wait()
+	close(errChan)
+	for err := range errChan {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	inactive := totalCustomers - len(customers)
+	if inactive < 0 {
+		inactive = 0 // clients supprimés entre les deux requêtes
+	}
+
+	analysis := domain.NewRFMAnalysis(dateRange, customers, inactive)
+	s.cache.Set(cacheKey, analysis, s.cacheTTL)
+
+	return analysis, nil
+}
+
+// GetCustomerLifetimeValue calcule la CLV historique et prédictive d'un client à la date du jour
+// La date fait partie de la clé de cache: la prédiction dépend du temps écoulé depuis la première commande
+// Client inconnu: sql.ErrNoRows
+func (s *CustomerAnalyticsService) GetCustomerLifetimeValue(
+	id domain.CustomerID,
+	horizonMonths int,
+) (*domain.CustomerLifetimeValue, error) {
+	now := time.Now()
+	asOf := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("customers").
+		Add("v2").
+		Add("clv").
+		Add(strconv.FormatInt(int64(id), 10)).
+		Add(strconv.Itoa(horizonMonths)).
+		Add(asOf.Format("2006-01-02")).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.CustomerLifetimeValue), nil
+	}
+
+	history, err := s.statsRepo.GetPurchaseHistory(id)
+	if err != nil {
+		return nil, err
+	}
+
+	clv, err := domain.NewCustomerLifetimeValue(history, asOf, horizonMonths)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Set(cacheKey, clv, s.cacheTTL)
+
+	return clv, nil
+}
+
+// GetTopCustomers retourne une page du classement des clients par CA sur la période
+//
+// PARALLÉLISME: la page et le nombre total de clients classés sont indépendants (2 goroutines)
+func (s *CustomerAnalyticsService) GetTopCustomers(
+	dateRange shareddomain.DateRange,
+	pagination shareddomain.Pagination,
+) (*domain.TopCustomersPage, error) {
+	cacheKey := sharedinfra.NewCacheKeyBuilder().
+		Add("customers").
+		Add("v2").
+		Add("top").
+		Add(dateRange.Key()).
+		Add(pagination.Key()).
+		Build()
+	if cached, found := s.cache.Get(cacheKey); found {
+		return cached.(*domain.TopCustomersPage), nil
+	}
+
+	var (
+		wg        sync.WaitGroup
+		customers []*domain.TopCustomer
+		total     int
+	)
+	errChan := make(chan error, 2)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		var err error
+		if customers, err = s.statsRepo.GetTopCustomers(dateRange, pagination); err != nil {
+			errChan <- fmt.Errorf("top customers error: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		if total, err = s.statsRepo.CountActiveCustomers(dateRange); err != nil {
+			errChan <- fmt.Errorf("active customers count error: %w", err)
+		}
+	}()
+
+	wg.Wait()
+	close(errChan)
+	for err := range errChan {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	page := domain.NewTopCustomersPage(dateRange, pagination, total, customers)
+	s.cache.Set(cacheKey, page, s.cacheTTL)
+
+	return page, nil
+}
+
+// ExportSegmentsToCSV exporte la note RFM et le segment de chaque client actif
+// segment vide = tous les segments
+func (s *CustomerAnalyticsService) ExportSegmentsToCSV(
+	dateRange shareddomain.DateRange,
+	segment domain.Segment,
+) ([]byte, error) {
+	analysis, err := s.GetRFMAnalysis(dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	customers := analysis.Customers()
+	if segment != "" {
+		customers = analysis.CustomersInSegment(segment)
+	}
+
+	buffer := bytes.NewBuffer(make([]byte, 0, 64*1024)) // 64 KB
+	writer := csv.NewWriter(buffer)
+
+	writer.Write([]string{
+		"customer_id", "name", "email", "segment", "rfm_score",
+		"recency_score", "frequency_score", "monetary_score",
+		"last_order_date", "recency_days", "order_count", "total_spent",
+	})
+	for _, c := range customers {
+		writer.Write([]string{
+			strconv.FormatInt(int64(c.CustomerID()), 10),
+			c.Name(),
+			c.Email(),
+			string(c.Segment()),
+			c.Score().String(),
+			strconv.Itoa(c.Score().Recency()),
+			strconv.Itoa(c.Score().Frequency()),
+			strconv.Itoa(c.Score().Monetary()),
+			c.LastOrderDate().Format("2006-01-02"),
+			strconv.Itoa(c.RecencyDays()),
+			strconv.Itoa(c.Frequency()),
+			c.Monetary().Decimal(),
+		})
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
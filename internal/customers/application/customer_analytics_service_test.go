@@ -0,0 +1,117 @@
+package application
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"eval/internal/customers/domain"
+	shareddomain "eval/internal/shared/domain"
+	"eval/internal/testhelpers"
+)
+
+// TestCustomerAnalyticsService_FixtureRFM vérifie la notation RFM de janvier 2024
+// sur le jeu de testhelpers.SetupFixtureContext (commande annulée exclue du montant)
+func TestCustomerAnalyticsService_FixtureRFM(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	service := NewCustomerAnalyticsService(ctx.CustomerQueryRepo, ctx.CustomerStatsRepo, ctx.Cache)
+
+	dateRange, err := shareddomain.NewDateRangeForMonth(2024, time.January, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	analysis, err := service.GetRFMAnalysis(dateRange)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Avec 2 clients, NTILE(5) attribue les notes 1 et 2
+	want := []struct {
+		id          domain.CustomerID
+		recencyDays int
+		spent       float64
+		score       string
+		segment     domain.Segment
+	}{
+		{1, 21, 1040, "112", domain.SegmentLost},
+		{2, 11, 30, "221", domain.SegmentHibernating},
+	}
+
+	customers := analysis.Customers()
+	if len(customers) != len(want) || analysis.InactiveCustomers() != 0 {
+		t.Fatalf("customers = %d active / %d inactive, want 2 / 0", len(customers), analysis.InactiveCustomers())
+	}
+	for i, w := range want {
+		c := customers[i]
+		if c.CustomerID() != w.id || c.RecencyDays() != w.recencyDays || c.Monetary().Amount() != w.spent {
+			t.Errorf("customer %d = id %d, %d days, %v EUR, want %d days, %v EUR",
+				i, c.CustomerID(), c.RecencyDays(), c.Monetary().Amount(), w.recencyDays, w.spent)
+		}
+		if c.Score().String() != w.score || c.Segment() != w.segment {
+			t.Errorf("customer %d = %s/%s, want %s/%s", w.id, c.Score(), c.Segment(), w.score, w.segment)
+		}
+	}
+}
+
+// TestCustomerAnalyticsService_FixtureTopCustomers vérifie le classement paginé de janvier 2024
+func TestCustomerAnalyticsService_FixtureTopCustomers(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	service := NewCustomerAnalyticsService(ctx.CustomerQueryRepo, ctx.CustomerStatsRepo, ctx.Cache)
+
+	dateRange, _ := shareddomain.NewDateRangeForMonth(2024, time.January, time.UTC)
+	pagination, _ := shareddomain.NewPagination(2, 1)
+
+	page, err := service.GetTopCustomers(dateRange, pagination)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.TotalCustomers() != 2 || page.TotalPages() != 2 {
+		t.Fatalf("total = %d customers / %d pages, want 2 / 2", page.TotalCustomers(), page.TotalPages())
+	}
+
+	// Page 2 de taille 1: Bruno (30€) derrière Alice (1040€, commande annulée exclue)
+	customers := page.Customers()
+	if len(customers) != 1 {
+		t.Fatalf("page customers = %d, want 1", len(customers))
+	}
+	if c := customers[0]; c.Rank() != 2 || c.CustomerID() != 2 || c.Revenue().Amount() != 30 || c.OrderCount() != 1 {
+		t.Errorf("page 2 = rank %d, customer %d, %v EUR, %d orders, want rank 2, customer 2, 30 EUR, 1 order",
+			c.Rank(), c.CustomerID(), c.Revenue().Amount(), c.OrderCount())
+	}
+}
+
+// TestCustomerAnalyticsService_FixtureLifetimeValue vérifie la CLV historique sur tout l'historique
+func TestCustomerAnalyticsService_FixtureLifetimeValue(t *testing.T) {
+	testhelpers.SkipIfNoDatabase(t)
+
+	ctx := testhelpers.SetupFixtureContext(t)
+	defer ctx.Cleanup()
+
+	service := NewCustomerAnalyticsService(ctx.CustomerQueryRepo, ctx.CustomerStatsRepo, ctx.Cache)
+
+	// Alice: commandes 1 (1040€) et 5 (60€), la commande 3 annulée est exclue
+	clv, err := service.GetCustomerLifetimeValue(1, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clv.OrderCount() != 2 || clv.HistoricalValue().Amount() != 1100 || clv.AverageOrderValue().Amount() != 550 {
+		t.Errorf("alice = %d orders, %v EUR (AOV %v), want 2 / 1100 / 550",
+			clv.OrderCount(), clv.HistoricalValue().Amount(), clv.AverageOrderValue().Amount())
+	}
+	if clv.PredictedValue().Amount() <= 0 {
+		t.Errorf("predicted value = %v, want > 0", clv.PredictedValue().Amount())
+	}
+
+	if _, err := service.GetCustomerLifetimeValue(999, 12); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("unknown customer error = %v, want sql.ErrNoRows", err)
+	}
+}
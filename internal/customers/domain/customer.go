@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	ordersdomain "eval/internal/orders/domain"
+)
+
+// CustomerID identifiant d'un client
+// Alias du type déjà utilisé par les commandes: un Order référence un Customer par ce même ID
+type CustomerID = ordersdomain.CustomerID
+
+// Customer représente un client (aggregate root du contexte customers)
+type Customer struct {
+	id         CustomerID
+	firstName  string
+	lastName   string
+	email      string
+	phone      string
+	city       string
+	postalCode string
+	country    string
+	createdAt  time.Time
+}
+
+// NewCustomer crée une nouvelle instance de Customer avec validation
+func NewCustomer(
+	id CustomerID,
+	firstName, lastName string,
+	email, phone string,
+	city, postalCode, country string,
+	createdAt time.Time,
+) (*Customer, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid customer ID")
+	}
+	firstName, lastName = strings.TrimSpace(firstName), strings.TrimSpace(lastName)
+	if firstName == "" || lastName == "" {
+		return nil, errors.New("customer first and last name cannot be empty")
+	}
+
+	return &Customer{
+		id:         id,
+		firstName:  firstName,
+		lastName:   lastName,
+		email:      email,
+		phone:      phone,
+		city:       city,
+		postalCode: postalCode,
+		country:    country,
+		createdAt:  createdAt,
+	}, nil
+}
+
+// ID retourne l'identifiant du client
+func (c *Customer) ID() CustomerID {
+	return c.id
+}
+
+// FirstName retourne le prénom
+func (c *Customer) FirstName() string {
+	return c.firstName
+}
+
+// LastName retourne le nom de famille
+func (c *Customer) LastName() string {
+	return c.lastName
+}
+
+// FullName retourne "Prénom Nom"
+func (c *Customer) FullName() string {
+	return c.firstName + " " + c.lastName
+}
+
+// Email retourne l'email ("" si inconnu)
+func (c *Customer) Email() string {
+	return c.email
+}
+
+// Phone retourne le téléphone ("" si inconnu)
+func (c *Customer) Phone() string {
+	return c.phone
+}
+
+// City retourne la ville
+func (c *Customer) City() string {
+	return c.city
+}
+
+// PostalCode retourne le code postal
+func (c *Customer) PostalCode() string {
+	return c.postalCode
+}
+
+// Country retourne le pays
+func (c *Customer) Country() string {
+	return c.country
+}
+
+// CreatedAt retourne la date d'inscription
+func (c *Customer) CreatedAt() time.Time {
+	return c.createdAt
+}
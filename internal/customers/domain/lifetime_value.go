@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// ========================================
+// VALEUR VIE CLIENT (Customer Lifetime Value)
+// ========================================
+// Deux mesures complémentaires, calculées sur tout l'historique des commandes completed:
+//   - Historique: total déjà dépensé par le client
+//   - Prédictive: ce qu'il devrait dépenser sur les horizonMonths prochains mois,
+//     en prolongeant son rythme d'achat observé
+//
+//	fréquence mensuelle = commandes / mois écoulés depuis la première commande (1 mois minimum)
+//	CLV prédictive      = panier moyen × fréquence mensuelle × horizon (mois)
+//
+// Modèle volontairement simple: ni churn ni actualisation. Un client inactif depuis
+// longtemps voit sa fréquence baisser mécaniquement, mais pas tomber à zéro.
+
+// averageDaysPerMonth durée moyenne d'un mois grégorien (365,25 / 12)
+const averageDaysPerMonth = 30.4375
+
+// DefaultCLVHorizonMonths horizon de prédiction par défaut
+const DefaultCLVHorizonMonths = 12
+
+// PurchaseHistory agrégat des commandes d'un client (une ligne SQL)
+// Les dates sont nulles si le client n'a jamais commandé
+type PurchaseHistory struct {
+	customerID     CustomerID
+	name           string
+	email          string
+	firstOrderDate time.Time
+	lastOrderDate  time.Time
+	orderCount     int
+	totalSpent     domain.Money
+}
+
+// NewPurchaseHistory crée l'historique d'achat d'un client
+func NewPurchaseHistory(
+	customerID CustomerID,
+	name, email string,
+	firstOrderDate, lastOrderDate time.Time,
+	orderCount int,
+	totalSpent domain.Money,
+) *PurchaseHistory {
+	return &PurchaseHistory{
+		customerID:     customerID,
+		name:           name,
+		email:          email,
+		firstOrderDate: firstOrderDate,
+		lastOrderDate:  lastOrderDate,
+		orderCount:     orderCount,
+		totalSpent:     totalSpent,
+	}
+}
+
+// CustomerLifetimeValue valeur historique et prédictive d'un client
+type CustomerLifetimeValue struct {
+	history           *PurchaseHistory
+	asOf              time.Time
+	horizonMonths     int
+	averageOrderValue domain.Money
+	monthlyFrequency  float64
+	predictedValue    domain.Money
+}
+
+// NewCustomerLifetimeValue calcule la CLV d'un client à la date asOf
+// horizonMonths doit être positif (DefaultCLVHorizonMonths en l'absence de préférence)
+func NewCustomerLifetimeValue(history *PurchaseHistory, asOf time.Time, horizonMonths int) (*CustomerLifetimeValue, error) {
+	if horizonMonths < 1 {
+		return nil, domain.NewValidationError("invalid_horizon", "CLV horizon must be at least 1 month")
+	}
+
+	clv := &CustomerLifetimeValue{
+		history:       history,
+		asOf:          asOf,
+		horizonMonths: horizonMonths,
+	}
+	clv.averageOrderValue, _ = domain.NewMoney(0, "EUR")
+	clv.predictedValue, _ = domain.NewMoney(0, "EUR")
+
+	if history.orderCount == 0 {
+		return clv, nil
+	}
+
+	// PIÈGE: un client dont la première commande date d'hier aurait une fréquence
+	// de 30 commandes/mois sans le minimum d'un mois observé
+	observedMonths := asOf.Sub(history.firstOrderDate).Hours() / 24 / averageDaysPerMonth
+	if observedMonths < 1 {
+		observedMonths = 1
+	}
+
+	aov := history.totalSpent.Amount() / float64(history.orderCount)
+	clv.monthlyFrequency = float64(history.orderCount) / observedMonths
+	clv.averageOrderValue, _ = domain.NewMoney(aov, "EUR")
+	clv.predictedValue, _ = domain.NewMoney(aov*clv.monthlyFrequency*float64(horizonMonths), "EUR")
+
+	return clv, nil
+}
+
+// CustomerID retourne l'identifiant du client
+func (c *CustomerLifetimeValue) CustomerID() CustomerID {
+	return c.history.customerID
+}
+
+// Name retourne le nom complet du client
+func (c *CustomerLifetimeValue) Name() string {
+	return c.history.name
+}
+
+// Email retourne l'email du client
+func (c *CustomerLifetimeValue) Email() string {
+	return c.history.email
+}
+
+// FirstOrderDate retourne la date de la première commande (zéro si aucune)
+func (c *CustomerLifetimeValue) FirstOrderDate() time.Time {
+	return c.history.firstOrderDate
+}
+
+// LastOrderDate retourne la date de la dernière commande (zéro si aucune)
+func (c *CustomerLifetimeValue) LastOrderDate() time.Time {
+	return c.history.lastOrderDate
+}
+
+// OrderCount retourne le nombre de commandes passées
+func (c *CustomerLifetimeValue) OrderCount() int {
+	return c.history.orderCount
+}
+
+// HistoricalValue retourne le total déjà dépensé (CLV historique)
+func (c *CustomerLifetimeValue) HistoricalValue() domain.Money {
+	return c.history.totalSpent
+}
+
+// AverageOrderValue retourne le panier moyen du client
+func (c *CustomerLifetimeValue) AverageOrderValue() domain.Money {
+	return c.averageOrderValue
+}
+
+// MonthlyFrequency retourne le nombre moyen de commandes par mois depuis la première commande
+func (c *CustomerLifetimeValue) MonthlyFrequency() float64 {
+	return c.monthlyFrequency
+}
+
+// PredictedValue retourne la dépense attendue sur l'horizon (CLV prédictive)
+func (c *CustomerLifetimeValue) PredictedValue() domain.Money {
+	return c.predictedValue
+}
+
+// HorizonMonths retourne l'horizon de prédiction en mois
+func (c *CustomerLifetimeValue) HorizonMonths() int {
+	return c.horizonMonths
+}
+
+// AsOf retourne la date de référence du calcul
+func (c *CustomerLifetimeValue) AsOf() time.Time {
+	return c.asOf
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// TestNewCustomerLifetimeValue vérifie la CLV prédictive (panier moyen × fréquence × horizon)
+func TestNewCustomerLifetimeValue(t *testing.T) {
+	asOf := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	spent, _ := domain.NewMoney(1200, "EUR")
+
+	// 6 commandes en ~12 mois (365,25 jours): 0,5 commande/mois, panier moyen 200
+	history := NewPurchaseHistory(1, "Alice Martin", "", asOf.Add(-365*24*time.Hour-6*time.Hour), asOf, 6, spent)
+	clv, err := NewCustomerLifetimeValue(history, asOf, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clv.AverageOrderValue().Amount() != 200 || clv.MonthlyFrequency() != 0.5 {
+		t.Errorf("AOV = %v, frequency = %v, want 200 / 0.5", clv.AverageOrderValue().Amount(), clv.MonthlyFrequency())
+	}
+	if clv.PredictedValue().Amount() != 1200 || clv.HistoricalValue().Amount() != 1200 {
+		t.Errorf("predicted = %v, historical = %v, want 1200 / 1200", clv.PredictedValue().Amount(), clv.HistoricalValue().Amount())
+	}
+
+	// Première commande il y a 3 jours: la fréquence est calculée sur 1 mois minimum
+	recent := NewPurchaseHistory(2, "Bruno Durand", "", asOf.AddDate(0, 0, -3), asOf, 2, spent)
+	clv, _ = NewCustomerLifetimeValue(recent, asOf, 6)
+	if clv.MonthlyFrequency() != 2 || clv.PredictedValue().Amount() != 7200 {
+		t.Errorf("recent customer frequency = %v, predicted = %v, want 2 / 7200", clv.MonthlyFrequency(), clv.PredictedValue().Amount())
+	}
+
+	// Client sans commande: CLV nulle, pas de division par zéro
+	zero, _ := domain.NewMoney(0, "EUR")
+	clv, _ = NewCustomerLifetimeValue(NewPurchaseHistory(3, "Chloé", "", time.Time{}, time.Time{}, 0, zero), asOf, 12)
+	if clv.PredictedValue().Amount() != 0 || clv.AverageOrderValue().Amount() != 0 {
+		t.Error("customer without orders should have a zero CLV")
+	}
+
+	if _, err := NewCustomerLifetimeValue(history, asOf, 0); err == nil {
+		t.Error("zero horizon should be rejected")
+	}
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// TopCustomer position d'un client dans le classement par CA sur une période
+type TopCustomer struct {
+	rank          int
+	customerID    CustomerID
+	name          string
+	email         string
+	orderCount    int
+	lastOrderDate time.Time
+	revenue       domain.Money
+}
+
+// NewTopCustomer crée une entrée du classement (rank commence à 1)
+func NewTopCustomer(
+	rank int,
+	customerID CustomerID,
+	name, email string,
+	orderCount int,
+	lastOrderDate time.Time,
+	revenue domain.Money,
+) *TopCustomer {
+	return &TopCustomer{
+		rank:          rank,
+		customerID:    customerID,
+		name:          name,
+		email:         email,
+		orderCount:    orderCount,
+		lastOrderDate: lastOrderDate,
+		revenue:       revenue,
+	}
+}
+
+// Rank retourne la position dans le classement (1 = meilleur client)
+func (c *TopCustomer) Rank() int {
+	return c.rank
+}
+
+// CustomerID retourne l'identifiant du client
+func (c *TopCustomer) CustomerID() CustomerID {
+	return c.customerID
+}
+
+// Name retourne le nom complet du client
+func (c *TopCustomer) Name() string {
+	return c.name
+}
+
+// Email retourne l'email du client
+func (c *TopCustomer) Email() string {
+	return c.email
+}
+
+// OrderCount retourne le nombre de commandes sur la période
+func (c *TopCustomer) OrderCount() int {
+	return c.orderCount
+}
+
+// LastOrderDate retourne la date du dernier achat sur la période
+func (c *TopCustomer) LastOrderDate() time.Time {
+	return c.lastOrderDate
+}
+
+// Revenue retourne le CA réalisé avec le client sur la période
+func (c *TopCustomer) Revenue() domain.Money {
+	return c.revenue
+}
+
+// TopCustomersPage une page du classement des clients
+type TopCustomersPage struct {
+	dateRange      domain.DateRange
+	pagination     domain.Pagination
+	totalCustomers int
+	customers      []*TopCustomer
+}
+
+// NewTopCustomersPage crée une page du classement
+// totalCustomers = nombre de clients classés sur la période (toutes pages confondues)
+func NewTopCustomersPage(
+	dateRange domain.DateRange,
+	pagination domain.Pagination,
+	totalCustomers int,
+	customers []*TopCustomer,
+) *TopCustomersPage {
+	return &TopCustomersPage{
+		dateRange:      dateRange,
+		pagination:     pagination,
+		totalCustomers: totalCustomers,
+		customers:      customers,
+	}
+}
+
+// DateRange retourne la période du classement
+func (p *TopCustomersPage) DateRange() domain.DateRange {
+	return p.dateRange
+}
+
+// Pagination retourne la page demandée
+func (p *TopCustomersPage) Pagination() domain.Pagination {
+	return p.pagination
+}
+
+// TotalCustomers retourne le nombre total de clients classés
+func (p *TopCustomersPage) TotalCustomers() int {
+	return p.totalCustomers
+}
+
+// TotalPages retourne le nombre de pages du classement
+func (p *TopCustomersPage) TotalPages() int {
+	return p.pagination.TotalPages(p.totalCustomers)
+}
+
+// Customers retourne les clients de la page, par rang croissant
+func (p *TopCustomersPage) Customers() []*TopCustomer {
+	return append([]*TopCustomer{}, p.customers...)
+}
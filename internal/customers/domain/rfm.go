@@ -0,0 +1,356 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// ========================================
+// SEGMENTATION RFM (Recency, Frequency, Monetary)
+// ========================================
+// Chaque client actif sur la période reçoit 3 notes de 1 à 5 (quintiles):
+//   - R: récence de la dernière commande (5 = a commandé le plus récemment)
+//   - F: nombre de commandes (5 = les 20% de clients qui commandent le plus)
+//   - M: montant dépensé (5 = les 20% de clients qui dépensent le plus)
+//
+// Le segment nommé est déduit du couple R/F (M ne départage que les champions),
+// selon la grille classique du marketing relationnel.
+
+// RFMScore notes RFM d'un client (Value Object, chaque note entre 1 et 5)
+type RFMScore struct {
+	recency   int
+	frequency int
+	monetary  int
+}
+
+// NewRFMScore crée un score avec validation des bornes
+func NewRFMScore(recency, frequency, monetary int) (RFMScore, error) {
+	for _, score := range []int{recency, frequency, monetary} {
+		if score < 1 || score > 5 {
+			return RFMScore{}, errors.New("RFM scores must be between 1 and 5")
+		}
+	}
+	return RFMScore{recency: recency, frequency: frequency, monetary: monetary}, nil
+}
+
+// Recency retourne la note de récence (1-5)
+func (s RFMScore) Recency() int {
+	return s.recency
+}
+
+// Frequency retourne la note de fréquence (1-5)
+func (s RFMScore) Frequency() int {
+	return s.frequency
+}
+
+// Monetary retourne la note monétaire (1-5)
+func (s RFMScore) Monetary() int {
+	return s.monetary
+}
+
+// String retourne la notation usuelle "RFM" (ex: "545")
+func (s RFMScore) String() string {
+	return fmt.Sprintf("%d%d%d", s.recency, s.frequency, s.monetary)
+}
+
+// Segment segment client nommé
+type Segment string
+
+const (
+	SegmentChampions          Segment = "champions"
+	SegmentLoyal              Segment = "loyal"
+	SegmentPotentialLoyalists Segment = "potential_loyalists"
+	SegmentNewCustomers       Segment = "new_customers"
+	SegmentNeedAttention      Segment = "need_attention"
+	SegmentAtRisk             Segment = "at_risk"
+	SegmentHibernating        Segment = "hibernating"
+	SegmentLost               Segment = "lost"
+)
+
+// Segments liste les segments du plus au moins engagé (ordre des rapports)
+var Segments = []Segment{
+	SegmentChampions,
+	SegmentLoyal,
+	SegmentPotentialLoyalists,
+	SegmentNewCustomers,
+	SegmentNeedAttention,
+	SegmentAtRisk,
+	SegmentHibernating,
+	SegmentLost,
+}
+
+// ParseSegment valide un nom de segment
+func ParseSegment(value string) (Segment, error) {
+	for _, segment := range Segments {
+		if Segment(value) == segment {
+			return segment, nil
+		}
+	}
+	return "", domain.NewValidationError("invalid_segment", fmt.Sprintf("unknown customer segment: %q", value))
+}
+
+// ClassifyRFM associe un score à son segment (règles évaluées dans l'ordre, la première gagne)
+//
+//	R ≥ 4, F ≥ 4, M ≥ 4  champions            achètent souvent, beaucoup, récemment
+//	R ≥ 3, F ≥ 4         loyal                clients réguliers
+//	R ≥ 4, F = 1         new_customers        première(s) commande(s) récente(s)
+//	R ≥ 4                potential_loyalists  récents, fréquence à développer
+//	R = 3                need_attention       ni récents ni anciens, peu fréquents
+//	R ≤ 2, F ≥ 3         at_risk              bons clients qui ne reviennent plus
+//	R = 2                hibernating          peu fréquents et pas récents
+//	R = 1                lost                 les plus anciens, peu fréquents
+func ClassifyRFM(score RFMScore) Segment {
+	r, f, m := score.recency, score.frequency, score.monetary
+
+	switch {
+	case r >= 4 && f >= 4 && m >= 4:
+		return SegmentChampions
+	case r >= 3 && f >= 4:
+		return SegmentLoyal
+	case r >= 4 && f == 1:
+		return SegmentNewCustomers
+	case r >= 4:
+		return SegmentPotentialLoyalists
+	case r == 3:
+		return SegmentNeedAttention
+	case f >= 3:
+		return SegmentAtRisk
+	case r == 2:
+		return SegmentHibernating
+	default:
+		return SegmentLost
+	}
+}
+
+// CustomerRFM indicateurs RFM bruts et notés d'un client sur la période
+type CustomerRFM struct {
+	customerID    CustomerID
+	name          string
+	email         string
+	lastOrderDate time.Time
+	recencyDays   int
+	frequency     int
+	monetary      domain.Money
+	score         RFMScore
+	segment       Segment
+}
+
+// NewCustomerRFM crée l'analyse RFM d'un client, le segment est déduit du score
+func NewCustomerRFM(
+	customerID CustomerID,
+	name, email string,
+	lastOrderDate time.Time,
+	recencyDays int,
+	frequency int,
+	monetary domain.Money,
+	score RFMScore,
+) *CustomerRFM {
+	return &CustomerRFM{
+		customerID:    customerID,
+		name:          name,
+		email:         email,
+		lastOrderDate: lastOrderDate,
+		recencyDays:   recencyDays,
+		frequency:     frequency,
+		monetary:      monetary,
+		score:         score,
+		segment:       ClassifyRFM(score),
+	}
+}
+
+// CustomerID retourne l'identifiant du client
+func (c *CustomerRFM) CustomerID() CustomerID {
+	return c.customerID
+}
+
+// Name retourne le nom complet du client
+func (c *CustomerRFM) Name() string {
+	return c.name
+}
+
+// Email retourne l'email du client
+func (c *CustomerRFM) Email() string {
+	return c.email
+}
+
+// LastOrderDate retourne la date de la dernière commande sur la période
+func (c *CustomerRFM) LastOrderDate() time.Time {
+	return c.lastOrderDate
+}
+
+// RecencyDays retourne le nombre de jours entre la dernière commande et la fin de la période
+func (c *CustomerRFM) RecencyDays() int {
+	return c.recencyDays
+}
+
+// Frequency retourne le nombre de commandes sur la période
+func (c *CustomerRFM) Frequency() int {
+	return c.frequency
+}
+
+// Monetary retourne le montant dépensé sur la période
+func (c *CustomerRFM) Monetary() domain.Money {
+	return c.monetary
+}
+
+// Score retourne les notes RFM
+func (c *CustomerRFM) Score() RFMScore {
+	return c.score
+}
+
+// Segment retourne le segment du client
+func (c *CustomerRFM) Segment() Segment {
+	return c.segment
+}
+
+// SegmentSummary agrégat d'un segment: taille, CA et profil moyen
+type SegmentSummary struct {
+	segment            Segment
+	customerCount      int
+	revenue            domain.Money
+	customerPercentage float64
+	revenuePercentage  float64
+	avgRecencyDays     float64
+	avgFrequency       float64
+	avgMonetary        domain.Money
+}
+
+// Segment retourne le segment résumé
+func (s *SegmentSummary) Segment() Segment {
+	return s.segment
+}
+
+// CustomerCount retourne le nombre de clients du segment
+func (s *SegmentSummary) CustomerCount() int {
+	return s.customerCount
+}
+
+// Revenue retourne le CA généré par le segment
+func (s *SegmentSummary) Revenue() domain.Money {
+	return s.revenue
+}
+
+// CustomerPercentage retourne la part des clients actifs dans ce segment
+func (s *SegmentSummary) CustomerPercentage() float64 {
+	return s.customerPercentage
+}
+
+// RevenuePercentage retourne la part du CA réalisée par ce segment
+func (s *SegmentSummary) RevenuePercentage() float64 {
+	return s.revenuePercentage
+}
+
+// AvgRecencyDays retourne la récence moyenne (jours)
+func (s *SegmentSummary) AvgRecencyDays() float64 {
+	return s.avgRecencyDays
+}
+
+// AvgFrequency retourne le nombre moyen de commandes
+func (s *SegmentSummary) AvgFrequency() float64 {
+	return s.avgFrequency
+}
+
+// AvgMonetary retourne le montant moyen dépensé par client
+func (s *SegmentSummary) AvgMonetary() domain.Money {
+	return s.avgMonetary
+}
+
+// RFMAnalysis résultat d'une segmentation RFM sur une période
+type RFMAnalysis struct {
+	dateRange         domain.DateRange
+	customers         []*CustomerRFM
+	segments          []*SegmentSummary
+	inactiveCustomers int
+}
+
+// NewRFMAnalysis construit l'analyse et ses résumés par segment
+// Tous les segments sont présents (à 0 si vides), dans l'ordre de Segments
+// inactiveCustomers = clients connus sans commande sur la période (hors notation RFM)
+func NewRFMAnalysis(dateRange domain.DateRange, customers []*CustomerRFM, inactiveCustomers int) *RFMAnalysis {
+	type accumulator struct {
+		count       int
+		revenue     float64
+		recencyDays int
+		orders      int
+	}
+
+	bySegment := make(map[Segment]*accumulator, len(Segments))
+	for _, segment := range Segments {
+		bySegment[segment] = &accumulator{}
+	}
+
+	var totalRevenue float64
+	for _, c := range customers {
+		acc := bySegment[c.segment]
+		acc.count++
+		acc.revenue += c.monetary.Amount()
+		acc.recencyDays += c.recencyDays
+		acc.orders += c.frequency
+		totalRevenue += c.monetary.Amount()
+	}
+
+	segments := make([]*SegmentSummary, 0, len(Segments))
+	for _, segment := range Segments {
+		acc := bySegment[segment]
+		summary := &SegmentSummary{segment: segment, customerCount: acc.count}
+		summary.revenue, _ = domain.NewMoney(acc.revenue, "EUR")
+		summary.avgMonetary, _ = domain.NewMoney(0, "EUR")
+
+		if acc.count > 0 {
+			summary.customerPercentage = float64(acc.count) / float64(len(customers)) * 100
+			summary.avgRecencyDays = float64(acc.recencyDays) / float64(acc.count)
+			summary.avgFrequency = float64(acc.orders) / float64(acc.count)
+			summary.avgMonetary, _ = domain.NewMoney(acc.revenue/float64(acc.count), "EUR")
+		}
+		if totalRevenue > 0 {
+			summary.revenuePercentage = acc.revenue / totalRevenue * 100
+		}
+		segments = append(segments, summary)
+	}
+
+	return &RFMAnalysis{
+		dateRange:         dateRange,
+		customers:         customers,
+		segments:          segments,
+		inactiveCustomers: inactiveCustomers,
+	}
+}
+
+// DateRange retourne la période analysée
+func (a *RFMAnalysis) DateRange() domain.DateRange {
+	return a.dateRange
+}
+
+// Customers retourne l'analyse RFM de chaque client actif
+func (a *RFMAnalysis) Customers() []*CustomerRFM {
+	return append([]*CustomerRFM{}, a.customers...)
+}
+
+// CustomersInSegment retourne les clients d'un segment
+func (a *RFMAnalysis) CustomersInSegment(segment Segment) []*CustomerRFM {
+	var result []*CustomerRFM
+	for _, c := range a.customers {
+		if c.segment == segment {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// Segments retourne les résumés par segment
+func (a *RFMAnalysis) Segments() []*SegmentSummary {
+	return append([]*SegmentSummary{}, a.segments...)
+}
+
+// ActiveCustomers retourne le nombre de clients notés (au moins une commande sur la période)
+func (a *RFMAnalysis) ActiveCustomers() int {
+	return len(a.customers)
+}
+
+// InactiveCustomers retourne le nombre de clients sans commande sur la période
+func (a *RFMAnalysis) InactiveCustomers() int {
+	return a.inactiveCustomers
+}
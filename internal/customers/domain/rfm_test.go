@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// TestClassifyRFM vérifie la grille de segmentation
+func TestClassifyRFM(t *testing.T) {
+	tests := []struct {
+		r, f, m int
+		want    Segment
+	}{
+		{5, 5, 5, SegmentChampions},
+		{4, 4, 4, SegmentChampions},
+		{5, 5, 2, SegmentLoyal},
+		{3, 4, 5, SegmentLoyal},
+		{5, 1, 1, SegmentNewCustomers},
+		{4, 3, 5, SegmentPotentialLoyalists},
+		{3, 2, 2, SegmentNeedAttention},
+		{2, 5, 5, SegmentAtRisk},
+		{1, 3, 1, SegmentAtRisk},
+		{2, 1, 4, SegmentHibernating},
+		{1, 2, 5, SegmentLost},
+	}
+
+	for _, tt := range tests {
+		score, err := NewRFMScore(tt.r, tt.f, tt.m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ClassifyRFM(score); got != tt.want {
+			t.Errorf("ClassifyRFM(%s) = %s, want %s", score, got, tt.want)
+		}
+	}
+
+	if _, err := NewRFMScore(0, 3, 6); err == nil {
+		t.Error("scores outside 1-5 should be rejected")
+	}
+}
+
+// TestNewRFMAnalysis vérifie les résumés par segment
+func TestNewRFMAnalysis(t *testing.T) {
+	dateRange, _ := domain.NewDateRangeFromDays(365)
+	customer := func(id CustomerID, r, f, m int, recencyDays, orders int, spent float64) *CustomerRFM {
+		score, _ := NewRFMScore(r, f, m)
+		money, _ := domain.NewMoney(spent, "EUR")
+		return NewCustomerRFM(id, "Client", "", time.Now(), recencyDays, orders, money, score)
+	}
+
+	analysis := NewRFMAnalysis(dateRange, []*CustomerRFM{
+		customer(1, 5, 5, 5, 2, 10, 900),
+		customer(2, 4, 4, 4, 10, 6, 500),
+		customer(3, 1, 1, 1, 300, 1, 100),
+	}, 4)
+
+	segments := analysis.Segments()
+	if len(segments) != len(Segments) {
+		t.Fatalf("segments = %d, want all %d", len(segments), len(Segments))
+	}
+
+	champions := segments[0]
+	if champions.Segment() != SegmentChampions || champions.CustomerCount() != 2 {
+		t.Fatalf("champions = %s/%d, want 2 customers", champions.Segment(), champions.CustomerCount())
+	}
+	if champions.Revenue().Amount() != 1400 || champions.RevenuePercentage() != 93.33333333333333 {
+		t.Errorf("champions revenue = %v (%v%%)", champions.Revenue().Amount(), champions.RevenuePercentage())
+	}
+	if champions.AvgFrequency() != 8 || champions.AvgRecencyDays() != 6 || champions.AvgMonetary().Amount() != 700 {
+		t.Errorf("champions averages = %v orders / %v days / %v EUR",
+			champions.AvgFrequency(), champions.AvgRecencyDays(), champions.AvgMonetary().Amount())
+	}
+	if len(analysis.CustomersInSegment(SegmentLost)) != 1 || analysis.InactiveCustomers() != 4 {
+		t.Error("expected one lost customer and 4 inactive customers")
+	}
+}
@@ -0,0 +1,69 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"time"
+
+	"eval/internal/customers/domain"
+	"eval/internal/shared/infrastructure"
+)
+
+// CustomerQueryRepository repository pour les requêtes de lecture sur les clients
+type CustomerQueryRepository struct {
+	infrastructure.BaseRepository
+}
+
+// NewCustomerQueryRepository crée un nouveau repository de lecture pour les clients
+func NewCustomerQueryRepository(db *sql.DB) *CustomerQueryRepository {
+	return &CustomerQueryRepository{
+		BaseRepository: infrastructure.NewBaseRepository(db),
+	}
+}
+
+// customerColumns colonnes lues par scanCustomer, dans l'ordre
+// COALESCE: les colonnes de contact sont optionnelles, "" plutôt que sql.NullString dans le domaine
+const customerColumns = `
+	c.id, c.first_name, c.last_name,
+	COALESCE(c.email, ''), COALESCE(c.phone, ''),
+	COALESCE(c.city, ''), COALESCE(c.postal_code, ''), COALESCE(c.country, ''),
+	c.created_at`
+
+// FindByID trouve un client par son ID (sql.ErrNoRows si inconnu)
+func (r *CustomerQueryRepository) FindByID(id domain.CustomerID) (*domain.Customer, error) {
+	query := `SELECT ` + customerColumns + ` FROM customers c WHERE c.id = $1`
+	return scanCustomer(r.QueryRow(query, int64(id)))
+}
+
+// Count retourne le nombre total de clients
+func (r *CustomerQueryRepository) Count() (int, error) {
+	var count int
+	err := r.QueryRow(`SELECT COUNT(*) FROM customers`).Scan(&count)
+	return count, err
+}
+
+// rowScanner interface commune à *sql.Row et *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanCustomer lit une ligne produite par customerColumns
+func scanCustomer(row rowScanner) (*domain.Customer, error) {
+	var (
+		id                                  int64
+		firstName, lastName                 string
+		email, phone, city, postal, country string
+		createdAt                           time.Time
+	)
+
+	if err := row.Scan(&id, &firstName, &lastName, &email, &phone, &city, &postal, &country, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return domain.NewCustomer(
+		domain.CustomerID(id),
+		firstName, lastName,
+		email, phone,
+		city, postal, country,
+		createdAt,
+	)
+}
@@ -0,0 +1,240 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"time"
+
+	"eval/internal/customers/domain"
+	ordersdomain "eval/internal/orders/domain"
+	ordersinfra "eval/internal/orders/infrastructure"
+	shareddomain "eval/internal/shared/domain"
+	"eval/internal/shared/infrastructure"
+)
+
+// CustomerStatsQueryRepository repository pour les analyses clients (RFM, ...)
+// Les montants sont agrégés dans la devise pivot (commandes converties au taux de leur date)
+type CustomerStatsQueryRepository struct {
+	infrastructure.BaseRepository
+}
+
+// NewCustomerStatsQueryRepository crée un nouveau repository d'analyses clients
+func NewCustomerStatsQueryRepository(db *sql.DB) *CustomerStatsQueryRepository {
+	return &CustomerStatsQueryRepository{
+		BaseRepository: infrastructure.NewBaseRepository(db),
+	}
+}
+
+// GetCustomerRFM calcule les indicateurs et notes RFM de chaque client ayant commandé sur la période
+// Seules les commandes completed comptent (même définition des ventes que les stats)
+//
+// SYNTAXE SQL:
+//   - NTILE(5) OVER (ORDER BY x) découpe les clients en 5 groupes de taille égale selon x:
+//     1 = les 20% les plus faibles, 5 = les 20% les plus forts
+//   - $2::date - last_order_date = nombre de jours (soustraction de deux DATE)
+//
+// PIÈGE: NTILE répartit les ex-aequo dans des groupes voisins (ex: beaucoup de clients à 1 commande)
+//   - Le tri secondaire par customer_id rend le découpage déterministe d'un appel à l'autre
+//
+// PERFORMANCE: agrégation et notation en une passe côté PostgreSQL
+//   - Seule la ligne finale par client est transférée, jamais les commandes
+func (r *CustomerStatsQueryRepository) GetCustomerRFM(dateRange shareddomain.DateRange) ([]*domain.CustomerRFM, error) {
+	statusWhere, statusArgs := infrastructure.BindSpecification(
+		ordersinfra.StatusSpecification("o", ordersdomain.StatusFilter{}), 3)
+	query := `
+		WITH customer_orders AS (
+			SELECT o.customer_id,
+			       MAX(o.order_date) AS last_order_date,
+			       COUNT(*) AS frequency,
+			       COALESCE(SUM(` + infrastructure.DefaultCurrencyAmountSQL("o.total_amount", "o") + `), 0) AS monetary
+			FROM orders o` + infrastructure.DefaultCurrencyJoinSQL("o") + `
+			WHERE o.order_date >= $1 AND o.order_date <= $2
+			  AND ` + statusWhere + `
+			GROUP BY o.customer_id
+		)
+		SELECT c.id, c.first_name || ' ' || c.last_name, COALESCE(c.email, ''),
+		       co.last_order_date,
+		       $2::date - co.last_order_date AS recency_days,
+		       co.frequency,
+		       co.monetary,
+		       NTILE(5) OVER (ORDER BY co.last_order_date, c.id) AS r_score,
+		       NTILE(5) OVER (ORDER BY co.frequency, c.id) AS f_score,
+		       NTILE(5) OVER (ORDER BY co.monetary, c.id) AS m_score
+		FROM customer_orders co
+		INNER JOIN customers c ON c.id = co.customer_id
+		ORDER BY c.id
+	`
+
+	args := append([]interface{}{dateRange.Start(), dateRange.End()}, statusArgs...)
+	if err := r.CheckExchangeRates(shareddomain.DefaultCurrency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var customers []*domain.CustomerRFM
+	for rows.Next() {
+		var (
+			customerID     int64
+			name, email    string
+			lastOrderDate  time.Time
+			recencyDays    int
+			frequency      int
+			spent          shareddomain.Money
+			rScore, fScore int
+			mScore         int
+		)
+
+		if err := rows.Scan(
+			&customerID, &name, &email,
+			&lastOrderDate, &recencyDays, &frequency, &spent,
+			&rScore, &fScore, &mScore,
+		); err != nil {
+			return nil, err
+		}
+
+		score, err := domain.NewRFMScore(rScore, fScore, mScore)
+		if err != nil {
+			return nil, err
+		}
+
+		customers = append(customers, domain.NewCustomerRFM(
+			domain.CustomerID(customerID), name, email,
+			lastOrderDate, recencyDays, frequency, spent, score,
+		))
+	}
+
+	return customers, rows.Err()
+}
+
+// GetPurchaseHistory agrège toutes les commandes completed d'un client (sql.ErrNoRows si client inconnu)
+// Un client connu sans commande retourne un historique vide (dates nulles, 0 commande)
+//
+// SYNTAXE SQL: le filtre de statut est dans la condition du LEFT JOIN, pas dans le WHERE:
+// dans le WHERE, il éliminerait la ligne du client sans commande (o.* NULL)
+func (r *CustomerStatsQueryRepository) GetPurchaseHistory(id domain.CustomerID) (*domain.PurchaseHistory, error) {
+	statusWhere, statusArgs := infrastructure.BindSpecification(
+		ordersinfra.StatusSpecification("o", ordersdomain.StatusFilter{}), 2)
+	query := `
+		SELECT c.id, c.first_name || ' ' || c.last_name, COALESCE(c.email, ''),
+		       MIN(o.order_date), MAX(o.order_date),
+		       COUNT(o.id),
+		       COALESCE(SUM(` + infrastructure.DefaultCurrencyAmountSQL("o.total_amount", "o") + `), 0)
+		FROM customers c
+		LEFT JOIN orders o ON o.customer_id = c.id AND ` + statusWhere + infrastructure.DefaultCurrencyJoinSQL("o") + `
+		WHERE c.id = $1
+		GROUP BY c.id
+	`
+
+	var (
+		customerID            int64
+		name, email           string
+		firstOrder, lastOrder sql.NullTime
+		orderCount            int
+		spent                 shareddomain.Money
+	)
+
+	args := append([]interface{}{int64(id)}, statusArgs...)
+	if err := r.QueryRow(query, args...).Scan(
+		&customerID, &name, &email, &firstOrder, &lastOrder, &orderCount, &spent,
+	); err != nil {
+		return nil, err
+	}
+
+	// Historique complet du client: les taux sont vérifiés sur sa période d'achat, connue après la requête
+	if orderCount > 0 {
+		if err := r.CheckExchangeRates(shareddomain.DefaultCurrency, firstOrder.Time, lastOrder.Time); err != nil {
+			return nil, err
+		}
+	}
+
+	return domain.NewPurchaseHistory(
+		domain.CustomerID(customerID), name, email,
+		firstOrder.Time, lastOrder.Time, orderCount, spent,
+	), nil
+}
+
+// GetTopCustomers retourne une page du classement des clients par CA sur la période
+// Tri secondaire par customer_id: deux clients à CA égal gardent le même ordre d'une page à l'autre
+//
+// PERFORMANCE: LIMIT/OFFSET appliqués après agrégation, seule la page est transférée
+//   - OFFSET reste linéaire (PostgreSQL calcule et jette les lignes précédentes),
+//     acceptable ici car le nombre de clients actifs sur une période reste modéré
+func (r *CustomerStatsQueryRepository) GetTopCustomers(
+	dateRange shareddomain.DateRange,
+	pagination shareddomain.Pagination,
+) ([]*domain.TopCustomer, error) {
+	statusWhere, statusArgs := infrastructure.BindSpecification(
+		ordersinfra.StatusSpecification("o", ordersdomain.StatusFilter{}), 5)
+	query := `
+		WITH customer_sales AS (
+			SELECT o.customer_id,
+			       COUNT(*) AS order_count,
+			       MAX(o.order_date) AS last_order_date,
+			       COALESCE(SUM(` + infrastructure.DefaultCurrencyAmountSQL("o.total_amount", "o") + `), 0) AS revenue
+			FROM orders o` + infrastructure.DefaultCurrencyJoinSQL("o") + `
+			WHERE o.order_date >= $1 AND o.order_date <= $2
+			  AND ` + statusWhere + `
+			GROUP BY o.customer_id
+		)
+		SELECT c.id, c.first_name || ' ' || c.last_name, COALESCE(c.email, ''),
+		       cs.order_count, cs.last_order_date, cs.revenue
+		FROM customer_sales cs
+		INNER JOIN customers c ON c.id = cs.customer_id
+		ORDER BY cs.revenue DESC, c.id
+		LIMIT $3 OFFSET $4
+	`
+
+	args := append([]interface{}{
+		dateRange.Start(), dateRange.End(), pagination.Limit(), pagination.Offset(),
+	}, statusArgs...)
+	if err := r.CheckExchangeRates(shareddomain.DefaultCurrency, dateRange.Start(), dateRange.End()); err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	customers := make([]*domain.TopCustomer, 0, pagination.Limit())
+	for rows.Next() {
+		var (
+			customerID    int64
+			name, email   string
+			orderCount    int
+			lastOrderDate time.Time
+			money         shareddomain.Money
+		)
+
+		if err := rows.Scan(&customerID, &name, &email, &orderCount, &lastOrderDate, &money); err != nil {
+			return nil, err
+		}
+
+		rank := pagination.Offset() + len(customers) + 1
+		customers = append(customers, domain.NewTopCustomer(
+			rank, domain.CustomerID(customerID), name, email, orderCount, lastOrderDate, money,
+		))
+	}
+
+	return customers, rows.Err()
+}
+
+// CountActiveCustomers compte les clients ayant au moins une commande completed sur la période
+// (nombre total d'entrées du classement GetTopCustomers)
+func (r *CustomerStatsQueryRepository) CountActiveCustomers(dateRange shareddomain.DateRange) (int, error) {
+	statusWhere, statusArgs := infrastructure.BindSpecification(
+		ordersinfra.StatusSpecification("o", ordersdomain.StatusFilter{}), 3)
+	query := `
+		SELECT COUNT(DISTINCT o.customer_id)
+		FROM orders o
+		WHERE o.order_date >= $1 AND o.order_date <= $2
+		  AND ` + statusWhere
+
+	var count int
+	args := append([]interface{}{dateRange.Start(), dateRange.End()}, statusArgs...)
+	err := r.QueryRow(query, args...).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,295 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	analyticsdomain "eval/internal/analytics/domain"
+	"eval/internal/export/domain"
+	"eval/internal/export/infrastructure"
+	ordersdomain "eval/internal/orders/domain"
+	shareddomain "eval/internal/shared/domain"
+	sharedinfra "eval/internal/shared/infrastructure"
+)
+
+// ExportJobRequest paramètres de création d'un job d'export asynchrone
+type ExportJobRequest struct {
+	Format         domain.ExportFormat
+	ExportType     domain.ExportType
+	DateRange      shareddomain.DateRange
+	Statuses       ordersdomain.StatusFilter // statuts de commande exportés (completed par défaut)
+	ParquetOptions domain.ParquetOptions
+}
+
+// ExportJobService exécute les exports en arrière-plan
+// Le handler HTTP crée le job et répond immédiatement avec son ID,
+// le fichier est produit sur disque par le worker pool et téléchargé plus tard
+//
+// WORKER POOL: pool partagé des tâches de fond de l'application (injecté, démarré et arrêté par main)
+//   - Distinct de celui d'ExportServiceV2: un job Parquet soumet lui-même ses row groups au pool
+//     d'ExportServiceV2 et les attend; sur un pool unique, des jobs occupant tous les workers
+//     attendraient des tâches jamais exécutées (deadlock)
+//   - File bornée: un job qui n'y trouve pas de place est refusé (503 export_queue_full)
+//
+// RÉTENTION: un job terminé et son fichier sont supprimés retention après sa fin
+//   - Sans cela le store (mémoire) et le répertoire de sortie (disque) grossissent sans limite
+//   - Les fichiers laissés par un processus précédent (jobs en mémoire perdus au redémarrage)
+//     sont supprimés sur leur date de modification
+type ExportJobService struct {
+	exportService *ExportServiceV2
+	jobStore      *infrastructure.ExportJobStore
+	workerPool    *sharedinfra.WorkerPool
+	outputDir     string
+	retention     time.Duration
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+// evictionInterval fréquence de suppression des jobs expirés
+const evictionInterval = 10 * time.Minute
+
+// NewExportJobService crée le service de jobs et le répertoire de sortie
+// et démarre la suppression périodique des jobs terminés depuis plus de retention
+func NewExportJobService(
+	exportService *ExportServiceV2,
+	jobStore *infrastructure.ExportJobStore,
+	workerPool *sharedinfra.WorkerPool,
+	outputDir string,
+	retention time.Duration,
+) (*ExportJobService, error) {
+	if retention <= 0 {
+		return nil, fmt.Errorf("export retention must be positive, got %s", retention)
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &ExportJobService{
+		exportService: exportService,
+		jobStore:      jobStore,
+		workerPool:    workerPool,
+		outputDir:     outputDir,
+		retention:     retention,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+	go s.evictExpiredPeriodically()
+
+	return s, nil
+}
+
+// Submit crée un job en statut queued et le soumet au worker pool
+// Retourne domain.ErrExportQueueFull si le pool n'a plus de place: aucun job n'est créé
+func (s *ExportJobService) Submit(req ExportJobRequest) (*domain.ExportJob, error) {
+	id, err := newExportJobID()
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := domain.NewExportJob(id, req.Format, req.ExportType, req.DateRange)
+	if err != nil {
+		return nil, err
+	}
+	// Enregistré avant la soumission: un worker libre peut démarrer le job immédiatement
+	s.jobStore.Save(job)
+
+	// PIÈGE: Submit bloque quand la file est pleine; depuis une goroutine par requête,
+	// chaque POST ajouterait une goroutine en attente sans limite
+	if err := s.workerPool.TrySubmit(func() error { return s.run(id, req) }); err != nil {
+		s.jobStore.Delete(id)
+		if errors.Is(err, sharedinfra.ErrWorkerPoolFull) {
+			return nil, domain.ErrExportQueueFull
+		}
+		return nil, err
+	}
+
+	return s.jobStore.FindByID(id)
+}
+
+// GetJob retourne l'état courant d'un job
+func (s *ExportJobService) GetJob(id domain.ExportJobID) (*domain.ExportJob, error) {
+	return s.jobStore.FindByID(id)
+}
+
+// GetResultFile retourne le job et le chemin du fichier produit s'il est terminé
+func (s *ExportJobService) GetResultFile(id domain.ExportJobID) (*domain.ExportJob, string, error) {
+	job, err := s.jobStore.FindByID(id)
+	if err != nil {
+		return nil, "", err
+	}
+	if job.Status() != domain.ExportJobStatusDone {
+		return job, "", domain.ErrExportJobNotReady
+	}
+	return job, job.FilePath(), nil
+}
+
+// run exécute un job dans un worker: écrit dans un fichier temporaire puis le renomme
+// Le renommage est atomique: un fichier présent sous son nom final est toujours complet
+func (s *ExportJobService) run(id domain.ExportJobID, req ExportJobRequest) (err error) {
+	job, err := s.jobStore.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			s.fail(id, err)
+		}
+	}()
+
+	var totalRows int64
+	if job.ExportType() == domain.ExportTypeSales {
+		totalRows, err = s.exportService.CountSalesRows(s.ctx, job.DateRange(), req.Statuses)
+		if err != nil {
+			return fmt.Errorf("failed to count rows: %w", err)
+		}
+	}
+	if err = s.jobStore.Update(id, func(j *domain.ExportJob) error { return j.Start(totalRows) }); err != nil {
+		return err
+	}
+
+	finalPath := filepath.Join(s.outputDir, string(id)+job.FileExtension())
+	tmpPath := finalPath + ".tmp"
+
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	var rowsWritten int64
+	onProgress := func(rows int64) {
+		rowsWritten = rows
+		_ = s.jobStore.Update(id, func(j *domain.ExportJob) error { return j.ReportProgress(rows) })
+	}
+
+	switch {
+	case job.ExportType() == domain.ExportTypeStats:
+		var filter analyticsdomain.StatsFilter
+		filter, err = analyticsdomain.NewStatsFilter(analyticsdomain.StatsFilterCriteria{Status: req.Statuses})
+		if err != nil {
+			break
+		}
+		var data []byte
+		data, err = s.exportService.ExportStatsToCSV(job.DateRange(), filter)
+		if err == nil {
+			_, err = file.Write(data)
+		}
+	case job.Format() == domain.ExportFormatParquet:
+		err = s.exportService.WriteSalesParquet(s.ctx, file, job.DateRange(), req.Statuses, req.ParquetOptions, onProgress)
+	default:
+		err = s.exportService.WriteSalesCSV(s.ctx, file, job.DateRange(), req.Statuses, onProgress)
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	if err = os.Rename(tmpPath, finalPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	return s.jobStore.Update(id, func(j *domain.ExportJob) error { return j.Complete(finalPath, rowsWritten) })
+}
+
+// fail marque le job comme échoué et journalise l'erreur brute
+// Le job ne conserve que le code et le message exposables (voir ExportJob.Fail):
+// l'ID du job dans le log relie l'échec vu par le client à sa cause
+func (s *ExportJobService) fail(id domain.ExportJobID, err error) {
+	log.Printf("export job %s failed: %v", id, err)
+	_ = s.jobStore.Update(id, func(j *domain.ExportJob) error { return j.Fail(err) })
+}
+
+// evictExpiredPeriodically supprime les jobs expirés jusqu'à l'arrêt du service (Cleanup)
+func (s *ExportJobService) evictExpiredPeriodically() {
+	ticker := time.NewTicker(evictionInterval)
+	defer ticker.Stop()
+
+	s.evictExpired(time.Now()) // fichiers d'un processus précédent
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.evictExpired(now)
+		}
+	}
+}
+
+// evictExpired supprime les jobs terminés avant now - retention, leurs fichiers
+// et les fichiers d'export plus anciens qui n'appartiennent à aucun job connu
+// PIÈGE: le job est retiré du store avant son fichier: un GET /download ne trouve plus le job
+// au lieu d'un fichier manquant. Un téléchargement déjà commencé se termine (fichier ouvert)
+func (s *ExportJobService) evictExpired(now time.Time) {
+	cutoff := now.Add(-s.retention)
+
+	for _, job := range s.jobStore.FinishedBefore(cutoff) {
+		s.jobStore.Delete(job.ID())
+		if job.FilePath() != "" {
+			if err := os.Remove(job.FilePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+				log.Printf("export job %s: failed to remove file: %v", job.ID(), err)
+			}
+		}
+	}
+
+	entries, err := os.ReadDir(s.outputDir)
+	if err != nil {
+		log.Printf("export retention: %v", err)
+		return
+	}
+	for _, entry := range entries {
+		// SÉCURITÉ: seuls les fichiers produits par les jobs, jamais d'autres fichiers du répertoire
+		switch filepath.Ext(entry.Name()) {
+		case ".csv", ".parquet", ".tmp":
+		default:
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if _, err := s.jobStore.FindByID(domain.ExportJobID(jobIDFromFileName(entry.Name()))); err == nil {
+			continue // job encore connu (en cours depuis plus de retention): il garde son fichier
+		}
+		if err := os.Remove(filepath.Join(s.outputDir, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("export retention: %v", err)
+		}
+	}
+}
+
+// jobIDFromFileName extrait l'ID du job d'un nom de fichier ("<id>.csv", "<id>.parquet.tmp")
+func jobIDFromFileName(name string) string {
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// Cleanup annule les jobs en cours
+// Le worker pool partagé est arrêté par son propriétaire (main), après les services qui l'utilisent
+func (s *ExportJobService) Cleanup() {
+	s.cancel()
+}
+
+// newExportJobID génère un identifiant aléatoire de 128 bits (hexadécimal)
+func newExportJobID() (domain.ExportJobID, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return domain.ExportJobID(hex.EncodeToString(b)), nil
+}
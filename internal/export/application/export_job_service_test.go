@@ -0,0 +1,71 @@
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"eval/internal/export/domain"
+	"eval/internal/export/infrastructure"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// TestExportJobService_EvictExpired vérifie la suppression des jobs expirés et des fichiers orphelins
+func TestExportJobService_EvictExpired(t *testing.T) {
+	dir := t.TempDir()
+	store := infrastructure.NewExportJobStore()
+	s := &ExportJobService{jobStore: store, outputDir: dir, retention: time.Hour}
+
+	dateRange, err := shareddomain.NewDateRangeFromDays(30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newJob := func(id domain.ExportJobID) *domain.ExportJob {
+		job, err := domain.NewExportJob(id, domain.ExportFormatCSV, domain.ExportTypeSales, dateRange)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := job.Start(0); err != nil {
+			t.Fatal(err)
+		}
+		store.Save(job)
+		return job
+	}
+	writeFile := func(name string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("order_id\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	donePath := writeFile("done.csv")
+	if err := newJob("done").Complete(donePath, 0); err != nil {
+		t.Fatal(err)
+	}
+	runningPath := writeFile("running.csv.tmp")
+	newJob("running")
+	orphanPath := writeFile("previous-process.parquet")
+	otherPath := writeFile("README.txt")
+
+	// Avant l'expiration: rien n'est supprimé
+	s.evictExpired(time.Now())
+	if _, err := store.FindByID("done"); err != nil {
+		t.Errorf("done job evicted before its retention: %v", err)
+	}
+
+	s.evictExpired(time.Now().Add(2 * time.Hour))
+	if _, err := store.FindByID("done"); err == nil {
+		t.Error("done job still in the store after its retention")
+	}
+	if _, err := store.FindByID("running"); err != nil {
+		t.Errorf("running job evicted: %v", err)
+	}
+	for path, wantKept := range map[string]bool{donePath: false, orphanPath: false, runningPath: true, otherPath: true} {
+		_, err := os.Stat(path)
+		if kept := err == nil; kept != wantKept {
+			t.Errorf("%s: kept = %v, want %v", filepath.Base(path), kept, wantKept)
+		}
+	}
+}
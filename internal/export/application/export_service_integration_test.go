@@ -1,9 +1,13 @@
 package application
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
 	analyticsapp "eval/internal/analytics/application"
+	exportdomain "eval/internal/export/domain"
+	ordersdomain "eval/internal/orders/domain"
 	shareddomain "eval/internal/shared/domain"
 	"eval/internal/testhelpers"
 )
@@ -43,7 +47,6 @@ func BenchmarkComparison_V1_vs_V2_CSV_30Days(b *testing.B) {
 	defer ctx.Cleanup()
 
 	exportServiceV1, exportServiceV2 := setupExportServices(ctx)
-	defer exportServiceV2.Cleanup()
 
 	b.Run("V1_N+1_Queries", func(b *testing.B) {
 		b.ReportAllocs()
@@ -82,7 +85,6 @@ func BenchmarkExportServiceV2_CSV_7Days(b *testing.B) {
 	defer ctx.Cleanup()
 
 	_, exportServiceV2 := setupExportServices(ctx)
-	defer exportServiceV2.Cleanup()
 
 	b.ResetTimer()
 	b.ReportAllocs()
@@ -104,7 +106,6 @@ func BenchmarkExportServiceV2_CSV_30Days(b *testing.B) {
 	defer ctx.Cleanup()
 
 	_, exportServiceV2 := setupExportServices(ctx)
-	defer exportServiceV2.Cleanup()
 
 	b.ResetTimer()
 	b.ReportAllocs()
@@ -126,7 +127,6 @@ func BenchmarkExportServiceV2_CSV_365Days(b *testing.B) {
 	defer ctx.Cleanup()
 
 	_, exportServiceV2 := setupExportServices(ctx)
-	defer exportServiceV2.Cleanup()
 
 	b.ResetTimer()
 	b.ReportAllocs()
@@ -140,6 +140,43 @@ func BenchmarkExportServiceV2_CSV_365Days(b *testing.B) {
 	}
 }
 
+// BenchmarkExportServiceV2_StreamCSV_365Days compare le streaming au CSV en mémoire sur 365 jours
+// B/op doit rester faible et stable: aucune ligne n'est conservée entre deux écritures
+func BenchmarkExportServiceV2_StreamCSV_365Days(b *testing.B) {
+	testhelpers.SkipIfNoDatabase(b)
+
+	ctx := testhelpers.SetupTestContext(b)
+	defer ctx.Cleanup()
+
+	_, exportServiceV2 := setupExportServices(ctx)
+
+	dateRange, err := shareddomain.NewDateRangeFromDays(365)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		counter := &countingWriter{}
+		if err := exportServiceV2.StreamSalesToCSV(context.Background(), counter, dateRange, ordersdomain.StatusFilter{}); err != nil {
+			b.Fatal(err)
+		}
+		b.ReportMetric(float64(counter.n), "bytes")
+	}
+}
+
+// countingWriter io.Writer qui compte les octets sans les garder en mémoire
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
 // ========================================
 // Parquet Export Benchmark
 // ========================================
@@ -152,17 +189,21 @@ func BenchmarkExportServiceV2_Parquet_30Days(b *testing.B) {
 	defer ctx.Cleanup()
 
 	_, exportServiceV2 := setupExportServices(ctx)
-	defer exportServiceV2.Cleanup()
+
+	dateRange, err := shareddomain.NewDateRangeFromDays(30)
+	if err != nil {
+		b.Fatal(err)
+	}
 
 	b.ResetTimer()
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		data, err := exportServiceV2.ExportToParquet(30)
-		if err != nil {
+		var buffer bytes.Buffer
+		if err := exportServiceV2.WriteSalesParquet(context.Background(), &buffer, dateRange, ordersdomain.StatusFilter{}, exportdomain.DefaultParquetOptions(), nil); err != nil {
 			b.Fatal(err)
 		}
-		b.ReportMetric(float64(len(data)), "bytes")
+		b.ReportMetric(float64(buffer.Len()), "bytes")
 	}
 }
 
@@ -186,7 +227,7 @@ func BenchmarkExportRepo_GetSalesDataOptimized_30Days(b *testing.B) {
 			b.Fatal(err)
 		}
 
-		salesData, err := ctx.ExportQueryRepo.GetSalesDataOptimized(dateRange)
+		salesData, err := ctx.ExportQueryRepo.GetSalesDataOptimized(dateRange, ordersdomain.StatusFilter{})
 		if err != nil {
 			b.Fatal(err)
 		}
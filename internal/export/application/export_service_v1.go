@@ -8,6 +8,7 @@ import (
 	"eval/internal/analytics/application"
 	"eval/internal/export/domain"
 	"eval/internal/export/infrastructure"
+	ordersdomain "eval/internal/orders/domain"
 	shareddomain "eval/internal/shared/domain"
 )
 
@@ -37,7 +38,7 @@ func (s *ExportServiceV1) ExportSalesToCSV(days int) ([]byte, error) {
 	}
 
 	// Récupérer les données avec N+1 queries (INEFFICACE!)
-	salesData, err := s.exportRepo.GetSalesDataInefficient(dateRange)
+	salesData, err := s.exportRepo.GetSalesDataInefficient(dateRange, ordersdomain.StatusFilter{})
 	if err != nil {
 		return nil, err
 	}
@@ -87,9 +88,9 @@ func (s *ExportServiceV1) ExportStatsToCSV(days int) ([]byte, error) {
 	writer.Write([]string{"Type", "Metric", "Value"})
 
 	// Stats globales
-	writer.Write([]string{"Global", "Total Revenue", fmt.Sprintf("%.2f", stats.TotalRevenue().Amount())})
+	writer.Write([]string{"Global", "Total Revenue", stats.TotalRevenue().Decimal()})
 	writer.Write([]string{"Global", "Total Orders", fmt.Sprintf("%d", stats.TotalOrders())})
-	writer.Write([]string{"Global", "Average Order Value", fmt.Sprintf("%.2f", stats.AverageOrderValue().Amount())})
+	writer.Write([]string{"Global", "Average Order Value", stats.AverageOrderValue().Decimal()})
 
 	// Saut de ligne
 	writer.Write([]string{})
@@ -100,7 +101,7 @@ func (s *ExportServiceV1) ExportStatsToCSV(days int) ([]byte, error) {
 	for _, cs := range stats.CategoryStats() {
 		writer.Write([]string{
 			cs.CategoryName(),
-			fmt.Sprintf("%.2f", cs.TotalRevenue().Amount()),
+			cs.TotalRevenue().Decimal(),
 			fmt.Sprintf("%d", cs.TotalOrders()),
 		})
 	}
@@ -114,7 +115,7 @@ func (s *ExportServiceV1) ExportStatsToCSV(days int) ([]byte, error) {
 	for _, ps := range stats.TopProducts() {
 		writer.Write([]string{
 			ps.ProductName(),
-			fmt.Sprintf("%.2f", ps.TotalRevenue().Amount()),
+			ps.TotalRevenue().Decimal(),
 			fmt.Sprintf("%d", ps.TotalOrders()),
 			fmt.Sprintf("%d", ps.TotalQuantity().Value()),
 		})
@@ -136,7 +137,7 @@ func (s *ExportServiceV1) ExportToParquet(days int) ([]byte, error) {
 	}
 
 	// Récupérer TOUTES les données en mémoire d'un coup (INEFFICACE!)
-	salesData, err := s.exportRepo.GetSalesDataInefficient(dateRange)
+	salesData, err := s.exportRepo.GetSalesDataInefficient(dateRange, ordersdomain.StatusFilter{})
 	if err != nil {
 		return nil, err
 	}
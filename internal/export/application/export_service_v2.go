@@ -2,24 +2,23 @@ package application
 
 import (
 	"bytes"
+	"context"
 	"encoding/csv"
 	"fmt"
-	"strconv"
-	"strings"
-	"sync"
+	"io"
 
 	"eval/internal/analytics/application"
+	analyticsdomain "eval/internal/analytics/domain"
 	"eval/internal/export/domain"
 	"eval/internal/export/infrastructure"
+	ordersdomain "eval/internal/orders/domain"
 	shareddomain "eval/internal/shared/domain"
-	sharedinfra "eval/internal/shared/infrastructure"
 )
 
 // ExportServiceV2 service optimisé pour les exports (Version 2)
 type ExportServiceV2 struct {
 	exportRepo   *infrastructure.ExportQueryRepository
 	statsService *application.StatsServiceV2
-	workerPool   *sharedinfra.WorkerPool
 	batchSize    int
 }
 
@@ -28,13 +27,9 @@ func NewExportServiceV2(
 	exportRepo *infrastructure.ExportQueryRepository,
 	statsService *application.StatsServiceV2,
 ) *ExportServiceV2 {
-	wp := sharedinfra.NewWorkerPool(4) // 4 workers
-	wp.Start()                         // Démarrer les workers
-
 	return &ExportServiceV2{
 		exportRepo:   exportRepo,
 		statsService: statsService,
-		workerPool:   wp,
 		batchSize:    1000,
 	}
 }
@@ -50,9 +45,9 @@ func (s *ExportServiceV2) ExportSalesToCSV(days int) ([]byte, error) {
 		return nil, err
 	}
 
-	// Récupère toutes les ventes sur la période via une requête SQL optimisée
+	// Récupère toutes les ventes sur la période via une requête SQL optimisée (commandes completed)
 	// Retourne une slice allouée sur le heap contenant les structs de ventes
-	salesData, err := s.exportRepo.GetSalesDataOptimized(dateRange)
+	salesData, err := s.exportRepo.GetSalesDataOptimized(dateRange, ordersdomain.StatusFilter{})
 	if err != nil {
 		return nil, err
 	}
@@ -99,10 +94,102 @@ func (s *ExportServiceV2) ExportSalesToCSV(days int) ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+// CountSalesRows compte les lignes d'un export de ventes (utilisé pour la progression des jobs)
+func (s *ExportServiceV2) CountSalesRows(
+	ctx context.Context,
+	dateRange shareddomain.DateRange,
+	statuses ordersdomain.StatusFilter,
+) (int64, error) {
+	return s.exportRepo.CountSalesRows(ctx, dateRange, statuses)
+}
+
+// flusher interface implémentée par http.ResponseWriter (http.Flusher)
+// Permet d'envoyer les chunks au client sans dépendre du package net/http
+type flusher interface {
+	Flush()
+}
+
+// StreamSalesToCSV écrit les ventes en CSV directement dans w (ex: http.ResponseWriter)
+// MÉMOIRE: ✓ Constante quelle que soit la période
+//   - Les lignes sont lues curseur par curseur (StreamSalesData), jamais stockées dans un slice
+//   - csv.Writer garde seulement un petit buffer interne (4 KB), vidé tous les batchSize lignes
+//   - Si w implémente Flush() (http.Flusher), chaque batch part immédiatement sur le réseau (chunked)
+//
+// ANNULATION: si ctx est annulé (client déconnecté), la requête SQL et l'écriture s'arrêtent
+func (s *ExportServiceV2) StreamSalesToCSV(
+	ctx context.Context,
+	w io.Writer,
+	dateRange shareddomain.DateRange,
+	statuses ordersdomain.StatusFilter,
+) error {
+	return s.WriteSalesCSV(ctx, w, dateRange, statuses, nil)
+}
+
+// WriteSalesCSV écrit les ventes de la période en CSV dans w (réponse HTTP, fichier, ...)
+// statuses restreint les commandes exportées (StatusFilter{} = completed uniquement)
+// onProgress (optionnel) est appelé après chaque batch avec le nombre de lignes déjà écrites
+func (s *ExportServiceV2) WriteSalesCSV(
+	ctx context.Context,
+	w io.Writer,
+	dateRange shareddomain.DateRange,
+	statuses ordersdomain.StatusFilter,
+	onProgress func(rowsWritten int64),
+) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(domain.CSVHeaders()); err != nil {
+		return err
+	}
+
+	var rowCount int64
+	err := s.exportRepo.StreamSalesData(ctx, dateRange, statuses, func(row *domain.SaleExportRow) error {
+		if err := writer.Write(row.ToCSVRow()); err != nil {
+			return err
+		}
+
+		rowCount++
+		if rowCount%int64(s.batchSize) == 0 {
+			if onProgress != nil {
+				onProgress(rowCount)
+			}
+			return s.flushCSV(ctx, writer, w)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if onProgress != nil {
+		onProgress(rowCount)
+	}
+	return s.flushCSV(ctx, writer, w)
+}
+
+// flushCSV vide le buffer CSV vers w puis pousse les données au client
+func (s *ExportServiceV2) flushCSV(ctx context.Context, writer *csv.Writer, w io.Writer) error {
+	// Client parti: inutile de continuer à écrire
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	if f, ok := w.(flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
 // ExportStatsToCSV exporte les statistiques en CSV
-func (s *ExportServiceV2) ExportStatsToCSV(days int) ([]byte, error) {
+func (s *ExportServiceV2) ExportStatsToCSV(
+	dateRange shareddomain.DateRange,
+	filter analyticsdomain.StatsFilter,
+) ([]byte, error) {
 	// Utiliser le service de stats optimisé avec cache
-	stats, err := s.statsService.GetStats(days)
+	stats, err := s.statsService.GetStatsForRange(dateRange, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -115,9 +202,9 @@ func (s *ExportServiceV2) ExportStatsToCSV(days int) ([]byte, error) {
 	writer.Write([]string{"Type", "Metric", "Value"})
 
 	// Stats globales
-	writer.Write([]string{"Global", "Total Revenue", fmt.Sprintf("%.2f", stats.TotalRevenue().Amount())})
+	writer.Write([]string{"Global", "Total Revenue", stats.TotalRevenue().Decimal()})
 	writer.Write([]string{"Global", "Total Orders", fmt.Sprintf("%d", stats.TotalOrders())})
-	writer.Write([]string{"Global", "Average Order Value", fmt.Sprintf("%.2f", stats.AverageOrderValue().Amount())})
+	writer.Write([]string{"Global", "Average Order Value", stats.AverageOrderValue().Decimal()})
 
 	// Saut de ligne
 	writer.Write([]string{})
@@ -128,7 +215,7 @@ func (s *ExportServiceV2) ExportStatsToCSV(days int) ([]byte, error) {
 	for _, cs := range stats.CategoryStats() {
 		writer.Write([]string{
 			cs.CategoryName(),
-			fmt.Sprintf("%.2f", cs.TotalRevenue().Amount()),
+			cs.TotalRevenue().Decimal(),
 			fmt.Sprintf("%d", cs.TotalOrders()),
 		})
 	}
@@ -142,12 +229,27 @@ func (s *ExportServiceV2) ExportStatsToCSV(days int) ([]byte, error) {
 	for _, ps := range stats.TopProducts() {
 		writer.Write([]string{
 			ps.ProductName(),
-			fmt.Sprintf("%.2f", ps.TotalRevenue().Amount()),
+			ps.TotalRevenue().Decimal(),
 			fmt.Sprintf("%d", ps.TotalOrders()),
 			fmt.Sprintf("%d", ps.TotalQuantity().Value()),
 		})
 	}
 
+	// Saut de ligne
+	writer.Write([]string{})
+
+	// Ventilation par statut (tous statuts: montre ce que le filtre écarte)
+	writer.Write([]string{"Status Breakdown", "", ""})
+	writer.Write([]string{"Status", "Total Revenue", "Total Orders", "Order Percentage"})
+	for _, oss := range stats.StatusBreakdown() {
+		writer.Write([]string{
+			string(oss.Status()),
+			oss.TotalRevenue().Decimal(),
+			fmt.Sprintf("%d", oss.TotalOrders()),
+			fmt.Sprintf("%.2f", oss.OrderPercentage()),
+		})
+	}
+
 	writer.Flush()
 	if err := writer.Error(); err != nil {
 		return nil, err
@@ -156,130 +258,49 @@ func (s *ExportServiceV2) ExportStatsToCSV(days int) ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
-// ExportToParquet exporte en format Parquet avec worker pool (simplifié ici - juste structure)
-// Note: L'implémentation complète de Parquet nécessiterait la library parquet-go
-// Cette version utilise le WorkerPool pour traiter les données en parallèle par batches
-func (s *ExportServiceV2) ExportToParquet(days int) ([]byte, error) {
-	dateRange, err := shareddomain.NewDateRangeFromDays(days)
-	if err != nil {
-		return nil, err
-	}
-
-	// Récupérer les données optimisées
-	salesData, err := s.exportRepo.GetSalesDataOptimized(dateRange)
+// WriteSalesParquet écrit les ventes de la période en vrai fichier Apache Parquet dans w
+// (lisible par Spark, DuckDB, pandas); onProgress (optionnel) est appelé après chaque row group
+// Les lignes sont lues curseur par curseur (StreamSalesData) et découpées en row groups
+// de options.RowGroupSize lignes, écrits dans w dès qu'ils sont complets, puis le footer (FileMetaData)
+//
+// MÉMOIRE: seul le row group en cours est gardé (pages encodées et compressées par parquet-go):
+// la mémoire dépend de options.RowGroupSize, pas de la période exportée
+//
+// ANNULATION: si ctx est annulé (client déconnecté, arrêt du service), la requête SQL s'arrête
+// et l'export retourne ctx.Err()
+// PIÈGE: en cas d'erreur, w a pu recevoir un fichier partiel (sans footer, donc illisible)
+func (s *ExportServiceV2) WriteSalesParquet(
+	ctx context.Context,
+	w io.Writer,
+	dateRange shareddomain.DateRange,
+	statuses ordersdomain.StatusFilter,
+	options domain.ParquetOptions,
+	onProgress func(rowsWritten int64),
+) error {
+	writer, err := infrastructure.NewSalesParquetWriter(w, options)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if len(salesData) == 0 {
-		return []byte("No data to export"), nil
-	}
-
-	// Créer un buffer principal pour collecter tous les résultats
-	var mainBuffer bytes.Buffer
-	var mu sync.Mutex // Mutex pour protéger l'accès au buffer
-
-	// En-tête Parquet simulé
-	mainBuffer.WriteString(fmt.Sprintf("PARQUET-LIKE FORMAT\nTotal Rows: %d\nBatch Size: %d\nWorkers: 4\n\n",
-		len(salesData), s.batchSize))
-
-	// Diviser les données en batches et soumettre au worker pool
-	numBatches := (len(salesData) + s.batchSize - 1) / s.batchSize
-	errChan := make(chan error, numBatches)
-
-	for i := 0; i < numBatches; i++ {
-		batchStart := i * s.batchSize
-		batchEnd := batchStart + s.batchSize
-		if batchEnd > len(salesData) {
-			batchEnd = len(salesData)
-		}
-
-		// Créer une copie locale pour la closure
-		batch := salesData[batchStart:batchEnd]
-		batchNum := i + 1
-
-		// Soumettre la tâche au worker pool
-		task := func() error {
-			// Traiter le batch en parallèle
-			var batchBuffer bytes.Buffer
-			batchBuffer.WriteString(fmt.Sprintf("--- Batch %d (Rows %d-%d) ---\n",
-				batchNum, batchStart+1, batchEnd))
-
-			for _, row := range batch {
-
-				// Utilisation de strings.Builder pour construire la ligne efficacement.
-				// - Grow pré-alloue la capacité pour éviter les réallocations et copies successives.
-				// - WriteString + strconv écrivent directement dans le buffer sans créer de chaînes temporaires.
-				// - fmt.Sprintf analyse le format et alloue des strings temporaires (plus coûteux en CPU et GC).
-				// - Réduction des allocations entraîne moins de pression GC et un meilleur débit pour les exports volumineux.
-				var builder = strings.Builder{}
-				builder.Grow(256)
-				builder.WriteString("Order: ")
-				builder.WriteString(strconv.FormatInt(row.OrderID, 10))
-				builder.WriteString(" | Product: ")
-				builder.WriteString(row.ProductName)
-				builder.WriteString(" | Qty: ")
-				builder.WriteString(strconv.Itoa(row.Quantity))
-				builder.WriteString(" | Amount: ")
-				builder.WriteString(fmt.Sprintf("%.2f", row.UnitPrice*float64(row.Quantity)))
-				builder.WriteString(" | Date: ")
-				builder.WriteString(row.OrderDate.Format("2006-01-02"))
-				builder.WriteString("\n")
-
-				line := builder.String()
-
-				batchBuffer.WriteString(line)
-			}
-
-			// Ajouter le résultat du batch au buffer principal (thread-safe)
-			mu.Lock()
-			mainBuffer.Write(batchBuffer.Bytes())
-			mu.Unlock()
-
-			return nil
+	rowGroupSize := int64(options.RowGroupSize())
+	err = s.exportRepo.StreamSalesData(ctx, dateRange, statuses, func(row *domain.SaleExportRow) error {
+		if err := writer.Write(row); err != nil {
+			return err
 		}
-
-		// Soumettre la tâche
-		if err := s.workerPool.Submit(task); err != nil {
-			errChan <- err
+		if onProgress != nil && writer.NumRows()%rowGroupSize == 0 {
+			onProgress(writer.NumRows())
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	// Attendre que toutes les tâches soient terminées
-	// Note: On ne ferme pas le pool car il sera réutilisé
-	// On attend juste que les tâches actuelles soient terminées
-	go func() {
-		for i := 0; i < numBatches; i++ {
-			select {
-			case err := <-s.workerPool.Errors():
-				if err != nil {
-					errChan <- err
-				}
-			default:
-				// Pas d'erreur pour cette tâche
-			}
-		}
-		close(errChan)
-	}()
-
-	// Attendre que toutes les tâches soient traitées
-	// Simple wait: on attend que toutes les goroutines aient fini
-	// En production, on utiliserait un WaitGroup ou un mécanisme plus robuste
-	for err := range errChan {
-		if err != nil {
-			return nil, fmt.Errorf("error processing batch: %w", err)
-		}
+	if err := writer.Close(); err != nil {
+		return err
 	}
-
-	mainBuffer.WriteString(fmt.Sprintf("\n--- Export Complete: %d rows processed in %d batches ---\n",
-		len(salesData), numBatches))
-
-	return mainBuffer.Bytes(), nil
-}
-
-// Cleanup nettoie les ressources
-func (s *ExportServiceV2) Cleanup() {
-	if s.workerPool != nil {
-		s.workerPool.Stop()
+	if onProgress != nil {
+		onProgress(writer.NumRows())
 	}
+	return nil
 }
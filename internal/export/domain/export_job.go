@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"eval/internal/shared/domain"
@@ -24,35 +25,111 @@ const (
 	ExportTypeStats ExportType = "stats"
 )
 
-// ExportJob représente un job d'export
+// ExportJobID représente l'identifiant unique d'un job d'export
+type ExportJobID string
+
+// ExportJobStatus représente l'état d'un job d'export
+type ExportJobStatus string
+
+const (
+	ExportJobStatusQueued  ExportJobStatus = "queued"
+	ExportJobStatusRunning ExportJobStatus = "running"
+	ExportJobStatusDone    ExportJobStatus = "done"
+	ExportJobStatusFailed  ExportJobStatus = "failed"
+)
+
+// ErrExportJobNotFound retourné quand aucun job ne correspond à l'identifiant
+var ErrExportJobNotFound = domain.NewNotFoundError("export_job_not_found", "export job not found")
+
+// ErrExportJobNotReady retourné quand on demande le fichier d'un job non terminé
+var ErrExportJobNotReady = domain.NewConflictError("export_job_not_ready", "export job is not finished")
+
+// ErrExportQueueFull retourné quand la file des jobs est pleine: le client doit réessayer plus tard
+var ErrExportQueueFull = domain.NewUnavailableError("export_queue_full", "too many export jobs in progress, retry later", nil)
+
+// Code et message d'échec d'un job dont l'erreur n'est pas une erreur du domaine
+const (
+	FailureCodeInternal    = "internal_error"
+	FailureMessageInternal = "internal error"
+)
+
+// ExportJob représente un job d'export (aggregate root)
+// Cycle de vie: queued → running → done | failed
 type ExportJob struct {
-	format     ExportFormat
-	exportType ExportType
-	dateRange  domain.DateRange
-	createdAt  time.Time
+	id            ExportJobID
+	format        ExportFormat
+	exportType    ExportType
+	dateRange     domain.DateRange
+	status        ExportJobStatus
+	rowsProcessed int64
+	totalRows     int64
+	errorCode     string
+	errorMessage  string
+	filePath      string
+	createdAt     time.Time
+	startedAt     time.Time
+	finishedAt    time.Time
 }
 
-// NewExportJob crée un nouveau job d'export avec validation
+// NewExportJob crée un nouveau job d'export avec validation (statut queued)
 func NewExportJob(
+	id ExportJobID,
 	format ExportFormat,
 	exportType ExportType,
 	dateRange domain.DateRange,
 ) (*ExportJob, error) {
+	if id == "" {
+		return nil, errors.New("export job ID cannot be empty")
+	}
 	if format != ExportFormatCSV && format != ExportFormatParquet {
 		return nil, errors.New("invalid export format")
 	}
 	if exportType != ExportTypeSales && exportType != ExportTypeStats {
 		return nil, errors.New("invalid export type")
 	}
+	if exportType == ExportTypeStats && format != ExportFormatCSV {
+		return nil, domain.NewValidationError("invalid_export_type", "stats export is only available as CSV")
+	}
 
 	return &ExportJob{
+		id:         id,
 		format:     format,
 		exportType: exportType,
 		dateRange:  dateRange,
+		status:     ExportJobStatusQueued,
 		createdAt:  time.Now(),
 	}, nil
 }
 
+// ParseExportFormat convertit un paramètre utilisateur ("csv", "parquet") en ExportFormat
+func ParseExportFormat(value string) (ExportFormat, error) {
+	switch strings.ToLower(value) {
+	case "", "csv":
+		return ExportFormatCSV, nil
+	case "parquet":
+		return ExportFormatParquet, nil
+	default:
+		return "", domain.NewValidationError("invalid_format", fmt.Sprintf("unsupported export format: %q", value))
+	}
+}
+
+// ParseExportType convertit un paramètre utilisateur ("sales", "stats") en ExportType
+func ParseExportType(value string) (ExportType, error) {
+	switch ExportType(strings.ToLower(value)) {
+	case "", ExportTypeSales:
+		return ExportTypeSales, nil
+	case ExportTypeStats:
+		return ExportTypeStats, nil
+	default:
+		return "", domain.NewValidationError("invalid_export_type", fmt.Sprintf("unsupported export type: %q", value))
+	}
+}
+
+// ID retourne l'identifiant du job
+func (ej *ExportJob) ID() ExportJobID {
+	return ej.id
+}
+
 // Format retourne le format d'export
 func (ej *ExportJob) Format() ExportFormat {
 	return ej.format
@@ -68,11 +145,133 @@ func (ej *ExportJob) DateRange() domain.DateRange {
 	return ej.dateRange
 }
 
+// Status retourne l'état du job
+func (ej *ExportJob) Status() ExportJobStatus {
+	return ej.status
+}
+
+// RowsProcessed retourne le nombre de lignes déjà exportées
+func (ej *ExportJob) RowsProcessed() int64 {
+	return ej.rowsProcessed
+}
+
+// TotalRows retourne le nombre de lignes attendu (0 si inconnu)
+func (ej *ExportJob) TotalRows() int64 {
+	return ej.totalRows
+}
+
+// Progress retourne l'avancement en pourcentage (0-100)
+func (ej *ExportJob) Progress() float64 {
+	if ej.status == ExportJobStatusDone {
+		return 100
+	}
+	if ej.totalRows <= 0 {
+		return 0
+	}
+	progress := float64(ej.rowsProcessed) / float64(ej.totalRows) * 100
+	if progress > 100 {
+		return 100
+	}
+	return progress
+}
+
+// ErrorCode retourne le code d'erreur si le job a échoué (même code que les réponses problem+json)
+func (ej *ExportJob) ErrorCode() string {
+	return ej.errorCode
+}
+
+// ErrorMessage retourne le message d'erreur si le job a échoué
+func (ej *ExportJob) ErrorMessage() string {
+	return ej.errorMessage
+}
+
+// FilePath retourne le chemin du fichier produit (vide tant que le job n'est pas terminé)
+func (ej *ExportJob) FilePath() string {
+	return ej.filePath
+}
+
 // CreatedAt retourne la date de création
 func (ej *ExportJob) CreatedAt() time.Time {
 	return ej.createdAt
 }
 
+// StartedAt retourne la date de démarrage (zéro si pas encore démarré)
+func (ej *ExportJob) StartedAt() time.Time {
+	return ej.startedAt
+}
+
+// FinishedAt retourne la date de fin (zéro si pas encore terminé)
+func (ej *ExportJob) FinishedAt() time.Time {
+	return ej.finishedAt
+}
+
+// FileExtension retourne l'extension du fichier produit
+func (ej *ExportJob) FileExtension() string {
+	if ej.format == ExportFormatParquet {
+		return ".parquet"
+	}
+	return ".csv"
+}
+
+// Start passe le job en cours d'exécution
+func (ej *ExportJob) Start(totalRows int64) error {
+	if ej.status != ExportJobStatusQueued {
+		return fmt.Errorf("cannot start export job in status %s", ej.status)
+	}
+	ej.status = ExportJobStatusRunning
+	ej.totalRows = totalRows
+	ej.startedAt = time.Now()
+	return nil
+}
+
+// ReportProgress met à jour le nombre de lignes exportées
+func (ej *ExportJob) ReportProgress(rowsProcessed int64) error {
+	if ej.status != ExportJobStatusRunning {
+		return fmt.Errorf("cannot report progress on export job in status %s", ej.status)
+	}
+	ej.rowsProcessed = rowsProcessed
+	return nil
+}
+
+// Complete marque le job comme terminé avec le fichier produit
+func (ej *ExportJob) Complete(filePath string, rowsProcessed int64) error {
+	if ej.status != ExportJobStatusRunning {
+		return fmt.Errorf("cannot complete export job in status %s", ej.status)
+	}
+	if filePath == "" {
+		return errors.New("file path cannot be empty")
+	}
+	ej.status = ExportJobStatusDone
+	ej.filePath = filePath
+	ej.rowsProcessed = rowsProcessed
+	ej.finishedAt = time.Now()
+	return nil
+}
+
+// Fail marque le job comme échoué
+// SÉCURITÉ: le message est exposé à quiconque connaît l'ID du job
+//   - erreur du domaine: son code et son message, destinés au client
+//   - autre erreur (SQL, chemin de fichier, ...): code internal_error, le détail n'est pas conservé
+//     (l'appelant journalise l'erreur brute)
+func (ej *ExportJob) Fail(cause error) error {
+	if ej.status == ExportJobStatusDone || ej.status == ExportJobStatusFailed {
+		return fmt.Errorf("cannot fail export job in status %s", ej.status)
+	}
+	ej.status = ExportJobStatusFailed
+	if domainErr, ok := domain.AsError(cause); ok {
+		ej.errorCode, ej.errorMessage = domainErr.Code(), domainErr.Message()
+	} else {
+		ej.errorCode, ej.errorMessage = FailureCodeInternal, FailureMessageInternal
+	}
+	ej.finishedAt = time.Now()
+	return nil
+}
+
+// IsFinished vérifie si le job est dans un état final
+func (ej *ExportJob) IsFinished() bool {
+	return ej.status == ExportJobStatusDone || ej.status == ExportJobStatusFailed
+}
+
 // SaleExportRow représente une ligne d'export de vente
 type SaleExportRow struct {
 	OrderID       int64
@@ -83,11 +282,12 @@ type SaleExportRow struct {
 	ProductName   string
 	CategoryName  string
 	Quantity      int
-	UnitPrice     float64
-	Subtotal      float64
+	UnitPrice     domain.Money
+	Subtotal      domain.Money
 	PaymentMethod string
 	PromotionCode string
 	OrderDate     time.Time
+	Status        string
 }
 
 // NewSaleExportRow crée une nouvelle ligne d'export
@@ -98,9 +298,10 @@ func NewSaleExportRow(
 	orderID, customerID, storeID, productID int64,
 	storeName, productName, categoryName string,
 	quantity int,
-	unitPrice, subtotal float64,
+	unitPrice, subtotal domain.Money,
 	paymentMethod, promotionCode string,
 	orderDate time.Time,
+	status string,
 ) *SaleExportRow {
 	return &SaleExportRow{
 		OrderID:       orderID,
@@ -116,6 +317,7 @@ func NewSaleExportRow(
 		PaymentMethod: paymentMethod,
 		PromotionCode: promotionCode,
 		OrderDate:     orderDate,
+		Status:        status,
 	}
 }
 
@@ -130,11 +332,13 @@ func (ser *SaleExportRow) ToCSVRow() []string {
 		ser.ProductName,
 		ser.CategoryName,
 		fmt.Sprintf("%d", ser.Quantity),
-		fmt.Sprintf("%.2f", ser.UnitPrice),
-		fmt.Sprintf("%.2f", ser.Subtotal),
+		ser.UnitPrice.Decimal(),
+		ser.Subtotal.Decimal(),
+		ser.Subtotal.Currency(),
 		ser.PaymentMethod,
 		ser.PromotionCode,
 		ser.OrderDate.Format("2006-01-02 15:04:05"),
+		ser.Status,
 	}
 }
 
@@ -151,8 +355,10 @@ func CSVHeaders() []string {
 		"quantity",
 		"unit_price",
 		"subtotal",
+		"currency",
 		"payment_method",
 		"promotion_code",
 		"order_date",
+		"status",
 	}
 }
@@ -1,12 +1,25 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
+
+	"eval/internal/shared/domain"
 )
 
+// eur construit un montant exact pour les tests ("1299.99")
+func eur(value string) domain.Money {
+	m, err := domain.ParseMoney(value, "EUR")
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
 // ========================================
 // Benchmarks: ToCSVRow Method Optimization
 // ========================================
@@ -14,10 +27,10 @@ import (
 // BenchmarkSaleExportRow_ToCSVRow_Current benchmarks l'implémentation actuelle
 func BenchmarkSaleExportRow_ToCSVRow_Current(b *testing.B) {
 	row := NewSaleExportRow(
-		1001, 501, 1, "Store Downtown", 201, "Laptop Pro",
-		"Electronics", 2, 1299.99, 2599.98,
+		1001, 501, 1, 201, "Store Downtown", "Laptop Pro",
+		"Electronics", 2, eur("1299.99"), eur("2599.98"),
 		"Credit Card", "PROMO123",
-		time.Date(2024, 10, 15, 14, 30, 0, 0, time.UTC),
+		time.Date(2024, 10, 15, 14, 30, 0, 0, time.UTC), "completed",
 	)
 
 	b.ResetTimer()
@@ -31,10 +44,10 @@ func BenchmarkSaleExportRow_ToCSVRow_Current(b *testing.B) {
 // BenchmarkSaleExportRow_ToCSVRow_Optimized benchmarks version optimisée avec strconv
 func BenchmarkSaleExportRow_ToCSVRow_Optimized(b *testing.B) {
 	row := NewSaleExportRow(
-		1001, 501, 1, "Store Downtown", 201, "Laptop Pro",
-		"Electronics", 2, 1299.99, 2599.98,
+		1001, 501, 1, 201, "Store Downtown", "Laptop Pro",
+		"Electronics", 2, eur("1299.99"), eur("2599.98"),
 		"Credit Card", "PROMO123",
-		time.Date(2024, 10, 15, 14, 30, 0, 0, time.UTC),
+		time.Date(2024, 10, 15, 14, 30, 0, 0, time.UTC), "completed",
 	)
 
 	b.ResetTimer()
@@ -56,11 +69,12 @@ func toCSVRowOptimized(ser *SaleExportRow) []string {
 		ser.ProductName,
 		ser.CategoryName,
 		strconv.Itoa(ser.Quantity),
-		strconv.FormatFloat(ser.UnitPrice, 'f', 2, 64),
-		strconv.FormatFloat(ser.Subtotal, 'f', 2, 64),
+		ser.UnitPrice.Decimal(),
+		ser.Subtotal.Decimal(),
 		ser.PaymentMethod,
 		ser.PromotionCode,
 		ser.OrderDate.Format("2006-01-02 15:04:05"),
+		ser.Status,
 	}
 }
 
@@ -89,10 +103,10 @@ func BenchmarkNewSaleExportRow(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
 		_ = NewSaleExportRow(
-			1001, 501, 1, "Store Downtown", 201, "Laptop Pro",
-			"Electronics", 2, 1299.99, 2599.98,
+			1001, 501, 1, 201, "Store Downtown", "Laptop Pro",
+			"Electronics", 2, eur("1299.99"), eur("2599.98"),
 			"Credit Card", "PROMO123",
-			time.Now(),
+			time.Now(), "completed",
 		)
 	}
 }
@@ -106,9 +120,9 @@ func BenchmarkBatchRowProcessing_100(b *testing.B) {
 	rows := make([]*SaleExportRow, 100)
 	for i := 0; i < 100; i++ {
 		rows[i] = NewSaleExportRow(
-			int64(1000+i), int64(500+i), int64(1+i%10), "Store",
-			int64(200+i), "Product", "Category", 2, 99.99, 199.98,
-			"Credit Card", "PROMO", time.Now(),
+			int64(1000+i), int64(500+i), int64(1+i%10), int64(200+i),
+			"Store", "Product", "Category", 2, eur("99.99"), eur("199.98"),
+			"Credit Card", "PROMO", time.Now(), "completed",
 		)
 	}
 
@@ -127,9 +141,9 @@ func BenchmarkBatchRowProcessing_1000(b *testing.B) {
 	rows := make([]*SaleExportRow, 1000)
 	for i := 0; i < 1000; i++ {
 		rows[i] = NewSaleExportRow(
-			int64(1000+i), int64(500+i), int64(1+i%10), "Store",
-			int64(200+i), "Product", "Category", 2, 99.99, 199.98,
-			"Credit Card", "PROMO", time.Now(),
+			int64(1000+i), int64(500+i), int64(1+i%10), int64(200+i),
+			"Store", "Product", "Category", 2, eur("99.99"), eur("199.98"),
+			"Credit Card", "PROMO", time.Now(), "completed",
 		)
 	}
 
@@ -150,9 +164,9 @@ func BenchmarkBatchRowProcessing_1000(b *testing.B) {
 // BenchmarkStringBuilding_Concatenation teste avec concaténation simple
 func BenchmarkStringBuilding_Concatenation(b *testing.B) {
 	row := NewSaleExportRow(
-		1001, 501, 1, "Store", 201, "Product",
-		"Category", 2, 99.99, 199.98,
-		"Credit", "PROMO", time.Now(),
+		1001, 501, 1, 201, "Store", "Product",
+		"Category", 2, eur("99.99"), eur("199.98"),
+		"Credit", "PROMO", time.Now(), "completed",
 	)
 
 	b.ResetTimer()
@@ -168,9 +182,9 @@ func BenchmarkStringBuilding_Concatenation(b *testing.B) {
 // BenchmarkStringBuilding_Builder teste avec strings.Builder
 func BenchmarkStringBuilding_Builder(b *testing.B) {
 	row := NewSaleExportRow(
-		1001, 501, 1, "Store", 201, "Product",
-		"Category", 2, 99.99, 199.98,
-		"Credit", "PROMO", time.Now(),
+		1001, 501, 1, 201, "Store", "Product",
+		"Category", 2, eur("99.99"), eur("199.98"),
+		"Credit", "PROMO", time.Now(), "completed",
 	)
 
 	b.ResetTimer()
@@ -191,9 +205,9 @@ func BenchmarkStringBuilding_Builder(b *testing.B) {
 // BenchmarkStringBuilding_PreallocatedSlice teste avec slice pré-allouée
 func BenchmarkStringBuilding_PreallocatedSlice(b *testing.B) {
 	row := NewSaleExportRow(
-		1001, 501, 1, "Store", 201, "Product",
-		"Category", 2, 99.99, 199.98,
-		"Credit", "PROMO", time.Now(),
+		1001, 501, 1, 201, "Store", "Product",
+		"Category", 2, eur("99.99"), eur("199.98"),
+		"Credit", "PROMO", time.Now(), "completed",
 	)
 
 	b.ResetTimer()
@@ -209,3 +223,107 @@ func BenchmarkStringBuilding_PreallocatedSlice(b *testing.B) {
 		_ = fields
 	}
 }
+
+// ========================================
+// Tests: cycle de vie d'un job asynchrone
+// ========================================
+
+// TestExportJob_Lifecycle vérifie les transitions queued -> running -> done
+func TestExportJob_Lifecycle(t *testing.T) {
+	dateRange, err := domain.NewDateRangeFromDays(30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, err := NewExportJob("job-1", ExportFormatCSV, ExportTypeSales, dateRange)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := job.Complete("/tmp/job-1.csv", 0); err == nil {
+		t.Error("Complete on a queued job should fail")
+	}
+	if err := job.Start(200); err != nil {
+		t.Fatal(err)
+	}
+	if err := job.ReportProgress(50); err != nil {
+		t.Fatal(err)
+	}
+	if got := job.Progress(); got != 25 {
+		t.Errorf("Progress = %v, want 25", got)
+	}
+	if err := job.Complete("/tmp/job-1.csv", 200); err != nil {
+		t.Fatal(err)
+	}
+	if job.Status() != ExportJobStatusDone || job.Progress() != 100 || !job.IsFinished() {
+		t.Errorf("status = %s, progress = %v after Complete", job.Status(), job.Progress())
+	}
+	if err := job.Fail(errors.New("late failure")); err == nil {
+		t.Error("Fail on a finished job should fail")
+	}
+}
+
+// TestExportJob_FailSanitized vérifie que seul le message d'une erreur du domaine est conservé
+func TestExportJob_FailSanitized(t *testing.T) {
+	dateRange, err := domain.NewDateRangeFromDays(30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		cause     error
+		code, msg string
+	}{
+		{
+			cause: fmt.Errorf("failed to count rows: %w", domain.NewMissingExchangeRateError("USD", dateRange.Start())),
+			code:  "fx_rate_missing",
+			msg:   "no USD exchange rate on or before " + dateRange.Start().Format("2006-01-02"),
+		},
+		{
+			cause: errors.New(`open /var/exports/abc.csv.tmp: permission denied`),
+			code:  FailureCodeInternal,
+			msg:   FailureMessageInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		job, err := NewExportJob("job-1", ExportFormatCSV, ExportTypeSales, dateRange)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := job.Fail(tt.cause); err != nil {
+			t.Fatal(err)
+		}
+		if job.ErrorCode() != tt.code || job.ErrorMessage() != tt.msg {
+			t.Errorf("Fail(%v) = %s %q, want %s %q", tt.cause, job.ErrorCode(), job.ErrorMessage(), tt.code, tt.msg)
+		}
+	}
+}
+
+// TestNewExportJob_StatsParquetRejected vérifie que les stats ne s'exportent qu'en CSV
+func TestNewExportJob_StatsParquetRejected(t *testing.T) {
+	dateRange, _ := domain.NewDateRangeFromDays(30)
+	if _, err := NewExportJob("job-2", ExportFormatParquet, ExportTypeStats, dateRange); err == nil {
+		t.Error("expected an error for a stats export in Parquet")
+	}
+}
+
+// TestSaleExportRow_ToCSVRow vérifie que les montants sont écrits à partir des centimes exacts
+func TestSaleExportRow_ToCSVRow(t *testing.T) {
+	subtotal, err := eur("19.99").Times(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	row := NewSaleExportRow(
+		1001, 501, 1, 201, "Store", "Product",
+		"Category", 3, eur("19.99"), subtotal,
+		"Credit", "", time.Date(2024, 10, 15, 14, 30, 0, 0, time.UTC), "completed",
+	)
+
+	fields := row.ToCSVRow()
+	if fields[8] != "19.99" || fields[9] != "59.97" {
+		t.Errorf("unit_price, subtotal = %s, %s, want 19.99, 59.97", fields[8], fields[9])
+	}
+	if len(fields) != len(CSVHeaders()) || fields[10] != "EUR" {
+		t.Errorf("currency = %s (%d fields), want EUR (%d fields)", fields[10], len(fields), len(CSVHeaders()))
+	}
+}
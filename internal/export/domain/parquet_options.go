@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+
+	"eval/internal/shared/domain"
+)
+
+// ParquetCompression représente le codec de compression des pages Parquet
+type ParquetCompression string
+
+const (
+	ParquetCompressionNone   ParquetCompression = "none"
+	ParquetCompressionSnappy ParquetCompression = "snappy"
+	ParquetCompressionGzip   ParquetCompression = "gzip"
+	ParquetCompressionZstd   ParquetCompression = "zstd"
+)
+
+// DefaultParquetRowGroupSize nombre de lignes par row group par défaut
+// Un row group est l'unité de lecture parallèle pour Spark/DuckDB:
+// trop petit = beaucoup de métadonnées, trop grand = peu de parallélisme côté lecteur
+const DefaultParquetRowGroupSize = 50000
+
+// MaxParquetRowGroupSize borne haute pour éviter de garder un row group énorme en mémoire
+const MaxParquetRowGroupSize = 1000000
+
+// ParquetOptions représente les options d'écriture d'un fichier Parquet
+// DESIGN PATTERN: Value Object (immutable, validé à la création)
+type ParquetOptions struct {
+	compression  ParquetCompression
+	rowGroupSize int
+}
+
+// NewParquetOptions crée des options Parquet avec validation
+// Une compression vide utilise snappy, une taille de row group <= 0 utilise la valeur par défaut
+func NewParquetOptions(compression string, rowGroupSize int) (ParquetOptions, error) {
+	codec := ParquetCompression(compression)
+	switch codec {
+	case "":
+		codec = ParquetCompressionSnappy
+	case ParquetCompressionNone, ParquetCompressionSnappy, ParquetCompressionGzip, ParquetCompressionZstd:
+	default:
+		return ParquetOptions{}, domain.NewValidationError("invalid_parquet_options", fmt.Sprintf("unsupported parquet compression: %q", compression))
+	}
+
+	if rowGroupSize <= 0 {
+		rowGroupSize = DefaultParquetRowGroupSize
+	}
+	if rowGroupSize > MaxParquetRowGroupSize {
+		return ParquetOptions{}, domain.NewValidationError("invalid_parquet_options",
+			fmt.Sprintf("parquet row group size cannot exceed %d rows", MaxParquetRowGroupSize))
+	}
+
+	return ParquetOptions{
+		compression:  codec,
+		rowGroupSize: rowGroupSize,
+	}, nil
+}
+
+// DefaultParquetOptions retourne les options par défaut (snappy, 50k lignes par row group)
+func DefaultParquetOptions() ParquetOptions {
+	return ParquetOptions{
+		compression:  ParquetCompressionSnappy,
+		rowGroupSize: DefaultParquetRowGroupSize,
+	}
+}
+
+// Compression retourne le codec de compression
+func (o ParquetOptions) Compression() ParquetCompression {
+	return o.compression
+}
+
+// RowGroupSize retourne le nombre de lignes par row group
+func (o ParquetOptions) RowGroupSize() int {
+	return o.rowGroupSize
+}
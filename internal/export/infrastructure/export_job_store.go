@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"sync"
+	"time"
+
+	"eval/internal/export/domain"
+)
+
+// ExportJobStore stockage en mémoire des jobs d'export
+// CONCURRENCE: les jobs sont modifiés par les workers et lus par les handlers HTTP
+//   - RWMutex: lectures (GET status) concurrentes, écritures (progression) exclusives
+//   - FindByID retourne une COPIE: l'appelant ne voit jamais un job en cours de modification
+type ExportJobStore struct {
+	mu   sync.RWMutex
+	jobs map[domain.ExportJobID]*domain.ExportJob
+}
+
+// NewExportJobStore crée un nouveau store de jobs
+func NewExportJobStore() *ExportJobStore {
+	return &ExportJobStore{
+		jobs: make(map[domain.ExportJobID]*domain.ExportJob),
+	}
+}
+
+// Save enregistre un nouveau job
+func (s *ExportJobStore) Save(job *domain.ExportJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[job.ID()] = job
+}
+
+// FindByID retourne une copie du job
+func (s *ExportJobStore) FindByID(id domain.ExportJobID) (*domain.ExportJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, exists := s.jobs[id]
+	if !exists {
+		return nil, domain.ErrExportJobNotFound
+	}
+
+	snapshot := *job
+	return &snapshot, nil
+}
+
+// Delete supprime un job (sans effet s'il n'existe pas)
+func (s *ExportJobStore) Delete(id domain.ExportJobID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.jobs, id)
+}
+
+// FinishedBefore retourne une copie des jobs terminés (done ou failed) avant cutoff
+func (s *ExportJobStore) FinishedBefore(cutoff time.Time) []*domain.ExportJob {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var expired []*domain.ExportJob
+	for _, job := range s.jobs {
+		if job.IsFinished() && job.FinishedAt().Before(cutoff) {
+			snapshot := *job
+			expired = append(expired, &snapshot)
+		}
+	}
+	return expired
+}
+
+// Update applique une transition d'état au job sous verrou exclusif
+func (s *ExportJobStore) Update(id domain.ExportJobID, fn func(job *domain.ExportJob) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, exists := s.jobs[id]
+	if !exists {
+		return domain.ErrExportJobNotFound
+	}
+	return fn(job)
+}
@@ -1,10 +1,14 @@
 package infrastructure
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"eval/internal/export/domain"
+	ordersdomain "eval/internal/orders/domain"
+	ordersinfra "eval/internal/orders/infrastructure"
 	shareddomain "eval/internal/shared/domain"
 	"eval/internal/shared/infrastructure"
 )
@@ -21,93 +25,196 @@ func NewExportQueryRepository(db *sql.DB) *ExportQueryRepository {
 	}
 }
 
+// salesDataQuery requête unique avec tous les JOINs, partagée par GetSalesDataOptimized et StreamSalesData
+// Le prédicat de statut (%s) est injecté par buildSalesDataQuery, ses paramètres commencent à $3
+// SYNTAXE SQL optimisée avec JOINS:
+//   - INNER JOIN = seulement les lignes avec correspondance (orders, order_items, etc.)
+//   - LEFT JOIN = garde la ligne même si pas de correspondance (promotions optionnelles)
+//   - COALESCE(value, 'default') = retourne 'default' si value est NULL
+//
+// PERFORMANCE: PostgreSQL fait tous les JOINs en UNE PASSE
+//   - Query planner optimise l'ordre des joins
+//   - Utilise les index pour accélérer les joins
+//   - Dénormalise les données côté DB (plus efficace qu'en Go)
+//
+// MÉMOIRE: Transfère toutes les colonnes nécessaires d'un coup
+//   - Évite les round-trips réseau (latence majeure en DB)
+const salesDataQuery = `
+	SELECT
+		o.id as order_id,
+		o.customer_id,
+		o.store_id,
+		s.name as store_name,
+		oi.product_id,
+		p.name as product_name,
+		COALESCE(c.name, 'Uncategorized') as category_name,
+		oi.quantity,
+		o.currency,
+		oi.unit_price,
+		oi.subtotal,
+		pm.name as payment_method,
+		COALESCE(pr.code, '') as promotion_code,
+		o.order_date,
+		COALESCE(o.status, 'completed') as status
+	FROM orders o
+	INNER JOIN order_items oi ON o.id = oi.order_id
+	INNER JOIN products p ON oi.product_id = p.id
+	INNER JOIN stores s ON o.store_id = s.id
+	INNER JOIN payment_methods pm ON o.payment_method_id = pm.id
+	LEFT JOIN promotions pr ON o.promotion_id = pr.id
+	LEFT JOIN product_categories pc ON p.id = pc.product_id
+	LEFT JOIN categories c ON pc.category_id = c.id
+	WHERE o.order_date >= $1 AND o.order_date <= $2
+	  AND %s
+	ORDER BY o.order_date DESC, o.id, oi.id
+`
+
+// buildSalesDataQuery complète salesDataQuery avec le filtre de statut et retourne ses paramètres
+// Par défaut (StatusFilter{}) seules les commandes completed sont exportées
+func buildSalesDataQuery(dateRange shareddomain.DateRange, statuses ordersdomain.StatusFilter) (string, []interface{}) {
+	statusWhere, statusArgs := infrastructure.BindSpecification(ordersinfra.StatusSpecification("o", statuses), 3)
+	args := append([]interface{}{dateRange.Start(), dateRange.End()}, statusArgs...)
+	return fmt.Sprintf(salesDataQuery, statusWhere), args
+}
+
 // GetSalesDataOptimized récupère les données de vente de manière optimisée (une seule requête)
 // PERFORMANCE: ✓ OPTIMISÉ - UNE SEULE requête avec tous les JOINs
 //   - Vs V1 qui fait 1 query initiale + 6 queries par order_item (N+1 × 6!)
 //   - Ex: 10k order_items → V1 = 60,001 queries vs V2 = 1 query
 //   - Temps: V1 ≈ 60s (1ms/query) vs V2 ≈ 100ms
-func (r *ExportQueryRepository) GetSalesDataOptimized(dateRange shareddomain.DateRange) ([]*domain.SaleExportRow, error) {
-	// SYNTAXE SQL optimisée avec JOINS:
-	//   - INNER JOIN = seulement les lignes avec correspondance (orders, order_items, etc.)
-	//   - LEFT JOIN = garde la ligne même si pas de correspondance (promotions optionnelles)
-	//   - COALESCE(value, 'default') = retourne 'default' si value est NULL
-	// PERFORMANCE: PostgreSQL fait tous les JOINs en UNE PASSE
-	//   - Query planner optimise l'ordre des joins
-	//   - Utilise les index pour accélérer les joins
-	//   - Dénormalise les données côté DB (plus efficace qu'en Go)
-	// MÉMOIRE: Transfère toutes les colonnes nécessaires d'un coup
-	//   - Évite les round-trips réseau (latence majeure en DB)
-	query := `
-		SELECT
-			o.id as order_id,
-			o.customer_id,
-			o.store_id,
-			s.name as store_name,
-			oi.product_id,
-			p.name as product_name,
-			COALESCE(c.name, 'Uncategorized') as category_name,
-			oi.quantity,
-			oi.unit_price,
-			oi.subtotal,
-			pm.name as payment_method,
-			COALESCE(pr.code, '') as promotion_code,
-			o.order_date
-		FROM orders o
-		INNER JOIN order_items oi ON o.id = oi.order_id
-		INNER JOIN products p ON oi.product_id = p.id
-		INNER JOIN stores s ON o.store_id = s.id
-		INNER JOIN payment_methods pm ON o.payment_method_id = pm.id
-		LEFT JOIN promotions pr ON o.promotion_id = pr.id
-		LEFT JOIN product_categories pc ON p.id = pc.product_id
-		LEFT JOIN categories c ON pc.category_id = c.id
-		WHERE o.order_date >= $1 AND o.order_date <= $2
-		ORDER BY o.order_date DESC, o.id, oi.id
-	`
-
-	rows, err := r.Query(query, dateRange.Start(), dateRange.End())
+//
+// MÉMOIRE: ⚠️ Charge toutes les lignes dans un slice, préférer StreamSalesData pour les gros exports
+func (r *ExportQueryRepository) GetSalesDataOptimized(
+	dateRange shareddomain.DateRange,
+	statuses ordersdomain.StatusFilter,
+) ([]*domain.SaleExportRow, error) {
+	query, args := buildSalesDataQuery(dateRange, statuses)
+	rows, err := r.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	var salesData []*domain.SaleExportRow
-	//
 	for rows.Next() {
-		var (
-			orderID       int64
-			customerID    int64
-			storeID       int64
-			storeName     string
-			productID     int64
-			productName   string
-			categoryName  string
-			quantity      int
-			unitPrice     float64
-			subtotal      float64
-			paymentMethod string
-			promotionCode string
-			orderDate     time.Time
-		)
-
-		if err := rows.Scan(
-			&orderID, &customerID, &storeID, &storeName,
-			&productID, &productName, &categoryName,
-			&quantity, &unitPrice, &subtotal,
-			&paymentMethod, &promotionCode, &orderDate,
-		); err != nil {
+		row, err := scanSaleExportRow(rows)
+		if err != nil {
 			return nil, err
 		}
-
-		row := domain.NewSaleExportRow(
-			orderID, customerID, storeID, productID,
-			storeName, productName, categoryName,
-			quantity, unitPrice, subtotal,
-			paymentMethod, promotionCode, orderDate,
-		)
 		salesData = append(salesData, row)
 	}
 
-	return salesData, nil
+	return salesData, rows.Err()
+}
+
+// StreamSalesData parcourt les ventes curseur par curseur et appelle fn pour chaque ligne
+// MÉMOIRE: ✓ Une seule ligne vivante à la fois, quelle que soit la période demandée
+//   - database/sql lit les lignes au fil de l'eau depuis la connexion
+//   - Aucun slice intermédiaire: mémoire constante même sur 5 ans de données
+//
+// ANNULATION: la requête est liée à ctx, si le client HTTP se déconnecte
+// PostgreSQL arrête l'exécution et rows.Next() retourne false avec ctx.Err()
+func (r *ExportQueryRepository) StreamSalesData(
+	ctx context.Context,
+	dateRange shareddomain.DateRange,
+	statuses ordersdomain.StatusFilter,
+	fn func(row *domain.SaleExportRow) error,
+) error {
+	query, args := buildSalesDataQuery(dateRange, statuses)
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row, err := scanSaleExportRow(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// CountSalesRows compte les lignes que produira salesDataQuery (pour la progression des jobs)
+// Seul le LEFT JOIN product_categories peut dupliquer des lignes, les autres JOINs suivent des FK
+func (r *ExportQueryRepository) CountSalesRows(
+	ctx context.Context,
+	dateRange shareddomain.DateRange,
+	statuses ordersdomain.StatusFilter,
+) (int64, error) {
+	statusWhere, statusArgs := infrastructure.BindSpecification(ordersinfra.StatusSpecification("o", statuses), 3)
+	query := `
+		SELECT COUNT(*)
+		FROM orders o
+		INNER JOIN order_items oi ON o.id = oi.order_id
+		LEFT JOIN product_categories pc ON oi.product_id = pc.product_id
+		WHERE o.order_date >= $1 AND o.order_date <= $2
+		  AND ` + statusWhere
+
+	var count int64
+	args := append([]interface{}{dateRange.Start(), dateRange.End()}, statusArgs...)
+	err := r.Executor().QueryRowContext(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// scanSaleExportRow lit la ligne courante de salesDataQuery
+func scanSaleExportRow(rows *sql.Rows) (*domain.SaleExportRow, error) {
+	var (
+		orderID       int64
+		customerID    int64
+		storeID       int64
+		storeName     string
+		productID     int64
+		productName   string
+		categoryName  string
+		quantity      int
+		currency      string
+		unitPrice     []byte
+		subtotal      []byte
+		paymentMethod string
+		promotionCode string
+		orderDate     time.Time
+		status        string
+	)
+
+	if err := rows.Scan(
+		&orderID, &customerID, &storeID, &storeName,
+		&productID, &productName, &categoryName,
+		&quantity, &currency, &unitPrice, &subtotal,
+		&paymentMethod, &promotionCode, &orderDate, &status,
+	); err != nil {
+		return nil, err
+	}
+
+	price, err := scanAmountIn(unitPrice, currency)
+	if err != nil {
+		return nil, err
+	}
+	total, err := scanAmountIn(subtotal, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewSaleExportRow(
+		orderID, customerID, storeID, productID,
+		storeName, productName, categoryName,
+		quantity, price, total,
+		paymentMethod, promotionCode, orderDate, status,
+	), nil
+}
+
+// scanAmountIn lit une colonne NUMERIC dans la devise de la commande
+// La devise est une colonne de la même ligne: shareddomain.MoneyIn ne peut pas servir,
+// la devise n'est connue qu'après rows.Scan
+func scanAmountIn(raw []byte, currency string) (shareddomain.Money, error) {
+	amount := shareddomain.Currency(currency).Zero()
+	err := amount.Scan(raw)
+	return amount, err
 }
 
 // GetSalesDataInefficient récupère les données avec N+1 queries (version inefficace)
@@ -122,18 +229,24 @@ func (r *ExportQueryRepository) GetSalesDataOptimized(dateRange shareddomain.Dat
 //   - 1 query pour promotion
 //   - Total: 1 + (N × 6) queries où N = nombre d'order_items
 //   - Ex: 10,000 items = 60,001 queries! Temps: ~60 secondes minimum
-func (r *ExportQueryRepository) GetSalesDataInefficient(dateRange shareddomain.DateRange) ([]*domain.SaleExportRow, error) {
+func (r *ExportQueryRepository) GetSalesDataInefficient(
+	dateRange shareddomain.DateRange,
+	statuses ordersdomain.StatusFilter,
+) ([]*domain.SaleExportRow, error) {
 	// Première query: récupère tous les order items
 	// PERFORMANCE: Cette query est ok, mais c'est ce qui suit qui est terrible
+	statusWhere, statusArgs := infrastructure.BindSpecification(ordersinfra.StatusSpecification("o", statuses), 3)
 	query1 := `
-		SELECT oi.id, oi.order_id, oi.product_id, oi.quantity, oi.unit_price, oi.subtotal
+		SELECT oi.id, oi.order_id, oi.product_id, oi.quantity, o.currency, oi.unit_price, oi.subtotal
 		FROM order_items oi
 		INNER JOIN orders o ON oi.order_id = o.id
 		WHERE o.order_date >= $1 AND o.order_date <= $2
+		  AND ` + statusWhere + `
 		ORDER BY o.order_date DESC
 	`
 
-	rows, err := r.Query(query1, dateRange.Start(), dateRange.End())
+	args := append([]interface{}{dateRange.Start(), dateRange.End()}, statusArgs...)
+	rows, err := r.Query(query1, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -145,14 +258,24 @@ func (r *ExportQueryRepository) GetSalesDataInefficient(dateRange shareddomain.D
 		orderID   int64
 		productID int64
 		quantity  int
-		unitPrice float64
-		subtotal  float64
+		unitPrice shareddomain.Money
+		subtotal  shareddomain.Money
 	}
 
 	var items []itemData
 	for rows.Next() {
-		var item itemData
-		if err := rows.Scan(&item.itemID, &item.orderID, &item.productID, &item.quantity, &item.unitPrice, &item.subtotal); err != nil {
+		var (
+			item                      itemData
+			currency                  string
+			rawUnitPrice, rawSubtotal []byte
+		)
+		if err := rows.Scan(&item.itemID, &item.orderID, &item.productID, &item.quantity, &currency, &rawUnitPrice, &rawSubtotal); err != nil {
+			return nil, err
+		}
+		if item.unitPrice, err = scanAmountIn(rawUnitPrice, currency); err != nil {
+			return nil, err
+		}
+		if item.subtotal, err = scanAmountIn(rawSubtotal, currency); err != nil {
 			return nil, err
 		}
 		items = append(items, item)
@@ -172,8 +295,9 @@ func (r *ExportQueryRepository) GetSalesDataInefficient(dateRange shareddomain.D
 		var customerID, storeID, paymentMethodID int64
 		var promotionID sql.NullInt64
 		var orderDate time.Time
-		orderQuery := `SELECT customer_id, store_id, payment_method_id, promotion_id, order_date FROM orders WHERE id = $1`
-		err := r.QueryRow(orderQuery, item.orderID).Scan(&customerID, &storeID, &paymentMethodID, &promotionID, &orderDate)
+		var status string
+		orderQuery := `SELECT customer_id, store_id, payment_method_id, promotion_id, order_date, COALESCE(status, 'completed') FROM orders WHERE id = $1`
+		err := r.QueryRow(orderQuery, item.orderID).Scan(&customerID, &storeID, &paymentMethodID, &promotionID, &orderDate, &status)
 		if err != nil {
 			continue
 		}
@@ -213,7 +337,7 @@ func (r *ExportQueryRepository) GetSalesDataInefficient(dateRange shareddomain.D
 			item.orderID, customerID, storeID, item.productID,
 			storeName, productName, categoryName,
 			item.quantity, item.unitPrice, item.subtotal,
-			paymentMethod, promotionCode, orderDate,
+			paymentMethod, promotionCode, orderDate, status,
 		)
 		salesData = append(salesData, row)
 	}
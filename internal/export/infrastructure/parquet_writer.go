@@ -0,0 +1,144 @@
+package infrastructure
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+
+	"eval/internal/export/domain"
+)
+
+// Montants en DECIMAL(18, 2) stockés en INT64 (centimes): exacts, contrairement à un DOUBLE
+// PIÈGE: 99.99 en DOUBLE est relu 99.98999999999999... et les sommes côté Spark/DuckDB dérivent
+// 18 chiffres = précision maximale d'un DECIMAL stocké sur INT64
+const amountScale = 2
+
+// parquetMarshalers goroutines de parquet-go qui encodent les pages d'un row group en parallèle
+const parquetMarshalers = 4
+
+// saleParquetRecord schéma Parquet d'une ligne de vente (tags parquet-go)
+// Toutes les colonnes sont REQUIRED (pas de NULL), encodées en PLAIN
+//   - unit_price, subtotal: DECIMAL(18, 2) en INT64 (voir amountScale)
+//   - order_date: DATE = nombre de jours depuis 1970-01-01 en INT32
+type saleParquetRecord struct {
+	OrderID       int64  `parquet:"name=order_id, type=INT64"`
+	CustomerID    int64  `parquet:"name=customer_id, type=INT64"`
+	StoreID       int64  `parquet:"name=store_id, type=INT64"`
+	StoreName     string `parquet:"name=store_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ProductID     int64  `parquet:"name=product_id, type=INT64"`
+	ProductName   string `parquet:"name=product_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	CategoryName  string `parquet:"name=category_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Quantity      int32  `parquet:"name=quantity, type=INT32"`
+	UnitPrice     int64  `parquet:"name=unit_price, type=INT64, convertedtype=DECIMAL, scale=2, precision=18"`
+	Subtotal      int64  `parquet:"name=subtotal, type=INT64, convertedtype=DECIMAL, scale=2, precision=18"`
+	Currency      string `parquet:"name=currency, type=BYTE_ARRAY, convertedtype=UTF8"`
+	PaymentMethod string `parquet:"name=payment_method, type=BYTE_ARRAY, convertedtype=UTF8"`
+	PromotionCode string `parquet:"name=promotion_code, type=BYTE_ARRAY, convertedtype=UTF8"`
+	OrderDate     int32  `parquet:"name=order_date, type=INT32, convertedtype=DATE"`
+	Status        string `parquet:"name=status, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// newSaleParquetRecord convertit une ligne d'export en ligne Parquet
+func newSaleParquetRecord(row *domain.SaleExportRow) saleParquetRecord {
+	return saleParquetRecord{
+		OrderID:       row.OrderID,
+		CustomerID:    row.CustomerID,
+		StoreID:       row.StoreID,
+		StoreName:     row.StoreName,
+		ProductID:     row.ProductID,
+		ProductName:   row.ProductName,
+		CategoryName:  row.CategoryName,
+		Quantity:      int32(row.Quantity),
+		UnitPrice:     row.UnitPrice.Scaled(amountScale),
+		Subtotal:      row.Subtotal.Scaled(amountScale),
+		Currency:      row.Subtotal.Currency(),
+		PaymentMethod: row.PaymentMethod,
+		PromotionCode: row.PromotionCode,
+		OrderDate:     daysSinceEpoch(row.OrderDate),
+		Status:        row.Status,
+	}
+}
+
+// SalesParquetWriter écrit un fichier Parquet de ventes dans un io.Writer, via le writer de parquet-go
+// Les lignes sont découpées en row groups de options.RowGroupSize lignes:
+// chaque row group complet est écrit dans w, seul le row group en cours reste en mémoire
+//
+// CONCURRENCE: Write et Close doivent être appelés séquentiellement
+// (parquet-go encode lui-même les pages en parallèle, voir parquetMarshalers)
+type SalesParquetWriter struct {
+	pw           *writer.ParquetWriter
+	rowGroupSize int64
+	numRows      int64
+}
+
+// NewSalesParquetWriter crée un writer Parquet et écrit l'entête magique
+func NewSalesParquetWriter(w io.Writer, options domain.ParquetOptions) (*SalesParquetWriter, error) {
+	codec, err := toParquetCodec(options.Compression())
+	if err != nil {
+		return nil, err
+	}
+
+	pw, err := writer.NewParquetWriterFromWriter(w, new(saleParquetRecord), parquetMarshalers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parquet writer: %w", err)
+	}
+	pw.CompressionType = codec
+	// PIÈGE: RowGroupSize de parquet-go est une taille en octets (128 MB par défaut);
+	// le découpage se fait ici en nombre de lignes (Write), jamais par parquet-go
+	pw.RowGroupSize = math.MaxInt64
+
+	return &SalesParquetWriter{
+		pw:           pw,
+		rowGroupSize: int64(options.RowGroupSize()),
+	}, nil
+}
+
+// Write ajoute une ligne au row group en cours et l'écrit dans w quand il est complet
+func (spw *SalesParquetWriter) Write(row *domain.SaleExportRow) error {
+	if err := spw.pw.Write(newSaleParquetRecord(row)); err != nil {
+		return err
+	}
+
+	spw.numRows++
+	if spw.numRows%spw.rowGroupSize == 0 {
+		return spw.pw.Flush(true)
+	}
+	return nil
+}
+
+// Close écrit le dernier row group (incomplet) puis le footer (FileMetaData) et le magic de fin
+// Le writer sous-jacent n'est pas fermé (ex: http.ResponseWriter ou fichier du job)
+func (spw *SalesParquetWriter) Close() error {
+	return spw.pw.WriteStop()
+}
+
+// NumRows retourne le nombre de lignes écrites
+func (spw *SalesParquetWriter) NumRows() int64 {
+	return spw.numRows
+}
+
+// toParquetCodec convertit la compression du domaine en codec Parquet
+func toParquetCodec(compression domain.ParquetCompression) (parquet.CompressionCodec, error) {
+	switch compression {
+	case domain.ParquetCompressionNone:
+		return parquet.CompressionCodec_UNCOMPRESSED, nil
+	case domain.ParquetCompressionSnappy:
+		return parquet.CompressionCodec_SNAPPY, nil
+	case domain.ParquetCompressionGzip:
+		return parquet.CompressionCodec_GZIP, nil
+	case domain.ParquetCompressionZstd:
+		return parquet.CompressionCodec_ZSTD, nil
+	default:
+		return 0, fmt.Errorf("unsupported parquet compression: %q", compression)
+	}
+}
+
+// daysSinceEpoch convertit une date en nombre de jours depuis 1970-01-01 (type logique DATE)
+func daysSinceEpoch(t time.Time) int32 {
+	y, m, d := t.Date()
+	return int32(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
@@ -0,0 +1,256 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+
+	"eval/internal/export/domain"
+	shareddomain "eval/internal/shared/domain"
+)
+
+// ========================================
+// Test Helpers
+// ========================================
+
+// eur construit un montant exact pour les tests ("99.99")
+func eur(value string) shareddomain.Money {
+	m, err := shareddomain.ParseMoney(value, "EUR")
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// sampleSaleRows génère n lignes de vente déterministes
+func sampleSaleRows(n int) []*domain.SaleExportRow {
+	rows := make([]*domain.SaleExportRow, n)
+	for i := 0; i < n; i++ {
+		rows[i] = domain.NewSaleExportRow(
+			int64(1000+i), int64(500+i), int64(1+i%10), int64(200+i),
+			"Store", "Product", "Category", 2, eur("99.99"), eur("199.98"),
+			"Credit Card", "", time.Date(2024, 10, 15, 0, 0, 0, 0, time.UTC), "completed",
+		)
+	}
+	return rows
+}
+
+// writeSalesParquet écrit les lignes en row groups de rowGroupSize lignes
+func writeSalesParquet(t testing.TB, rows []*domain.SaleExportRow, compression string, rowGroupSize int) []byte {
+	t.Helper()
+
+	options, err := domain.NewParquetOptions(compression, rowGroupSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewSalesParquetWriter(&buf, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if writer.NumRows() != int64(len(rows)) {
+		t.Fatalf("NumRows = %d, want %d", writer.NumRows(), len(rows))
+	}
+	return buf.Bytes()
+}
+
+// readFooter relit le FileMetaData à la fin du fichier
+func readFooter(t *testing.T, data []byte) *parquet.FileMetaData {
+	t.Helper()
+
+	magic := []byte("PAR1")
+	if !bytes.Equal(data[:4], magic) || !bytes.Equal(data[len(data)-4:], magic) {
+		t.Fatal("missing PAR1 magic bytes")
+	}
+
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8 : len(data)-4]))
+	footerBytes := data[len(data)-8-footerLen : len(data)-8]
+
+	footer := parquet.NewFileMetaData()
+	transport := thrift.NewStreamTransportR(bytes.NewReader(footerBytes))
+	if err := footer.Read(context.TODO(), thrift.NewTCompactProtocolConf(transport, nil)); err != nil {
+		t.Fatalf("failed to decode footer: %v", err)
+	}
+	return footer
+}
+
+// ========================================
+// Tests: format du fichier
+// ========================================
+
+// TestSalesParquetWriter_RowGroupsAndFooter vérifie le découpage en row groups et le schéma
+func TestSalesParquetWriter_RowGroupsAndFooter(t *testing.T) {
+	data := writeSalesParquet(t, sampleSaleRows(5), "snappy", 2)
+	footer := readFooter(t, data)
+
+	if footer.NumRows != 5 {
+		t.Errorf("NumRows = %d, want 5", footer.NumRows)
+	}
+	if len(footer.RowGroups) != 3 {
+		t.Fatalf("row groups = %d, want 3", len(footer.RowGroups))
+	}
+	columns := reflect.TypeOf(saleParquetRecord{}).NumField()
+	if len(footer.Schema) != columns+1 {
+		t.Errorf("schema elements = %d, want %d", len(footer.Schema), columns+1)
+	}
+
+	for _, element := range footer.Schema[1:] {
+		if element.Name != "unit_price" && element.Name != "subtotal" {
+			continue
+		}
+		if *element.Type != parquet.Type_INT64 || *element.ConvertedType != parquet.ConvertedType_DECIMAL ||
+			*element.Scale != 2 || *element.Precision != 18 || element.LogicalType.DECIMAL == nil {
+			t.Errorf("%s: want INT64 DECIMAL(18, 2), got %+v", element.Name, element)
+		}
+	}
+
+	for i, want := range []int64{2, 2, 1} {
+		rg := footer.RowGroups[i]
+		if rg.NumRows != want {
+			t.Errorf("row group %d NumRows = %d, want %d", i, rg.NumRows, want)
+		}
+		if len(rg.Columns) != columns {
+			t.Errorf("row group %d columns = %d, want %d", i, len(rg.Columns), columns)
+		}
+		if rg.Columns[0].MetaData.Codec != parquet.CompressionCodec_SNAPPY {
+			t.Errorf("row group %d codec = %v, want SNAPPY", i, rg.Columns[0].MetaData.Codec)
+		}
+	}
+}
+
+// TestSalesParquetWriter_Empty vérifie qu'un export vide reste un fichier Parquet valide
+func TestSalesParquetWriter_Empty(t *testing.T) {
+	data := writeSalesParquet(t, nil, "none", 10)
+	footer := readFooter(t, data)
+
+	if footer.NumRows != 0 || len(footer.RowGroups) != 0 {
+		t.Errorf("empty file: NumRows = %d, row groups = %d", footer.NumRows, len(footer.RowGroups))
+	}
+}
+
+// ========================================
+// Benchmarks: Compression
+// ========================================
+
+// BenchmarkSalesParquetWriter_Compression compare la taille et le coût des codecs
+func BenchmarkSalesParquetWriter_Compression(b *testing.B) {
+	rows := sampleSaleRows(10000)
+
+	for _, compression := range []string{"none", "snappy", "gzip", "zstd"} {
+		b.Run(compression, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				data := writeSalesParquet(b, rows, compression, 5000)
+				b.ReportMetric(float64(len(data)), "bytes")
+			}
+		})
+	}
+}
+
+// ========================================
+// Tests: relecture par un lecteur indépendant
+// ========================================
+
+// saleParquetReadRecord ligne relue par parquet-go (schéma redéclaré côté lecteur: un changement
+// de type ou de nom de colonne dans saleParquetRecord fait échouer la relecture)
+type saleParquetReadRecord struct {
+	OrderID       int64  `parquet:"name=order_id, type=INT64"`
+	CustomerID    int64  `parquet:"name=customer_id, type=INT64"`
+	StoreID       int64  `parquet:"name=store_id, type=INT64"`
+	StoreName     string `parquet:"name=store_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ProductID     int64  `parquet:"name=product_id, type=INT64"`
+	ProductName   string `parquet:"name=product_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	CategoryName  string `parquet:"name=category_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Quantity      int32  `parquet:"name=quantity, type=INT32"`
+	UnitPrice     int64  `parquet:"name=unit_price, type=INT64, convertedtype=DECIMAL, scale=2, precision=18"`
+	Subtotal      int64  `parquet:"name=subtotal, type=INT64, convertedtype=DECIMAL, scale=2, precision=18"`
+	Currency      string `parquet:"name=currency, type=BYTE_ARRAY, convertedtype=UTF8"`
+	PaymentMethod string `parquet:"name=payment_method, type=BYTE_ARRAY, convertedtype=UTF8"`
+	PromotionCode string `parquet:"name=promotion_code, type=BYTE_ARRAY, convertedtype=UTF8"`
+	OrderDate     int32  `parquet:"name=order_date, type=INT32, convertedtype=DATE"`
+	Status        string `parquet:"name=status, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// TestSalesParquetWriter_RoundTrip relit le fichier avec le lecteur de parquet-go pour chaque codec:
+// valeurs, DECIMAL, DATE, UTF-8 et découpage en row groups de 2 lignes
+func TestSalesParquetWriter_RoundTrip(t *testing.T) {
+	jpy, err := shareddomain.ParseMoney("1040", "JPY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := append(sampleSaleRows(4), domain.NewSaleExportRow(
+		9001, 42, 3, 77, "Magasin Été", "Thé vert", "Épicerie", 1, jpy, jpy,
+		"PayPal", "WINTER10", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "pending",
+	))
+
+	codecs := map[string]parquet.CompressionCodec{
+		"none":   parquet.CompressionCodec_UNCOMPRESSED,
+		"snappy": parquet.CompressionCodec_SNAPPY,
+		"gzip":   parquet.CompressionCodec_GZIP,
+		"zstd":   parquet.CompressionCodec_ZSTD,
+	}
+	for compression, codec := range codecs {
+		t.Run(compression, func(t *testing.T) {
+			data := writeSalesParquet(t, rows, compression, 2)
+			footer := readFooter(t, data)
+			if len(footer.RowGroups) != 3 || footer.RowGroups[0].Columns[0].MetaData.Codec != codec {
+				t.Errorf("row groups = %d, codec = %v, want 3, %v",
+					len(footer.RowGroups), footer.RowGroups[0].Columns[0].MetaData.Codec, codec)
+			}
+
+			file, err := buffer.NewBufferFile(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pr, err := reader.NewParquetReader(file, new(saleParquetReadRecord), 1)
+			if err != nil {
+				t.Fatalf("parquet-go cannot open the file: %v", err)
+			}
+			defer pr.ReadStop()
+
+			if pr.GetNumRows() != int64(len(rows)) {
+				t.Fatalf("NumRows = %d, want %d", pr.GetNumRows(), len(rows))
+			}
+			records := make([]saleParquetReadRecord, pr.GetNumRows())
+			if err := pr.Read(&records); err != nil {
+				t.Fatalf("parquet-go read failed: %v", err)
+			}
+
+			first := records[0]
+			if first.OrderID != 1000 || first.StoreName != "Store" || first.Quantity != 2 ||
+				first.UnitPrice != 9999 || first.Subtotal != 19998 || first.Currency != "EUR" {
+				t.Errorf("row 0 = %+v", first)
+			}
+
+			last := records[len(records)-1]
+			want := saleParquetReadRecord{
+				OrderID: 9001, CustomerID: 42, StoreID: 3, StoreName: "Magasin Été",
+				ProductID: 77, ProductName: "Thé vert", CategoryName: "Épicerie", Quantity: 1,
+				UnitPrice: 104000, Subtotal: 104000, Currency: "JPY", // 1040 JPY en DECIMAL(18, 2)
+				PaymentMethod: "PayPal", PromotionCode: "WINTER10",
+				OrderDate: 19724, Status: "pending", // 2024-01-02 = 19724 jours après 1970-01-01
+			}
+			if last != want {
+				t.Errorf("last row = %+v, want %+v", last, want)
+			}
+		})
+	}
+}
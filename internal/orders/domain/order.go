@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	catalogdomain "eval/internal/catalog/domain"
@@ -40,6 +41,7 @@ type Order struct {
 	paymentMethodID PaymentMethodID
 	promotionID     *PromotionID
 	orderDate       time.Time
+	currency        domain.Currency
 	totalAmount     domain.Money
 	status          OrderStatus
 	items           []*OrderItem
@@ -47,6 +49,7 @@ type Order struct {
 }
 
 // NewOrder crée une nouvelle commande avec validation
+// currency = devise de la commande (celle du magasin): total et lignes sont exprimés dans cette devise
 func NewOrder(
 	id OrderID,
 	customerID CustomerID,
@@ -54,6 +57,7 @@ func NewOrder(
 	paymentMethodID PaymentMethodID,
 	promotionID *PromotionID,
 	orderDate time.Time,
+	currency domain.Currency,
 	status OrderStatus,
 	createdAt time.Time,
 ) (*Order, error) {
@@ -67,7 +71,9 @@ func NewOrder(
 		return nil, errors.New("invalid payment method ID")
 	}
 
-	totalAmount, _ := domain.NewMoney(0, "EUR")
+	if _, err := domain.ParseCurrency(currency.String()); err != nil || currency == "" {
+		return nil, fmt.Errorf("invalid order currency: %q", currency)
+	}
 
 	return &Order{
 		id:              id,
@@ -76,7 +82,8 @@ func NewOrder(
 		paymentMethodID: paymentMethodID,
 		promotionID:     promotionID,
 		orderDate:       orderDate,
-		totalAmount:     totalAmount,
+		currency:        currency,
+		totalAmount:     currency.Zero(),
 		status:          status,
 		items:           make([]*OrderItem, 0),
 		createdAt:       createdAt,
@@ -113,6 +120,11 @@ func (o *Order) OrderDate() time.Time {
 	return o.orderDate
 }
 
+// Currency retourne la devise de la commande
+func (o *Order) Currency() domain.Currency {
+	return o.currency
+}
+
 // TotalAmount retourne le montant total
 func (o *Order) TotalAmount() domain.Money {
 	return o.totalAmount
@@ -138,6 +150,9 @@ func (o *Order) AddItem(item *OrderItem) error {
 	if item == nil {
 		return errors.New("item cannot be nil")
 	}
+	if item.Subtotal().Currency() != o.currency.String() {
+		return fmt.Errorf("item currency %s does not match order currency %s", item.Subtotal().Currency(), o.currency)
+	}
 
 	// Vérifier que l'item n'existe pas déjà
 	for _, existingItem := range o.items {
@@ -175,7 +190,8 @@ func (o *Order) RemoveItem(productID catalogdomain.ProductID) error {
 
 // recalculateTotal recalcule le montant total de la commande
 func (o *Order) recalculateTotal() error {
-	total, _ := domain.NewMoney(0, "EUR")
+	// Add refuse les devises différentes: une ligne dans une autre devise est une erreur
+	total := o.currency.Zero()
 
 	for _, item := range o.items {
 		newTotal, err := total.Add(item.Subtotal())
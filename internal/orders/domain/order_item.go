@@ -44,8 +44,8 @@ func NewOrderItem(
 		return nil, errors.New("unit price cannot be zero")
 	}
 
-	// Calculer le subtotal
-	subtotal, err := unitPrice.Multiply(float64(quantity.Value()))
+	// Calculer le subtotal (exact: quantité entière × centimes)
+	subtotal, err := unitPrice.Times(quantity.Value())
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +105,7 @@ func (oi *OrderItem) UpdateQuantity(newQuantity domain.Quantity) error {
 	oi.quantity = newQuantity
 
 	// Recalculer le subtotal
-	subtotal, err := oi.unitPrice.Multiply(float64(newQuantity.Value()))
+	subtotal, err := oi.unitPrice.Times(newQuantity.Value())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// Promotion représente une opération commerciale (code promo) et sa fenêtre d'activité
+type Promotion struct {
+	id              PromotionID
+	code            string
+	name            string
+	discountPercent float64
+	activeWindow    domain.DateRange
+	active          bool
+}
+
+// NewPromotion crée une promotion avec validation
+// startDate et endDate sont inclus (colonnes DATE de la table promotions)
+func NewPromotion(
+	id PromotionID,
+	code, name string,
+	discountPercent float64,
+	startDate, endDate time.Time,
+	active bool,
+) (*Promotion, error) {
+	if id <= 0 {
+		return nil, errors.New("promotion ID must be positive")
+	}
+	if code == "" {
+		return nil, errors.New("promotion code cannot be empty")
+	}
+	if discountPercent < 0 || discountPercent > 100 {
+		return nil, errors.New("discount percent must be between 0 and 100")
+	}
+
+	window, err := domain.NewDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Promotion{
+		id:              id,
+		code:            code,
+		name:            name,
+		discountPercent: discountPercent,
+		activeWindow:    window,
+		active:          active,
+	}, nil
+}
+
+// ID retourne l'identifiant de la promotion
+func (p *Promotion) ID() PromotionID {
+	return p.id
+}
+
+// Code retourne le code saisi par le client (ex: PROMO12)
+func (p *Promotion) Code() string {
+	return p.code
+}
+
+// Name retourne le libellé de l'opération
+func (p *Promotion) Name() string {
+	return p.name
+}
+
+// DiscountPercent retourne la remise en pourcentage (0-100)
+func (p *Promotion) DiscountPercent() float64 {
+	return p.discountPercent
+}
+
+// IsActive indique si la promotion est activée (indépendamment de ses dates)
+func (p *Promotion) IsActive() bool {
+	return p.active
+}
+
+// ActiveWindow retourne la période de validité (bornes incluses)
+func (p *Promotion) ActiveWindow() domain.DateRange {
+	return p.activeWindow
+}
+
+// BaselineWindow retourne la période de référence: même nombre de jours, juste avant le début de la promotion
+// Sert à mesurer l'effet de la promotion sur les ventes (uplift)
+//
+// PIÈGE: pas de DateRange.Previous() ici: une promotion du 1er au 31 mars aurait février (28 jours)
+// comme référence, alors que la requête SQL compare des fenêtres de même durée
+func (p *Promotion) BaselineWindow() domain.DateRange {
+	end := p.activeWindow.Start().AddDate(0, 0, -1)
+	baseline, _ := domain.NewDateRange(end.AddDate(0, 0, -(p.activeWindow.Days()-1)), end)
+	return baseline
+}
+
+// RunsOn vérifie si la promotion est valable à la date donnée
+func (p *Promotion) RunsOn(date time.Time) bool {
+	y, m, d := date.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, p.activeWindow.Location())
+	return !day.Before(p.activeWindow.Start()) && !day.After(p.activeWindow.End())
+}
+
+// EstimatedDiscountCost estime la remise accordée sur un CA réalisé avec la promotion
+// Estimation: total_amount est traité comme le montant avant remise (le seed n'applique pas la remise)
+// Arrondi bancaire: sur des milliers de commandes, l'arrondi commercial surestimerait le coût
+func (p *Promotion) EstimatedDiscountCost(revenue domain.Money) domain.Money {
+	cost, _ := revenue.MultiplyRounded(p.discountPercent/100, domain.RoundHalfEven)
+	return cost
+}
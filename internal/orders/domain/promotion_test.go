@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"eval/internal/shared/domain"
+)
+
+// TestPromotion vérifie la fenêtre de référence et l'estimation de la remise
+func TestPromotion(t *testing.T) {
+	promo, err := NewPromotion(1, "PROMO1", "Soldes", 20,
+		time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 31 jours avant le 1er mars (et non février entier)
+	if got := promo.BaselineWindow().Key(); got != "2025-01-29..2025-02-28" {
+		t.Errorf("baseline = %s, want 2025-01-29..2025-02-28", got)
+	}
+	if !promo.RunsOn(time.Date(2025, 3, 31, 18, 0, 0, 0, time.UTC)) || promo.RunsOn(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("promotion should run until March 31 included")
+	}
+
+	revenue, _ := domain.NewMoney(250, "EUR")
+	if cost := promo.EstimatedDiscountCost(revenue); cost.Amount() != 50 {
+		t.Errorf("discount cost = %v, want 50", cost.Amount())
+	}
+
+	if _, err := NewPromotion(2, "BAD", "", 120, time.Now(), time.Now(), true); err == nil {
+		t.Error("discount above 100% should be rejected")
+	}
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"eval/internal/shared/domain"
+)
+
+// OrderStatuses liste les statuts connus, dans l'ordre du cycle de vie d'une commande
+var OrderStatuses = []OrderStatus{OrderStatusPending, OrderStatusCompleted, OrderStatusCancelled}
+
+// ParseOrderStatus valide un statut reçu de l'extérieur (paramètre HTTP, colonne SQL)
+func ParseOrderStatus(value string) (OrderStatus, error) {
+	status := OrderStatus(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range OrderStatuses {
+		if status == known {
+			return status, nil
+		}
+	}
+	return "", domain.NewValidationError("invalid_status", fmt.Sprintf("unknown order status: %q", value))
+}
+
+// StatusFilter ensemble des statuts de commande retenus par une analyse ou un export
+// DESIGN PATTERN: Value Object
+//   - La valeur zéro retient uniquement les commandes completed: seules les ventes réalisées
+//     comptent dans le CA (une commande annulée ou en attente n'est pas un encaissement)
+//   - {completed} explicite est normalisé vers la valeur zéro: même clé de cache, même SQL
+//
+// MÉMOIRE: bitmask d'un octet, le filtre reste comparable avec == (utilisable dans un autre VO)
+type StatusFilter struct {
+	mask uint8
+}
+
+// NewStatusFilter crée un filtre sur les statuts donnés (aucun statut = completed uniquement)
+func NewStatusFilter(statuses ...OrderStatus) (StatusFilter, error) {
+	var mask uint8
+	for _, status := range statuses {
+		bit, err := statusBit(status)
+		if err != nil {
+			return StatusFilter{}, err
+		}
+		mask |= bit
+	}
+	return StatusFilter{mask: normalizeStatusMask(mask)}, nil
+}
+
+// AllStatuses retourne un filtre retenant tous les statuts connus
+func AllStatuses() StatusFilter {
+	filter, _ := NewStatusFilter(OrderStatuses...)
+	return filter
+}
+
+// ParseStatusFilter lit un filtre du type "completed,pending" ou "all" ("" = completed uniquement)
+func ParseStatusFilter(value string) (StatusFilter, error) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "":
+		return StatusFilter{}, nil
+	case "all":
+		return AllStatuses(), nil
+	}
+
+	var statuses []OrderStatus
+	for _, part := range strings.Split(value, ",") {
+		status, err := ParseOrderStatus(part)
+		if err != nil {
+			return StatusFilter{}, err
+		}
+		statuses = append(statuses, status)
+	}
+	return NewStatusFilter(statuses...)
+}
+
+// Statuses retourne les statuts retenus, dans l'ordre de OrderStatuses
+func (f StatusFilter) Statuses() []OrderStatus {
+	mask := f.mask
+	if mask == 0 {
+		mask = completedBit
+	}
+
+	statuses := make([]OrderStatus, 0, len(OrderStatuses))
+	for i, status := range OrderStatuses {
+		if mask&(1<<i) != 0 {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// Contains vérifie si le statut est retenu par le filtre
+func (f StatusFilter) Contains(status OrderStatus) bool {
+	for _, retained := range f.Statuses() {
+		if retained == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDefault vérifie si le filtre retient uniquement les commandes completed
+func (f StatusFilter) IsDefault() bool {
+	return f.mask == 0
+}
+
+// Key retourne une représentation canonique pour les clés de cache ("completed", "pending,completed", ...)
+func (f StatusFilter) Key() string {
+	statuses := f.Statuses()
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+	return strings.Join(names, ",")
+}
+
+// completedBit position de OrderStatusCompleted dans OrderStatuses
+const completedBit uint8 = 1 << 1
+
+// statusBit retourne le bit d'un statut dans le mask
+func statusBit(status OrderStatus) (uint8, error) {
+	for i, known := range OrderStatuses {
+		if status == known {
+			return 1 << i, nil
+		}
+	}
+	return 0, domain.NewValidationError("invalid_status", fmt.Sprintf("unknown order status: %q", status))
+}
+
+// normalizeStatusMask ramène {completed} à la valeur zéro
+func normalizeStatusMask(mask uint8) uint8 {
+	if mask == completedBit {
+		return 0
+	}
+	return mask
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseStatusFilter vérifie la valeur par défaut, "all" et la normalisation
+func TestParseStatusFilter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []OrderStatus
+		key   string
+	}{
+		{"", []OrderStatus{OrderStatusCompleted}, "completed"},
+		{"completed", []OrderStatus{OrderStatusCompleted}, "completed"},
+		{"cancelled, Pending", []OrderStatus{OrderStatusPending, OrderStatusCancelled}, "pending,cancelled"},
+		{"all", OrderStatuses, "pending,completed,cancelled"},
+	}
+
+	for _, tt := range tests {
+		filter, err := ParseStatusFilter(tt.value)
+		if err != nil {
+			t.Fatalf("ParseStatusFilter(%q): %v", tt.value, err)
+		}
+		if got := filter.Statuses(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseStatusFilter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+		if filter.Key() != tt.key {
+			t.Errorf("ParseStatusFilter(%q).Key() = %q, want %q", tt.value, filter.Key(), tt.key)
+		}
+	}
+
+	if explicit, _ := ParseStatusFilter("completed"); explicit != (StatusFilter{}) {
+		t.Error("explicit completed filter should equal the zero value")
+	}
+	if _, err := ParseStatusFilter("refunded"); err == nil {
+		t.Error("unknown status should be rejected")
+	}
+}
@@ -26,7 +26,7 @@ func NewOrderQueryRepository(db *sql.DB) *OrderQueryRepository {
 func (r *OrderQueryRepository) FindByDateRange(dateRange shareddomain.DateRange) ([]*domain.Order, error) {
 	query := `
 		SELECT o.id, o.customer_id, o.store_id, o.payment_method_id, o.promotion_id,
-		       o.order_date, o.total_amount, o.status, o.created_at
+		       o.order_date, o.currency, o.total_amount, o.status, o.created_at
 		FROM orders o
 		WHERE o.order_date >= $1 AND o.order_date <= $2
 		ORDER BY o.order_date DESC
@@ -46,7 +46,7 @@ func (r *OrderQueryRepository) FindByDateRange(dateRange shareddomain.DateRange)
 		}
 
 		// Charger les items
-		items, err := r.findItemsByOrderID(order.ID())
+		items, err := r.findItemsByOrderID(order.ID(), order.Currency())
 		if err != nil {
 			return nil, err
 		}
@@ -64,7 +64,7 @@ func (r *OrderQueryRepository) FindByDateRange(dateRange shareddomain.DateRange)
 func (r *OrderQueryRepository) FindByID(id domain.OrderID) (*domain.Order, error) {
 	query := `
 		SELECT o.id, o.customer_id, o.store_id, o.payment_method_id, o.promotion_id,
-		       o.order_date, o.total_amount, o.status, o.created_at
+		       o.order_date, o.currency, o.total_amount, o.status, o.created_at
 		FROM orders o
 		WHERE o.id = $1
 	`
@@ -76,7 +76,7 @@ func (r *OrderQueryRepository) FindByID(id domain.OrderID) (*domain.Order, error
 	}
 
 	// Charger les items
-	items, err := r.findItemsByOrderID(order.ID())
+	items, err := r.findItemsByOrderID(order.ID(), order.Currency())
 	if err != nil {
 		return nil, err
 	}
@@ -87,8 +87,11 @@ func (r *OrderQueryRepository) FindByID(id domain.OrderID) (*domain.Order, error
 	return order, nil
 }
 
-// findItemsByOrderID récupère les items d'une commande
-func (r *OrderQueryRepository) findItemsByOrderID(orderID domain.OrderID) ([]*domain.OrderItem, error) {
+// findItemsByOrderID récupère les items d'une commande (prix dans la devise de la commande)
+func (r *OrderQueryRepository) findItemsByOrderID(
+	orderID domain.OrderID,
+	currency shareddomain.Currency,
+) ([]*domain.OrderItem, error) {
 	query := `
 		SELECT oi.id, oi.order_id, oi.product_id, oi.quantity, oi.unit_price, oi.created_at
 		FROM order_items oi
@@ -109,23 +112,22 @@ func (r *OrderQueryRepository) findItemsByOrderID(orderID domain.OrderID) ([]*do
 			ordID     int64
 			productID int64
 			quantity  int
-			unitPrice float64
+			unitPrice shareddomain.Money // NUMERIC lu directement: pas de détour par float64
 			createdAt time.Time
 		)
 
-		if err := rows.Scan(&itemID, &ordID, &productID, &quantity, &unitPrice, &createdAt); err != nil {
+		if err := rows.Scan(&itemID, &ordID, &productID, &quantity, shareddomain.MoneyIn(&unitPrice, currency), &createdAt); err != nil {
 			return nil, err
 		}
 
 		qty, _ := shareddomain.NewQuantity(quantity)
-		price, _ := shareddomain.NewMoney(unitPrice, "EUR")
 
 		item, err := domain.NewOrderItem(
 			domain.OrderItemID(itemID),
 			domain.OrderID(ordID),
 			catalogdomain.ProductID(productID),
 			qty,
-			price,
+			unitPrice,
 			createdAt,
 		)
 		if err != nil {
@@ -147,13 +149,14 @@ func (r *OrderQueryRepository) scanOrder(rows *sql.Rows) (*domain.Order, error)
 		paymentMethodID int64
 		promotionID     sql.NullInt64
 		orderDate       time.Time
-		totalAmount     float64
+		currency        string
+		totalAmount     shareddomain.Money
 		status          string
 		createdAt       time.Time
 	)
 
 	if err := rows.Scan(&id, &customerID, &storeID, &paymentMethodID, &promotionID,
-		&orderDate, &totalAmount, &status, &createdAt); err != nil {
+		&orderDate, &currency, &totalAmount, &status, &createdAt); err != nil {
 		return nil, err
 	}
 
@@ -170,6 +173,7 @@ func (r *OrderQueryRepository) scanOrder(rows *sql.Rows) (*domain.Order, error)
 		domain.PaymentMethodID(paymentMethodID),
 		promID,
 		orderDate,
+		shareddomain.Currency(currency),
 		domain.OrderStatus(status),
 		createdAt,
 	)
@@ -184,13 +188,14 @@ func (r *OrderQueryRepository) scanOrderRow(row *sql.Row) (*domain.Order, error)
 		paymentMethodID int64
 		promotionID     sql.NullInt64
 		orderDate       time.Time
-		totalAmount     float64
+		currency        string
+		totalAmount     shareddomain.Money
 		status          string
 		createdAt       time.Time
 	)
 
 	if err := row.Scan(&id, &customerID, &storeID, &paymentMethodID, &promotionID,
-		&orderDate, &totalAmount, &status, &createdAt); err != nil {
+		&orderDate, &currency, &totalAmount, &status, &createdAt); err != nil {
 		return nil, err
 	}
 
@@ -207,6 +212,7 @@ func (r *OrderQueryRepository) scanOrderRow(row *sql.Row) (*domain.Order, error)
 		domain.PaymentMethodID(paymentMethodID),
 		promID,
 		orderDate,
+		shareddomain.Currency(currency),
 		domain.OrderStatus(status),
 		createdAt,
 	)
@@ -0,0 +1,28 @@
+package infrastructure
+
+import (
+	"strings"
+
+	"eval/internal/orders/domain"
+	"eval/internal/shared/infrastructure"
+)
+
+// StatusSpecification traduit un StatusFilter en prédicat sur la colonne status d'une table orders
+// Partagée par les requêtes d'analyse et d'export: une seule définition de "vente réalisée"
+//
+// PIÈGE: orders.status est nullable (DEFAULT 'completed' sans NOT NULL)
+//   - COALESCE traite NULL comme 'completed', la valeur par défaut de la colonne
+//   - Sans COALESCE, "status IN (...)" écarterait silencieusement ces commandes
+func StatusSpecification(orderAlias string, filter domain.StatusFilter) infrastructure.Specification {
+	statuses := filter.Statuses()
+
+	placeholders := make([]string, len(statuses))
+	args := make([]interface{}, len(statuses))
+	for i, status := range statuses {
+		placeholders[i] = "?"
+		args[i] = string(status)
+	}
+
+	return infrastructure.NewSQLSpecification(
+		"COALESCE("+orderAlias+".status, 'completed') IN ("+strings.Join(placeholders, ", ")+")", args...)
+}
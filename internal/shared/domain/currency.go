@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ========================================
+// DEVISES ET TAUX DE CHANGE
+// ========================================
+// Magasins et commandes portent leur propre devise. Les taux de la table fx_rates sont exprimés
+// par rapport à DefaultCurrency (devise pivot): 1 USD = rate_to_eur EUR à la date du taux
+//
+// Conversion d'un montant de la devise A vers la devise B à une date d:
+//   montant × taux(A, d) / taux(B, d), taux(EUR, d) = 1
+// taux(X, d) = dernier taux connu de X à la date d ou avant (pas de cotation le week-end)
+
+// ExchangeRateDigits nombre de décimales des taux (colonne NUMERIC(18, 8))
+const ExchangeRateDigits = 8
+
+// Currency code ISO 4217 d'une devise (EUR, USD, GBP, ...)
+type Currency string
+
+// ParseCurrency valide un code de devise ("" = DefaultCurrency, la casse est ignorée)
+func ParseCurrency(value string) (Currency, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" {
+		return DefaultCurrency, nil
+	}
+	if len(value) != 3 || strings.Trim(value, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", NewValidationError("invalid_currency",
+			fmt.Sprintf("invalid currency: %q (expected an ISO 4217 code such as EUR)", value))
+	}
+	return Currency(value), nil
+}
+
+// String retourne le code de la devise
+func (c Currency) String() string {
+	return string(c)
+}
+
+// IsDefault vérifie s'il s'agit de la devise pivot (pas de conversion nécessaire en base)
+func (c Currency) IsDefault() bool {
+	return c == DefaultCurrency
+}
+
+// Zero retourne un montant nul dans la devise
+func (c Currency) Zero() Money {
+	return Money{currency: string(c)}
+}
+
+// ExchangeRate taux de change d'une devise vers la devise pivot à une date
+// DESIGN PATTERN: Value Object (immutable, validé à la création)
+type ExchangeRate struct {
+	currency Currency
+	date     time.Time
+	rate     int64 // taux × 10^ExchangeRateDigits (exact, comme la colonne NUMERIC)
+}
+
+// NewExchangeRate crée un taux à partir de son écriture décimale ("0.92150000")
+// Erreur si le taux n'est pas strictement positif ou a plus de ExchangeRateDigits décimales
+func NewExchangeRate(currency Currency, date time.Time, rate string) (ExchangeRate, error) {
+	if _, err := ParseCurrency(string(currency)); err != nil || currency == "" {
+		return ExchangeRate{}, fmt.Errorf("invalid currency: %q", currency)
+	}
+	if currency.IsDefault() {
+		return ExchangeRate{}, fmt.Errorf("%s is the base currency: its rate is always 1", currency)
+	}
+	if date.IsZero() {
+		return ExchangeRate{}, errors.New("exchange rate date is required")
+	}
+
+	scaled, err := parseMinorUnits(strings.TrimSpace(rate), ExchangeRateDigits, nil)
+	if err != nil {
+		return ExchangeRate{}, fmt.Errorf("invalid exchange rate %q: %w", rate, err)
+	}
+	if scaled == 0 {
+		return ExchangeRate{}, errors.New("exchange rate must be positive")
+	}
+
+	y, m, d := date.Date()
+	return ExchangeRate{
+		currency: currency,
+		date:     time.Date(y, m, d, 0, 0, 0, 0, time.UTC),
+		rate:     scaled,
+	}, nil
+}
+
+// Currency retourne la devise cotée
+func (r ExchangeRate) Currency() Currency {
+	return r.currency
+}
+
+// Date retourne la date du taux (minuit UTC)
+func (r ExchangeRate) Date() time.Time {
+	return r.date
+}
+
+// Rate retourne le taux en écriture décimale exacte ("0.92150000"), format de la colonne NUMERIC
+func (r ExchangeRate) Rate() string {
+	return formatScaled(r.rate, ExchangeRateDigits)
+}
+
+// NewMissingExchangeRateError aucun taux de currency connu à date ou avant (table fx_rates incomplète)
+// Catégorie conflict: la demande est valide, c'est l'état des taux qui empêche la conversion
+// (charger les taux manquants avec cmd/fxrates puis réessayer)
+func NewMissingExchangeRateError(currency Currency, date time.Time) error {
+	return NewConflictError("fx_rate_missing",
+		fmt.Sprintf("no %s exchange rate on or before %s", currency, date.Format("2006-01-02")))
+}
+
+// MoneyIn adapte dest pour rows.Scan: la colonne NUMERIC est lue dans currency
+// (Money.Scan seul utilise DefaultCurrency, les montants convertis en base sont dans la devise de reporting)
+//
+// Exemple: rows.Scan(&id, shareddomain.MoneyIn(&revenue, currency))
+func MoneyIn(dest *Money, currency Currency) sql.Scanner {
+	return moneyScanner{dest: dest, currency: currency}
+}
+
+// moneyScanner sql.Scanner qui fixe la devise avant de déléguer à Money.Scan
+type moneyScanner struct {
+	dest     *Money
+	currency Currency
+}
+
+// Scan implémente sql.Scanner
+func (s moneyScanner) Scan(src interface{}) error {
+	*s.dest = s.currency.Zero()
+	return s.dest.Scan(src)
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseCurrency vérifie la validation des codes de devise
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Currency
+		wantErr bool
+	}{
+		{"", DefaultCurrency, false},
+		{"usd", "USD", false},
+		{" GBP ", "GBP", false},
+		{"EU1", "", true},
+		{"EURO", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCurrency(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCurrency(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCurrency(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestNewExchangeRate vérifie la validation et l'écriture exacte des taux
+func TestNewExchangeRate(t *testing.T) {
+	date := time.Date(2024, 1, 2, 15, 30, 0, 0, time.UTC)
+
+	rate, err := NewExchangeRate("USD", date, "0.9215")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate.Rate() != "0.92150000" {
+		t.Errorf("Rate() = %s, want 0.92150000", rate.Rate())
+	}
+	if !rate.Date().Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Date() = %s, want 2024-01-02 (truncated to the day)", rate.Date())
+	}
+
+	invalid := []struct {
+		name     string
+		currency Currency
+		date     time.Time
+		rate     string
+	}{
+		{"base currency", DefaultCurrency, date, "1"},
+		{"zero date", "USD", time.Time{}, "0.92"},
+		{"zero rate", "USD", date, "0"},
+		{"too many decimals", "USD", date, "0.921500001"},
+		{"not a number", "USD", date, "abc"},
+	}
+	for _, tt := range invalid {
+		if _, err := NewExchangeRate(tt.currency, tt.date, tt.rate); err == nil {
+			t.Errorf("%s: NewExchangeRate should fail", tt.name)
+		}
+	}
+}
+
+// TestNewMissingExchangeRateError vérifie la catégorie, le code et le message d'un taux manquant
+func TestNewMissingExchangeRateError(t *testing.T) {
+	err := NewMissingExchangeRateError("USD", time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))
+
+	domainErr, ok := AsError(err)
+	if !ok || domainErr.Kind() != ErrorKindConflict || domainErr.Code() != "fx_rate_missing" {
+		t.Fatalf("error = %v, want a conflict error fx_rate_missing", err)
+	}
+	if got := domainErr.Message(); got != "no USD exchange rate on or before 2023-12-31" {
+		t.Errorf("Message() = %q", got)
+	}
+}
+
+// TestMoneyIn vérifie qu'une colonne convertie est lue dans la devise de reporting
+func TestMoneyIn(t *testing.T) {
+	var m Money
+	if err := MoneyIn(&m, "USD").Scan([]byte("1040.00")); err != nil {
+		t.Fatal(err)
+	}
+	if m.MinorUnits() != 104000 || m.Currency() != "USD" {
+		t.Errorf("MoneyIn(USD).Scan(1040.00) = %s, want 1040.00 USD", m)
+	}
+}
@@ -1,7 +1,9 @@
 package domain
 
 import (
-	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -11,6 +13,12 @@ import (
 //   - Validation dans le constructeur (NewDateRangeFromDays)
 //   - Égalité basée sur les valeurs, pas l'identité
 //
+// BORNES: inclusives, à la granularité du jour
+//   - Les requêtes filtrent orders.order_date (type DATE) avec >= start AND <= end
+//   - PostgreSQL convertit le paramètre en DATE: seule la date calendaire de start/end
+//     dans leur fuseau horaire compte, l'heure est ignorée
+//   - "Septembre 2025 à Paris" = 2025-09-01 .. 2025-09-30, quel que soit le fuseau du serveur
+//
 // MÉMOIRE: Taille de la struct
 //   - start: time.Time = 24 bytes (wall: 8b, ext: 8b, loc: 8b pointer)
 //   - end: time.Time = 24 bytes
@@ -36,13 +44,19 @@ type DateRange struct {
 // PERFORMANCE: time.Now() fait un syscall (lent, ~1-2μs)
 //   - time.AddDate fait des calculs de calendrier (complexe)
 func NewDateRangeFromDays(days int) (DateRange, error) {
-	if days < 0 {
-		return DateRange{}, errors.New("days cannot be negative")
-	}
 	// PERFORMANCE: time.Now() = syscall vers l'horloge système
 	//   - Sur Linux: appel VDSO optimisé (pas de context switch)
 	//   - ~1-2 microseconde, acceptable pour ce use case
-	now := time.Now()
+	return NewDateRangeFromDaysUntil(days, time.Now())
+}
+
+// NewDateRangeFromDaysUntil crée un DateRange des `days` jours précédant `now`
+// FUSEAU HORAIRE: "aujourd'hui" est évalué dans now.Location()
+//   - time.Now().In(paris) à 00:30 heure de Paris = déjà le lendemain d'UTC
+func NewDateRangeFromDaysUntil(days int, now time.Time) (DateRange, error) {
+	if days < 0 {
+		return DateRange{}, NewValidationError("invalid_days", "days cannot be negative")
+	}
 	start := now.AddDate(0, 0, -days)
 	// SYNTAXE: DateRange{start: start, end: now}
 	//   - Composite literal, crée struct sur STACK (souvent)
@@ -69,3 +83,286 @@ func (dr DateRange) Start() time.Time {
 func (dr DateRange) End() time.Time {
 	return dr.end
 }
+
+// NewDateRange crée un DateRange à partir de bornes absolues (incluses)
+// Les bornes sont normalisées à minuit dans le fuseau de start:
+//   - même période = mêmes valeurs = même clé de cache (voir Key)
+func NewDateRange(start, end time.Time) (DateRange, error) {
+	if start.IsZero() || end.IsZero() {
+		return DateRange{}, NewValidationError("invalid_date_range", "date range bounds cannot be empty")
+	}
+
+	start = startOfDay(start)
+	end = startOfDay(end.In(start.Location()))
+	if end.Before(start) {
+		return DateRange{}, NewValidationError("invalid_date_range", fmt.Sprintf("end date %s is before start date %s",
+			end.Format(dateLayout), start.Format(dateLayout)))
+	}
+
+	return DateRange{
+		start: start,
+		end:   end,
+	}, nil
+}
+
+// NewDateRangeForMonth crée un DateRange couvrant un mois calendaire
+// SYNTAXE: time.Date normalise les débordements
+//   - AddDate(0, 1, -1) depuis le 1er = dernier jour du mois (28, 29, 30 ou 31)
+func NewDateRangeForMonth(year int, month time.Month, loc *time.Location) (DateRange, error) {
+	if month < time.January || month > time.December {
+		return DateRange{}, NewValidationError("invalid_period", fmt.Sprintf("invalid month: %d", month))
+	}
+	if err := validateYear(year); err != nil {
+		return DateRange{}, err
+	}
+
+	start := time.Date(year, month, 1, 0, 0, 0, 0, locationOrUTC(loc))
+	return DateRange{
+		start: start,
+		end:   start.AddDate(0, 1, -1),
+	}, nil
+}
+
+// NewDateRangeForQuarter crée un DateRange couvrant un trimestre (1 à 4)
+func NewDateRangeForQuarter(year, quarter int, loc *time.Location) (DateRange, error) {
+	if quarter < 1 || quarter > 4 {
+		return DateRange{}, NewValidationError("invalid_period", fmt.Sprintf("invalid quarter: %d", quarter))
+	}
+	if err := validateYear(year); err != nil {
+		return DateRange{}, err
+	}
+
+	start := time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, locationOrUTC(loc))
+	return DateRange{
+		start: start,
+		end:   start.AddDate(0, 3, -1),
+	}, nil
+}
+
+// NewDateRangeForYear crée un DateRange couvrant une année calendaire
+func NewDateRangeForYear(year int, loc *time.Location) (DateRange, error) {
+	if err := validateYear(year); err != nil {
+		return DateRange{}, err
+	}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, locationOrUTC(loc))
+	return DateRange{
+		start: start,
+		end:   start.AddDate(1, 0, -1),
+	}, nil
+}
+
+// NewDateRangeForISOWeek crée un DateRange couvrant une semaine ISO 8601 (lundi au dimanche)
+// ISO 8601: la semaine 1 est celle qui contient le 4 janvier
+//   - Le lundi de la semaine 1 peut tomber fin décembre de l'année précédente
+//   - Une année a 52 ou 53 semaines: la semaine 53 n'existe que si l'année commence un jeudi
+//     (ou un mercredi pour une année bissextile)
+func NewDateRangeForISOWeek(year, week int, loc *time.Location) (DateRange, error) {
+	if err := validateYear(year); err != nil {
+		return DateRange{}, err
+	}
+
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, locationOrUTC(loc))
+	// Weekday(): dimanche = 0 → (weekday+6)%7 = nombre de jours depuis lundi
+	mondayWeek1 := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	start := mondayWeek1.AddDate(0, 0, (week-1)*7)
+
+	// Vérification par aller-retour: rejette la semaine 0 et la semaine 53 inexistante
+	if y, w := start.ISOWeek(); week < 1 || y != year || w != week {
+		return DateRange{}, NewValidationError("invalid_period", fmt.Sprintf("invalid ISO week: %d-W%02d", year, week))
+	}
+
+	return DateRange{
+		start: start,
+		end:   start.AddDate(0, 0, 6),
+	}, nil
+}
+
+// ParsePeriod convertit un identifiant de période en DateRange
+// Formats acceptés:
+//   - absolus: "2025" (année), "2025-09" (mois), "2025-Q3" (trimestre), "2025-W38" (semaine ISO)
+//   - relatifs à now: "today", "yesterday", "this_week", "last_week", "this_month", "last_month",
+//     "this_quarter", "last_quarter", "this_year", "last_year"
+//
+// FUSEAU HORAIRE: les périodes sont calculées dans now.Location()
+func ParsePeriod(period string, now time.Time) (DateRange, error) {
+	loc := now.Location()
+	value := strings.ToUpper(strings.TrimSpace(period))
+
+	switch value {
+	case "TODAY":
+		return NewDateRange(now, now)
+	case "YESTERDAY":
+		yesterday := now.AddDate(0, 0, -1)
+		return NewDateRange(yesterday, yesterday)
+	case "THIS_WEEK":
+		year, week := now.ISOWeek()
+		return NewDateRangeForISOWeek(year, week, loc)
+	case "LAST_WEEK":
+		year, week := now.AddDate(0, 0, -7).ISOWeek()
+		return NewDateRangeForISOWeek(year, week, loc)
+	case "THIS_MONTH":
+		return NewDateRangeForMonth(now.Year(), now.Month(), loc)
+	case "LAST_MONTH":
+		// Depuis le 1er du mois: AddDate(0, -1, 0) le 31 mars donnerait le 3 mars (31 février normalisé)
+		previous := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, loc)
+		return NewDateRangeForMonth(previous.Year(), previous.Month(), loc)
+	case "THIS_QUARTER":
+		return NewDateRangeForQuarter(now.Year(), quarterOf(now.Month()), loc)
+	case "LAST_QUARTER":
+		previous := time.Date(now.Year(), now.Month()-3, 1, 0, 0, 0, 0, loc)
+		return NewDateRangeForQuarter(previous.Year(), quarterOf(previous.Month()), loc)
+	case "THIS_YEAR":
+		return NewDateRangeForYear(now.Year(), loc)
+	case "LAST_YEAR":
+		return NewDateRangeForYear(now.Year()-1, loc)
+	}
+
+	// Formats absolus: l'année est toujours sur 4 chiffres, suivie d'un suffixe optionnel
+	if len(value) < 4 {
+		return DateRange{}, invalidPeriod(period)
+	}
+	year, err := strconv.Atoi(value[:4])
+	if err != nil {
+		return DateRange{}, invalidPeriod(period)
+	}
+	suffix := value[4:]
+
+	switch {
+	case suffix == "":
+		return NewDateRangeForYear(year, loc)
+	case strings.HasPrefix(suffix, "-Q"):
+		quarter, err := strconv.Atoi(suffix[2:])
+		if err != nil {
+			return DateRange{}, invalidPeriod(period)
+		}
+		return NewDateRangeForQuarter(year, quarter, loc)
+	case strings.HasPrefix(suffix, "-W"):
+		week, err := strconv.Atoi(suffix[2:])
+		if err != nil {
+			return DateRange{}, invalidPeriod(period)
+		}
+		return NewDateRangeForISOWeek(year, week, loc)
+	case strings.HasPrefix(suffix, "-") && len(suffix) == 3:
+		month, err := strconv.Atoi(suffix[1:])
+		if err != nil {
+			return DateRange{}, invalidPeriod(period)
+		}
+		return NewDateRangeForMonth(year, time.Month(month), loc)
+	default:
+		return DateRange{}, invalidPeriod(period)
+	}
+}
+
+// Days retourne le nombre de jours calendaires couverts (bornes incluses)
+// PIÈGE: end.Sub(start) / 24h est faux lors des changements d'heure (journées de 23h ou 25h)
+//   - On compare les dates calendaires projetées en UTC, où tous les jours font 24h
+func (dr DateRange) Days() int {
+	y1, m1, d1 := dr.start.Date()
+	y2, m2, d2 := dr.end.Date()
+	start := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+// Previous retourne la période de même durée qui précède immédiatement celle-ci
+// Une période composée de mois entiers est décalée en mois calendaires:
+//   - mars (31 j) → février (28 j), et non "les 31 jours avant le 1er mars"
+//   - T3 → T2, 2025 → 2024
+func (dr DateRange) Previous() DateRange {
+	start := startOfDay(dr.start)
+
+	if months := dr.wholeMonths(); months > 0 {
+		prevStart := start.AddDate(0, -months, 0)
+		return DateRange{start: prevStart, end: start.AddDate(0, 0, -1)}
+	}
+
+	prevEnd := start.AddDate(0, 0, -1)
+	return DateRange{start: prevEnd.AddDate(0, 0, -(dr.Days() - 1)), end: prevEnd}
+}
+
+// SamePeriodLastYear retourne la même période un an plus tôt
+// PIÈGE: AddDate(-1, 0, 0) sur un 29 février donne le 1er mars (28 février + 1 normalisé)
+//   - Les mois entiers sont décalés de 12 mois (février 2024 → 1er..28 février 2023)
+//   - Sinon la date est ramenée au dernier jour du mois si elle déborde
+func (dr DateRange) SamePeriodLastYear() DateRange {
+	start, end := startOfDay(dr.start), startOfDay(dr.end)
+
+	if months := dr.wholeMonths(); months > 0 {
+		lastYearStart := start.AddDate(-1, 0, 0)
+		return DateRange{start: lastYearStart, end: lastYearStart.AddDate(0, months, -1)}
+	}
+
+	return DateRange{start: addYearsClamped(start, -1), end: addYearsClamped(end, -1)}
+}
+
+// wholeMonths retourne le nombre de mois si la période va du 1er d'un mois au dernier jour d'un mois, 0 sinon
+func (dr DateRange) wholeMonths() int {
+	if dr.start.Day() != 1 || dr.end.AddDate(0, 0, 1).Day() != 1 {
+		return 0
+	}
+	return (dr.end.Year()-dr.start.Year())*12 + int(dr.end.Month()-dr.start.Month()) + 1
+}
+
+// Location retourne le fuseau horaire dans lequel la période a été définie
+func (dr DateRange) Location() *time.Location {
+	return dr.start.Location()
+}
+
+// Key retourne une représentation normalisée "2025-07-01..2025-09-30"
+// Utilisée pour les clés de cache: ?days=92, ?period=2025-Q3 et ?from=...&to=...
+// désignant les mêmes jours partagent la même entrée de cache
+func (dr DateRange) Key() string {
+	return dr.start.Format(dateLayout) + ".." + dr.end.Format(dateLayout)
+}
+
+// String implémente fmt.Stringer
+func (dr DateRange) String() string {
+	return dr.Key()
+}
+
+// dateLayout format ISO 8601 d'une date calendaire (layout Go: 2006-01-02)
+const dateLayout = "2006-01-02"
+
+// startOfDay retourne minuit du même jour dans le fuseau de t
+// SYNTAXE: t.Truncate(24 * time.Hour) tronquerait en UTC, pas dans le fuseau local
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// addYearsClamped décale t de `years` ans en restant dans le même mois (29 février → 28 février)
+func addYearsClamped(t time.Time, years int) time.Time {
+	shifted := t.AddDate(years, 0, 0)
+	if shifted.Month() != t.Month() {
+		// Débordement sur le mois suivant: revenir au dernier jour du mois visé
+		return shifted.AddDate(0, 0, -shifted.Day())
+	}
+	return shifted
+}
+
+// locationOrUTC retourne UTC si loc est nil (time.Date panique avec un fuseau nil)
+func locationOrUTC(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// validateYear vérifie que l'année tient sur 4 chiffres
+func validateYear(year int) error {
+	if year < 1 || year > 9999 {
+		return NewValidationError("invalid_period", fmt.Sprintf("invalid year: %d", year))
+	}
+	return nil
+}
+
+// invalidPeriod erreur de validation d'un identifiant de période non reconnu
+func invalidPeriod(period string) error {
+	return NewValidationError("invalid_period", fmt.Sprintf("invalid period: %q", period))
+}
+
+// quarterOf retourne le trimestre (1 à 4) d'un mois
+func quarterOf(month time.Month) int {
+	return (int(month)-1)/3 + 1
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// ========================================
+// Tests: périodes calendaires
+// ========================================
+
+// TestParsePeriod vérifie les bornes des périodes absolues et relatives
+func TestParsePeriod(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	// 31 mars 2025 à 00:30 heure de Paris = 30 mars en UTC
+	now := time.Date(2025, time.March, 31, 0, 30, 0, 0, paris)
+
+	tests := []struct {
+		period string
+		want   string
+		days   int
+	}{
+		{"2025", "2025-01-01..2025-12-31", 365},
+		{"2024-02", "2024-02-01..2024-02-29", 29},
+		{"2025-Q3", "2025-07-01..2025-09-30", 92},
+		{"2025-W01", "2024-12-30..2025-01-05", 7},
+		{"2020-W53", "2020-12-28..2021-01-03", 7},
+		{"today", "2025-03-31..2025-03-31", 1},
+		{"yesterday", "2025-03-30..2025-03-30", 1},
+		{"last_month", "2025-02-01..2025-02-28", 28},
+		{"this_quarter", "2025-01-01..2025-03-31", 90},
+		{"last_quarter", "2024-10-01..2024-12-31", 92},
+		{"this_week", "2025-03-31..2025-04-06", 7},
+		{"last_year", "2024-01-01..2024-12-31", 366},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			dr, err := ParsePeriod(tt.period, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dr.Key() != tt.want {
+				t.Errorf("Key() = %s, want %s", dr.Key(), tt.want)
+			}
+			if dr.Days() != tt.days {
+				t.Errorf("Days() = %d, want %d", dr.Days(), tt.days)
+			}
+			if dr.Location() != paris {
+				t.Errorf("Location() = %v, want Europe/Paris", dr.Location())
+			}
+		})
+	}
+}
+
+// TestParsePeriod_Invalid vérifie le rejet des périodes inexistantes
+func TestParsePeriod_Invalid(t *testing.T) {
+	for _, period := range []string{"", "25", "2025-13", "2025-Q5", "2025-W53", "2025-W00", "next_month", "2025-1"} {
+		if _, err := ParsePeriod(period, time.Now()); err == nil {
+			t.Errorf("ParsePeriod(%q) should fail", period)
+		}
+	}
+}
+
+// TestNewDateRange_Normalized vérifie que des bornes équivalentes donnent la même clé
+func TestNewDateRange_Normalized(t *testing.T) {
+	a, err := NewDateRange(
+		time.Date(2025, 7, 1, 15, 4, 5, 0, time.UTC),
+		time.Date(2025, 9, 30, 23, 59, 0, 0, time.UTC),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := NewDateRangeForQuarter(2025, 3, time.UTC)
+
+	if a != b {
+		t.Errorf("NewDateRange = %s, quarter = %s: expected equal value objects", a, b)
+	}
+
+	if _, err := NewDateRange(b.End(), b.Start()); err == nil {
+		t.Error("reversed bounds should fail")
+	}
+}
+
+// TestDateRange_Comparisons vérifie les périodes de comparaison (période précédente, N-1)
+func TestDateRange_Comparisons(t *testing.T) {
+	march, _ := NewDateRangeForMonth(2025, time.March, time.UTC)
+	q3, _ := NewDateRangeForQuarter(2025, 3, time.UTC)
+	leapFeb, _ := NewDateRangeForMonth(2024, time.February, time.UTC)
+	days, _ := NewDateRange(time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		got  DateRange
+		want string
+	}{
+		{"march previous", march.Previous(), "2025-02-01..2025-02-28"},
+		{"march last year", march.SamePeriodLastYear(), "2024-03-01..2024-03-31"},
+		{"q3 previous", q3.Previous(), "2025-04-01..2025-06-30"},
+		{"leap february last year", leapFeb.SamePeriodLastYear(), "2023-02-01..2023-02-28"},
+		{"10 days previous", days.Previous(), "2024-02-10..2024-02-19"},
+		{"10 days last year", days.SamePeriodLastYear(), "2023-02-20..2023-02-28"},
+	}
+
+	for _, tt := range tests {
+		if tt.got.Key() != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, tt.got.Key(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package domain
+
+import "errors"
+
+// ErrorKind catégorie d'une erreur du domaine, indépendante du transport
+// La couche HTTP (api/problem) en déduit le status code:
+//   - validation: donnée d'entrée invalide (400)
+//   - not_found: ressource inconnue (404)
+//   - conflict: opération incompatible avec l'état courant (409)
+//   - unavailable: dépendance indisponible, réessayer plus tard (503)
+//
+// Une erreur non typée (SQL, I/O, invariant interne) reste une erreur technique (500)
+type ErrorKind string
+
+const (
+	ErrorKindValidation  ErrorKind = "validation"
+	ErrorKindNotFound    ErrorKind = "not_found"
+	ErrorKindConflict    ErrorKind = "conflict"
+	ErrorKindUnavailable ErrorKind = "unavailable"
+)
+
+// Error erreur typée du domaine
+// DESIGN PATTERN: Value Object, comme les autres types de shared/domain
+//   - kind: catégorie (status HTTP)
+//   - code: identifiant stable pour les clients ("invalid_period"), le message peut évoluer
+//   - message: explication lisible, destinée à l'appelant
+//   - cause: erreur technique d'origine (unavailable uniquement), jamais exposée au client
+//
+// SYNTAXE: les constructeurs retournent error et non *Error
+//   - PIÈGE: un *Error nil affecté à une variable error donne une interface non nil (err != nil)
+//   - Récupérer l'erreur typée avec errors.As (ou AsError), même enveloppée par fmt.Errorf("...: %w", err)
+type Error struct {
+	kind    ErrorKind
+	code    string
+	message string
+	cause   error
+}
+
+// NewValidationError donnée d'entrée invalide (jours négatifs, période inconnue, devise invalide, ...)
+func NewValidationError(code, message string) error {
+	return &Error{kind: ErrorKindValidation, code: code, message: message}
+}
+
+// NewNotFoundError ressource inconnue (client, job d'export, ...)
+func NewNotFoundError(code, message string) error {
+	return &Error{kind: ErrorKindNotFound, code: code, message: message}
+}
+
+// NewConflictError opération refusée dans l'état courant (téléchargement d'un export non terminé, ...)
+func NewConflictError(code, message string) error {
+	return &Error{kind: ErrorKindConflict, code: code, message: message}
+}
+
+// NewUnavailableError dépendance indisponible (base de données injoignable, ...)
+// cause est conservée pour les logs (Error, errors.Is) mais pas dans Message
+func NewUnavailableError(code, message string, cause error) error {
+	return &Error{kind: ErrorKindUnavailable, code: code, message: message, cause: cause}
+}
+
+// Kind retourne la catégorie de l'erreur
+func (e *Error) Kind() ErrorKind {
+	return e.kind
+}
+
+// Code retourne l'identifiant stable de l'erreur
+func (e *Error) Code() string {
+	return e.code
+}
+
+// Message retourne l'explication destinée à l'appelant (sans la cause technique)
+func (e *Error) Message() string {
+	return e.message
+}
+
+// Error implémente l'interface error (message et cause technique éventuelle)
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.message + ": " + e.cause.Error()
+	}
+	return e.message
+}
+
+// Unwrap expose la cause à errors.Is / errors.As (context.DeadlineExceeded, ...)
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// AsError retourne l'erreur typée du domaine contenue dans err (false si err n'est pas typée)
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
+// IsKind indique si err contient une erreur du domaine de catégorie kind
+func IsKind(err error, kind ErrorKind) bool {
+	domainErr, ok := AsError(err)
+	return ok && domainErr.kind == kind
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestError_Kinds vérifie catégorie, code et message de chaque constructeur
+func TestError_Kinds(t *testing.T) {
+	tests := []struct {
+		err  error
+		kind ErrorKind
+		code string
+	}{
+		{NewValidationError("invalid_days", "days cannot be negative"), ErrorKindValidation, "invalid_days"},
+		{NewNotFoundError("customer_not_found", "customer 42 not found"), ErrorKindNotFound, "customer_not_found"},
+		{NewConflictError("export_job_not_ready", "export job is not finished"), ErrorKindConflict, "export_job_not_ready"},
+		{NewUnavailableError("database_unavailable", "database is unreachable", nil), ErrorKindUnavailable, "database_unavailable"},
+	}
+
+	for _, tt := range tests {
+		domainErr, ok := AsError(tt.err)
+		if !ok {
+			t.Fatalf("AsError(%v) = false, want a domain error", tt.err)
+		}
+		if domainErr.Kind() != tt.kind || domainErr.Code() != tt.code {
+			t.Errorf("%v: kind = %s, code = %s, want %s, %s", tt.err, domainErr.Kind(), domainErr.Code(), tt.kind, tt.code)
+		}
+		if !IsKind(tt.err, tt.kind) {
+			t.Errorf("IsKind(%v, %s) = false", tt.err, tt.kind)
+		}
+	}
+}
+
+// TestError_Wrapped vérifie que l'erreur typée est retrouvée à travers fmt.Errorf("%w")
+func TestError_Wrapped(t *testing.T) {
+	sentinel := NewNotFoundError("job_not_found", "job not found")
+	wrapped := fmt.Errorf("loading job: %w", sentinel)
+
+	if !errors.Is(wrapped, sentinel) || !IsKind(wrapped, ErrorKindNotFound) {
+		t.Errorf("%v: the wrapped domain error should be found", wrapped)
+	}
+	if IsKind(wrapped, ErrorKindConflict) {
+		t.Error("IsKind(not_found, conflict) = true")
+	}
+	if _, ok := AsError(errors.New("sql: connection refused")); ok {
+		t.Error("an untyped error should not be a domain error")
+	}
+}
+
+// TestError_UnavailableCause vérifie que la cause reste accessible sans faire partie du message
+func TestError_UnavailableCause(t *testing.T) {
+	err := NewUnavailableError("database_unavailable", "database is unreachable", context.DeadlineExceeded)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("errors.Is(err, context.DeadlineExceeded) = false, want the cause to be unwrapped")
+	}
+	if got := err.Error(); got != "database is unreachable: context deadline exceeded" {
+		t.Errorf("Error() = %q", got)
+	}
+	if domainErr, _ := AsError(err); domainErr.Message() != "database is unreachable" {
+		t.Errorf("Message() = %q, want the message without the cause", domainErr.Message())
+	}
+}
+
+// TestNewDateRangeFromDays_ValidationError vérifie que des jours négatifs sont une erreur de validation
+func TestNewDateRangeFromDays_ValidationError(t *testing.T) {
+	_, err := NewDateRangeFromDays(-1)
+	domainErr, ok := AsError(err)
+	if !ok || domainErr.Kind() != ErrorKindValidation || domainErr.Code() != "invalid_days" {
+		t.Errorf("NewDateRangeFromDays(-1) error = %v, want a validation error invalid_days", err)
+	}
+}
@@ -1,33 +1,238 @@
 package domain
 
 import (
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
 )
 
+// ========================================
+// MONEY: MONTANTS EXACTS EN UNITÉS MINEURES
+// ========================================
+// Le montant est stocké en entier d'unités mineures (centimes pour l'euro), jamais en float64:
+//   - 0.1 + 0.2 != 0.3 en float64: les sous-totaux et les totaux de commande dérivaient au centime
+//   - Les colonnes NUMERIC(12, 2) sont exactes: une somme de Money retombe exactement sur SUM(...)
+//
+// Les conversions depuis un float64 (AVG SQL, ratios, prévisions) passent par un arrondi explicite
+// (RoundingMode). Les additions, multiplications par un entier et répartitions sont exactes.
+//
+// Montant maximum: 2^63 - 1 unités mineures (~92 millions de milliards d'euros)
+
+// DefaultCurrency devise utilisée quand aucune n'est précisée (Scan depuis la base)
+const DefaultCurrency = "EUR"
+
+// RoundingMode règle d'arrondi à l'unité mineure
+type RoundingMode int
+
+const (
+	// RoundHalfUp arrondi commercial: 0.005 → 0.01 (défaut)
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven arrondi bancaire: 0.005 → 0.00, 0.015 → 0.02 (pas de biais sur de grandes sommes)
+	RoundHalfEven
+	// RoundDown troncature: 0.019 → 0.01
+	RoundDown
+	// RoundUp arrondi supérieur: 0.011 → 0.02
+	RoundUp
+)
+
+// minorUnitDigits nombre de décimales de la devise (ISO 4217)
+func minorUnitDigits(currency string) int {
+	switch currency {
+	case "JPY", "KRW":
+		return 0
+	default:
+		return 2
+	}
+}
+
 // Money représente une valeur monétaire avec garanties d'invariants
 type Money struct {
-	amount   float64
+	minor    int64
 	currency string
 }
 
 // NewMoney crée une nouvelle instance de Money avec validation
+// amount est arrondi à l'unité mineure (RoundHalfUp), voir NewMoneyRounded
 func NewMoney(amount float64, currency string) (Money, error) {
-	if amount < 0 {
+	return NewMoneyRounded(amount, currency, RoundHalfUp)
+}
+
+// NewMoneyRounded crée un Money depuis un float64 avec une règle d'arrondi explicite
+//
+// PIÈGE: 1.005 vaut 1.00499999999999989... en float64: multiplier par 100 puis arrondir donne 1.00
+// Le float est d'abord converti en sa plus courte écriture décimale ("1.005"),
+// qui est ensuite arrondie exactement: 1.01 en RoundHalfUp
+func NewMoneyRounded(amount float64, currency string, mode RoundingMode) (Money, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return Money{}, fmt.Errorf("invalid amount: %v", amount)
+	}
+	return ParseMoneyRounded(strconv.FormatFloat(amount, 'f', -1, 64), currency, mode)
+}
+
+// NewMoneyFromMinorUnits crée un Money depuis un nombre d'unités mineures (centimes)
+func NewMoneyFromMinorUnits(minor int64, currency string) (Money, error) {
+	if minor < 0 {
 		return Money{}, errors.New("amount cannot be negative")
 	}
 	if currency == "" {
 		return Money{}, errors.New("currency cannot be empty")
 	}
-	return Money{
-		amount:   amount,
-		currency: currency,
-	}, nil
+	return Money{minor: minor, currency: currency}, nil
+}
+
+// ParseMoney lit un montant décimal exact ("1040.00", "12.5")
+// Erreur si le montant a plus de décimales significatives que la devise ("1.005" en EUR)
+func ParseMoney(value, currency string) (Money, error) {
+	return parseMoney(value, currency, nil)
+}
+
+// ParseMoneyRounded lit un montant décimal et arrondit les décimales excédentaires selon mode
+func ParseMoneyRounded(value, currency string, mode RoundingMode) (Money, error) {
+	return parseMoney(value, currency, &mode)
+}
+
+// parseMoney lit un montant décimal dans les unités mineures de currency
+// mode = nil: aucune décimale excédentaire non nulle n'est acceptée
+func parseMoney(value, currency string, mode *RoundingMode) (Money, error) {
+	if currency == "" {
+		return Money{}, errors.New("currency cannot be empty")
+	}
+	minor, err := parseMinorUnits(value, minorUnitDigits(currency), mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{minor: minor, currency: currency}, nil
+}
+
+// parseMinorUnits lit "[+]entier[.fraction]" (format NUMERIC de PostgreSQL)
+// et retourne value × 10^digits, les décimales au-delà de digits étant arrondies selon mode
+func parseMinorUnits(value string, digits int, mode *RoundingMode) (int64, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(value), "+")
+	if strings.HasPrefix(s, "-") {
+		// "-0", "-0.00" restent acceptés: zéro n'a pas de signe
+		if strings.Trim(s[1:], "0.") != "" {
+			return 0, errors.New("amount cannot be negative")
+		}
+		s = s[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(s, ".")
+	if (integer == "" && fraction == "") || !isDigits(integer) || !isDigits(fraction) {
+		return 0, fmt.Errorf("invalid amount: %q", value)
+	}
+
+	kept, excess := fraction, ""
+	if len(fraction) > digits {
+		kept, excess = fraction[:digits], fraction[digits:]
+	}
+	kept += strings.Repeat("0", digits-len(kept))
+
+	minor, ok := new(big.Int).SetString("0"+integer+kept, 10)
+	if !ok {
+		return 0, fmt.Errorf("invalid amount: %q", value)
+	}
+	if strings.Trim(excess, "0") != "" {
+		if mode == nil {
+			return 0, fmt.Errorf("amount %q has more than %d decimals", value, digits)
+		}
+		if roundsUp(*mode, excess, minor.Bit(0) == 1) {
+			minor.Add(minor, big.NewInt(1))
+		}
+	}
+	if !minor.IsInt64() {
+		return 0, fmt.Errorf("amount out of range: %q", value)
+	}
+
+	return minor.Int64(), nil
+}
+
+// isDigits vrai si s ne contient que des chiffres (vide accepté)
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// roundsUp indique si les décimales excédentaires (non nulles) font monter l'unité mineure
+// odd = l'unité mineure conservée est impaire (départage de RoundHalfEven)
+func roundsUp(mode RoundingMode, excess string, odd bool) bool {
+	switch mode {
+	case RoundDown:
+		return false
+	case RoundUp:
+		return true
+	}
+
+	// Comparaison à la moitié: "5", "50", "500"... exactement la moitié
+	half := "5" + strings.Repeat("0", len(excess)-1)
+	switch {
+	case excess > half:
+		return true
+	case excess < half:
+		return false
+	default:
+		return mode == RoundHalfUp || odd
+	}
 }
 
 // Amount retourne le montant
+// PIÈGE: float64 pour l'affichage et les ratios uniquement, les calculs restent sur Money
 func (m Money) Amount() float64 {
-	return m.amount
+	return float64(m.minor) / math.Pow10(minorUnitDigits(m.currency))
+}
+
+// MinorUnits retourne le montant en unités mineures (centimes)
+func (m Money) MinorUnits() int64 {
+	return m.minor
+}
+
+// Scaled retourne le montant en multiples de 10^-scale (entier d'un DECIMAL(p, scale))
+// Exact quand scale >= nombre de décimales de la devise: 1040 JPY → 104000 pour scale = 2
+// PIÈGE: tronqué vers zéro sinon (scale = 0 pour des euros perd les centimes)
+func (m Money) Scaled(scale int) int64 {
+	digits := minorUnitDigits(m.currency)
+	minor := m.minor
+	for ; digits < scale; digits++ {
+		minor *= 10
+	}
+	for ; digits > scale; digits-- {
+		minor /= 10
+	}
+	return minor
+}
+
+// Currency retourne le code ISO 4217 de la devise
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// Decimal retourne le montant exact en écriture décimale ("1040.00"), format des exports CSV
+func (m Money) Decimal() string {
+	return formatScaled(m.minor, minorUnitDigits(m.currency))
+}
+
+// formatScaled écrit value / 10^digits en décimal exact (montants et taux de change)
+func formatScaled(value int64, digits int) string {
+	s := strconv.FormatInt(value, 10)
+	if digits == 0 {
+		return s
+	}
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+	return s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
+
+// String retourne le montant et sa devise ("1040.00 EUR")
+func (m Money) String() string {
+	return m.Decimal() + " " + m.currency
 }
 
 // Add additionne deux Money (même devise requise)
@@ -35,24 +240,168 @@ func (m Money) Add(other Money) (Money, error) {
 	if m.currency != other.currency {
 		return Money{}, fmt.Errorf("cannot add different currencies: %s and %s", m.currency, other.currency)
 	}
+	if other.minor > math.MaxInt64-m.minor {
+		return Money{}, errors.New("amount out of range")
+	}
 	return Money{
-		amount:   m.amount + other.amount,
+		minor:    m.minor + other.minor,
 		currency: m.currency,
 	}, nil
 }
 
-// Multiply multiplie le montant par un facteur
+// Subtract soustrait other (même devise, résultat positif ou nul requis)
+func (m Money) Subtract(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, fmt.Errorf("cannot subtract different currencies: %s and %s", m.currency, other.currency)
+	}
+	if other.minor > m.minor {
+		return Money{}, errors.New("amount cannot be negative")
+	}
+	return Money{
+		minor:    m.minor - other.minor,
+		currency: m.currency,
+	}, nil
+}
+
+// Multiply multiplie le montant par un facteur (arrondi RoundHalfUp)
 func (m Money) Multiply(factor float64) (Money, error) {
+	return m.MultiplyRounded(factor, RoundHalfUp)
+}
+
+// MultiplyRounded multiplie le montant par un facteur avec une règle d'arrondi explicite
+// Pour une quantité entière, Times est exact
+func (m Money) MultiplyRounded(factor float64, mode RoundingMode) (Money, error) {
 	if factor < 0 {
 		return Money{}, errors.New("multiplication factor cannot be negative")
 	}
+	if math.IsNaN(factor) || math.IsInf(factor, 0) {
+		return Money{}, fmt.Errorf("invalid multiplication factor: %v", factor)
+	}
+
+	// Même principe que NewMoneyRounded, appliqué aux unités mineures: arrondi à 0 décimale
+	product, err := parseMinorUnits(strconv.FormatFloat(float64(m.minor)*factor, 'f', -1, 64), 0, &mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{
+		minor:    product,
+		currency: m.currency,
+	}, nil
+}
+
+// Times multiplie le montant par une quantité entière (exact)
+func (m Money) Times(quantity int) (Money, error) {
+	if quantity < 0 {
+		return Money{}, errors.New("quantity cannot be negative")
+	}
+	if quantity > 0 && m.minor > math.MaxInt64/int64(quantity) {
+		return Money{}, errors.New("amount out of range")
+	}
 	return Money{
-		amount:   m.amount * factor,
+		minor:    m.minor * int64(quantity),
 		currency: m.currency,
 	}, nil
 }
 
+// Allocate répartit le montant selon des poids entiers, sans perdre ni créer de centime
+// Les unités mineures restantes après la division sont données une à une aux premières parts:
+// 100.00 réparti 1:1:1 → 33.34, 33.33, 33.33 (la somme vaut toujours exactement le montant)
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, errors.New("at least one ratio required")
+	}
+	var total int64
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, errors.New("ratios cannot be negative")
+		}
+		total += int64(r)
+	}
+	if total == 0 {
+		return nil, errors.New("sum of ratios must be positive")
+	}
+
+	// big.Int: minor × ratio peut dépasser int64 avant la division
+	amount := big.NewInt(m.minor)
+	parts := make([]Money, len(ratios))
+	remainder := m.minor
+	for i, r := range ratios {
+		share := new(big.Int).Mul(amount, big.NewInt(int64(r)))
+		share.Quo(share, big.NewInt(total))
+		parts[i] = Money{minor: share.Int64(), currency: m.currency}
+		remainder -= share.Int64()
+	}
+	for i := 0; remainder > 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].minor++
+		remainder--
+	}
+
+	return parts, nil
+}
+
+// Split répartit le montant en n parts égales (à une unité mineure près), voir Allocate
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, errors.New("number of parts must be positive")
+	}
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
 // IsZero vérifie si le montant est zéro
 func (m Money) IsZero() bool {
-	return m.amount == 0
+	return m.minor == 0
+}
+
+// Equals compare montant et devise
+func (m Money) Equals(other Money) bool {
+	return m.minor == other.minor && m.currency == other.currency
+}
+
+// Scan implémente sql.Scanner: lecture directe d'une colonne NUMERIC (texte exact, sans passer par float64)
+// La devise est celle déjà présente dans m, DefaultCurrency sinon
+// Les décimales excédentaires (AVG, divisions SQL) sont arrondies en RoundHalfUp
+//
+// PIÈGE: NULL est refusé (un montant absent n'est pas zéro): COALESCE(..., 0) dans la requête
+func (m *Money) Scan(src interface{}) error {
+	currency := m.currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	var (
+		scanned Money
+		err     error
+	)
+	switch v := src.(type) {
+	case []byte:
+		scanned, err = ParseMoneyRounded(string(v), currency, RoundHalfUp)
+	case string:
+		scanned, err = ParseMoneyRounded(v, currency, RoundHalfUp)
+	case int64:
+		scanned, err = ParseMoney(strconv.FormatInt(v, 10), currency)
+	case float64:
+		scanned, err = NewMoney(v, currency)
+	case nil:
+		return errors.New("cannot scan NULL into Money")
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+	if err != nil {
+		return err
+	}
+
+	*m = scanned
+	return nil
+}
+
+// Value implémente driver.Valuer: le montant est transmis en texte décimal exact ("1040.00")
+func (m Money) Value() (driver.Value, error) {
+	return m.Decimal(), nil
 }
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"testing"
+)
+
+// TestMoney_ExactArithmetic vérifie que les additions ne dérivent pas (0.1 + 0.2 == 0.3)
+func TestMoney_ExactArithmetic(t *testing.T) {
+	a, _ := NewMoney(0.1, "EUR")
+	b, _ := NewMoney(0.2, "EUR")
+	want, _ := ParseMoney("0.30", "EUR")
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sum.Equals(want) || sum.Decimal() != "0.30" {
+		t.Errorf("0.1 + 0.2 = %s, want 0.30", sum.Decimal())
+	}
+
+	// 3 × 19.99 = 59.97 exactement (59.970000000000006 en float64)
+	price, _ := ParseMoney("19.99", "EUR")
+	subtotal, _ := price.Times(3)
+	if subtotal.MinorUnits() != 5997 {
+		t.Errorf("3 × 19.99 = %s, want 59.97", subtotal.Decimal())
+	}
+
+	if _, err := a.Subtract(b); err == nil {
+		t.Error("0.1 - 0.2 should fail (negative amount)")
+	}
+	usd, _ := NewMoney(1, "USD")
+	if _, err := a.Add(usd); err == nil {
+		t.Error("EUR + USD should fail")
+	}
+}
+
+// TestMoney_RoundingModes vérifie les règles d'arrondi sur les demi-centimes
+func TestMoney_RoundingModes(t *testing.T) {
+	tests := []struct {
+		value string
+		mode  RoundingMode
+		want  string
+	}{
+		{"1.005", RoundHalfUp, "1.01"},
+		{"1.005", RoundHalfEven, "1.00"},
+		{"1.015", RoundHalfEven, "1.02"},
+		{"1.0051", RoundHalfEven, "1.01"},
+		{"1.019", RoundDown, "1.01"},
+		{"1.011", RoundUp, "1.02"},
+		{"1.0100", RoundUp, "1.01"},
+		{"0.999", RoundHalfUp, "1.00"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoneyRounded(tt.value, "EUR", tt.mode)
+		if err != nil {
+			t.Errorf("ParseMoneyRounded(%q): %v", tt.value, err)
+			continue
+		}
+		if got.Decimal() != tt.want {
+			t.Errorf("ParseMoneyRounded(%q, %d) = %s, want %s", tt.value, tt.mode, got.Decimal(), tt.want)
+		}
+	}
+
+	// Le float64 1.005 est légèrement inférieur à 1.005: l'arrondi se fait sur son écriture décimale
+	if m, _ := NewMoney(1.005, "EUR"); m.Decimal() != "1.01" {
+		t.Errorf("NewMoney(1.005) = %s, want 1.01", m.Decimal())
+	}
+	if _, err := ParseMoney("1.005", "EUR"); err == nil {
+		t.Error("ParseMoney(1.005) should fail: more decimals than EUR")
+	}
+	if m, _ := NewMoney(1234.5, "JPY"); m.Decimal() != "1235" {
+		t.Errorf("NewMoney(1234.5 JPY) = %s, want 1235", m.Decimal())
+	}
+
+	price, _ := ParseMoney("10.00", "EUR")
+	if discounted, _ := price.MultiplyRounded(0.333, RoundDown); discounted.Decimal() != "3.33" {
+		t.Errorf("10.00 × 0.333 (down) = %s, want 3.33", discounted.Decimal())
+	}
+}
+
+// TestMoney_Allocate vérifie qu'une répartition conserve exactement le montant
+func TestMoney_Allocate(t *testing.T) {
+	total, _ := ParseMoney("100.00", "EUR")
+
+	parts, err := total.Split(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"33.34", "33.33", "33.33"}
+	for i, p := range parts {
+		if p.Decimal() != want[i] {
+			t.Errorf("part %d = %s, want %s", i, p.Decimal(), want[i])
+		}
+	}
+
+	// 0.05 en 30% / 70% / 0%: la part à poids nul ne reçoit aucun centime de reste
+	small, _ := ParseMoney("0.05", "EUR")
+	parts, err = small.Allocate(3, 7, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum int64
+	for _, p := range parts {
+		sum += p.MinorUnits()
+	}
+	if sum != 5 || parts[2].MinorUnits() != 0 {
+		t.Errorf("allocation = %v, want 5 cents total and 0 for the last part", parts)
+	}
+
+	if _, err := total.Allocate(0, 0); err == nil {
+		t.Error("allocation with zero ratios should fail")
+	}
+}
+
+// TestMoney_Scan vérifie la lecture directe d'une colonne NUMERIC
+func TestMoney_Scan(t *testing.T) {
+	var m Money
+	if err := m.Scan([]byte("1040.00")); err != nil {
+		t.Fatal(err)
+	}
+	if m.MinorUnits() != 104000 || m.Currency() != DefaultCurrency {
+		t.Errorf("Scan(1040.00) = %s, want 1040.00 EUR", m)
+	}
+
+	// AVG(numeric) renvoie 16 décimales: arrondi au centime
+	if err := m.Scan([]byte("535.3333333333333333")); err != nil || m.Decimal() != "535.33" {
+		t.Errorf("Scan(AVG) = %s (err %v), want 535.33", m.Decimal(), err)
+	}
+	if err := m.Scan(nil); err == nil {
+		t.Error("Scan(NULL) should fail")
+	}
+	if err := m.Scan([]byte("-1.00")); err == nil {
+		t.Error("Scan(-1.00) should fail")
+	}
+
+	value, _ := m.Value()
+	if value != "535.33" {
+		t.Errorf("Value() = %v, want 535.33", value)
+	}
+}
+
+// TestMoney_Scaled vérifie la mise à l'échelle d'un DECIMAL(p, 2) quelle que soit la devise
+func TestMoney_Scaled(t *testing.T) {
+	tests := []struct {
+		amount, currency string
+		scale            int
+		want             int64
+	}{
+		{"99.99", "EUR", 2, 9999},
+		{"1040", "JPY", 2, 104000},
+		{"99.99", "EUR", 4, 999900},
+		{"99.99", "EUR", 0, 99},
+	}
+
+	for _, tt := range tests {
+		m, err := ParseMoney(tt.amount, tt.currency)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := m.Scaled(tt.scale); got != tt.want {
+			t.Errorf("%s.Scaled(%d) = %d, want %d", m, tt.scale, got, tt.want)
+		}
+	}
+}
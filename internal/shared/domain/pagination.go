@@ -0,0 +1,58 @@
+package domain
+
+import "fmt"
+
+// MaxPageSize taille de page maximale acceptée (borne la taille des réponses et du cache)
+const MaxPageSize = 500
+
+// Pagination représente une page demandée d'un classement (Value Object)
+// page commence à 1, comme dans les paramètres d'URL (?page=1&page_size=20)
+type Pagination struct {
+	page     int
+	pageSize int
+}
+
+// NewPagination crée une pagination avec validation des bornes
+func NewPagination(page, pageSize int) (Pagination, error) {
+	if page < 1 {
+		return Pagination{}, NewValidationError("invalid_pagination", fmt.Sprintf("page must be >= 1, got %d", page))
+	}
+	if pageSize < 1 || pageSize > MaxPageSize {
+		return Pagination{}, NewValidationError("invalid_pagination",
+			fmt.Sprintf("page size must be between 1 and %d, got %d", MaxPageSize, pageSize))
+	}
+	return Pagination{page: page, pageSize: pageSize}, nil
+}
+
+// Page retourne le numéro de page (1 = première page)
+func (p Pagination) Page() int {
+	return p.page
+}
+
+// PageSize retourne le nombre d'éléments par page
+func (p Pagination) PageSize() int {
+	return p.pageSize
+}
+
+// Offset retourne le nombre d'éléments à sauter (SQL OFFSET)
+func (p Pagination) Offset() int {
+	return (p.page - 1) * p.pageSize
+}
+
+// Limit retourne le nombre d'éléments de la page (SQL LIMIT)
+func (p Pagination) Limit() int {
+	return p.pageSize
+}
+
+// TotalPages retourne le nombre de pages nécessaires pour total éléments
+func (p Pagination) TotalPages(total int) int {
+	if total <= 0 {
+		return 0
+	}
+	return (total + p.pageSize - 1) / p.pageSize
+}
+
+// Key retourne une représentation stable pour les clés de cache
+func (p Pagination) Key() string {
+	return fmt.Sprintf("page=%d,size=%d", p.page, p.pageSize)
+}
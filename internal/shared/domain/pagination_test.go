@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+// TestPagination vérifie le calcul LIMIT/OFFSET et du nombre de pages
+func TestPagination(t *testing.T) {
+	p, err := NewPagination(3, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Offset() != 40 || p.Limit() != 20 {
+		t.Errorf("page 3 = OFFSET %d LIMIT %d, want OFFSET 40 LIMIT 20", p.Offset(), p.Limit())
+	}
+
+	for total, want := range map[int]int{0: 0, 1: 1, 20: 1, 21: 2, 100: 5} {
+		if got := p.TotalPages(total); got != want {
+			t.Errorf("TotalPages(%d) = %d, want %d", total, got, want)
+		}
+	}
+
+	for _, invalid := range [][2]int{{0, 20}, {1, 0}, {1, MaxPageSize + 1}} {
+		if _, err := NewPagination(invalid[0], invalid[1]); err == nil {
+			t.Errorf("NewPagination(%d, %d) should fail", invalid[0], invalid[1])
+		}
+	}
+}
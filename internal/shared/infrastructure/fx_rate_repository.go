@@ -0,0 +1,289 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"eval/internal/shared/domain"
+)
+
+// fxRatesCSVHeader colonnes attendues du fichier de taux (dans cet ordre)
+var fxRatesCSVHeader = []string{"currency", "rate_date", "rate_to_eur"}
+
+// ReadExchangeRatesCSV lit un fichier de taux au format:
+//
+//	currency,rate_date,rate_to_eur
+//	USD,2024-01-02,0.91240000
+//
+// Toutes les lignes sont validées avant chargement: une seule ligne invalide rejette le fichier
+// (l'erreur indique le numéro de ligne)
+func ReadExchangeRatesCSV(r io.Reader) ([]domain.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(fxRatesCSVHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("empty fx rates file")
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range fxRatesCSVHeader {
+		if strings.ToLower(strings.TrimSpace(header[i])) != name {
+			return nil, fmt.Errorf("invalid fx rates header: expected %s", strings.Join(fxRatesCSVHeader, ","))
+		}
+	}
+
+	var rates []domain.ExchangeRate
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		currency, err := domain.ParseCurrency(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid rate_date %q (expected YYYY-MM-DD)", line, record[1])
+		}
+		rate, err := domain.NewExchangeRate(currency, date, record[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+// FXRateRepository repository d'écriture de la table fx_rates
+// La lecture des taux se fait en SQL (ExchangeRateJoinSQL / ConvertedAmountSQL),
+// au plus près des agrégations
+type FXRateRepository struct {
+	BaseRepository
+}
+
+// NewFXRateRepository crée un nouveau repository des taux de change
+func NewFXRateRepository(db *sql.DB) *FXRateRepository {
+	return &FXRateRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// SaveExchangeRates insère ou met à jour les taux (clé: devise + date) et retourne le nombre de lignes écrites
+//
+// PERFORMANCE: une seule requête quel que soit le nombre de taux
+//   - unnest($1, $2, $3) transforme les 3 tableaux en lignes côté PostgreSQL
+//   - Vs une requête par taux: 1 round-trip au lieu de N (plusieurs années de cotations = milliers de lignes)
+//
+// ATOMICITÉ: une requête = une transaction, le fichier est chargé entièrement ou pas du tout
+func (r *FXRateRepository) SaveExchangeRates(rates []domain.ExchangeRate) (int64, error) {
+	if len(rates) == 0 {
+		return 0, nil
+	}
+
+	currencies := make([]string, len(rates))
+	dates := make([]string, len(rates))
+	values := make([]string, len(rates))
+	for i, rate := range rates {
+		currencies[i] = rate.Currency().String()
+		dates[i] = rate.Date().Format("2006-01-02")
+		values[i] = rate.Rate()
+	}
+
+	query := `
+		INSERT INTO fx_rates (currency, rate_date, rate_to_eur)
+		SELECT * FROM unnest($1::char(3)[], $2::date[], $3::numeric[])
+		ON CONFLICT (currency, rate_date) DO UPDATE SET rate_to_eur = EXCLUDED.rate_to_eur
+	`
+
+	result, err := r.Exec(query, pq.Array(currencies), pq.Array(dates), pq.Array(values))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ========================================
+// CONVERSION DES MONTANTS EN SQL
+// ========================================
+// Le taux de chaque commande est résolu par deux jointures LATERAL sur fx_rates
+// (ExchangeRateJoinSQL), le montant converti est une expression sur leurs colonnes (ConvertedAmountSQL):
+//
+//	SELECT SUM(` + ConvertedAmountSQL("o.total_amount", "o", 3) + `)
+//	FROM orders o` + ExchangeRateJoinSQL("o", 3) + `
+//	WHERE ...
+//
+// PERFORMANCE: chaque LATERAL est un ORDER BY rate_date DESC LIMIT 1 servi par la clé primaire
+// (currency, rate_date): un accès d'index par commande, planifié avec la requête
+//   - Vs une fonction plpgsql appelée dans SUM: ni appel de fonction ni plan interne par ligne
+//   - Commande déjà dans la devise de reporting (tout en euros): la condition "o.currency <> devise"
+//     ne dépend que de la commande, PostgreSQL en fait un filtre préalable (One-Time Filter)
+//     et n'accède pas du tout à l'index
+//
+// PIÈGE: un taux manquant donne NULL, que SUM ignorerait en silence
+//   - Les repositories appellent CheckExchangeRates avant d'agréger: un taux manquant est une
+//     erreur du domaine (fx_rate_missing, 409), pas une exception SQL transformée en 500
+
+// ExchangeRateJoinSQL jointures LATERAL des taux de la commande (alias orders) utilisés par ConvertedAmountSQL
+//   - <orders>_fx_from: taux de la devise de la commande vers l'euro à la date de la commande
+//   - <orders>_fx_to: taux de la devise de reporting ($currencyParam) vers l'euro à la même date
+//
+// À placer dans le FROM, après la table orders (alias orders)
+func ExchangeRateJoinSQL(orders string, currencyParam int) string {
+	return exchangeRateJoinSQL(orders, fmt.Sprintf("$%d::char(3)", currencyParam))
+}
+
+// ConvertedAmountSQL expression SQL d'un montant converti dans la devise de reporting
+//   - amount: colonne NUMERIC du montant (o.total_amount, oi.subtotal)
+//   - orders: alias de la table orders, qui porte la devise et la date de la commande
+//   - currencyParam: numéro du paramètre contenant la devise de reporting
+//
+// La requête doit joindre ExchangeRateJoinSQL(orders, currencyParam)
+// Un montant NULL (LEFT JOIN sans commande) reste NULL
+//
+// SÉCURITÉ: amount et orders sont des identifiants constants écrits dans le code, la devise passe par un paramètre
+func ConvertedAmountSQL(amount, orders string, currencyParam int) string {
+	return convertedAmountSQL(amount, orders, fmt.Sprintf("$%d::char(3)", currencyParam))
+}
+
+// DefaultCurrencyJoinSQL jointures des taux utilisés par DefaultCurrencyAmountSQL
+// La devise cible est une constante: la jointure du taux cible est éliminée par le planner
+func DefaultCurrencyJoinSQL(orders string) string {
+	return exchangeRateJoinSQL(orders, defaultCurrencySQL)
+}
+
+// DefaultCurrencyAmountSQL expression SQL d'un montant converti dans la devise pivot (domain.DefaultCurrency)
+// Pour les agrégats sans devise de reporting (analyses clients): la somme reste cohérente
+// quand un client commande dans des magasins de devises différentes
+// La requête doit joindre DefaultCurrencyJoinSQL(orders)
+func DefaultCurrencyAmountSQL(amount, orders string) string {
+	return convertedAmountSQL(amount, orders, defaultCurrencySQL)
+}
+
+// defaultCurrencySQL devise pivot en littéral SQL
+const defaultCurrencySQL = "'" + domain.DefaultCurrency + "'::char(3)"
+
+// exchangeRateJoinSQL jointures des taux de la commande et de la devise target (expression SQL)
+// SYNTAXE SQL: LEFT JOIN LATERAL ... ON TRUE garde la commande sans taux (colonne NULL)
+//   - la sous-requête LATERAL peut référencer les colonnes de orders (o.currency, o.order_date)
+//   - pas de ligne EUR dans fx_rates: le taux de l'euro (1) est appliqué par convertedAmountSQL
+func exchangeRateJoinSQL(orders, target string) string {
+	return fmt.Sprintf(`
+		LEFT JOIN LATERAL (
+			SELECT r.rate_to_eur FROM fx_rates r
+			WHERE %[1]s.currency <> %[2]s AND %[1]s.currency <> %[3]s
+			  AND r.currency = %[1]s.currency AND r.rate_date <= %[1]s.order_date
+			ORDER BY r.rate_date DESC
+			LIMIT 1
+		) %[1]s_fx_from ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT r.rate_to_eur FROM fx_rates r
+			WHERE %[1]s.currency <> %[2]s AND %[2]s <> %[3]s
+			  AND r.currency = %[2]s AND r.rate_date <= %[1]s.order_date
+			ORDER BY r.rate_date DESC
+			LIMIT 1
+		) %[1]s_fx_to ON TRUE`, orders, target, defaultCurrencySQL)
+}
+
+// convertedAmountSQL montant × taux(devise de la commande) / taux(target), taux(EUR) = 1
+func convertedAmountSQL(amount, orders, target string) string {
+	return fmt.Sprintf(`CASE WHEN %[2]s.currency = %[3]s THEN %[1]s
+		ELSE %[1]s * CASE WHEN %[2]s.currency = %[4]s THEN 1 ELSE %[2]s_fx_from.rate_to_eur END
+		          / CASE WHEN %[3]s = %[4]s THEN 1 ELSE %[2]s_fx_to.rate_to_eur END END`,
+		amount, orders, target, defaultCurrencySQL)
+}
+
+// CheckExchangeRates vérifie que fx_rates permet de convertir dans currency toutes les commandes
+// passées entre start et end (bornes incluses)
+// Retourne domain.NewMissingExchangeRateError pour la première commande sans taux
+//
+// Un taux est connu à partir de la première cotation de la devise (dernier taux connu ensuite):
+// une commande n'est pas convertible si elle précède la première cotation
+//   - de sa devise (devises des commandes de la période, hors euro)
+//   - ou de la devise de reporting, si elle est dans une autre devise
+//
+// PIÈGE: les devises à couvrir sont lues sur orders.currency, pas sur stores.currency:
+// rien ne lie la devise d'une commande à celle de son magasin, et une commande dans une devise
+// qu'aucun magasin n'utilise serait convertie en NULL puis ignorée par SUM (CA sous-estimé)
+//
+// PERFORMANCE: une requête
+//   - devises de la période: un parcours de idx_orders_date sur la période
+//   - puis quelques devises × un parcours limité aux jours qui précèdent la première cotation
+//     (intervalle vide quand les taux couvrent la période)
+//
+// PIÈGE: les filtres de la requête appelante (statut, magasin...) ne sont pas repris:
+// une commande écartée par le filtre mais sans taux bloque aussi la conversion
+func (r *BaseRepository) CheckExchangeRates(currency domain.Currency, start, end time.Time) error {
+	query := `
+		WITH needed AS (
+			SELECT DISTINCT o.currency FROM orders o
+			WHERE o.order_date >= $2 AND o.order_date <= $3 AND o.currency <> ` + defaultCurrencySQL + `
+			UNION
+			SELECT $1::char(3) WHERE $1::char(3) <> ` + defaultCurrencySQL + `
+		),
+		coverage AS (
+			SELECT n.currency,
+			       COALESCE((SELECT MIN(r.rate_date) FROM fx_rates r WHERE r.currency = n.currency),
+			                'infinity'::date) AS covered_from
+			FROM needed n
+		)
+		SELECT c.currency, o.order_date
+		FROM coverage c
+		CROSS JOIN LATERAL (
+			SELECT o.order_date
+			FROM orders o
+			WHERE o.order_date >= $2 AND o.order_date <= $3 AND o.order_date < c.covered_from
+			  AND o.currency <> $1::char(3)
+			  AND (o.currency = c.currency OR c.currency = $1::char(3))
+			ORDER BY o.order_date
+			LIMIT 1
+		) o
+		ORDER BY o.order_date, c.currency
+		LIMIT 1
+	`
+
+	var (
+		missing string
+		date    time.Time
+	)
+	err := r.QueryRow(query, currency.String(), start, end).Scan(&missing, &date)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return domain.NewMissingExchangeRateError(domain.Currency(missing), date)
+}
+
+// CheckExchangeRate vérifie qu'un taux de currency est connu à date (valorisation à une date fixe)
+func (r *BaseRepository) CheckExchangeRate(currency domain.Currency, date time.Time) error {
+	if currency.IsDefault() {
+		return nil
+	}
+
+	var known bool
+	query := `SELECT EXISTS (SELECT 1 FROM fx_rates WHERE currency = $1::char(3) AND rate_date <= $2)`
+	if err := r.QueryRow(query, currency.String(), date).Scan(&known); err != nil {
+		return err
+	}
+	if !known {
+		return domain.NewMissingExchangeRateError(currency, date)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package infrastructure
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestReadExchangeRatesCSV vérifie la lecture et la validation ligne à ligne du fichier de taux
+func TestReadExchangeRatesCSV(t *testing.T) {
+	input := "currency,rate_date,rate_to_eur\n" +
+		"usd,2024-01-02,0.9124\n" +
+		"GBP,2024-01-02,1.15800000\n"
+
+	rates, err := ReadExchangeRatesCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) != 2 {
+		t.Fatalf("got %d rates, want 2", len(rates))
+	}
+	if rates[0].Currency() != "USD" || rates[0].Rate() != "0.91240000" {
+		t.Errorf("first rate = %s %s, want USD 0.91240000", rates[0].Currency(), rates[0].Rate())
+	}
+
+	if _, err := ReadExchangeRatesCSV(strings.NewReader("code,date,rate\nUSD,2024-01-02,0.91\n")); err == nil {
+		t.Error("invalid header should fail")
+	}
+
+	// Une seule ligne invalide rejette le fichier, avec son numéro de ligne
+	_, err = ReadExchangeRatesCSV(strings.NewReader(input + "USD,02/01/2024,0.91\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Errorf("invalid date error = %v, want an error on line 4", err)
+	}
+}
+
+// TestConvertedAmountSQL vérifie que l'expression de conversion n'utilise que les taux
+// joints par ExchangeRateJoinSQL, avec le paramètre de devise demandé
+func TestConvertedAmountSQL(t *testing.T) {
+	amount := ConvertedAmountSQL("oi.subtotal", "o", 4)
+	join := ExchangeRateJoinSQL("o", 4)
+
+	for _, alias := range []string{"o_fx_from.rate_to_eur", "o_fx_to.rate_to_eur"} {
+		if !strings.Contains(amount, alias) {
+			t.Errorf("amount expression does not use %s: %s", alias, amount)
+		}
+	}
+	for _, fragment := range []string{"LEFT JOIN LATERAL", ") o_fx_from ON TRUE", ") o_fx_to ON TRUE", "r.currency = $4::char(3)"} {
+		if !strings.Contains(join, fragment) {
+			t.Errorf("join does not contain %q: %s", fragment, join)
+		}
+	}
+	if strings.Contains(amount+join, "$3") {
+		t.Error("conversion should only reference the currency parameter $4")
+	}
+
+	// Devise pivot: aucun paramètre, même alias de jointure
+	if sql := DefaultCurrencyAmountSQL("o.total_amount", "o") + DefaultCurrencyJoinSQL("o"); strings.Contains(sql, "$") {
+		t.Errorf("default currency conversion should not use parameters: %s", sql)
+	}
+}
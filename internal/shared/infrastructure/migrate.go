@@ -0,0 +1,79 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+// ========================================
+// MIGRATIONS DU SCHÉMA
+// ========================================
+// init.sql n'est exécuté par l'image postgres qu'à la création du volume (docker-entrypoint-initdb.d):
+// une base existante ne reçoit jamais les tables et colonnes ajoutées ensuite.
+// Les migrations complètent ces bases au démarrage de l'application.
+//
+// Règles d'écriture d'une migration (migrations/NNN_description.sql):
+//   - idempotente: ADD COLUMN IF NOT EXISTS, CREATE TABLE IF NOT EXISTS, CREATE OR REPLACE FUNCTION...
+//     toutes les migrations sont rejouées à chaque démarrage, sans table de suivi
+//   - sans effet sur une base créée avec l'init.sql courant (init.sql reste le schéma de référence)
+
+// migrationsLockID clé du verrou consultatif qui sérialise les migrations
+// PIÈGE: deux instances démarrées en même temps feraient le même ALTER TABLE en parallèle
+const migrationsLockID = 20240101
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrate applique les migrations dans l'ordre de leur nom (001_, 002_, ...)
+// ATOMICITÉ: une transaction par fichier, une migration est appliquée entièrement ou pas du tout
+func Migrate(db *sql.DB) error {
+	names, err := migrationNames()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		script, err := migrationFiles.ReadFile("migrations/" + name)
+		if err != nil {
+			return err
+		}
+		if err := applyMigration(db, string(script)); err != nil {
+			return fmt.Errorf("migration %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// migrationNames retourne les fichiers de migration triés par nom
+func migrationNames() ([]string, error) {
+	names, err := fs.Glob(migrationFiles, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range names {
+		names[i] = name[len("migrations/"):]
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// applyMigration exécute un script dans une transaction verrouillée
+// SYNTAXE: sans argument, lib/pq envoie le script en "simple query": plusieurs instructions acceptées
+func applyMigration(db *sql.DB, script string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // sans effet après Commit
+
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", migrationsLockID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(script); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,53 @@
+package infrastructure
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestMigrations_Idempotent vérifie que les migrations embarquées sont triées et rejouables
+// (IF NOT EXISTS / OR REPLACE): elles sont exécutées à chaque démarrage
+func TestMigrations_Idempotent(t *testing.T) {
+	// instruction → forme rejouable attendue ("" = interdite, utiliser CREATE OR REPLACE)
+	ddl := []struct{ statement, idempotent string }{
+		{"CREATE TABLE", "CREATE TABLE IF NOT EXISTS"},
+		{"CREATE INDEX", "CREATE INDEX IF NOT EXISTS"},
+		{"CREATE UNIQUE INDEX", "CREATE UNIQUE INDEX IF NOT EXISTS"},
+		{"ADD COLUMN", "ADD COLUMN IF NOT EXISTS"},
+		{"DROP TABLE", "DROP TABLE IF EXISTS"},
+		{"DROP FUNCTION", "DROP FUNCTION IF EXISTS"},
+		{"DROP INDEX", "DROP INDEX IF EXISTS"},
+		{"CREATE FUNCTION", ""},
+		{"CREATE VIEW", ""},
+	}
+
+	names, err := migrationNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Fatal("no embedded migration")
+	}
+
+	for i, name := range names {
+		if i > 0 && names[i-1] >= name {
+			t.Errorf("migrations not sorted: %s before %s", names[i-1], name)
+		}
+
+		script, err := migrationFiles.ReadFile("migrations/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for lineNo, line := range strings.Split(string(script), "\n") {
+			line = strings.ToUpper(strings.Join(strings.Fields(line), " "))
+			if strings.HasPrefix(line, "--") {
+				continue
+			}
+			for _, d := range ddl {
+				if strings.Contains(line, d.statement) && (d.idempotent == "" || !strings.Contains(line, d.idempotent)) {
+					t.Errorf("%s:%d: not idempotent: %s", name, lineNo+1, line)
+				}
+			}
+		}
+	}
+}
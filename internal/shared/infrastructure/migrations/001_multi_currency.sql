@@ -0,0 +1,22 @@
+-- ============================================================================
+-- 001 - Devises des magasins et des commandes, taux de change (fx_rates)
+-- ============================================================================
+-- Base créée avant le multi-devises: init.sql n'est exécuté qu'à la création du volume
+-- postgres_data (docker-entrypoint-initdb.d), ces objets y manquent.
+-- Idempotent: sans effet sur une base créée avec l'init.sql courant.
+
+-- Les lignes existantes prennent la devise par défaut (historique en euros)
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'EUR';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'EUR';
+
+CREATE TABLE IF NOT EXISTS fx_rates (
+    currency CHAR(3) NOT NULL CHECK (currency <> 'EUR'),
+    rate_date DATE NOT NULL,
+    rate_to_eur NUMERIC(18, 8) NOT NULL CHECK (rate_to_eur > 0),
+    PRIMARY KEY (currency, rate_date)
+);
+
+-- Fonctions de conversion des premières versions multi-devises: les taux sont désormais
+-- résolus par jointure (ExchangeRateJoinSQL), plus aucune requête ne les appelle
+DROP FUNCTION IF EXISTS fx_convert(NUMERIC, CHAR(3), CHAR(3), DATE);
+DROP FUNCTION IF EXISTS fx_rate(CHAR(3), DATE);
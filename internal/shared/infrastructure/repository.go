@@ -3,6 +3,8 @@ package infrastructure
 import (
 	"context"
 	"database/sql"
+	"strconv"
+	"strings"
 )
 
 // QueryRepository interface de base pour les opérations de lecture
@@ -77,10 +79,95 @@ func (uow *DBUnitOfWork) Execute(fn func(tx *sql.Tx) error) error {
 }
 
 // Specification pattern pour les requêtes complexes
+// ToSQL retourne un prédicat SQL utilisant "?" comme placeholder et ses arguments
+//   - "?" plutôt que $1: une spécification ne connaît pas sa position dans la requête finale
+//   - BindSpecification numérote les placeholders au moment de l'assemblage
+//
+// SÉCURITÉ: le fragment SQL est toujours du texte constant écrit dans le code,
+// les valeurs utilisateur passent exclusivement par les arguments (pas d'injection SQL)
 type Specification interface {
 	ToSQL() (string, []interface{})
 }
 
+// SQLSpecification spécification élémentaire: un prédicat et ses arguments
+type SQLSpecification struct {
+	predicate string
+	args      []interface{}
+}
+
+// NewSQLSpecification crée une spécification à partir d'un prédicat constant
+// Exemple: NewSQLSpecification("o.store_id = ?", storeID)
+func NewSQLSpecification(predicate string, args ...interface{}) SQLSpecification {
+	return SQLSpecification{predicate: predicate, args: args}
+}
+
+// ToSQL implémente Specification
+func (s SQLSpecification) ToSQL() (string, []interface{}) {
+	return s.predicate, s.args
+}
+
+// AndSpecification combine plusieurs spécifications avec AND
+type AndSpecification struct {
+	specs []Specification
+}
+
+// And combine des spécifications (une combinaison vide est toujours vraie)
+func And(specs ...Specification) AndSpecification {
+	return AndSpecification{specs: specs}
+}
+
+// ToSQL implémente Specification: "(a) AND (b)", ou "TRUE" si aucune spécification
+func (s AndSpecification) ToSQL() (string, []interface{}) {
+	var sb strings.Builder
+	var args []interface{}
+
+	for _, spec := range s.specs {
+		predicate, specArgs := spec.ToSQL()
+		if predicate == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString(" AND ")
+		}
+		sb.WriteByte('(')
+		sb.WriteString(predicate)
+		sb.WriteByte(')')
+		args = append(args, specArgs...)
+	}
+
+	if sb.Len() == 0 {
+		return "TRUE", nil
+	}
+	return sb.String(), args
+}
+
+// BindSpecification convertit les placeholders "?" en paramètres PostgreSQL numérotés
+// firstParam = numéro du premier paramètre libre ($3 si la requête utilise déjà $1 et $2)
+//
+// Exemple: BindSpecification(And(storeSpec, paymentSpec), 3) retourne
+// "(o.store_id = $3) AND (o.payment_method_id = $4)" et [storeID, paymentMethodID]
+func BindSpecification(spec Specification, firstParam int) (string, []interface{}) {
+	predicate, args := spec.ToSQL()
+	if predicate == "" {
+		return "TRUE", nil
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(predicate) + 2*len(args))
+
+	param := firstParam
+	for i := 0; i < len(predicate); i++ {
+		if predicate[i] == '?' {
+			sb.WriteByte('$')
+			sb.WriteString(strconv.Itoa(param))
+			param++
+			continue
+		}
+		sb.WriteByte(predicate[i])
+	}
+	return sb.String(), args
+}
+
 // BaseRepository structure de base pour les repositories
 type BaseRepository struct {
 	db  *sql.DB
@@ -118,6 +205,13 @@ func (r *BaseRepository) Query(query string, args ...interface{}) (*sql.Rows, er
 	return r.Executor().QueryContext(r.ctx, query, args...)
 }
 
+// QueryContext exécute une requête de lecture avec un contexte explicite
+// Utile pour les requêtes longues (streaming): l'annulation du contexte
+// (ex: client HTTP déconnecté) interrompt la requête côté PostgreSQL
+func (r *BaseRepository) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.Executor().QueryContext(ctx, query, args...)
+}
+
 // QueryRow exécute une requête de lecture pour une seule ligne
 func (r *BaseRepository) QueryRow(query string, args ...interface{}) *sql.Row {
 	return r.Executor().QueryRowContext(r.ctx, query, args...)
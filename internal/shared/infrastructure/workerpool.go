@@ -2,10 +2,14 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 )
 
+// ErrWorkerPoolFull retourné par TrySubmit quand la file d'attente du pool est pleine
+var ErrWorkerPoolFull = errors.New("worker pool queue is full")
+
 // Task représente une tâche à exécuter
 type Task func() error
 
@@ -72,6 +76,24 @@ func (wp *WorkerPool) Submit(task Task) error {
 	}
 }
 
+// TrySubmit soumet une tâche sans attendre: ErrWorkerPoolFull si la file est pleine
+// À utiliser depuis une requête HTTP qui ne doit pas rester bloquée derrière des tâches longues
+// (l'appelant rejette la demande au lieu d'accumuler des goroutines en attente)
+func (wp *WorkerPool) TrySubmit(task Task) error {
+	select {
+	case <-wp.ctx.Done():
+		return fmt.Errorf("worker pool is stopped")
+	default:
+	}
+
+	select {
+	case wp.tasks <- task:
+		return nil
+	default:
+		return ErrWorkerPoolFull
+	}
+}
+
 // Wait attend que toutes les tâches soient terminées et ferme le canal de tâches
 func (wp *WorkerPool) Wait() {
 	close(wp.tasks)
@@ -84,6 +106,13 @@ func (wp *WorkerPool) Stop() {
 	wp.wg.Wait()
 }
 
+// Done retourne un canal fermé à l'arrêt du pool (Stop)
+// PIÈGE: les tâches encore en file à l'arrêt ne sont jamais exécutées: l'appelant qui attend
+// leur résultat doit aussi attendre sur Done pour ne pas rester bloqué
+func (wp *WorkerPool) Done() <-chan struct{} {
+	return wp.ctx.Done()
+}
+
 // Errors retourne le canal d'erreurs
 func (wp *WorkerPool) Errors() <-chan error {
 	return wp.errors
@@ -1,6 +1,7 @@
 package infrastructure
 
 import (
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -643,3 +644,67 @@ func BenchmarkWorkerPool_Scalability(b *testing.B) {
 		})
 	}
 }
+
+// TestWorkerPool_TrySubmit vérifie que TrySubmit refuse au lieu de bloquer quand la file est pleine
+func TestWorkerPool_TrySubmit(t *testing.T) {
+	wp := NewWorkerPool(1) // file de 2 tâches
+	wp.Start()
+	defer wp.Stop()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocking := func() error {
+		<-release
+		return nil
+	}
+
+	// 1 tâche en cours + 2 en file
+	if err := wp.TrySubmit(func() error { close(started); return blocking() }); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	for i := 0; i < 2; i++ {
+		if err := wp.TrySubmit(blocking); err != nil {
+			t.Fatalf("task %d: %v", i, err)
+		}
+	}
+
+	if err := wp.TrySubmit(blocking); err != ErrWorkerPoolFull {
+		t.Errorf("TrySubmit on a full queue = %v, want ErrWorkerPoolFull", err)
+	}
+	close(release)
+}
+
+// TestWorkerPool_Done vérifie que Done débloque l'attente d'une tâche restée en file à l'arrêt
+func TestWorkerPool_Done(t *testing.T) {
+	wp := NewWorkerPool(1)
+	wp.Start()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	if err := wp.Submit(func() error { close(started); <-release; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	queued := make(chan struct{})
+	if err := wp.Submit(func() error { close(queued); return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		wp.Stop()
+		close(stopped)
+	}()
+	close(release)
+
+	select {
+	case <-queued:
+		// Le worker a pu prendre la tâche avant de voir l'arrêt: rien à vérifier
+	case <-wp.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done not closed after Stop")
+	}
+	<-stopped
+}
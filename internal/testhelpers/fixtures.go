@@ -0,0 +1,161 @@
+package testhelpers
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	analyticsinfra "eval/internal/analytics/infrastructure"
+	cataloginfra "eval/internal/catalog/infrastructure"
+	customersinfra "eval/internal/customers/infrastructure"
+	exportinfra "eval/internal/export/infrastructure"
+	sharedinfra "eval/internal/shared/infrastructure"
+)
+
+// ========================================
+// JEU DE DONNÉES DE RÉGRESSION
+// ========================================
+// Petit jeu de données connu, chargé dans un schéma PostgreSQL dédié:
+//   - Les totaux attendus se calculent à la main (voir FixtureOrders)
+//   - Le schéma est supprimé au Cleanup: aucune interférence avec la base de benchmark
+//
+// Clients: 1 = Alice Martin (commandes 1, 3, 5), 2 = Bruno Durand (commandes 2, 4)
+// Catégories (init.sql): 1 = Électronique, 2 = Vêtements, 3 = Alimentation
+// Produits: 1 = Laptop (cat. 1, 1000€), 2 = T-shirt (cat. 2, 20€), 3 = Pâtes (cat. 3, 2€)
+// Stock: Laptop 4, T-shirt 1, Pâtes 0
+// Fournisseurs: 1 = Fournisseur Test (Laptop, T-shirt), 2 = Épicerie Test (Pâtes)
+// Magasins: 1 = Paris (Île-de-France), 2 = Lyon (Auvergne-Rhône-Alpes)
+// Promotions: 1 = HIVER10 (10%, du 2024-01-15 au 2024-01-25), utilisée par la commande 2
+// Taux de change: 1 USD = 0.90 EUR à partir du 2024-01-01, 0.80 EUR à partir du 2024-01-15
+// (toutes les commandes sont en EUR: les taux ne servent qu'aux rapports en devise de reporting)
+//
+// Commandes:
+//
+//	#  date        magasin  paiement  statut     articles                  total
+//	1  2024-01-10  Paris    1         completed  1 Laptop + 2 T-shirt      1040
+//	2  2024-01-20  Lyon     2         completed  5 Pâtes + 1 T-shirt         30
+//	3  2024-01-25  Paris    1         cancelled  1 Laptop                  1000
+//	4  2024-02-05  Lyon     1         completed  2 Laptop                  2000
+//	5  2023-12-31  Paris    2         completed  3 T-shirt                   60
+//
+// Les commandes 3 (annulée) et 5 (hors janvier) sont les pièges de la régression:
+// elles ne doivent jamais apparaître dans les totaux de janvier 2024
+const fixtureData = `
+INSERT INTO suppliers (id, name, email, city, country) VALUES
+    (1, 'Fournisseur Test', 'contact@fournisseur.test', 'Paris', 'France'),
+    (2, 'Épicerie Test', NULL, 'Lyon', 'France');
+
+INSERT INTO products (id, name, supplier_id, base_price, stock_quantity) VALUES
+    (1, 'Laptop', 1, 1000.00, 4),
+    (2, 'T-shirt', 1, 20.00, 1),
+    (3, 'Pâtes', 2, 2.00, 0);
+
+INSERT INTO product_categories (product_id, category_id) VALUES (1, 1), (2, 2), (3, 3);
+
+INSERT INTO customers (id, first_name, last_name, email) VALUES
+    (1, 'Alice', 'Martin', 'alice@example.test'),
+    (2, 'Bruno', 'Durand', 'bruno@example.test');
+
+INSERT INTO stores (id, name, city, region) VALUES
+    (1, 'Magasin Paris', 'Paris', 'Île-de-France'),
+    (2, 'Magasin Lyon', 'Lyon', 'Auvergne-Rhône-Alpes');
+
+INSERT INTO promotions (id, code, name, discount_percent, start_date, end_date) VALUES
+    (1, 'HIVER10', 'Soldes d''hiver', 10.00, '2024-01-15', '2024-01-25');
+
+INSERT INTO fx_rates (currency, rate_date, rate_to_eur) VALUES
+    ('USD', '2024-01-01', 0.90000000),
+    ('USD', '2024-01-15', 0.80000000);
+
+INSERT INTO orders (id, customer_id, store_id, payment_method_id, promotion_id, order_date, total_amount, status) VALUES
+    (1, 1, 1, 1, NULL, '2024-01-10', 1040.00, 'completed'),
+    (2, 2, 2, 2, 1, '2024-01-20', 30.00, 'completed'),
+    (3, 1, 1, 1, NULL, '2024-01-25', 1000.00, 'cancelled'),
+    (4, 2, 2, 1, NULL, '2024-02-05', 2000.00, 'completed'),
+    (5, 1, 1, 2, NULL, '2023-12-31', 60.00, 'completed');
+
+INSERT INTO order_items (order_id, product_id, quantity, unit_price, subtotal) VALUES
+    (1, 1, 1, 1000.00, 1000.00),
+    (1, 2, 2, 20.00, 40.00),
+    (2, 3, 5, 2.00, 10.00),
+    (2, 2, 1, 20.00, 20.00),
+    (3, 1, 1, 1000.00, 1000.00),
+    (4, 1, 2, 1000.00, 2000.00),
+    (5, 2, 3, 20.00, 60.00);
+`
+
+// SetupFixtureContext initialise un contexte de test sur un schéma isolé contenant le jeu de régression
+// Étapes: création du schéma, connexion avec search_path dessus, init.sql, migrations, puis fixtureData
+// Les migrations sont rejouées sur le schéma tout juste créé: elles doivent y être sans effet
+//
+// ISOLATION: un schéma par appel (nom unique), les tests peuvent tourner en parallèle
+// sans toucher aux tables de la base de benchmark
+func SetupFixtureContext(tb testing.TB) *TestContext {
+	tb.Helper()
+
+	schema := fmt.Sprintf("regress_%d_%d", os.Getpid(), time.Now().UnixNano())
+
+	admin := SetupTestDB(tb)
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		admin.Close()
+		tb.Fatalf("Failed to create fixture schema: %v", err)
+	}
+
+	ctx := &TestContext{}
+	ctx.teardown = append(ctx.teardown, func() {
+		// CASCADE: supprime toutes les tables, vues et séquences du schéma
+		_, _ = admin.Exec("DROP SCHEMA IF EXISTS " + schema + " CASCADE")
+		admin.Close()
+	})
+
+	// SYNTAXE: lib/pq transmet les clés inconnues de la connection string comme paramètres de session
+	// search_path s'applique donc à toutes les connexions du pool
+	db, err := sql.Open("postgres", connectionString()+" search_path="+schema)
+	if err != nil {
+		ctx.Cleanup()
+		tb.Fatalf("Failed to open fixture database: %v", err)
+	}
+	ctx.DB = db
+
+	schemaSQL, err := os.ReadFile(initSQLPath())
+	if err != nil {
+		ctx.Cleanup()
+		tb.Fatalf("Failed to read init.sql: %v", err)
+	}
+
+	// Exec sans argument: protocole simple, plusieurs instructions par appel
+	if _, err := db.Exec(string(schemaSQL)); err != nil {
+		ctx.Cleanup()
+		tb.Fatalf("Failed to load fixture schema: %v", err)
+	}
+	if err := sharedinfra.Migrate(db); err != nil {
+		ctx.Cleanup()
+		tb.Fatalf("Failed to migrate fixture schema: %v", err)
+	}
+	if _, err := db.Exec(fixtureData); err != nil {
+		ctx.Cleanup()
+		tb.Fatalf("Failed to load fixture data: %v", err)
+	}
+
+	ctx.Cache = sharedinfra.NewShardedCache(16)
+	ctx.ProductQueryRepo = cataloginfra.NewProductQueryRepository(ctx.DB)
+	ctx.StatsQueryRepo = analyticsinfra.NewStatsQueryRepository(ctx.DB)
+	ctx.ExportQueryRepo = exportinfra.NewExportQueryRepository(ctx.DB)
+	ctx.CustomerQueryRepo = customersinfra.NewCustomerQueryRepository(ctx.DB)
+	ctx.CustomerStatsRepo = customersinfra.NewCustomerStatsQueryRepository(ctx.DB)
+	ctx.SupplierQueryRepo = cataloginfra.NewSupplierQueryRepository(ctx.DB)
+	ctx.SupplierStatsRepo = cataloginfra.NewSupplierStatsQueryRepository(ctx.DB)
+	ctx.InventoryRepo = cataloginfra.NewInventoryQueryRepository(ctx.DB)
+
+	return ctx
+}
+
+// initSQLPath localise init.sql à la racine du module, quel que soit le package de test appelant
+func initSQLPath() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "init.sql")
+}
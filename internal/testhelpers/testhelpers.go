@@ -11,6 +11,7 @@ import (
 
 	analyticsinfra "eval/internal/analytics/infrastructure"
 	cataloginfra "eval/internal/catalog/infrastructure"
+	customersinfra "eval/internal/customers/infrastructure"
 	exportinfra "eval/internal/export/infrastructure"
 	sharedinfra "eval/internal/shared/infrastructure"
 )
@@ -26,8 +27,18 @@ type TestContext struct {
 	StatsQueryRepo   *analyticsinfra.StatsQueryRepository
 	ExportQueryRepo  *exportinfra.ExportQueryRepository
 
+	CustomerQueryRepo *customersinfra.CustomerQueryRepository
+	CustomerStatsRepo *customersinfra.CustomerStatsQueryRepository
+
+	SupplierQueryRepo *cataloginfra.SupplierQueryRepository
+	SupplierStatsRepo *cataloginfra.SupplierStatsQueryRepository
+	InventoryRepo     *cataloginfra.InventoryQueryRepository
+
 	// Infrastructure
 	Cache sharedinfra.Cache
+
+	// teardown actions exécutées après la fermeture de DB (ex: suppression du schéma de fixtures)
+	teardown []func()
 }
 
 // SetupTestDB initialise une connexion à la base de données de test
@@ -38,14 +49,7 @@ func SetupTestDB(tb testing.TB) *sql.DB {
 	_ = godotenv.Load("../../.env")
 
 	// Construire la connection string
-	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		getEnv("DB_HOST", "localhost"),
-		getEnv("DB_PORT", "5432"),
-		getEnv("DB_USER", "evaluser"),
-		getEnv("DB_PASSWORD", "evalpass"),
-		getEnv("DB_NAME", "evaldb"),
-		getEnv("DB_SSLMODE", "disable"),
-	)
+	connStr := connectionString()
 
 	db, err := sql.Open("postgres", connStr)
 	if err != nil {
@@ -80,6 +84,11 @@ func SetupTestContext(tb testing.TB) *TestContext {
 	ctx.ProductQueryRepo = cataloginfra.NewProductQueryRepository(ctx.DB)
 	ctx.StatsQueryRepo = analyticsinfra.NewStatsQueryRepository(ctx.DB)
 	ctx.ExportQueryRepo = exportinfra.NewExportQueryRepository(ctx.DB)
+	ctx.CustomerQueryRepo = customersinfra.NewCustomerQueryRepository(ctx.DB)
+	ctx.CustomerStatsRepo = customersinfra.NewCustomerStatsQueryRepository(ctx.DB)
+	ctx.SupplierQueryRepo = cataloginfra.NewSupplierQueryRepository(ctx.DB)
+	ctx.SupplierStatsRepo = cataloginfra.NewSupplierStatsQueryRepository(ctx.DB)
+	ctx.InventoryRepo = cataloginfra.NewInventoryQueryRepository(ctx.DB)
 
 	return ctx
 }
@@ -89,6 +98,10 @@ func (ctx *TestContext) Cleanup() {
 	if ctx.DB != nil {
 		ctx.DB.Close()
 	}
+	for _, fn := range ctx.teardown {
+		fn()
+	}
+	ctx.teardown = nil
 }
 
 // ClearCache vide le cache (utile entre les benchmarks)
@@ -98,6 +111,18 @@ func (ctx *TestContext) ClearCache() {
 	}
 }
 
+// connectionString construit la connection string de la base de test depuis l'environnement (.env)
+func connectionString() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		getEnv("DB_HOST", "localhost"),
+		getEnv("DB_PORT", "5432"),
+		getEnv("DB_USER", "evaluser"),
+		getEnv("DB_PASSWORD", "evalpass"),
+		getEnv("DB_NAME", "evaldb"),
+		getEnv("DB_SSLMODE", "disable"),
+	)
+}
+
 // getEnv récupère une variable d'environnement avec fallback
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
@@ -118,14 +143,7 @@ func SkipIfNoDatabase(tb testing.TB) {
 
 	_ = godotenv.Load("../../.env")
 
-	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		getEnv("DB_HOST", "localhost"),
-		getEnv("DB_PORT", "5432"),
-		getEnv("DB_USER", "evaluser"),
-		getEnv("DB_PASSWORD", "evalpass"),
-		getEnv("DB_NAME", "evaldb"),
-		getEnv("DB_SSLMODE", "disable"),
-	)
+	connStr := connectionString()
 
 	db, err := sql.Open("postgres", connStr)
 	if err != nil {
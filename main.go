@@ -8,11 +8,16 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 
 	// API handlers
+	"eval/api/openapi"
+	"eval/api/problem"
 	apiv1 "eval/api/v1"
 	apiv2 "eval/api/v2"
 
@@ -21,8 +26,13 @@ import (
 	analyticsinfra "eval/internal/analytics/infrastructure"
 
 	// Catalog
+	catalogapp "eval/internal/catalog/application"
 	cataloginfra "eval/internal/catalog/infrastructure"
 
+	// Customers
+	customersapp "eval/internal/customers/application"
+	customersinfra "eval/internal/customers/infrastructure"
+
 	// Export
 	exportapp "eval/internal/export/application"
 	exportinfra "eval/internal/export/infrastructure"
@@ -30,7 +40,8 @@ import (
 	// Orders
 	ordersinfra "eval/internal/orders/infrastructure"
 
-	// Shared infrastructure
+	// Shared
+	shareddomain "eval/internal/shared/domain"
 	sharedinfra "eval/internal/shared/infrastructure"
 )
 
@@ -43,13 +54,32 @@ type Application struct {
 	orderQueryRepo    *ordersinfra.OrderQueryRepository
 	statsQueryRepo    *analyticsinfra.StatsQueryRepository
 	exportQueryRepo   *exportinfra.ExportQueryRepository
+	exportJobStore    *exportinfra.ExportJobStore
+	customerQueryRepo *customersinfra.CustomerQueryRepository
+	customerStatsRepo *customersinfra.CustomerStatsQueryRepository
+	supplierQueryRepo *cataloginfra.SupplierQueryRepository
+	supplierStatsRepo *cataloginfra.SupplierStatsQueryRepository
+	inventoryRepo     *cataloginfra.InventoryQueryRepository
 
 	// Services
-	cache             sharedinfra.Cache
-	statsServiceV1    *analyticsapp.StatsServiceV1
-	statsServiceV2    *analyticsapp.StatsServiceV2
-	exportServiceV1   *exportapp.ExportServiceV1
-	exportServiceV2   *exportapp.ExportServiceV2
+	cache               sharedinfra.Cache
+	workerPool          *sharedinfra.WorkerPool
+	basketPool          *sharedinfra.WorkerPool
+	statsServiceV1      *analyticsapp.StatsServiceV1
+	statsServiceV2      *analyticsapp.StatsServiceV2
+	exportServiceV1     *exportapp.ExportServiceV1
+	exportServiceV2     *exportapp.ExportServiceV2
+	exportJobService    *exportapp.ExportJobService
+	customerService     *customersapp.CustomerAnalyticsService
+	cohortService       *analyticsapp.CohortService
+	basketService       *analyticsapp.BasketService
+	promotionService    *analyticsapp.PromotionService
+	storeService        *analyticsapp.StoreService
+	forecastService     *analyticsapp.ForecastService
+	anomalyService      *analyticsapp.AnomalyService
+	distributionService *analyticsapp.DistributionService
+	supplierService     *catalogapp.SupplierAnalyticsService
+	inventoryService    *catalogapp.InventoryService
 
 	// Handlers
 	handlersV1 *apiv1.Handlers
@@ -79,7 +109,8 @@ func main() {
 	port := getEnv("APP_PORT", "8080")
 	app.printBanner(port)
 
-	log.Fatal(http.ListenAndServe(":"+port, nil))
+	// Identifiant de requête (X-Request-ID) sur toutes les routes, repris dans les réponses d'erreur
+	log.Fatal(http.ListenAndServe(":"+port, problem.RequestID(http.DefaultServeMux)))
 }
 
 // initializeApplication initialise toute l'application avec dependency injection
@@ -109,16 +140,36 @@ func initializeApplication() (*Application, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	// Schéma d'une base créée avec un init.sql plus ancien (volume postgres_data existant)
+	if err := sharedinfra.Migrate(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
 	app.db = db
 
 	// 2. Initialiser l'infrastructure partagée
 	app.cache = sharedinfra.NewShardedCache(16) // 16 shards pour réduire contention
 
+	// Pool des jobs d'export: file bornée, les jobs refusent au lieu d'empiler
+	app.workerPool = sharedinfra.NewWorkerPool(4)
+	app.workerPool.Start()
+
+	// Pool dédié au comptage des paniers: des lots courts qui ne doivent pas attendre
+	// derrière des exports de plusieurs minutes (curseur SQL des paniers ouvert pendant l'attente)
+	app.basketPool = sharedinfra.NewWorkerPool(4)
+	app.basketPool.Start()
+
 	// 3. Initialiser les repositories
 	app.productQueryRepo = cataloginfra.NewProductQueryRepository(db)
 	app.orderQueryRepo = ordersinfra.NewOrderQueryRepository(db)
 	app.statsQueryRepo = analyticsinfra.NewStatsQueryRepository(db)
 	app.exportQueryRepo = exportinfra.NewExportQueryRepository(db)
+	app.exportJobStore = exportinfra.NewExportJobStore()
+	app.customerQueryRepo = customersinfra.NewCustomerQueryRepository(db)
+	app.customerStatsRepo = customersinfra.NewCustomerStatsQueryRepository(db)
+	app.supplierQueryRepo = cataloginfra.NewSupplierQueryRepository(db)
+	app.supplierStatsRepo = cataloginfra.NewSupplierStatsQueryRepository(db)
+	app.inventoryRepo = cataloginfra.NewInventoryQueryRepository(db)
 
 	// 4. Initialiser les services V1 (non-optimisés)
 	app.statsServiceV1 = analyticsapp.NewStatsServiceV1(
@@ -135,10 +186,67 @@ func initializeApplication() (*Application, error) {
 		app.statsQueryRepo,
 		app.cache,
 	)
+	app.cohortService = analyticsapp.NewCohortService(
+		app.statsQueryRepo,
+		app.cache,
+	)
+	app.basketService = analyticsapp.NewBasketService(
+		app.statsQueryRepo,
+		app.cache,
+		app.basketPool,
+	)
+	app.promotionService = analyticsapp.NewPromotionService(
+		app.statsQueryRepo,
+		app.cache,
+	)
+	app.storeService = analyticsapp.NewStoreService(
+		app.statsQueryRepo,
+		app.cache,
+	)
+	app.forecastService = analyticsapp.NewForecastService(
+		app.statsQueryRepo,
+		app.cache,
+	)
+	app.anomalyService = analyticsapp.NewAnomalyService(
+		app.statsQueryRepo,
+		app.cache,
+	)
+	app.distributionService = analyticsapp.NewDistributionService(
+		app.statsQueryRepo,
+		app.cache,
+	)
 	app.exportServiceV2 = exportapp.NewExportServiceV2(
 		app.exportQueryRepo,
 		app.statsServiceV2,
 	)
+	retentionHours, err := strconv.Atoi(getEnv("EXPORT_RETENTION_HOURS", "24"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EXPORT_RETENTION_HOURS: %w", err)
+	}
+	app.exportJobService, err = exportapp.NewExportJobService(
+		app.exportServiceV2,
+		app.exportJobStore,
+		app.workerPool,
+		getEnv("EXPORT_DIR", filepath.Join(os.TempDir(), "eval-exports")),
+		time.Duration(retentionHours)*time.Hour,
+	)
+	if err != nil {
+		return nil, err
+	}
+	app.customerService = customersapp.NewCustomerAnalyticsService(
+		app.customerQueryRepo,
+		app.customerStatsRepo,
+		app.cache,
+	)
+	app.supplierService = catalogapp.NewSupplierAnalyticsService(
+		app.supplierQueryRepo,
+		app.supplierStatsRepo,
+		app.cache,
+	)
+	app.inventoryService = catalogapp.NewInventoryService(
+		app.inventoryRepo,
+		app.cache,
+	)
 
 	// 6. Initialiser les handlers
 	app.handlersV1 = apiv1.NewHandlers(
@@ -148,31 +256,68 @@ func initializeApplication() (*Application, error) {
 	app.handlersV2 = apiv2.NewHandlers(
 		app.statsServiceV2,
 		app.exportServiceV2,
+		app.exportJobService,
+		app.customerService,
+		app.cohortService,
+		app.basketService,
+		app.promotionService,
+		app.storeService,
+		app.forecastService,
+		app.anomalyService,
+		app.distributionService,
+		app.supplierService,
+		app.inventoryService,
 	)
 
 	return app, nil
 }
 
 // registerRoutes enregistre toutes les routes HTTP
+// Chaque package de handlers décrit ses routes (api/openapi.Route): la même liste sert à
+// l'enregistrement, à la validation des paramètres et au document /api/openapi.json
 func (app *Application) registerRoutes() {
 	// Health check
-	http.HandleFunc("/api/health", app.healthHandler)
+	routes := []openapi.Route{{
+		Method: http.MethodGet, Path: "/api/health", Handler: app.healthHandler,
+		Operation: openapi.Operation{
+			OperationID: "getHealth",
+			Summary:     "Status de l'application",
+			Tags:        []string{"health"},
+			Responses: map[string]openapi.Response{
+				"200": openapi.JSONResponse("Application disponible", nil),
+				"503": openapi.ProblemResponse("Base de données injoignable"),
+			},
+		},
+	}}
 
 	// API V1 - Non-optimisée (DDD)
-	http.HandleFunc("/api/v1/stats", app.handlersV1.GetStats)
-	http.HandleFunc("/api/v1/export/csv", app.handlersV1.ExportCSV)
-	http.HandleFunc("/api/v1/export/stats-csv", app.handlersV1.ExportStatsCSV)
-	http.HandleFunc("/api/v1/export/parquet", app.handlersV1.ExportParquet)
-
-	// API V2 - Optimisée (DDD)
-	http.HandleFunc("/api/v2/stats", app.handlersV2.GetStats)
-	http.HandleFunc("/api/v2/export/csv", app.handlersV2.ExportCSV)
-	http.HandleFunc("/api/v2/export/stats-csv", app.handlersV2.ExportStatsCSV)
-	http.HandleFunc("/api/v2/export/parquet", app.handlersV2.ExportParquet)
+	routes = append(routes, app.handlersV1.Routes()...)
+
+	// API V2 - Optimisée (DDD): stats, exports, analyses clients, jobs d'export asynchrones
+	routes = append(routes, app.handlersV2.Routes()...)
+
+	// Validation des paramètres de requête avant chaque handler (400 structuré si invalide)
+	for _, route := range routes {
+		http.HandleFunc(route.Pattern(), openapi.Validate(route))
+	}
+
+	// Spécification OpenAPI 3 générée depuis les routes
+	document := openapi.NewDocument(openapi.Info{
+		Title:       "Eval API",
+		Version:     "2.0.0",
+		Description: "V1 (non-optimisée) et V2 (optimisée) avec architecture DDD",
+	}, routes)
+	http.HandleFunc("GET /api/openapi.json", document.Handler())
 }
 
 // healthHandler retourne le status de l'application
-func (app *Application) healthHandler(w http.ResponseWriter, _ *http.Request) {
+// 503 si la base de données ne répond pas (load balancer: retirer l'instance)
+func (app *Application) healthHandler(w http.ResponseWriter, r *http.Request) {
+	if err := app.db.PingContext(r.Context()); err != nil {
+		problem.Write(w, r, shareddomain.NewUnavailableError("database_unavailable", "database is unreachable", err))
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{
 		"status":       "ok",
@@ -183,8 +328,16 @@ func (app *Application) healthHandler(w http.ResponseWriter, _ *http.Request) {
 
 // cleanup libère les ressources
 func (app *Application) cleanup() {
-	if app.exportServiceV2 != nil {
-		app.exportServiceV2.Cleanup()
+	if app.exportJobService != nil {
+		app.exportJobService.Cleanup()
+	}
+	// Après les services qui soumettent des tâches
+	if app.workerPool != nil {
+		app.workerPool.Stop()
+	}
+	// Interrompt un comptage de paniers en cours (GetBasketAnalysis retourne une erreur)
+	if app.basketPool != nil {
+		app.basketPool.Stop()
 	}
 	if app.db != nil {
 		app.db.Close()
@@ -202,6 +355,7 @@ func (app *Application) printBanner(port string) {
 	fmt.Println("📦 Bounded Contexts:")
 	fmt.Println("   • Catalog (Products, Categories, Suppliers)")
 	fmt.Println("   • Orders (Orders, OrderItems)")
+	fmt.Println("   • Customers (Customers, RFM Segmentation)")
 	fmt.Println("   • Analytics (Stats, Reporting)")
 	fmt.Println("   • Export (CSV, Parquet)")
 	fmt.Println()